@@ -0,0 +1,30 @@
+package ginhtmx
+
+// Alias makes alias a name for the template named target in htmx and every copy
+// made by With, so that handlers, hx-get endpoints and templates can keep using a
+// stable name while the template behind it is replaced:
+//
+//	htmx.Alias("login_form", "auth/login_form_v2")
+//
+// Renders of "login_form" then render "auth/login_form_v2", which may itself be
+// themed. Aliases are not followed further, so target is always the name of a
+// template rather than of another alias. Adding an alias which already exists
+// replaces its target.
+func (htmx *Htmx) Alias(alias string, target string) {
+	htmx.templates.mutex.Lock()
+	defer htmx.templates.mutex.Unlock()
+
+	htmx.templates.aliases[alias] = target
+}
+
+// alias returns the target of the alias name, or name itself if it is not an alias.
+func (store *templateStore) alias(name string) string {
+	store.mutex.RLock()
+	defer store.mutex.RUnlock()
+
+	if target, found := store.aliases[name]; found {
+		return target
+	}
+
+	return name
+}
@@ -0,0 +1,93 @@
+package ginhtmx_test
+
+import (
+	"html/template"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/jeffscottbrown/ginhtmxtemplates/ginhtmx"
+	"github.com/stretchr/testify/suite"
+)
+
+func (suite *AliasTestSuite) TestAliasesRenderTheirTargets() {
+	suite.htmx.Alias("login_form", "auth/login_form_v2")
+
+	suite.Equal(`<html><form>v2</form></html>`, suite.render(suite.htmx, false, "login_form"))
+	suite.Equal(`<form>v2</form>`, suite.render(suite.htmx.With(), true, "login_form"))
+
+	rendered, err := suite.htmx.RenderToString("page", nil)
+	suite.Require().NoError(err)
+	suite.Equal(`<main><form>v2</form></main>`, rendered)
+}
+
+func (suite *AliasTestSuite) TestAliasesCanBeRetargeted() {
+	htmx := suite.htmx.With(ginhtmx.CacheFor(time.Minute, ""))
+
+	suite.Equal(`<form>v1</form>`, suite.render(htmx, true, "login_form"))
+
+	suite.htmx.Alias("login_form", "auth/login_form_v2")
+
+	suite.Equal(`<form>v2</form>`, suite.render(htmx, true, "login_form"))
+}
+
+func (suite *AliasTestSuite) TestAliasesAreNotFollowedFurther() {
+	suite.htmx.Alias("form", "login_form")
+	suite.htmx.Alias("login_form", "auth/login_form_v2")
+
+	rendered, err := suite.htmx.RenderToString("form", nil)
+	suite.Require().NoError(err)
+	suite.Equal(`<form>v1</form>`, rendered)
+}
+
+func (suite *AliasTestSuite) TestAliasesAreValidated() {
+	suite.htmx.Alias("signup_form", "auth/signup_form")
+	suite.htmx.Alias("register_form", "signup_form")
+
+	err := suite.htmx.Validate("login_form", "signup_form")
+	suite.Require().ErrorIs(err, ginhtmx.ErrTemplateNotFound)
+	suite.Equal(`template not found: "signup_form"
+alias "register_form" template not found: "signup_form"
+alias "signup_form" template not found: "auth/signup_form"`, err.Error())
+}
+
+func (suite *AliasTestSuite) render(htmx *ginhtmx.Htmx, fragment bool, templateNames ...string) string {
+	recorder := httptest.NewRecorder()
+	testContext, _ := gin.CreateTestContext(recorder)
+	testContext.Request = httptest.NewRequest(http.MethodGet, "/", nil)
+
+	if fragment {
+		testContext.Request.Header.Set("Hx-Request", "true")
+	}
+
+	htmx.Render(testContext, nil, templateNames...)
+
+	return recorder.Body.String()
+}
+
+func (suite *AliasTestSuite) SetupTest() {
+	tmpl := template.Must(template.New("").Funcs(ginhtmx.FuncMap()).Parse(`
+{{- define "layout"}}<html>{{ .Content }}</html>{{end -}}
+{{- define "page"}}<main>{{ partial "login_form" . }}</main>{{end -}}
+{{- define "login_form"}}<form>v1</form>{{end -}}
+{{- define "auth/login_form_v2"}}<form>v2</form>{{end -}}
+`))
+	suite.htmx = ginhtmx.NewHtmxWithConfig(tmpl, ginhtmx.HtmxConfig{
+		LayoutTemplateName:  "layout",
+		ContentVariableName: "Content",
+		Cache:               ginhtmx.NewMemoryCache(),
+	})
+}
+
+func TestAliasTestSuite(t *testing.T) {
+	t.Parallel()
+	suite.Run(t, new(AliasTestSuite))
+}
+
+type AliasTestSuite struct {
+	suite.Suite
+
+	htmx *ginhtmx.Htmx
+}
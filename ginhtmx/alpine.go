@@ -0,0 +1,35 @@
+package ginhtmx
+
+import (
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// IsAlpineRequest reports whether the request was made by Alpine AJAX, which sends
+// the X-Alpine-Request request header.
+func IsAlpineRequest(ginContext *gin.Context) bool {
+	return ginContext.GetHeader("X-Alpine-Request") != ""
+}
+
+// AlpineTargets returns the ids of the elements Alpine AJAX replaces with the
+// elements of the response which have the same ids, from the X-Alpine-Target
+// request header.
+func AlpineTargets(ginContext *gin.Context) []string {
+	return strings.Fields(ginContext.GetHeader("X-Alpine-Target"))
+}
+
+// applyAlpineRequest renders the requests of Alpine AJAX, when AlpineAJAX is
+// enabled, like HTMX requests, and expects the response to contain the targets of
+// the request as if they were declared with ExpectSelect.
+func (htmx *Htmx) applyAlpineRequest(ginContext *gin.Context, settings *renderSettings) {
+	if !htmx.config.AlpineAJAX || !IsAlpineRequest(ginContext) {
+		return
+	}
+
+	if settings.layout == nil {
+		ForceFragment()(settings)
+	}
+
+	ExpectSelect(AlpineTargets(ginContext)...)(settings)
+}
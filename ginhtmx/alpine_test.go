@@ -0,0 +1,114 @@
+package ginhtmx_test
+
+import (
+	"html/template"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/jeffscottbrown/ginhtmxtemplates/ginhtmx"
+	"github.com/stretchr/testify/suite"
+)
+
+func (suite *AlpineTestSuite) TestAlpineRequestsAreRenderedAsFragmentsWhenEnabled() {
+	htmx := suite.newHtmx(ginhtmx.HtmxConfig{
+		LayoutTemplateName:  "layout",
+		ContentVariableName: "Content",
+		AlpineAJAX:          true,
+	})
+
+	recorder, testContext := suite.render(htmx, "comments")
+
+	suite.Equal(`<ul id="comments"></ul>`, recorder.Body.String())
+	suite.True(ginhtmx.IsAlpineRequest(testContext))
+	suite.Equal([]string{"comments", "count"}, ginhtmx.AlpineTargets(testContext))
+
+	recorder, _ = suite.render(htmx.With(ginhtmx.ForceFullPage()), "comments")
+	suite.Equal(`<html><ul id="comments"></ul></html>`, recorder.Body.String())
+}
+
+func (suite *AlpineTestSuite) TestAlpineRequestsAreRenderedAsPagesWhenDisabled() {
+	htmx := suite.newHtmx(ginhtmx.HtmxConfig{
+		LayoutTemplateName:  "layout",
+		ContentVariableName: "Content",
+	})
+
+	recorder, _ := suite.render(htmx, "comments")
+
+	suite.Equal(`<html><ul id="comments"></ul></html>`, recorder.Body.String())
+}
+
+func (suite *AlpineTestSuite) TestAlpineRequestHeaderIsAddedToVaryWhenEnabled() {
+	config := ginhtmx.HtmxConfig{
+		LayoutTemplateName:  "layout",
+		ContentVariableName: "Content",
+		ETags:               true,
+	}
+
+	suite.Equal([]string{"HX-Request", "Turbo-Frame", "X-Up-Target"}, suite.vary(suite.newHtmx(config)))
+
+	config.AlpineAJAX = true
+
+	suite.Equal([]string{"HX-Request", "Turbo-Frame", "X-Up-Target", "X-Alpine-Request"},
+		suite.vary(suite.newHtmx(config)))
+}
+
+// vary returns the Vary header of the response to a GET request of Alpine AJAX.
+func (suite *AlpineTestSuite) vary(htmx *ginhtmx.Htmx) []string {
+	recorder := httptest.NewRecorder()
+	testContext, _ := gin.CreateTestContext(recorder)
+	testContext.Request = httptest.NewRequest(http.MethodGet, "/comments", nil)
+	testContext.Request.Header.Set("X-Alpine-Request", "true")
+
+	htmx.Render(testContext, gin.H{}, "comments")
+
+	return recorder.Header().Values("Vary")
+}
+
+func (suite *AlpineTestSuite) TestMissingTargetsAreRecordedInDebugMode() {
+	htmx := suite.newHtmx(ginhtmx.HtmxConfig{
+		LayoutTemplateName:  "layout",
+		ContentVariableName: "Content",
+		AlpineAJAX:          true,
+		Debug:               true,
+	})
+
+	recorder, testContext := suite.render(htmx, "comments")
+
+	suite.Contains(recorder.Body.String(), `<ul id="comments"></ul>`)
+
+	renderErr, _ := testContext.Value(ginhtmx.RenderErrorKey).(error)
+	suite.Require().ErrorIs(renderErr, ginhtmx.ErrSelectTargetMissing)
+	suite.Require().EqualError(renderErr, "hx-select target missing from rendered output: #count")
+}
+
+func (suite *AlpineTestSuite) render(htmx *ginhtmx.Htmx, templateNames ...string) (*httptest.ResponseRecorder, *gin.Context) {
+	recorder := httptest.NewRecorder()
+	testContext, _ := gin.CreateTestContext(recorder)
+	testContext.Request = httptest.NewRequest(http.MethodPost, "/comments", nil)
+	testContext.Request.Header.Set("X-Alpine-Request", "true")
+	testContext.Request.Header.Set("X-Alpine-Target", "comments count")
+
+	htmx.Render(testContext, gin.H{}, templateNames...)
+
+	return recorder, testContext
+}
+
+func (suite *AlpineTestSuite) newHtmx(config ginhtmx.HtmxConfig) *ginhtmx.Htmx {
+	tmpl := template.Must(template.New("").Parse(`
+{{define "layout"}}<html>{{.Content}}</html>{{end}}
+{{define "comments"}}<ul id="comments"></ul>{{end}}
+`))
+
+	return ginhtmx.NewHtmxWithConfig(tmpl, config)
+}
+
+func TestAlpineTestSuite(t *testing.T) {
+	t.Parallel()
+	suite.Run(t, new(AlpineTestSuite))
+}
+
+type AlpineTestSuite struct {
+	suite.Suite
+}
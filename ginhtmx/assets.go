@@ -0,0 +1,240 @@
+package ginhtmx
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io/fs"
+	"net/http"
+	"path"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// immutableCacheControl is sent with assets whose URL contains a content hash.
+const immutableCacheControl = "public, max-age=31536000, immutable"
+
+// revalidateCacheControl is sent with assets whose URL does not contain a content
+// hash, which may change without their URL changing.
+const revalidateCacheControl = "public, max-age=0, must-revalidate"
+
+// assetHashLength is the number of hex characters of the content hash used in
+// fingerprinted file names.
+const assetHashLength = 12
+
+// AssetManifest maps the logical names of static assets, such as "app.css", to
+// cache-busted URLs. It backs the "asset" template function, which layouts may
+// use to reference stylesheets, scripts and images:
+//
+//	<link rel="stylesheet" href="{{ asset "app.css" }}">
+//
+// A manifest is either read from a manifest file produced by a bundler, using
+// LoadAssetManifest, or computed by hashing the files in an fs.FS, using HashAssets.
+type AssetManifest struct {
+	prefix string
+	fsys   fs.FS
+
+	// urls maps logical names to file names relative to fsys
+	urls map[string]string
+
+	// fingerprinted maps fingerprinted file names to the file names in fsys
+	fingerprinted map[string]string
+
+	// plainURLs makes URL return the names of hashed files without their fingerprint
+	plainURLs bool
+}
+
+// LoadAssetManifest reads a bundler manifest file from fsys. Both the Vite format,
+// in which each entry is an object with a "file" property, and the flat format
+// produced by many esbuild and webpack plugins, in which each entry is a string,
+// are supported. The URLs returned by the manifest are the file names prefixed
+// with prefix, for example "/static/".
+func LoadAssetManifest(fsys fs.FS, manifestPath string, prefix string) (*AssetManifest, error) {
+	content, err := fs.ReadFile(fsys, manifestPath)
+	if err != nil {
+		return nil, fmt.Errorf("reading asset manifest: %w", err)
+	}
+
+	var entries map[string]json.RawMessage
+
+	err = json.Unmarshal(content, &entries)
+	if err != nil {
+		return nil, fmt.Errorf("parsing asset manifest %s: %w", manifestPath, err)
+	}
+
+	manifest := newAssetManifest(fsys, prefix)
+
+	for name, raw := range entries {
+		var file string
+		if json.Unmarshal(raw, &file) != nil {
+			var viteEntry struct {
+				File string `json:"file"`
+			}
+
+			err = json.Unmarshal(raw, &viteEntry)
+			if err != nil || viteEntry.File == "" {
+				return nil, fmt.Errorf("%w: unsupported manifest entry for %s", errInvalidArgument, name)
+			}
+
+			file = viteEntry.File
+		}
+
+		manifest.urls[strings.TrimPrefix(name, "/")] = strings.TrimPrefix(file, "/")
+	}
+
+	return manifest, nil
+}
+
+// HashAssets computes a manifest for every file in fsys. The URL of each file
+// includes a hash of its content, for example "/static/app.3f2a9c1b7d4e.css" for
+// "app.css", and Handler serves the file under that name.
+func HashAssets(fsys fs.FS, prefix string) (*AssetManifest, error) {
+	manifest := newAssetManifest(fsys, prefix)
+
+	err := fs.WalkDir(fsys, ".", func(name string, entry fs.DirEntry, err error) error {
+		if err != nil || entry.IsDir() {
+			return err
+		}
+
+		content, err := fs.ReadFile(fsys, name)
+		if err != nil {
+			return fmt.Errorf("reading asset: %w", err)
+		}
+
+		sum := sha256.Sum256(content)
+		extension := path.Ext(name)
+		hashed := strings.TrimSuffix(name, extension) + "." + hex.EncodeToString(sum[:])[:assetHashLength] + extension
+
+		manifest.urls[name] = hashed
+		manifest.fingerprinted[hashed] = name
+
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("hashing assets: %w", err)
+	}
+
+	return manifest, nil
+}
+
+func newAssetManifest(fsys fs.FS, prefix string) *AssetManifest {
+	return &AssetManifest{
+		prefix:        prefix,
+		fsys:          fsys,
+		urls:          map[string]string{},
+		fingerprinted: map[string]string{},
+		plainURLs:     false,
+	}
+}
+
+// URL returns the cache-busted URL of the named asset. Assets which are not in
+// the manifest are returned with the prefix but without a fingerprint so that a
+// missing entry degrades to an uncached URL rather than a broken one.
+func (manifest *AssetManifest) URL(name string) string {
+	name = strings.TrimPrefix(name, "/")
+	if file, found := manifest.urls[name]; found && !manifest.plainURLs {
+		return manifest.prefix + file
+	}
+
+	return manifest.prefix + name
+}
+
+// Handler returns an http.Handler which serves the assets from the file system the
+// manifest was created from. Request paths are expected to be relative to the
+// prefix, so the handler is usually wrapped with http.StripPrefix:
+//
+//	router.GET("/static/*filepath", gin.WrapH(http.StripPrefix("/static/", manifest.Handler())))
+//
+// Fingerprinted assets are served with a long-lived immutable Cache-Control header,
+// while hashed assets requested by their original name must be revalidated.
+func (manifest *AssetManifest) Handler() http.Handler {
+	return http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		name := strings.TrimPrefix(path.Clean("/"+request.URL.Path), "/")
+
+		if original, found := manifest.fingerprinted[name]; found {
+			writer.Header().Set("Cache-Control", immutableCacheControl)
+
+			name = original
+		} else if manifest.isBundlerOutput(name) {
+			writer.Header().Set("Cache-Control", immutableCacheControl)
+		} else if hashed, found := manifest.urls[name]; found && len(manifest.fingerprinted) > 0 {
+			// The file was requested without its fingerprint, so it may be
+			// revalidated using the fingerprint as its ETag.
+			writer.Header().Set("Cache-Control", revalidateCacheControl)
+			writer.Header().Set("ETag", `"`+hashed+`"`)
+		}
+
+		http.ServeFileFS(writer, request, manifest.fsys, name)
+	})
+}
+
+// ServeAssets serves the static assets in fsys, such as an embedded directory of
+// stylesheets, scripts and images, under prefix on router, and returns a manifest
+// for the Assets of the configuration so that the "asset" template function
+// produces their URLs:
+//
+//	//go:embed static
+//	var static embed.FS
+//
+//	staticFiles, _ := fs.Sub(static, "static")
+//	assets, err := ginhtmx.ServeAssets(router, "/static/", staticFiles, true)
+//
+// When fingerprint is true the URLs include a hash of the content of each file,
+// see HashAssets, and are served with a long-lived immutable Cache-Control header.
+// Otherwise the URLs are the names of the files, which are served with the hash as
+// their ETag so that browsers revalidate them cheaply. The prefix is relative to
+// router, so the URLs of the manifest include the base path of a router group.
+func ServeAssets(router gin.IRoutes, prefix string, fsys fs.FS, fingerprint bool) (*AssetManifest, error) {
+	prefix = "/" + strings.Trim(prefix, "/") + "/"
+	urlPrefix := prefix
+
+	if group, ok := router.(interface{ BasePath() string }); ok {
+		urlPrefix = strings.TrimSuffix(group.BasePath(), "/") + prefix
+	}
+
+	manifest, err := HashAssets(fsys, urlPrefix)
+	if err != nil {
+		return nil, err
+	}
+
+	manifest.plainURLs = !fingerprint
+
+	handler := manifest.Handler()
+	serve := func(ginContext *gin.Context) {
+		request := ginContext.Request.Clone(ginContext.Request.Context())
+		request.URL.Path = ginContext.Param("filepath")
+		request.URL.RawPath = ""
+		handler.ServeHTTP(ginContext.Writer, request)
+	}
+
+	router.GET(prefix+"*filepath", serve)
+	router.HEAD(prefix+"*filepath", serve)
+
+	return manifest, nil
+}
+
+// isBundlerOutput reports whether name is a file listed in a bundler manifest, the
+// names of which already contain a content hash.
+func (manifest *AssetManifest) isBundlerOutput(name string) bool {
+	if len(manifest.fingerprinted) > 0 {
+		return false
+	}
+
+	for _, file := range manifest.urls {
+		if file == name {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (scope *requestScope) asset(name string) string {
+	if scope.htmx == nil || scope.htmx.config.Assets == nil {
+		return name
+	}
+
+	return scope.htmx.config.Assets.URL(name)
+}
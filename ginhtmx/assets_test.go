@@ -0,0 +1,188 @@
+package ginhtmx_test
+
+import (
+	"html/template"
+	"io/fs"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"testing/fstest"
+
+	"github.com/gin-gonic/gin"
+	"github.com/jeffscottbrown/ginhtmxtemplates/ginhtmx"
+	"github.com/stretchr/testify/suite"
+)
+
+func (suite *AssetsTestSuite) TestViteManifest() {
+	fsys := fstest.MapFS{
+		"manifest.json": {Data: []byte(`{
+			"src/main.js": {"file": "assets/main-4f2c.js", "isEntry": true},
+			"app.css": "assets/app-9a1b.css"
+		}`)},
+		"assets/main-4f2c.js": {Data: []byte(`console.log("hi")`)},
+		"robots.txt":          {Data: []byte(`User-agent: *`)},
+	}
+
+	manifest, err := ginhtmx.LoadAssetManifest(fsys, "manifest.json", "/static/")
+	suite.Require().NoError(err)
+
+	suite.Equal("/static/assets/main-4f2c.js", manifest.URL("src/main.js"))
+	suite.Equal("/static/assets/app-9a1b.css", manifest.URL("/app.css"))
+	suite.Equal("/static/other.css", manifest.URL("other.css"))
+
+	recorder := httptest.NewRecorder()
+	manifest.Handler().ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/assets/main-4f2c.js", nil))
+	suite.Equal(http.StatusOK, recorder.Code)
+	suite.Equal("public, max-age=31536000, immutable", recorder.Header().Get("Cache-Control"))
+
+	recorder = httptest.NewRecorder()
+	manifest.Handler().ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/robots.txt", nil))
+	suite.Equal(http.StatusOK, recorder.Code)
+	suite.Empty(recorder.Header().Get("Cache-Control"))
+}
+
+func (suite *AssetsTestSuite) TestInvalidManifests() {
+	_, err := ginhtmx.LoadAssetManifest(fstest.MapFS{}, "manifest.json", "/")
+	suite.Require().Error(err)
+
+	_, err = ginhtmx.LoadAssetManifest(fstest.MapFS{"m.json": {Data: []byte(`[`)}}, "m.json", "/")
+	suite.Require().ErrorContains(err, "m.json")
+
+	_, err = ginhtmx.LoadAssetManifest(fstest.MapFS{"m.json": {Data: []byte(`{"a.css": 3}`)}}, "m.json", "/")
+	suite.Require().ErrorContains(err, "a.css")
+}
+
+func (suite *AssetsTestSuite) TestHashedAssetsAreServedWithTemplateFunction() {
+	fsys := fstest.MapFS{
+		"css/app.css": {Data: []byte(`body { color: red; }`)},
+	}
+
+	manifest, err := ginhtmx.HashAssets(fsys, "/static/")
+	suite.Require().NoError(err)
+
+	url := manifest.URL("css/app.css")
+	suite.Regexp(`^/static/css/app\.[0-9a-f]{12}\.css$`, url)
+
+	tmpl := template.Must(template.New("").Funcs(ginhtmx.FuncMap()).Parse(
+		`{{define "styles"}}<link rel="stylesheet" href="{{ asset "css/app.css" }}">{{end}}`))
+	htmx := ginhtmx.NewHtmxWithConfig(tmpl, ginhtmx.HtmxConfig{
+		LayoutTemplateName:  "layout",
+		ContentVariableName: "Content",
+		ModelDecorator:      nil,
+		Translator:          nil,
+		LocaleResolver:      nil,
+		Assets:              manifest,
+	})
+
+	router := gin.New()
+	router.GET("/", func(c *gin.Context) {
+		c.Request.Header.Set("Hx-Request", "true")
+		htmx.Render(c, gin.H{}, "styles")
+	})
+	router.GET("/static/*filepath", gin.WrapH(http.StripPrefix("/static/", manifest.Handler())))
+
+	recorder := httptest.NewRecorder()
+	router.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/", nil))
+	suite.Equal(`<link rel="stylesheet" href="`+url+`">`, recorder.Body.String())
+
+	recorder = httptest.NewRecorder()
+	router.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, url, nil))
+	suite.Equal(http.StatusOK, recorder.Code)
+	suite.Equal("body { color: red; }", recorder.Body.String())
+	suite.Equal("public, max-age=31536000, immutable", recorder.Header().Get("Cache-Control"))
+}
+
+func (suite *AssetsTestSuite) TestServeAssetsWithFingerprints() {
+	fsys := fstest.MapFS{
+		"css/app.css": {Data: []byte(`body { color: red; }`)},
+	}
+
+	router := gin.New()
+	manifest, err := ginhtmx.ServeAssets(router.Group("/app"), "static", fsys, true)
+	suite.Require().NoError(err)
+
+	url := manifest.URL("css/app.css")
+	suite.Regexp(`^/app/static/css/app\.[0-9a-f]{12}\.css$`, url)
+
+	recorder := suite.request(router, http.MethodGet, url, "")
+	suite.Equal(http.StatusOK, recorder.Code)
+	suite.Equal("body { color: red; }", recorder.Body.String())
+	suite.Equal("public, max-age=31536000, immutable", recorder.Header().Get("Cache-Control"))
+
+	recorder = suite.request(router, http.MethodGet, "/app/static/css/app.css", "")
+	suite.Equal(http.StatusOK, recorder.Code)
+	suite.Equal("public, max-age=0, must-revalidate", recorder.Header().Get("Cache-Control"))
+	suite.Equal(`"`+strings.TrimPrefix(url, "/app/static/")+`"`, recorder.Header().Get("ETag"))
+
+	recorder = suite.request(router, http.MethodGet, "/app/static/css/app.css", recorder.Header().Get("ETag"))
+	suite.Equal(http.StatusNotModified, recorder.Code)
+}
+
+func (suite *AssetsTestSuite) TestServeAssetsWithoutFingerprints() {
+	fsys := fstest.MapFS{
+		"js/app.js": {Data: []byte(`console.log("hello")`)},
+	}
+
+	router := gin.New()
+	manifest, err := ginhtmx.ServeAssets(router, "/static/", fsys, false)
+	suite.Require().NoError(err)
+	suite.Equal("/static/js/app.js", manifest.URL("js/app.js"))
+	suite.Equal("/static/missing.js", manifest.URL("missing.js"))
+
+	recorder := suite.request(router, http.MethodGet, "/static/js/app.js", "")
+	suite.Equal(http.StatusOK, recorder.Code)
+	suite.Equal(`console.log("hello")`, recorder.Body.String())
+	suite.Equal("public, max-age=0, must-revalidate", recorder.Header().Get("Cache-Control"))
+	suite.NotEmpty(recorder.Header().Get("ETag"))
+
+	recorder = suite.request(router, http.MethodHead, "/static/js/app.js", "")
+	suite.Equal(http.StatusOK, recorder.Code)
+	suite.Empty(recorder.Body.String())
+
+	recorder = suite.request(router, http.MethodGet, "/static/missing.js", "")
+	suite.Equal(http.StatusNotFound, recorder.Code)
+}
+
+func (suite *AssetsTestSuite) TestServeAssetsWithUnreadableFileSystem() {
+	fsys := fstest.MapFS{
+		"app.css": {Data: nil, Mode: fs.ModeSymlink},
+	}
+
+	_, err := ginhtmx.ServeAssets(gin.New(), "/static/", fsys, true)
+	suite.Error(err)
+}
+
+func (suite *AssetsTestSuite) TestAssetFunctionWithoutManifest() {
+	tmpl := template.Must(template.New("").Funcs(ginhtmx.FuncMap()).Parse(`{{define "x"}}{{ asset "app.css" }}{{end}}`))
+	htmx := ginhtmx.NewHtmx(tmpl)
+
+	recorder := httptest.NewRecorder()
+	testContext, _ := gin.CreateTestContext(recorder)
+	testContext.Request = httptest.NewRequest(http.MethodGet, "/", nil)
+	testContext.Request.Header.Set("Hx-Request", "true")
+
+	htmx.Render(testContext, gin.H{}, "x")
+	suite.Equal("app.css", recorder.Body.String())
+}
+
+func (suite *AssetsTestSuite) request(router *gin.Engine, method, url, ifNoneMatch string) *httptest.ResponseRecorder {
+	request := httptest.NewRequest(method, url, nil)
+	if ifNoneMatch != "" {
+		request.Header.Set("If-None-Match", ifNoneMatch)
+	}
+
+	recorder := httptest.NewRecorder()
+	router.ServeHTTP(recorder, request)
+
+	return recorder
+}
+
+func TestAssetsTestSuite(t *testing.T) {
+	t.Parallel()
+	suite.Run(t, new(AssetsTestSuite))
+}
+
+type AssetsTestSuite struct {
+	suite.Suite
+}
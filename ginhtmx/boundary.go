@@ -0,0 +1,43 @@
+package ginhtmx
+
+import (
+	"context"
+	"errors"
+	"html/template"
+
+	"github.com/gin-gonic/gin"
+)
+
+// FragmentError describes a template which failed to render, for the ErrorBoundary
+// template, which is rendered with it under the "Error" key.
+type FragmentError struct {
+	// Template is the name of the template which failed
+	Template string
+
+	// Err is the error of the template, which should not be shown to the user
+	Err error
+}
+
+// executeBoundedTemplate renders the named template like executeTemplate, except
+// that if an ErrorBoundary is configured and the template fails or panics, the
+// ErrorBoundary is rendered in its place. The error of the template is still
+// returned so that it is recorded as an error of the render.
+func (htmx *Htmx) executeBoundedTemplate(
+	ctx context.Context, tmpl *template.Template, name string, data gin.H, fragment bool,
+) (string, error) {
+	if htmx.config.ErrorBoundary == "" || htmx.config.Strict {
+		return htmx.executeTemplate(ctx, tmpl, name, data, fragment)
+	}
+
+	// Panics of the template are returned as errors by executeRecovering.
+	rendered, err := htmx.executeTemplate(ctx, tmpl, name, data, fragment)
+	if err == nil {
+		return rendered, nil
+	}
+
+	model := mergeModels(data, gin.H{"Error": FragmentError{Template: name, Err: err}})
+
+	placeholder, placeholderErr := htmx.executeTemplate(ctx, tmpl, htmx.config.ErrorBoundary, model, fragment)
+
+	return placeholder, errors.Join(err, placeholderErr)
+}
@@ -0,0 +1,112 @@
+package ginhtmx_test
+
+import (
+	"html/template"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/jeffscottbrown/ginhtmxtemplates/ginhtmx"
+	"github.com/stretchr/testify/suite"
+)
+
+func (suite *BoundaryTestSuite) TestFailingTemplatesAreReplacedByTheBoundary() {
+	recorder, testContext := suite.render(suite.config, true, "header", "broken", "footer")
+
+	suite.Equal(http.StatusOK, recorder.Code)
+	suite.Equal(`<h1>Jerry</h1><div class="unavailable" data-template="broken">Jerry, this section is unavailable</div>`+
+		`<footer></footer>`, recorder.Body.String())
+
+	renderError, _ := testContext.Value(ginhtmx.RenderErrorKey).(error)
+	suite.Require().ErrorContains(renderError, `executing "broken"`)
+}
+
+func (suite *BoundaryTestSuite) TestPanickingTemplatesAreReplacedByTheBoundary() {
+	suite.config.ConcurrentRendering = true
+
+	recorder, testContext := suite.render(suite.config, false, "header", "panicking")
+
+	suite.Equal(`<html><h1>Jerry</h1><div class="unavailable" data-template="panicking">`+
+		`Jerry, this section is unavailable</div></html>`, recorder.Body.String())
+
+	renderError, _ := testContext.Value(ginhtmx.RenderErrorKey).(error)
+	suite.Require().ErrorIs(renderError, ginhtmx.ErrTemplatePanic)
+	suite.Require().ErrorContains(renderError, `executing "panicking": exploded`)
+}
+
+func (suite *BoundaryTestSuite) TestStreamedPagesUseTheBoundary() {
+	suite.config.StreamLayout = true
+
+	recorder, _ := suite.render(suite.config, false, "broken", "footer")
+
+	suite.Equal(`<html><div class="unavailable" data-template="broken">Jerry, this section is unavailable</div>`+
+		`<footer></footer></html>`, recorder.Body.String())
+}
+
+func (suite *BoundaryTestSuite) TestBoundaryIsNotUsedInStrictMode() {
+	suite.config.Strict = true
+
+	recorder, _ := suite.render(suite.config, true, "header", "broken")
+
+	suite.Equal(http.StatusInternalServerError, recorder.Code)
+	suite.NotContains(recorder.Body.String(), "unavailable")
+}
+
+func (suite *BoundaryTestSuite) TestFailingBoundariesAreReported() {
+	suite.config.ErrorBoundary = "missing"
+
+	recorder, testContext := suite.render(suite.config, true, "header", "broken")
+
+	suite.Equal(`<h1>Jerry</h1>`, recorder.Body.String())
+
+	renderError, _ := testContext.Value(ginhtmx.RenderErrorKey).(error)
+	suite.Require().ErrorIs(renderError, ginhtmx.ErrTemplateNotFound)
+	suite.Require().ErrorContains(renderError, `executing "broken"`)
+}
+
+func (suite *BoundaryTestSuite) render(
+	config ginhtmx.HtmxConfig, fragment bool, templateNames ...string,
+) (*httptest.ResponseRecorder, *gin.Context) {
+	recorder := httptest.NewRecorder()
+	testContext, _ := gin.CreateTestContext(recorder)
+	testContext.Request = httptest.NewRequest(http.MethodGet, "/", nil)
+
+	if fragment {
+		testContext.Request.Header.Set("Hx-Request", "true")
+	}
+
+	data := gin.H{"Name": "Jerry", "Items": panickingItems}
+	ginhtmx.NewHtmxWithConfig(suite.template, config).Render(testContext, data, templateNames...)
+
+	return recorder, testContext
+}
+
+func (suite *BoundaryTestSuite) SetupTest() {
+	suite.template = template.Must(template.New("").Parse(`
+{{- define "layout"}}<html>{{ .Content }}</html>{{end -}}
+{{- define "header"}}<h1>{{ .Name }}</h1>{{end -}}
+{{- define "broken"}}<p>{{ index .Name 10 }}</p>{{end -}}
+{{- define "footer"}}<footer></footer>{{end -}}
+{{- define "panicking"}}<ul>{{ range .Items }}<li>{{ . }}</li>{{ end }}</ul>{{end -}}
+{{- define "unavailable"}}<div class="unavailable" data-template="{{ .Error.Template }}">
+{{- .Name }}, this section is unavailable</div>{{end -}}
+`))
+	suite.config = ginhtmx.HtmxConfig{
+		LayoutTemplateName:  "layout",
+		ContentVariableName: "Content",
+		ErrorBoundary:       "unavailable",
+	}
+}
+
+func TestBoundaryTestSuite(t *testing.T) {
+	t.Parallel()
+	suite.Run(t, new(BoundaryTestSuite))
+}
+
+type BoundaryTestSuite struct {
+	suite.Suite
+
+	template *template.Template
+	config   ginhtmx.HtmxConfig
+}
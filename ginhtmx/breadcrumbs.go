@@ -0,0 +1,83 @@
+package ginhtmx
+
+import "github.com/gin-gonic/gin"
+
+const (
+	// BreadcrumbsModelKey is the key under which BreadcrumbsDecorator exposes the
+	// breadcrumbs to templates.
+	BreadcrumbsModelKey = "Breadcrumbs"
+
+	// breadcrumbsKey is the key under which the breadcrumbs are stored in the gin context.
+	breadcrumbsKey = "ginhtmx.breadcrumbs"
+)
+
+// Breadcrumb is a single segment of a breadcrumb trail.
+type Breadcrumb struct {
+	// Label is the text of the segment
+	Label string
+
+	// URL is the URL the segment links to. The segment is not linked if it is empty.
+	URL string
+
+	// Current is true for the last segment, which is the current page
+	Current bool
+}
+
+// Breadcrumbs is the breadcrumb trail of a request. Middleware and handlers append
+// segments to the trail returned by BreadcrumbsFor, BreadcrumbsDecorator exposes it
+// to templates and the built-in "ginhtmx/breadcrumbs" template, which may be
+// overridden, renders it:
+//
+//	router.Use(func(c *gin.Context) { ginhtmx.BreadcrumbsFor(c).Add("Home", "/") })
+//
+//	ginhtmx.BreadcrumbsFor(c).Add("Users", "/users").Add(user.Name, "")
+//
+//	{{ template "ginhtmx/breadcrumbs" .Breadcrumbs }}
+type Breadcrumbs struct {
+	// Target is an optional hx-target for the links
+	Target string
+
+	segments []Breadcrumb
+}
+
+// BreadcrumbsFor returns the breadcrumb trail of the request, creating an empty
+// trail if the request does not have one yet.
+func BreadcrumbsFor(ginContext *gin.Context) *Breadcrumbs {
+	breadcrumbs, ok := ginContext.Value(breadcrumbsKey).(*Breadcrumbs)
+	if !ok {
+		breadcrumbs = &Breadcrumbs{Target: "", segments: nil}
+		ginContext.Set(breadcrumbsKey, breadcrumbs)
+	}
+
+	return breadcrumbs
+}
+
+// Add appends a segment to the trail and returns the trail so that calls may be chained.
+func (breadcrumbs *Breadcrumbs) Add(label string, url string) *Breadcrumbs {
+	breadcrumbs.segments = append(breadcrumbs.segments, Breadcrumb{Label: label, URL: url, Current: false})
+
+	return breadcrumbs
+}
+
+// Items returns the segments of the trail, with the last segment marked as current.
+func (breadcrumbs *Breadcrumbs) Items() []Breadcrumb {
+	items := make([]Breadcrumb, len(breadcrumbs.segments))
+	copy(items, breadcrumbs.segments)
+
+	if len(items) > 0 {
+		items[len(items)-1].Current = true
+	}
+
+	return items
+}
+
+// BreadcrumbsDecorator returns a ModelDecorator which exposes the breadcrumb trail
+// of the request to templates as .Breadcrumbs. Combine it with other decorators
+// using ModelDecorators.
+func BreadcrumbsDecorator() ModelDecorator {
+	return ModelDecoratorFunc(func(ginContext *gin.Context, model *gin.H) {
+		if _, exists := (*model)[BreadcrumbsModelKey]; !exists {
+			(*model)[BreadcrumbsModelKey] = BreadcrumbsFor(ginContext)
+		}
+	})
+}
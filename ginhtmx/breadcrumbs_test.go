@@ -0,0 +1,67 @@
+package ginhtmx_test
+
+import (
+	"html/template"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/jeffscottbrown/ginhtmxtemplates/ginhtmx"
+	"github.com/stretchr/testify/suite"
+)
+
+func (suite *BreadcrumbsTestSuite) TestTrailIsSharedWithinRequest() {
+	testContext, _ := gin.CreateTestContext(httptest.NewRecorder())
+
+	ginhtmx.BreadcrumbsFor(testContext).Add("Home", "/")
+	ginhtmx.BreadcrumbsFor(testContext).Add("Users", "/users").Add("Jerry", "")
+
+	suite.Equal([]ginhtmx.Breadcrumb{
+		{Label: "Home", URL: "/", Current: false},
+		{Label: "Users", URL: "/users", Current: false},
+		{Label: "Jerry", URL: "", Current: true},
+	}, ginhtmx.BreadcrumbsFor(testContext).Items())
+
+	other, _ := gin.CreateTestContext(httptest.NewRecorder())
+	suite.Empty(ginhtmx.BreadcrumbsFor(other).Items())
+}
+
+func (suite *BreadcrumbsTestSuite) TestDecoratorAndBuiltInTemplate() {
+	router := gin.New()
+	router.Use(func(c *gin.Context) {
+		ginhtmx.BreadcrumbsFor(c).Add("Home", "/")
+		ginhtmx.BreadcrumbsFor(c).Target = "#content"
+	})
+
+	tmpl := template.Must(template.New("").Parse(`{{define "user"}}{{template "ginhtmx/breadcrumbs" .Breadcrumbs}}{{end}}`))
+	htmx := ginhtmx.NewHtmxWithConfig(tmpl, ginhtmx.HtmxConfig{
+		LayoutTemplateName:  "layout",
+		ContentVariableName: "Content",
+		ModelDecorator:      ginhtmx.BreadcrumbsDecorator(),
+	})
+
+	router.GET("/users/3", func(c *gin.Context) {
+		ginhtmx.BreadcrumbsFor(c).Add("Users", "/users").Add("Jerry", "/users/3")
+		htmx.Render(c, gin.H{}, "user")
+	})
+
+	recorder := httptest.NewRecorder()
+	request := httptest.NewRequest(http.MethodGet, "/users/3", nil)
+	request.Header.Set("Hx-Request", "true")
+	router.ServeHTTP(recorder, request)
+
+	body := recorder.Body.String()
+	suite.Contains(body, `<li><a href="/" hx-get="/" hx-target="#content" hx-push-url="true">Home</a></li>`)
+	suite.Contains(body, `<li><a href="/users" hx-get="/users" hx-target="#content" hx-push-url="true">Users</a></li>`)
+	suite.Contains(body, `<li aria-current="page">Jerry</li>`)
+}
+
+func TestBreadcrumbsTestSuite(t *testing.T) {
+	t.Parallel()
+	suite.Run(t, new(BreadcrumbsTestSuite))
+}
+
+type BreadcrumbsTestSuite struct {
+	suite.Suite
+}
@@ -0,0 +1,36 @@
+package ginhtmx
+
+import (
+	"embed"
+	"html/template"
+	"io/fs"
+	"path"
+	"strings"
+)
+
+// builtinTemplatePrefix is the prefix of the names of the templates provided by this package.
+const builtinTemplatePrefix = "ginhtmx/"
+
+//go:embed templates/*.html
+var builtinTemplates embed.FS
+
+// installBuiltinTemplates adds the templates provided by this package, such as
+// "ginhtmx/pagination", to tmpl. A template which is already defined in tmpl is not
+// replaced, so applications may override any of them by defining a template with
+// the same name. The built-in templates only use the standard template functions.
+func installBuiltinTemplates(tmpl *template.Template) {
+	entries, _ := fs.ReadDir(builtinTemplates, "templates")
+
+	for _, entry := range entries {
+		name := builtinTemplatePrefix + strings.TrimSuffix(entry.Name(), path.Ext(entry.Name()))
+		if tmpl.Lookup(name) != nil {
+			continue
+		}
+
+		source, _ := fs.ReadFile(builtinTemplates, path.Join("templates", entry.Name()))
+
+		// Parsing only fails if tmpl has already been executed, in which case the
+		// template is left without the built-in templates.
+		_, _ = tmpl.New(name).Parse(strings.TrimSpace(string(source)))
+	}
+}
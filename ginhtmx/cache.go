@@ -0,0 +1,517 @@
+package ginhtmx
+
+import (
+	"container/list"
+	"context"
+	"errors"
+	"fmt"
+	"html/template"
+	"log/slog"
+	"maps"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Cache stores rendered fragments. MemoryCache is a simple implementation for a
+// single instance, while applications running several instances can implement
+// Cache with Redis or memcached so that the instances share their fragments.
+type Cache interface {
+	// Get returns the value stored under key and whether it was found.
+	Get(ctx context.Context, key string) ([]byte, bool, error)
+
+	// Set stores value under key for ttl. A ttl of zero means the value does not
+	// expire, although the cache may still evict it.
+	Set(ctx context.Context, key string, value []byte, ttl time.Duration) error
+
+	// Delete removes the value stored under key, if any.
+	Delete(ctx context.Context, key string) error
+}
+
+// cacheSettings describe how the output of a render is cached, see CacheFor.
+type cacheSettings struct {
+	ttl           time.Duration
+	discriminator string
+	tags          []string
+}
+
+// CacheFor returns a RenderOption which caches the output of the rendered templates
+// in the Cache of the configuration for ttl. The layout, wrappers and appended
+// templates such as toasts are rendered on every request, so only the output of
+// the templates passed to Render is cached:
+//
+//	htmx.With(ginhtmx.CacheFor(time.Minute, strconv.Itoa(product.ID))).Render(c, data, "product")
+//
+// The key, see CacheKey, is made of the template names, whether the response is a
+// fragment and discriminator, which must distinguish every variation of the
+// output, such as the ID of the product, the locale or the user. As cached output
+// is not rendered again, template functions such as "setTitle" have no effect when
+// it is used. Renders which fail are not cached, and failures of the cache itself
+// are logged to the Logger of the configuration rather than failing the render.
+// When Debug is enabled, a comment appended to fragments shows whether their output
+// was served from the cache, under which key and how old it is.
+//
+// The output may be tagged with the data it is derived from, so that every
+// fragment showing that data can be evicted with InvalidateTag when it changes:
+//
+//	ginhtmx.CacheFor(time.Hour, "42", "product:42", "user:7")
+func CacheFor(ttl time.Duration, discriminator string, tags ...string) RenderOption {
+	return func(settings *renderSettings) {
+		settings.cache = &cacheSettings{ttl: ttl, discriminator: discriminator, tags: tags}
+	}
+}
+
+// InvalidateTag evicts the output cached with any of tags from the Cache of the
+// configuration. Rather than deleting each entry, the version of each tag is
+// changed, which changes the keys of the entries tagged with it, so invalidation
+// takes a single write per tag with any Cache. The evicted entries expire with
+// their ttl or, as they are never read again, are the first to be evicted by a
+// Cache bounded by recency such as MemoryCache.
+func (htmx *Htmx) InvalidateTag(ctx context.Context, tags ...string) error {
+	if htmx.config.Cache == nil {
+		return nil
+	}
+
+	version := newTagVersion()
+
+	for _, tag := range tags {
+		err := htmx.config.Cache.Set(ctx, tagVersionKey(tag), version, 0)
+		if err != nil {
+			return fmt.Errorf("invalidating cache tag %q: %w", tag, err)
+		}
+	}
+
+	return nil
+}
+
+// CacheKey returns the key under which the output of templateNames is cached by
+// CacheFor, so that it can be deleted from the Cache when the data changes. Output
+// cached with tags is stored under a key which also includes the versions of its
+// tags and is evicted with InvalidateTag instead.
+func CacheKey(templateNames []string, fragment bool, discriminator string) string {
+	return "ginhtmx:" + variantLabel(fragment) + ":" + strings.Join(templateNames, ",") + ":" + discriminator
+}
+
+// tagVersionKey returns the key under which the current version of tag is stored.
+func tagVersionKey(tag string) string {
+	return "ginhtmx:tag:" + tag
+}
+
+// newTagVersion returns a version of a tag which differs from its previous versions.
+func newTagVersion() []byte {
+	return []byte(strconv.FormatInt(time.Now().UnixNano(), 36))
+}
+
+// taggedKey returns key extended with the current version of each of tags. The
+// versions are stored in the Cache along with the output, so they may be evicted
+// like any other value. A tag whose version is missing is given a new version, as
+// a constant one would bring back the output cached before the tag was last
+// invalidated.
+func (htmx *Htmx) taggedKey(ctx context.Context, key string, tags []string) (string, error) {
+	for _, tag := range tags {
+		version, found, err := htmx.config.Cache.Get(ctx, tagVersionKey(tag))
+		if err != nil {
+			return "", fmt.Errorf("reading version of cache tag %q: %w", tag, err)
+		}
+
+		if !found {
+			version = newTagVersion()
+
+			err = htmx.config.Cache.Set(ctx, tagVersionKey(tag), version, 0)
+			if err != nil {
+				return "", fmt.Errorf("writing version of cache tag %q: %w", tag, err)
+			}
+		}
+
+		key += ":" + tag + "@" + string(version)
+	}
+
+	return key, nil
+}
+
+// renderCachedTemplates renders the named templates, or returns their cached
+// output when the render uses CacheFor.
+func (htmx *Htmx) renderCachedTemplates(
+	ctx context.Context, ginContext *gin.Context, tmpl *template.Template, data gin.H, settings *renderSettings,
+	templateNames []string, fragment bool,
+) (string, []error) {
+	if settings.cache == nil || htmx.config.Cache == nil {
+		return htmx.renderTemplates(ctx, tmpl, data, templateNames, fragment)
+	}
+
+	names := htmx.resolveTemplateNames(ctx, tmpl, templateNames)
+
+	// cached output is shared between requests, so it is only served to those which
+	// may render the templates
+	for index, name := range templateNames {
+		err := htmx.guardTemplate(ctx, name, names[index])
+		if err != nil {
+			return "", []error{err}
+		}
+	}
+
+	if settings.templateSet != "" {
+		// templates of different sets may have the same names
+		for index, name := range names {
+			names[index] = settings.templateSet + ":" + name
+		}
+	}
+
+	baseKey := CacheKey(names, fragment, settings.cache.discriminator)
+
+	key, err := htmx.taggedKey(ctx, baseKey, settings.cache.tags)
+	if err != nil {
+		htmx.logCacheError(ctx, "ginhtmx: reading from cache failed", baseKey, err)
+		htmx.observeCache(templateNames, fragment, CacheMiss)
+
+		content, errs := htmx.renderTemplates(ctx, tmpl, data, templateNames, fragment)
+
+		return content + htmx.cacheStatusComment(fragment, CacheMiss, baseKey, 0), errs
+	}
+
+	if settings.staleGrace > 0 {
+		return htmx.renderStaleWhileRevalidate(ctx, ginContext, tmpl, data, settings, templateNames, fragment, key)
+	}
+
+	if value, found := htmx.cachedContent(ctx, key); found {
+		if storedAt, content, ok := parseEntry(value); ok {
+			htmx.observeCache(templateNames, fragment, CacheHit)
+
+			return content + htmx.cacheStatusComment(fragment, CacheHit, key, settings.currentTime().Sub(storedAt)), nil
+		}
+	}
+
+	htmx.observeCache(templateNames, fragment, CacheMiss)
+
+	content, errs := htmx.renderTemplates(ctx, tmpl, data, templateNames, fragment)
+
+	if errors.Join(errs...) == nil {
+		htmx.storeEntry(ctx, key, settings, settings.cache.ttl, content)
+	}
+
+	return content + htmx.cacheStatusComment(fragment, CacheMiss, key, 0), errs
+}
+
+// StaleWhileRevalidate returns a RenderOption which, along with CacheFor, keeps
+// serving cached output for up to grace after its ttl has expired. The stale output
+// is returned immediately while the templates are rendered again in the background
+// to refresh the cache, which keeps slow fragments such as reports and aggregates
+// fast for every request rather than for all but one request per ttl:
+//
+//	htmx.With(ginhtmx.CacheFor(time.Minute, "sales"), ginhtmx.StaleWhileRevalidate(time.Hour)).
+//	  Render(c, data, "sales_report")
+//
+// The background render uses a copy of the gin context and of the model, so the
+// values of the model must remain valid after the handler has returned. Output
+// older than ttl plus grace is rendered again before responding, as without this
+// option, and failures of the background render are logged to the Logger of the
+// configuration. Only one background render of each cache entry runs at a time,
+// and it is traced by a span of its own linked to the span of the request.
+func StaleWhileRevalidate(grace time.Duration) RenderOption {
+	return func(settings *renderSettings) {
+		settings.staleGrace = grace
+	}
+}
+
+// renderStaleWhileRevalidate returns the output cached under key, rendering it
+// again in the background when it is stale, or renders and caches it when nothing
+// is cached.
+func (htmx *Htmx) renderStaleWhileRevalidate(
+	ctx context.Context, ginContext *gin.Context, tmpl *template.Template, data gin.H, settings *renderSettings,
+	templateNames []string, fragment bool, key string,
+) (string, []error) {
+	key += ":swr"
+
+	if value, found := htmx.cachedContent(ctx, key); found {
+		storedAt, content, ok := parseEntry(value)
+		freshUntil := storedAt.Add(settings.cache.ttl)
+		now := settings.currentTime()
+
+		if ok && !now.After(freshUntil.Add(settings.staleGrace)) {
+			result := CacheHit
+
+			if now.After(freshUntil) {
+				result = CacheStale
+				htmx.revalidate(ctx, ginContext, data, settings, templateNames, fragment, key)
+			}
+
+			htmx.observeCache(templateNames, fragment, result)
+
+			return content + htmx.cacheStatusComment(fragment, result, key, now.Sub(storedAt)), nil
+		}
+	}
+
+	htmx.observeCache(templateNames, fragment, CacheMiss)
+
+	content, errs := htmx.renderTemplates(ctx, tmpl, data, templateNames, fragment)
+
+	if errors.Join(errs...) == nil {
+		htmx.storeEntry(ctx, key, settings, settings.cache.ttl+settings.staleGrace, content)
+	}
+
+	return content + htmx.cacheStatusComment(fragment, CacheMiss, key, 0), errs
+}
+
+// revalidate renders the templates again in the background and caches the output
+// under key, unless they are already being rendered again for key.
+func (htmx *Htmx) revalidate(
+	ctx context.Context, ginContext *gin.Context, data gin.H, settings *renderSettings, templateNames []string,
+	fragment bool, key string,
+) {
+	if _, running := htmx.revalidations.LoadOrStore(key, struct{}{}); running {
+		return
+	}
+
+	// the request, and with it its context, ends before the background render does
+	ginContext = ginContext.Copy()
+	requestCtx := ctx
+	ctx = htmx.backgroundRenderContext(ctx, ginContext)
+	data = maps.Clone(data)
+	templateNames = slices.Clone(templateNames)
+
+	go func() {
+		defer htmx.revalidations.Delete(key)
+
+		ctx, span := htmx.startRevalidateSpan(requestCtx, ctx, templateNames)
+
+		ctx, cancel := htmx.withRenderTimeout(ctx)
+		defer cancel()
+
+		scope := htmx.newRequestScope(ginContext)
+		scope.ctx = ctx
+		scope.meta = settings.meta
+		scope.funcs = htmx.tenantFuncs(ginContext, settings.funcs)
+		scope.now = settings.now
+
+		tmpl, release, err := htmx.acquireTemplate(scope, settings.templateSet)
+		defer release()
+
+		content, errs := htmx.renderTemplates(ctx, tmpl, data, templateNames, fragment)
+
+		err = errors.Join(append(errs, err)...)
+		endSpan(span, err)
+
+		if err != nil {
+			htmx.logCacheError(ctx, "ginhtmx: revalidating cached output failed", key, err)
+
+			return
+		}
+
+		htmx.storeEntry(ctx, key, settings, settings.cache.ttl+settings.staleGrace, content)
+	}()
+}
+
+// backgroundRenderContext returns a context for rendering the templates of the
+// request rendered with ctx after the request has ended. Only the values which
+// decide how the templates are resolved are carried over, as the span and the
+// Server-Timing header of the request are finished by then.
+func (htmx *Htmx) backgroundRenderContext(ctx context.Context, ginContext *gin.Context) context.Context {
+	background := context.Background()
+
+	if prefixes, ok := ctx.Value(templatePrefixesKey{}).([]string); ok {
+		background = context.WithValue(background, templatePrefixesKey{}, prefixes)
+	}
+
+	if variants, ok := ctx.Value(templateVariantsKey{}).(map[string]string); ok {
+		background = context.WithValue(background, templateVariantsKey{}, variants)
+	}
+
+	return htmx.withGuards(background, ginContext)
+}
+
+// storeEntry caches content under key for ttl, along with the time at which it was
+// rendered, from which its freshness and age are computed.
+func (htmx *Htmx) storeEntry(
+	ctx context.Context, key string, settings *renderSettings, ttl time.Duration, content string,
+) {
+	value := strconv.FormatInt(settings.currentTime().UnixNano(), 10) + "\n" + content
+
+	err := htmx.config.Cache.Set(ctx, key, []byte(value), ttl)
+	if err != nil {
+		htmx.logCacheError(ctx, "ginhtmx: writing to cache failed", key, err)
+	}
+}
+
+// parseEntry splits the value stored by storeEntry into the time at which the
+// output was rendered and the output.
+func parseEntry(value string) (time.Time, string, bool) {
+	storedAt, content, found := strings.Cut(value, "\n")
+	if !found {
+		return time.Time{}, "", false
+	}
+
+	nanos, err := strconv.ParseInt(storedAt, 10, 64)
+	if err != nil {
+		return time.Time{}, "", false
+	}
+
+	return time.Unix(0, nanos), content, true
+}
+
+// cachedContent returns the output cached under key, if any.
+func (htmx *Htmx) cachedContent(ctx context.Context, key string) (string, bool) {
+	value, found, err := htmx.config.Cache.Get(ctx, key)
+	if err != nil {
+		htmx.logCacheError(ctx, "ginhtmx: reading from cache failed", key, err)
+
+		return "", false
+	}
+
+	return string(value), found
+}
+
+// observeCache reports the result of looking up the output of templateNames to the
+// MetricsRecorder of the configuration.
+func (htmx *Htmx) observeCache(templateNames []string, fragment bool, result CacheResult) {
+	if htmx.config.Metrics != nil {
+		htmx.config.Metrics.ObserveCache(CacheObservation{Templates: templateNames, Fragment: fragment, Result: result})
+	}
+}
+
+// cacheStatusComment returns, when Debug is enabled, the comment appended to a
+// fragment to show whether its output was served from the cache, the key under
+// which it is cached and the age of the cached output, such as
+// <!-- ginhtmx cache: HIT key=ginhtmx:htmx:product:42 age=12s -->.
+func (htmx *Htmx) cacheStatusComment(fragment bool, result CacheResult, key string, age time.Duration) string {
+	if !htmx.config.Debug || !fragment {
+		return ""
+	}
+
+	// a comment cannot contain "--", which the discriminator of the key might
+	for strings.Contains(key, "--") {
+		key = strings.ReplaceAll(key, "--", "- -")
+	}
+
+	return fmt.Sprintf("<!-- ginhtmx cache: %s key=%s age=%s -->",
+		strings.ToUpper(string(result)), key, age.Round(time.Second))
+}
+
+func (htmx *Htmx) logCacheError(ctx context.Context, message string, key string, err error) {
+	if htmx.config.Logger != nil {
+		htmx.config.Logger.WarnContext(ctx, message, slog.String("key", key), slog.Any("error", err))
+	}
+}
+
+// DefaultMemoryCacheEntries is the number of values a MemoryCache created with
+// NewMemoryCache holds before it evicts the least recently used.
+const DefaultMemoryCacheEntries = 10000
+
+// MemoryCache is a Cache which keeps values in memory. It is safe for concurrent
+// use. Expired values are removed when they are next read, and once the cache
+// holds its maximum number of values the least recently used value is evicted to
+// make room for each new one. The bound matters as InvalidateTag leaves the
+// entries it evicts behind under keys which are never read again.
+type MemoryCache struct {
+	mutex      sync.Mutex
+	entries    map[string]*list.Element
+	recency    *list.List
+	maxEntries int
+}
+
+type memoryCacheEntry struct {
+	key     string
+	value   []byte
+	expires time.Time
+}
+
+// NewMemoryCache creates an empty MemoryCache holding up to
+// DefaultMemoryCacheEntries values.
+func NewMemoryCache() *MemoryCache {
+	return NewMemoryCacheWithLimit(DefaultMemoryCacheEntries)
+}
+
+// NewMemoryCacheWithLimit creates an empty MemoryCache holding up to maxEntries
+// values, or DefaultMemoryCacheEntries if maxEntries is not positive.
+func NewMemoryCacheWithLimit(maxEntries int) *MemoryCache {
+	if maxEntries <= 0 {
+		maxEntries = DefaultMemoryCacheEntries
+	}
+
+	return &MemoryCache{
+		mutex:      sync.Mutex{},
+		entries:    map[string]*list.Element{},
+		recency:    list.New(),
+		maxEntries: maxEntries,
+	}
+}
+
+// Get returns the value stored under key and whether it was found.
+func (cache *MemoryCache) Get(_ context.Context, key string) ([]byte, bool, error) {
+	cache.mutex.Lock()
+	defer cache.mutex.Unlock()
+
+	element, found := cache.entries[key]
+	if !found {
+		return nil, false, nil
+	}
+
+	entry, _ := element.Value.(*memoryCacheEntry)
+	if !entry.expires.IsZero() && time.Now().After(entry.expires) {
+		cache.remove(element)
+
+		return nil, false, nil
+	}
+
+	cache.recency.MoveToFront(element)
+
+	return entry.value, true, nil
+}
+
+// Set stores value under key for ttl.
+func (cache *MemoryCache) Set(_ context.Context, key string, value []byte, ttl time.Duration) error {
+	cache.mutex.Lock()
+	defer cache.mutex.Unlock()
+
+	var expires time.Time
+	if ttl > 0 {
+		expires = time.Now().Add(ttl)
+	}
+
+	entry := &memoryCacheEntry{key: key, value: value, expires: expires}
+
+	if element, found := cache.entries[key]; found {
+		element.Value = entry
+		cache.recency.MoveToFront(element)
+
+		return nil
+	}
+
+	cache.entries[key] = cache.recency.PushFront(entry)
+
+	for cache.recency.Len() > cache.maxEntries {
+		cache.remove(cache.recency.Back())
+	}
+
+	return nil
+}
+
+// Delete removes the value stored under key, if any.
+func (cache *MemoryCache) Delete(_ context.Context, key string) error {
+	cache.mutex.Lock()
+	defer cache.mutex.Unlock()
+
+	if element, found := cache.entries[key]; found {
+		cache.remove(element)
+	}
+
+	return nil
+}
+
+// Len returns the number of values held by the cache, including expired values
+// which have not been removed yet.
+func (cache *MemoryCache) Len() int {
+	cache.mutex.Lock()
+	defer cache.mutex.Unlock()
+
+	return cache.recency.Len()
+}
+
+// remove removes the entry of element from the cache.
+func (cache *MemoryCache) remove(element *list.Element) {
+	entry, _ := cache.recency.Remove(element).(*memoryCacheEntry)
+	delete(cache.entries, entry.key)
+}
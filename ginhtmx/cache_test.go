@@ -0,0 +1,405 @@
+package ginhtmx_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"html/template"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/jeffscottbrown/ginhtmxtemplates/ginhtmx"
+	"github.com/stretchr/testify/suite"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+var errCacheUnavailable = errors.New("cache unavailable")
+
+func (suite *CacheTestSuite) TestOutputIsCachedPerVariantAndDiscriminator() {
+	htmx := suite.newHtmx(ginhtmx.NewMemoryCache(), nil)
+	cached := htmx.With(ginhtmx.CacheFor(time.Minute, "7"))
+
+	suite.Equal("<p>Lamp</p>", suite.render(cached, gin.H{"Name": "Lamp"}, true, "product"))
+	suite.Equal("<p>Lamp</p>", suite.render(cached, gin.H{"Name": "Chair"}, true, "product"))
+	suite.Equal("<html><p>Chair</p></html>", suite.render(cached, gin.H{"Name": "Chair"}, false, "product"))
+	suite.Equal("<html><p>Chair</p></html>", suite.render(cached, gin.H{"Name": "Desk"}, false, "product"))
+
+	other := htmx.With(ginhtmx.CacheFor(time.Minute, "8"))
+	suite.Equal("<p>Desk</p>", suite.render(other, gin.H{"Name": "Desk"}, true, "product"))
+}
+
+func (suite *CacheTestSuite) TestDeletedOutputIsRenderedAgain() {
+	cache := ginhtmx.NewMemoryCache()
+	cached := suite.newHtmx(cache, nil).With(ginhtmx.CacheFor(time.Minute, "7"))
+
+	suite.render(cached, gin.H{"Name": "Lamp"}, true, "product")
+	suite.Require().NoError(cache.Delete(context.Background(), ginhtmx.CacheKey([]string{"product"}, true, "7")))
+
+	suite.Equal("<p>Chair</p>", suite.render(cached, gin.H{"Name": "Chair"}, true, "product"))
+}
+
+func (suite *CacheTestSuite) TestFailedRendersAreNotCached() {
+	cache := ginhtmx.NewMemoryCache()
+	cached := suite.newHtmx(cache, nil).With(ginhtmx.CacheFor(time.Minute, ""))
+
+	suite.render(cached, gin.H{"Name": "Lamp"}, true, "product", "missing")
+
+	_, found, err := cache.Get(context.Background(), ginhtmx.CacheKey([]string{"product", "missing"}, true, ""))
+	suite.Require().NoError(err)
+	suite.False(found)
+}
+
+func (suite *CacheTestSuite) TestOutputIsNotCachedWithoutCache() {
+	cached := suite.newHtmx(nil, nil).With(ginhtmx.CacheFor(time.Minute, ""))
+
+	suite.render(cached, gin.H{"Name": "Lamp"}, true, "product")
+
+	suite.Equal("<p>Chair</p>", suite.render(cached, gin.H{"Name": "Chair"}, true, "product"))
+}
+
+func (suite *CacheTestSuite) TestCacheFailuresAreLogged() {
+	var logs bytes.Buffer
+
+	cached := suite.newHtmx(failingCache{}, slog.New(slog.NewTextHandler(&logs, nil))).
+		With(ginhtmx.CacheFor(time.Minute, ""))
+
+	suite.Equal("<p>Lamp</p>", suite.render(cached, gin.H{"Name": "Lamp"}, true, "product"))
+	suite.Contains(logs.String(), `msg="ginhtmx: reading from cache failed" key=ginhtmx:htmx:product: error="cache unavailable"`)
+	suite.Contains(logs.String(), `msg="ginhtmx: writing to cache failed"`)
+}
+
+func (suite *CacheTestSuite) TestInvalidatingATagEvictsEveryFragmentTaggedWithIt() {
+	htmx := suite.newHtmx(ginhtmx.NewMemoryCache(), nil)
+	product := htmx.With(ginhtmx.CacheFor(time.Minute, "product", "product:7"))
+	listing := htmx.With(ginhtmx.CacheFor(time.Minute, "listing", "product:7", "category:2"))
+	other := htmx.With(ginhtmx.CacheFor(time.Minute, "other", "product:8"))
+
+	suite.render(product, gin.H{"Name": "Lamp"}, true, "product")
+	suite.render(listing, gin.H{"Name": "Lamp"}, true, "product")
+	suite.render(other, gin.H{"Name": "Chair"}, true, "product")
+
+	suite.Require().NoError(htmx.InvalidateTag(context.Background(), "product:7"))
+
+	suite.Equal("<p>Desk</p>", suite.render(product, gin.H{"Name": "Desk"}, true, "product"))
+	suite.Equal("<p>Desk</p>", suite.render(listing, gin.H{"Name": "Desk"}, true, "product"))
+	suite.Equal("<p>Chair</p>", suite.render(other, gin.H{"Name": "Desk"}, true, "product"))
+	suite.Equal("<p>Desk</p>", suite.render(product, gin.H{"Name": "Sofa"}, true, "product"))
+}
+
+func (suite *CacheTestSuite) TestOutputIsNotServedAgainWhenTheVersionOfItsTagIsEvicted() {
+	cache := ginhtmx.NewMemoryCache()
+	htmx := suite.newHtmx(cache, nil)
+	product := htmx.With(ginhtmx.CacheFor(time.Minute, "product", "product:7"))
+
+	suite.render(product, gin.H{"Name": "Lamp"}, true, "product")
+	suite.Require().NoError(htmx.InvalidateTag(context.Background(), "product:7"))
+	suite.Equal("<p>Desk</p>", suite.render(product, gin.H{"Name": "Desk"}, true, "product"))
+
+	suite.Require().NoError(cache.Delete(context.Background(), "ginhtmx:tag:product:7"))
+
+	suite.Equal("<p>Sofa</p>", suite.render(product, gin.H{"Name": "Sofa"}, true, "product"))
+	suite.Equal("<p>Sofa</p>", suite.render(product, gin.H{"Name": "Chair"}, true, "product"))
+}
+
+func (suite *CacheTestSuite) TestTaggedOutputIsRenderedWhenTagVersionsCannotBeWritten() {
+	var logs bytes.Buffer
+
+	cached := suite.newHtmx(readOnlyCache{Cache: ginhtmx.NewMemoryCache()}, slog.New(slog.NewTextHandler(&logs, nil))).
+		With(ginhtmx.CacheFor(time.Minute, "", "product:7"))
+
+	suite.Equal("<p>Lamp</p>", suite.render(cached, gin.H{"Name": "Lamp"}, true, "product"))
+	suite.Contains(logs.String(), `error="writing version of cache tag \"product:7\": cache unavailable"`)
+}
+
+func (suite *CacheTestSuite) TestInvalidateTagFailures() {
+	suite.Require().NoError(suite.newHtmx(nil, nil).InvalidateTag(context.Background(), "product:7"))
+
+	err := suite.newHtmx(failingCache{}, nil).InvalidateTag(context.Background(), "product:7")
+	suite.Require().ErrorIs(err, errCacheUnavailable)
+	suite.EqualError(err, `invalidating cache tag "product:7": cache unavailable`)
+}
+
+func (suite *CacheTestSuite) TestTaggedOutputIsRenderedWhenTagsCannotBeRead() {
+	var logs bytes.Buffer
+
+	cached := suite.newHtmx(failingCache{}, slog.New(slog.NewTextHandler(&logs, nil))).
+		With(ginhtmx.CacheFor(time.Minute, "", "product:7"))
+
+	suite.Equal("<p>Lamp</p>", suite.render(cached, gin.H{"Name": "Lamp"}, true, "product"))
+	suite.Contains(logs.String(), `error="reading version of cache tag \"product:7\": cache unavailable"`)
+}
+
+func (suite *CacheTestSuite) TestMemoryCacheExpiresValues() {
+	cache := ginhtmx.NewMemoryCache()
+	ctx := context.Background()
+
+	suite.Require().NoError(cache.Set(ctx, "short", []byte("value"), time.Millisecond))
+	suite.Require().NoError(cache.Set(ctx, "long", []byte("value"), time.Minute))
+	suite.Require().NoError(cache.Set(ctx, "forever", []byte("value"), 0))
+
+	time.Sleep(5 * time.Millisecond)
+
+	_, found, _ := cache.Get(ctx, "short")
+	suite.False(found)
+
+	value, found, _ := cache.Get(ctx, "long")
+	suite.True(found)
+	suite.Equal([]byte("value"), value)
+
+	_, found, _ = cache.Get(ctx, "forever")
+	suite.True(found)
+}
+
+func (suite *CacheTestSuite) TestMemoryCacheEvictsTheLeastRecentlyUsedValues() {
+	cache := ginhtmx.NewMemoryCacheWithLimit(2)
+	ctx := context.Background()
+
+	suite.Require().NoError(cache.Set(ctx, "a", []byte("a"), 0))
+	suite.Require().NoError(cache.Set(ctx, "b", []byte("b"), 0))
+	_, _, _ = cache.Get(ctx, "a")
+	suite.Require().NoError(cache.Set(ctx, "c", []byte("c"), 0))
+	suite.Require().NoError(cache.Set(ctx, "c", []byte("d"), 0))
+
+	suite.Equal(2, cache.Len())
+
+	_, found, _ := cache.Get(ctx, "b")
+	suite.False(found)
+
+	value, found, _ := cache.Get(ctx, "c")
+	suite.True(found)
+	suite.Equal([]byte("d"), value)
+
+	suite.Require().NoError(cache.Delete(ctx, "a"))
+	suite.Require().NoError(cache.Delete(ctx, "missing"))
+	suite.Equal(1, cache.Len())
+
+	defaulted := ginhtmx.NewMemoryCacheWithLimit(0)
+	for key := range 3 {
+		suite.Require().NoError(defaulted.Set(ctx, strconv.Itoa(key), nil, 0))
+	}
+
+	suite.Equal(3, defaulted.Len())
+}
+
+func (suite *CacheTestSuite) TestInvalidatedOutputIsEvictedFromTheMemoryCache() {
+	cache := ginhtmx.NewMemoryCacheWithLimit(4)
+	htmx := suite.newHtmx(cache, nil)
+	product := htmx.With(ginhtmx.CacheFor(0, "product", "product:7"))
+
+	for _, name := range []string{"Lamp", "Desk", "Sofa", "Chair", "Table"} {
+		suite.Equal("<p>"+name+"</p>", suite.render(product, gin.H{"Name": name}, true, "product"))
+		suite.Require().NoError(htmx.InvalidateTag(context.Background(), "product:7"))
+	}
+
+	suite.Equal(4, cache.Len())
+	suite.Equal("<p>Bed</p>", suite.render(product, gin.H{"Name": "Bed"}, true, "product"))
+	suite.Equal("<p>Bed</p>", suite.render(product, gin.H{"Name": "Rug"}, true, "product"))
+}
+
+func (suite *CacheTestSuite) TestStaleOutputIsServedWhileItIsRenderedAgain() {
+	var now atomic.Int64
+
+	start := time.Date(2025, time.March, 7, 18, 0, 0, 0, time.UTC)
+	now.Store(start.UnixNano())
+
+	cached := suite.newHtmx(ginhtmx.NewMemoryCache(), nil).With(
+		ginhtmx.CacheFor(time.Minute, ""),
+		ginhtmx.StaleWhileRevalidate(time.Hour),
+		ginhtmx.Clock(func() time.Time { return time.Unix(0, now.Load()) }),
+	)
+
+	suite.Equal("<p>Lamp</p>", suite.render(cached, gin.H{"Name": "Lamp"}, true, "product"))
+	suite.Equal("<p>Lamp</p>", suite.render(cached, gin.H{"Name": "Chair"}, true, "product"))
+
+	now.Store(start.Add(2 * time.Minute).UnixNano())
+	suite.Equal("<p>Lamp</p>", suite.render(cached, gin.H{"Name": "Chair"}, true, "product"))
+	suite.Eventually(func() bool {
+		return suite.render(cached, gin.H{"Name": "Desk"}, true, "product") == "<p>Chair</p>"
+	}, time.Second, time.Millisecond)
+
+	now.Store(start.Add(2 * time.Hour).UnixNano())
+	suite.Equal("<p>Desk</p>", suite.render(cached, gin.H{"Name": "Desk"}, true, "product"))
+}
+
+func (suite *CacheTestSuite) TestRevalidationsAreTracedApartFromTheRequest() {
+	var now atomic.Int64
+
+	provider := &recordingTracerProvider{}
+	htmx := ginhtmx.NewHtmxWithConfig(template.Must(template.New("").Parse(
+		`{{define "layout"}}<html>{{.Content}}</html>{{end}}{{define "product"}}<p>{{.Name}}</p>{{end}}`)),
+		ginhtmx.HtmxConfig{
+			LayoutTemplateName:  "layout",
+			ContentVariableName: "Content",
+			Cache:               ginhtmx.NewMemoryCache(),
+			TracerProvider:      provider,
+			ServerTiming:        true,
+		})
+	cached := htmx.With(
+		ginhtmx.CacheFor(time.Minute, ""),
+		ginhtmx.StaleWhileRevalidate(time.Hour),
+		ginhtmx.Clock(func() time.Time { return time.Unix(0, now.Load()) }),
+	)
+
+	suite.render(cached, gin.H{"Name": "Lamp"}, true, "product")
+
+	now.Store(int64(2 * time.Minute))
+	suite.render(cached, gin.H{"Name": "Chair"}, true, "product")
+	suite.Eventually(func() bool {
+		return suite.render(cached, gin.H{"Name": "Desk"}, true, "product") == "<p>Chair</p>"
+	}, time.Second, time.Millisecond)
+
+	provider.mutex.Lock()
+	defer provider.mutex.Unlock()
+
+	var revalidation *recordingSpan
+
+	for _, span := range provider.spans {
+		if span.name == "ginhtmx.Revalidate" {
+			revalidation = span
+		}
+	}
+
+	suite.Require().NotNil(revalidation)
+	suite.True(revalidation.newRoot)
+	suite.Equal(1, revalidation.links)
+	suite.Equal(attribute.StringSliceValue([]string{"product"}), revalidation.attributes["ginhtmx.templates"])
+
+	var children []string
+
+	for _, span := range provider.spans {
+		if span.parent == revalidation {
+			children = append(children, span.name)
+		}
+	}
+
+	suite.Equal([]string{"ginhtmx.ExecuteTemplate"}, children)
+}
+
+func (suite *CacheTestSuite) TestFailedRevalidationsAreLogged() {
+	logs := &lockedBuffer{mutex: sync.Mutex{}, buffer: bytes.Buffer{}}
+
+	var now atomic.Int64
+
+	cached := suite.newHtmx(ginhtmx.NewMemoryCache(), slog.New(slog.NewTextHandler(logs, nil))).With(
+		ginhtmx.CacheFor(time.Minute, ""),
+		ginhtmx.StaleWhileRevalidate(time.Hour),
+		ginhtmx.Clock(func() time.Time { return time.Unix(0, now.Load()) }),
+	)
+	fail := func() (string, error) { return "", errCacheUnavailable }
+
+	suite.Equal("<p>Lamp</p>", suite.render(cached, gin.H{"Name": "Lamp", "Fail": nil}, true, "report"))
+
+	now.Store(int64(2 * time.Minute))
+	suite.Equal("<p>Lamp</p>", suite.render(cached, gin.H{"Name": "Chair", "Fail": fail}, true, "report"))
+	suite.Eventually(func() bool {
+		return strings.Contains(logs.String(), `msg="ginhtmx: revalidating cached output failed"`)
+	}, time.Second, time.Millisecond)
+	suite.Equal("<p>Lamp</p>", suite.render(cached, gin.H{"Name": "Chair", "Fail": nil}, true, "report"))
+}
+
+func (suite *CacheTestSuite) TestDebugShowsTheCacheStatusOfFragments() {
+	var now atomic.Int64
+
+	htmx := ginhtmx.NewHtmxWithConfig(template.Must(template.New("").Parse(`
+{{define "layout"}}<html>{{.Content}}</html>{{end}}
+{{define "product"}}<p>{{.Name}}</p>{{end}}
+`)), ginhtmx.HtmxConfig{
+		LayoutTemplateName:  "layout",
+		ContentVariableName: "Content",
+		Cache:               ginhtmx.NewMemoryCache(),
+		Debug:               true,
+	})
+	clock := ginhtmx.Clock(func() time.Time { return time.Unix(0, now.Load()) })
+	cached := htmx.With(ginhtmx.CacheFor(time.Minute, "a--->"), clock)
+
+	suite.Equal("<!-- begin:product --><p>Lamp</p><!-- end:product -->"+
+		"<!-- ginhtmx cache: MISS key=ginhtmx:htmx:product:a- - -> age=0s -->",
+		suite.render(cached, gin.H{"Name": "Lamp"}, true, "product"))
+
+	now.Store(int64(42 * time.Second))
+	suite.Equal("<!-- begin:product --><p>Lamp</p><!-- end:product -->"+
+		"<!-- ginhtmx cache: HIT key=ginhtmx:htmx:product:a- - -> age=42s -->",
+		suite.render(cached, gin.H{"Name": "Chair"}, true, "product"))
+	suite.NotContains(suite.render(cached, gin.H{"Name": "Lamp"}, false, "product"), "ginhtmx cache")
+
+	stale := htmx.With(ginhtmx.CacheFor(time.Minute, "7"), ginhtmx.StaleWhileRevalidate(time.Hour), clock)
+	suite.Contains(suite.render(stale, gin.H{"Name": "Lamp"}, true, "product"), "cache: MISS")
+
+	now.Store(int64(3 * time.Minute))
+	suite.Contains(suite.render(stale, gin.H{"Name": "Lamp"}, true, "product"),
+		"<!-- ginhtmx cache: STALE key=ginhtmx:htmx:product:7:swr age=2m18s -->")
+}
+
+func (suite *CacheTestSuite) TestCacheKey() {
+	suite.Equal("ginhtmx:full:header,product:42", ginhtmx.CacheKey([]string{"header", "product"}, false, "42"))
+}
+
+func (suite *CacheTestSuite) newHtmx(cache ginhtmx.Cache, logger *slog.Logger) *ginhtmx.Htmx {
+	tmpl := template.Must(template.New("").Parse(`
+{{define "layout"}}<html>{{.Content}}</html>{{end}}
+{{define "product"}}<p>{{.Name}}</p>{{end}}
+{{define "report"}}<p>{{.Name}}</p>{{with .Fail}}{{call .}}{{end}}{{end}}
+`))
+
+	return ginhtmx.NewHtmxWithConfig(tmpl, ginhtmx.HtmxConfig{
+		LayoutTemplateName:  "layout",
+		ContentVariableName: "Content",
+		Logger:              logger,
+		Cache:               cache,
+	})
+}
+
+func (suite *CacheTestSuite) render(htmx *ginhtmx.Htmx, data gin.H, fragment bool, names ...string) string {
+	recorder := httptest.NewRecorder()
+	testContext, _ := gin.CreateTestContext(recorder)
+	testContext.Request = httptest.NewRequest(http.MethodGet, "/", nil)
+
+	if fragment {
+		testContext.Request.Header.Set("Hx-Request", "true")
+	}
+
+	htmx.Render(testContext, data, names...)
+
+	return recorder.Body.String()
+}
+
+// failingCache is a Cache whose backend is unavailable.
+type failingCache struct{}
+
+func (failingCache) Get(context.Context, string) ([]byte, bool, error) {
+	return nil, false, errCacheUnavailable
+}
+
+func (failingCache) Set(context.Context, string, []byte, time.Duration) error {
+	return errCacheUnavailable
+}
+
+func (failingCache) Delete(context.Context, string) error {
+	return errCacheUnavailable
+}
+
+// readOnlyCache is a Cache to which nothing can be written.
+type readOnlyCache struct {
+	ginhtmx.Cache
+}
+
+func (readOnlyCache) Set(context.Context, string, []byte, time.Duration) error {
+	return errCacheUnavailable
+}
+
+func TestCacheTestSuite(t *testing.T) {
+	t.Parallel()
+	suite.Run(t, new(CacheTestSuite))
+}
+
+type CacheTestSuite struct {
+	suite.Suite
+}
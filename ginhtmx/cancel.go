@@ -0,0 +1,113 @@
+package ginhtmx
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"strconv"
+	"syscall"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ErrClientDisconnected is wrapped by the errors of renders which could not be
+// completed because the client went away, so that they can be told apart from
+// genuine failures to write the response.
+var ErrClientDisconnected = errors.New("client disconnected")
+
+// withRenderTimeout returns a context which is done once the RenderTimeout of the
+// configuration has elapsed, if one is configured.
+func (htmx *Htmx) withRenderTimeout(ctx context.Context) (context.Context, context.CancelFunc) {
+	if htmx.config.RenderTimeout <= 0 {
+		return ctx, func() {}
+	}
+
+	return context.WithTimeout(ctx, htmx.config.RenderTimeout)
+}
+
+// abortRender aborts a render whose context was done before the response was
+// written, instead of writing the partial output of the templates. A render which
+// timed out receives a 503 Service Unavailable response, while nothing is written
+// when the client has gone away. It returns renderErr including the reason the
+// render was aborted, which wraps ErrClientDisconnected if the client has gone away.
+func abortRender(ginContext *gin.Context, ctxErr error, renderErr error) error {
+	if errors.Is(ctxErr, context.DeadlineExceeded) {
+		ginContext.AbortWithStatus(http.StatusServiceUnavailable)
+	} else {
+		ginContext.Abort()
+	}
+
+	switch {
+	case errors.Is(ctxErr, context.Canceled) && !errors.Is(renderErr, ErrClientDisconnected):
+		return errors.Join(renderErr, fmt.Errorf("render aborted: %w: %w", ErrClientDisconnected, ctxErr))
+	case errors.Is(renderErr, ctxErr):
+		return renderErr
+	default:
+		return errors.Join(renderErr, fmt.Errorf("render aborted: %w", ctxErr))
+	}
+}
+
+// clientGone reports whether err, an error writing the response, is due to the
+// client having gone away rather than a failure of the server.
+func clientGone(ginContext *gin.Context, err error) bool {
+	return ginContext.Request.Context().Err() != nil ||
+		errors.Is(err, syscall.EPIPE) || errors.Is(err, syscall.ECONNRESET) || errors.Is(err, net.ErrClosed)
+}
+
+// writeError wraps err, an error writing the response, and ErrClientDisconnected if
+// the client has gone away.
+func writeError(ginContext *gin.Context, err error) error {
+	switch {
+	case err == nil:
+		return nil
+	case clientGone(ginContext, err):
+		return fmt.Errorf("writing response: %w: %w", ErrClientDisconnected, err)
+	default:
+		return fmt.Errorf("writing response: %w", err)
+	}
+}
+
+// writeData writes body as the response with status and contentType, like
+// ginContext.Data, but returns the error of the write.
+func writeData(ginContext *gin.Context, status int, contentType string, body []byte) error {
+	ginContext.Status(status)
+	ginContext.Header("Content-Type", contentType)
+
+	if !bodyAllowedForStatus(status) {
+		ginContext.Writer.WriteHeaderNow()
+
+		return nil
+	}
+
+	if ginContext.Request.Method == http.MethodHead {
+		// the body was only rendered to compute its ETag, so only its length is sent
+		ginContext.Header("Content-Length", strconv.Itoa(len(body)))
+		ginContext.Writer.WriteHeaderNow()
+
+		return nil
+	}
+
+	_, err := ginContext.Writer.Write(body)
+
+	return writeError(ginContext, err)
+}
+
+// contextWriter fails writes once its context is done, which stops the execution
+// of a template at its next output when the client disconnects or the render
+// times out.
+type contextWriter struct {
+	ctx    context.Context //nolint:containedctx
+	writer io.Writer
+}
+
+func (writer *contextWriter) Write(p []byte) (int, error) {
+	err := writer.ctx.Err()
+	if err != nil {
+		return 0, err //nolint:wrapcheck
+	}
+
+	return writer.writer.Write(p) //nolint:wrapcheck
+}
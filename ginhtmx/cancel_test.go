@@ -0,0 +1,233 @@
+package ginhtmx_test
+
+import (
+	"bytes"
+	"context"
+	"html/template"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/jeffscottbrown/ginhtmxtemplates/ginhtmx"
+	"github.com/stretchr/testify/suite"
+)
+
+func (suite *CancellationTestSuite) TestRendersWhichTimeOutAreAborted() {
+	htmx := suite.newHtmx(10 * time.Millisecond)
+
+	recorder, testContext := suite.render(htmx, context.Background(), "slow")
+
+	suite.Equal(http.StatusServiceUnavailable, recorder.Code)
+	suite.Empty(recorder.Body.String())
+	suite.True(testContext.IsAborted())
+
+	renderErr, _ := testContext.Value(ginhtmx.RenderErrorKey).(error)
+	suite.ErrorIs(renderErr, context.DeadlineExceeded)
+}
+
+func (suite *CancellationTestSuite) TestTimeoutAfterTheTemplatesHaveRenderedAbortsTheRender() {
+	tmpl := template.Must(template.New("").Parse(`{{define "fast"}}Fast{{end}}`))
+	htmx := ginhtmx.NewHtmxWithConfig(tmpl, ginhtmx.HtmxConfig{
+		LayoutTemplateName:  "layout",
+		ContentVariableName: "Content",
+		Metrics:             slowRecorder{},
+		RenderTimeout:       10 * time.Millisecond,
+	})
+
+	recorder := httptest.NewRecorder()
+	testContext, _ := gin.CreateTestContext(recorder)
+	testContext.Request = httptest.NewRequest(http.MethodGet, "/", nil)
+	testContext.Request.Header.Set("Hx-Request", "true")
+
+	htmx.Render(testContext, gin.H{}, "fast")
+
+	suite.Equal(http.StatusServiceUnavailable, recorder.Code)
+
+	renderErr, _ := testContext.Value(ginhtmx.RenderErrorKey).(error)
+	suite.EqualError(renderErr, "render aborted: context deadline exceeded")
+}
+
+func (suite *CancellationTestSuite) TestRendersWithinTheTimeoutAreWritten() {
+	htmx := suite.newHtmx(time.Minute)
+
+	recorder, _ := suite.render(htmx, context.Background(), "slow")
+
+	suite.Equal(http.StatusOK, recorder.Code)
+	suite.Equal("<html>Before After</html>", recorder.Body.String())
+}
+
+func (suite *CancellationTestSuite) TestRendersForDisconnectedClientsAreAbandoned() {
+	htmx := suite.newHtmx(0)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	recorder, testContext := suite.render(htmx, ctx, "slow", "fast")
+
+	suite.Empty(recorder.Body.String())
+	suite.True(testContext.IsAborted())
+
+	err, _ := testContext.Get(ginhtmx.RenderErrorKey)
+	suite.ErrorIs(err.(error), context.Canceled)                              //nolint:forcetypeassert
+	suite.ErrorIs(err.(error), ginhtmx.ErrClientDisconnected)                 //nolint:forcetypeassert
+	suite.Contains(err.(error).Error(), `rendering "slow": context canceled`) //nolint:forcetypeassert
+	suite.NotContains(err.(error).Error(), `"fast"`)                          //nolint:forcetypeassert
+}
+
+func (suite *CancellationTestSuite) TestClientDisconnectsAreToldApartFromWriteFailures() {
+	var logs bytes.Buffer
+
+	htmx := ginhtmx.NewHtmxWithConfig(suite.templates(), ginhtmx.HtmxConfig{
+		LayoutTemplateName:  "layout",
+		ContentVariableName: "Content",
+		Logger:              slog.New(slog.NewTextHandler(&logs, &slog.HandlerOptions{Level: slog.LevelDebug})),
+	})
+
+	renderErr := suite.renderTo(htmx, &failingResponseWriter{err: syscall.EPIPE, allowed: 0}, "fast")
+	suite.Require().ErrorIs(renderErr, ginhtmx.ErrClientDisconnected)
+	suite.Require().ErrorIs(renderErr, syscall.EPIPE)
+	suite.Contains(logs.String(), `level=DEBUG msg="ginhtmx: client disconnected"`)
+
+	renderErr = suite.renderTo(htmx, &failingResponseWriter{err: errWriteFailed, allowed: 0}, "fast")
+	suite.Require().EqualError(renderErr, "writing response: write failed")
+	suite.Contains(logs.String(), `level=ERROR msg="ginhtmx: render failed"`)
+}
+
+func (suite *CancellationTestSuite) TestCompressedWriteFailuresAreReported() {
+	htmx := ginhtmx.NewHtmxWithConfig(suite.templates(), ginhtmx.HtmxConfig{
+		LayoutTemplateName:  "layout",
+		ContentVariableName: "Content",
+		Compression:         true,
+		CompressionMinSize:  1,
+	})
+
+	renderErr := suite.renderTo(htmx, &failingResponseWriter{err: syscall.ECONNRESET, allowed: 0}, "fast")
+	suite.Require().ErrorIs(renderErr, ginhtmx.ErrClientDisconnected)
+}
+
+func (suite *CancellationTestSuite) TestStreamingStopsOnceTheClientIsGone() {
+	htmx := ginhtmx.NewHtmxWithConfig(suite.templates(), ginhtmx.HtmxConfig{
+		LayoutTemplateName:  "layout",
+		ContentVariableName: "Content",
+		StreamLayout:        true,
+	})
+
+	writer := &failingResponseWriter{err: syscall.EPIPE, allowed: len("<html>")}
+	renderErr := suite.renderTo(htmx, writer, "fast", "slow")
+	suite.Require().ErrorIs(renderErr, ginhtmx.ErrClientDisconnected)
+	suite.NotContains(renderErr.Error(), `"slow"`)
+
+	renderErr = suite.renderTo(htmx, &failingResponseWriter{err: syscall.EPIPE, allowed: 0}, "fast")
+	suite.Require().EqualError(renderErr, "writing response: client disconnected: broken pipe")
+}
+
+func (suite *CancellationTestSuite) TestResponsesWithoutBodiesAreNotWritten() {
+	recorder := httptest.NewRecorder()
+	testContext, _ := gin.CreateTestContext(recorder)
+	testContext.Request = httptest.NewRequest(http.MethodGet, "/", nil)
+	testContext.Request.Header.Set("Hx-Request", "true")
+
+	suite.newHtmx(0).RenderWithStatus(testContext, gin.H{}, http.StatusNoContent, "fast")
+
+	suite.Equal(http.StatusNoContent, recorder.Code)
+	suite.Empty(recorder.Body.String())
+}
+
+func (suite *CancellationTestSuite) renderTo(htmx *ginhtmx.Htmx, writer http.ResponseWriter, names ...string) error {
+	testContext, _ := gin.CreateTestContext(writer)
+	testContext.Request = httptest.NewRequest(http.MethodGet, "/", nil)
+	testContext.Request.Header.Set("Accept-Encoding", "gzip")
+
+	htmx.Render(testContext, gin.H{}, names...)
+
+	renderErr, _ := testContext.Value(ginhtmx.RenderErrorKey).(error)
+
+	return renderErr
+}
+
+func (suite *CancellationTestSuite) newHtmx(timeout time.Duration) *ginhtmx.Htmx {
+	return ginhtmx.NewHtmxWithConfig(suite.templates(), ginhtmx.HtmxConfig{
+		LayoutTemplateName:  "layout",
+		ContentVariableName: "Content",
+		RenderTimeout:       timeout,
+	})
+}
+
+func (suite *CancellationTestSuite) templates() *template.Template {
+	return template.Must(template.New("").Funcs(template.FuncMap{
+		"pause": func() string {
+			time.Sleep(50 * time.Millisecond)
+
+			return ""
+		},
+	}).Parse(`
+{{define "layout"}}<html>{{.Content}}</html>{{end}}
+{{define "slow"}}Before {{pause}}After{{end}}
+{{define "fast"}}Fast{{end}}
+`))
+}
+
+func (suite *CancellationTestSuite) render(
+	htmx *ginhtmx.Htmx, ctx context.Context, names ...string,
+) (*httptest.ResponseRecorder, *gin.Context) {
+	recorder := httptest.NewRecorder()
+	testContext, _ := gin.CreateTestContext(recorder)
+	testContext.Request = httptest.NewRequestWithContext(ctx, http.MethodGet, "/", nil)
+
+	htmx.Render(testContext, gin.H{}, names...)
+
+	return recorder, testContext
+}
+
+// slowRecorder delays every render after the templates have been executed.
+type slowRecorder struct{}
+
+func (slowRecorder) ObserveTemplate(ginhtmx.TemplateObservation) { time.Sleep(50 * time.Millisecond) }
+
+func (slowRecorder) ObserveRender(ginhtmx.RenderObservation) {}
+
+func (slowRecorder) ObserveCache(ginhtmx.CacheObservation) {}
+
+func TestCancellationTestSuite(t *testing.T) {
+	t.Parallel()
+	suite.Run(t, new(CancellationTestSuite))
+}
+
+type CancellationTestSuite struct {
+	suite.Suite
+}
+
+// failingResponseWriter is a response writer which fails once more than allowed bytes
+// have been written.
+type failingResponseWriter struct {
+	httptest.ResponseRecorder
+
+	err     error
+	allowed int
+}
+
+func (writer *failingResponseWriter) Write(p []byte) (int, error) {
+	if len(p) > writer.allowed {
+		return 0, writer.err
+	}
+
+	writer.allowed -= len(p)
+
+	return len(p), nil
+}
+
+func (writer *failingResponseWriter) WriteString(s string) (int, error) {
+	return writer.Write([]byte(s))
+}
+
+func (writer *failingResponseWriter) Header() http.Header {
+	if writer.HeaderMap == nil {
+		writer.HeaderMap = http.Header{}
+	}
+
+	return writer.HeaderMap
+}
@@ -0,0 +1,85 @@
+package ginhtmx
+
+import (
+	"net/url"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// htmxParamPrefixes are the prefixes of query parameters added by HTMX, which are
+// removed from canonical URLs.
+var htmxParamPrefixes = []string{"hx-", "hx_", "org.htmx."}
+
+// CanonicalURL returns the canonical URL of the page the user is viewing. For HTMX
+// requests the HX-Current-URL header is used when present, so that fragments
+// loaded into a page share its canonical URL. The URL is made absolute using the
+// BaseURL of the configuration, or the host of the request when BaseURL is not
+// set, and query parameters added by HTMX are removed. Layouts may use the
+// "canonicalURL" template function:
+//
+//	<link rel="canonical" href="{{ canonicalURL }}">
+func (htmx *Htmx) CanonicalURL(ginContext *gin.Context) string {
+	page := *ginContext.Request.URL
+
+	if htmx.IsHTMXRequest(ginContext) {
+		if current, err := url.Parse(ginContext.GetHeader("HX-Current-URL")); err == nil && current.Path != "" {
+			page = *current
+		}
+	}
+
+	base := htmx.baseURL(ginContext)
+
+	query := page.Query()
+	for name := range query {
+		if isHTMXParam(name) {
+			query.Del(name)
+		}
+	}
+
+	canonical := url.URL{
+		Scheme:   base.Scheme,
+		Host:     base.Host,
+		Path:     strings.TrimSuffix(base.Path, "/") + page.Path,
+		RawQuery: query.Encode(),
+	}
+
+	return canonical.String()
+}
+
+// baseURL returns the BaseURL of the configuration, or the scheme and host of the
+// request when BaseURL is not set.
+func (htmx *Htmx) baseURL(ginContext *gin.Context) *url.URL {
+	if htmx.config.BaseURL != "" {
+		if configured, err := url.Parse(htmx.config.BaseURL); err == nil {
+			return configured
+		}
+	}
+
+	base := &url.URL{Scheme: "http", Host: ginContext.Request.Host}
+	if ginContext.Request.TLS != nil {
+		base.Scheme = "https"
+	}
+
+	return base
+}
+
+func isHTMXParam(name string) bool {
+	for _, prefix := range htmxParamPrefixes {
+		if strings.HasPrefix(strings.ToLower(name), prefix) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// canonicalURL returns the canonical URL of the current page, or an empty string
+// outside of a request.
+func (scope *requestScope) canonicalURL() string {
+	if scope.htmx == nil || scope.ginContext == nil || scope.ginContext.Request == nil {
+		return ""
+	}
+
+	return scope.htmx.CanonicalURL(scope.ginContext)
+}
@@ -0,0 +1,84 @@
+package ginhtmx_test
+
+import (
+	"crypto/tls"
+	"html/template"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/jeffscottbrown/ginhtmxtemplates/ginhtmx"
+	"github.com/stretchr/testify/suite"
+)
+
+func (suite *CanonicalURLTestSuite) TestRequestURLWithConfiguredBase() {
+	htmx := suite.newHtmx("https://example.com/app/")
+
+	testContext := suite.newContext("/users?page=2&hx-request=true&org.htmx.cache-buster=x")
+
+	suite.Equal("https://example.com/app/users?page=2", htmx.CanonicalURL(testContext))
+}
+
+func (suite *CanonicalURLTestSuite) TestRequestHostIsUsedWithoutBase() {
+	htmx := suite.newHtmx("")
+
+	testContext := suite.newContext("/users")
+	suite.Equal("http://example.com/users", htmx.CanonicalURL(testContext))
+
+	testContext.Request.TLS = &tls.ConnectionState{}
+	suite.Equal("https://example.com/users", htmx.CanonicalURL(testContext))
+}
+
+func (suite *CanonicalURLTestSuite) TestCurrentURLOfHTMXRequests() {
+	htmx := suite.newHtmx("https://example.com")
+
+	testContext := suite.newContext("/fragments/users")
+	testContext.Request.Header.Set("Hx-Request", "true")
+	testContext.Request.Header.Set("Hx-Current-Url", "http://localhost:8080/users/3?tab=posts")
+
+	suite.Equal("https://example.com/users/3?tab=posts", htmx.CanonicalURL(testContext))
+}
+
+func (suite *CanonicalURLTestSuite) TestTemplateFunction() {
+	recorder := httptest.NewRecorder()
+	testContext, _ := gin.CreateTestContext(recorder)
+	testContext.Request = httptest.NewRequest(http.MethodGet, "/about", nil)
+
+	suite.newHtmx("https://example.com").Render(testContext, gin.H{}, "about")
+
+	suite.Equal(`<link rel="canonical" href="https://example.com/about">`, recorder.Body.String())
+
+	output, err := suite.newHtmx("").RenderToString("layout", gin.H{})
+	suite.Require().NoError(err)
+	suite.Equal(`<link rel="canonical" href="">`, output)
+}
+
+func (suite *CanonicalURLTestSuite) newContext(target string) *gin.Context {
+	testContext, _ := gin.CreateTestContext(httptest.NewRecorder())
+	testContext.Request = httptest.NewRequest(http.MethodGet, target, nil)
+
+	return testContext
+}
+
+func (suite *CanonicalURLTestSuite) newHtmx(baseURL string) *ginhtmx.Htmx {
+	tmpl := template.Must(template.New("").Funcs(ginhtmx.FuncMap()).Parse(`
+{{- define "layout"}}<link rel="canonical" href="{{canonicalURL}}">{{.Content}}{{end}}
+{{- define "about"}}{{end}}
+`))
+
+	return ginhtmx.NewHtmxWithConfig(tmpl, ginhtmx.HtmxConfig{
+		LayoutTemplateName:  "layout",
+		ContentVariableName: "Content",
+		BaseURL:             baseURL,
+	})
+}
+
+func TestCanonicalURLTestSuite(t *testing.T) {
+	t.Parallel()
+	suite.Run(t, new(CanonicalURLTestSuite))
+}
+
+type CanonicalURLTestSuite struct {
+	suite.Suite
+}
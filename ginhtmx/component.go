@@ -0,0 +1,102 @@
+package ginhtmx
+
+import (
+	"errors"
+	"fmt"
+	"html/template"
+	"maps"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ErrComponentNotFound is returned when a component to be rendered is not registered.
+var ErrComponentNotFound = errors.New("component not found")
+
+// Component describes a reusable piece of user interface which is rendered from a
+// template with a set of properties. Components are registered in HtmxConfig and
+// rendered by name with RenderComponent or the "component" template function.
+type Component struct {
+	// Template is the name of the template which renders the component
+	Template string
+
+	// Defaults are the properties used when the caller does not provide them
+	Defaults gin.H
+
+	// Provider is an optional function which may add to or modify the properties
+	// before the component is rendered, for example to load data the component
+	// always needs. The gin context is nil when rendering outside of a request.
+	Provider func(ginContext *gin.Context, props gin.H) error
+}
+
+// Components maps component names to their definitions.
+type Components map[string]Component
+
+// RenderComponent renders the named component with props merged over the defaults
+// of the component. As with Render, the component is wrapped in the layout unless
+// the request is an HTMX request.
+func (htmx *Htmx) RenderComponent(ginContext *gin.Context, name string, props gin.H) {
+	templateName, data, err := htmx.componentProps(ginContext, name, props)
+
+	htmx.With(withRenderError(err)).RenderWithStatus(ginContext, data, http.StatusOK, templateName)
+}
+
+// componentProps returns the template name and the properties for rendering the
+// named component. If the component is not registered its name is used as the
+// template name.
+func (htmx *Htmx) componentProps(ginContext *gin.Context, name string, props gin.H) (string, gin.H, error) {
+	component, exists := htmx.config.Components[name]
+	if !exists {
+		return name, maps.Clone(props), fmt.Errorf("%w: %q", ErrComponentNotFound, name)
+	}
+
+	data := maps.Clone(component.Defaults)
+	if data == nil {
+		data = gin.H{}
+	}
+
+	maps.Copy(data, props)
+
+	if component.Provider != nil {
+		err := component.Provider(ginContext, data)
+		if err != nil {
+			return component.Template, data, fmt.Errorf("component %q: %w", name, err)
+		}
+	}
+
+	return component.Template, data, nil
+}
+
+// component renders the named component from within a template:
+//
+//	{{ component "user_card" (dict "User" .User) }}
+func (scope *requestScope) component(name string, props ...gin.H) (template.HTML, error) {
+	if scope.htmx == nil || scope.template == nil {
+		return "", fmt.Errorf("%w: component %q rendered outside of Htmx", errInvalidArgument, name)
+	}
+
+	var merged gin.H
+
+	for _, values := range props {
+		merged = mergeModels(merged, values)
+	}
+
+	templateName, data, err := scope.htmx.componentProps(scope.ginContext, name, merged)
+	if err != nil {
+		return "", err
+	}
+
+	rendered, err := scope.htmx.executeTemplate(scope.context(), scope.template, templateName, data, true)
+
+	//nolint:gosec
+	return template.HTML(rendered), err
+}
+
+// mergeModels returns a copy of base with the values of overrides added to it.
+func mergeModels(base gin.H, overrides gin.H) gin.H {
+	merged := make(gin.H, len(base)+len(overrides))
+	maps.Copy(merged, base)
+	maps.Copy(merged, overrides)
+
+	return merged
+}
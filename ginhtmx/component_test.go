@@ -0,0 +1,136 @@
+package ginhtmx_test
+
+import (
+	"bytes"
+	"errors"
+	"html/template"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/jeffscottbrown/ginhtmxtemplates/ginhtmx"
+	"github.com/stretchr/testify/suite"
+)
+
+var errNoAvatar = errors.New("no avatar")
+
+func (suite *ComponentTestSuite) TestRenderComponentMergesDefaultsAndProvider() {
+	recorder, testContext := suite.newContext(true)
+
+	suite.htmx.RenderComponent(testContext, "avatar", gin.H{"Name": "Jerry"})
+
+	suite.Equal(`<img alt="Jerry" width="32" src="/avatars/Jerry.png">`, recorder.Body.String())
+	suite.Nil(testContext.Value(ginhtmx.RenderErrorKey))
+}
+
+func (suite *ComponentTestSuite) TestRenderComponentUsesLayoutForFullPages() {
+	recorder, testContext := suite.newContext(false)
+
+	suite.htmx.RenderComponent(testContext, "avatar", gin.H{"Name": "Jerry", "Size": 64})
+
+	suite.Equal(`<html><img alt="Jerry" width="64" src="/avatars/Jerry.png"></html>`, recorder.Body.String())
+}
+
+func (suite *ComponentTestSuite) TestRenderComponentReportsErrors() {
+	_, testContext := suite.newContext(true)
+
+	suite.htmx.RenderComponent(testContext, "unknown", gin.H{})
+
+	renderErr, _ := testContext.Value(ginhtmx.RenderErrorKey).(error)
+	suite.Require().ErrorIs(renderErr, ginhtmx.ErrComponentNotFound)
+
+	_, testContext = suite.newContext(true)
+
+	suite.htmx.RenderComponent(testContext, "avatar", gin.H{})
+
+	renderErr, _ = testContext.Value(ginhtmx.RenderErrorKey).(error)
+	suite.Require().ErrorIs(renderErr, errNoAvatar)
+}
+
+func (suite *ComponentTestSuite) TestComponentFunction() {
+	recorder, testContext := suite.newContext(true)
+
+	suite.htmx.Render(testContext, gin.H{"People": []string{"Jerry", "Elaine"}}, "people")
+
+	suite.Equal(
+		`<li><img alt="Jerry" width="16" src="/avatars/Jerry.png"></li>`+
+			`<li><img alt="Elaine" width="16" src="/avatars/Elaine.png"></li>`,
+		recorder.Body.String())
+}
+
+func (suite *ComponentTestSuite) TestComponentFunctionOutsideRequest() {
+	var output bytes.Buffer
+
+	err := suite.htmx.RenderToWriter(&output, gin.H{"People": []string{"Kramer"}}, false, "people")
+
+	suite.Require().NoError(err)
+	suite.Equal(`<li><img alt="Kramer" width="16" src="/avatars/Kramer.png"></li>`, output.String())
+}
+
+func (suite *ComponentTestSuite) TestComponentFunctionErrors() {
+	var output bytes.Buffer
+
+	err := suite.htmx.RenderToWriter(&output, gin.H{}, false, "invalid")
+	suite.Require().Error(err)
+
+	err = suite.htmx.RenderToWriter(&output, gin.H{}, false, "missing")
+	suite.Require().ErrorIs(err, ginhtmx.ErrComponentNotFound)
+
+	err = template.Must(suite.template.Clone()).ExecuteTemplate(&output, "people", gin.H{"People": []string{"Jerry"}})
+	suite.Require().Error(err)
+}
+
+func (suite *ComponentTestSuite) newContext(htmxRequest bool) (*httptest.ResponseRecorder, *gin.Context) {
+	recorder := httptest.NewRecorder()
+	testContext, _ := gin.CreateTestContext(recorder)
+	testContext.Request = httptest.NewRequest(http.MethodGet, "/", nil)
+
+	if htmxRequest {
+		testContext.Request.Header.Set("Hx-Request", "true")
+	}
+
+	return recorder, testContext
+}
+
+func (suite *ComponentTestSuite) SetupSuite() {
+	suite.template = template.Must(template.New("").Funcs(ginhtmx.FuncMap()).Parse(`
+{{- define "layout"}}<html>{{.Content}}</html>{{end}}
+{{- define "avatar_component"}}<img alt="{{.Name}}" width="{{.Size}}" src="{{.URL}}">{{end}}
+{{- define "people"}}{{range .People}}<li>{{component "avatar" (dict "Name" .) (dict "Size" 16)}}</li>{{end}}{{end}}
+{{- define "invalid"}}{{component "avatar" (dict "Name")}}{{end}}
+{{- define "missing"}}{{component "missing"}}{{end}}
+`))
+	suite.htmx = ginhtmx.NewHtmxWithConfig(suite.template, ginhtmx.HtmxConfig{
+		LayoutTemplateName:  "layout",
+		ContentVariableName: "Content",
+		Components: ginhtmx.Components{
+			"avatar": {
+				Template: "avatar_component",
+				Defaults: gin.H{"Size": 32},
+				Provider: func(_ *gin.Context, props gin.H) error {
+					name, _ := props["Name"].(string)
+					if name == "" {
+						return errNoAvatar
+					}
+
+					props["URL"] = "/avatars/" + name + ".png"
+
+					return nil
+				},
+			},
+		},
+	})
+}
+
+func TestComponentTestSuite(t *testing.T) {
+	t.Parallel()
+	suite.Run(t, new(ComponentTestSuite))
+}
+
+type ComponentTestSuite struct {
+	suite.Suite
+
+	htmx     *ginhtmx.Htmx
+	template *template.Template
+}
@@ -0,0 +1,119 @@
+package ginhtmx
+
+import (
+	"bytes"
+	"compress/gzip"
+	"compress/zlib"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// DefaultCompressionMinSize is the size in bytes below which responses are not
+// compressed unless configured otherwise. Compressing tiny fragments costs more
+// than it saves.
+const DefaultCompressionMinSize = 1024
+
+// responseEncoding returns the content coding, "gzip" or "deflate", with which
+// body should be compressed, or an empty string if it should not be compressed.
+func (htmx *Htmx) responseEncoding(ginContext *gin.Context, status int, body []byte) string {
+	if !htmx.config.Compression {
+		return ""
+	}
+
+	ginContext.Writer.Header().Add("Vary", "Accept-Encoding")
+
+	minSize := htmx.config.CompressionMinSize
+	if minSize <= 0 {
+		minSize = DefaultCompressionMinSize
+	}
+
+	// A response which is already encoded, for example by compression middleware,
+	// must not be compressed again.
+	if len(body) < minSize || !bodyAllowedForStatus(status) || ginContext.Writer.Header().Get("Content-Encoding") != "" {
+		return ""
+	}
+
+	return negotiateEncoding(ginContext.GetHeader("Accept-Encoding"))
+}
+
+// negotiateEncoding returns the supported content coding the Accept-Encoding header
+// prefers, favouring gzip, or an empty string if it accepts neither.
+func negotiateEncoding(header string) string {
+	qualities := map[string]float64{}
+
+	for part := range strings.SplitSeq(header, ",") {
+		coding, params, _ := strings.Cut(strings.TrimSpace(part), ";")
+		quality := 1.0
+
+		if value, found := strings.CutPrefix(strings.TrimSpace(params), "q="); found {
+			parsed, err := strconv.ParseFloat(value, 64)
+			if err != nil {
+				continue
+			}
+
+			quality = parsed
+		}
+
+		qualities[strings.ToLower(strings.TrimSpace(coding))] = quality
+	}
+
+	var (
+		best        string
+		bestQuality float64
+	)
+
+	for _, coding := range []string{"gzip", "deflate"} {
+		quality, found := qualities[coding]
+		if !found {
+			quality, found = qualities["*"]
+		}
+
+		if found && quality > bestQuality {
+			best, bestQuality = coding, quality
+		}
+	}
+
+	return best
+}
+
+// compressBody compresses body with the given content coding.
+func compressBody(encoding string, body []byte) []byte {
+	var compressed bytes.Buffer
+
+	var writer io.WriteCloser
+
+	if encoding == "gzip" {
+		writer = gzip.NewWriter(&compressed)
+	} else {
+		// The deflate content coding is the zlib format, not raw DEFLATE, see RFC 9110
+		// section 8.4.1.2. The error is only returned for an invalid compression level.
+		writer, _ = zlib.NewWriterLevel(&compressed, zlib.DefaultCompression)
+	}
+
+	// Writing to a bytes.Buffer cannot fail.
+	_, _ = writer.Write(body)
+	_ = writer.Close()
+
+	return compressed.Bytes()
+}
+
+// writeCompressed writes body, which has the specified content type, compressed
+// with encoding.
+func writeCompressed(ginContext *gin.Context, status int, contentType string, encoding string, body []byte) error {
+	compressed := compressBody(encoding, body)
+
+	header := ginContext.Writer.Header()
+	header.Set("Content-Encoding", encoding)
+	header.Set("Content-Length", strconv.Itoa(len(compressed)))
+
+	return writeData(ginContext, status, contentType, compressed)
+}
+
+// bodyAllowedForStatus reports whether a response with status may have a body.
+func bodyAllowedForStatus(status int) bool {
+	return status >= http.StatusOK && status != http.StatusNoContent && status != http.StatusNotModified
+}
@@ -0,0 +1,128 @@
+package ginhtmx_test
+
+import (
+	"compress/gzip"
+	"compress/zlib"
+	"html/template"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/jeffscottbrown/ginhtmxtemplates/ginhtmx"
+	"github.com/stretchr/testify/suite"
+)
+
+func (suite *CompressionTestSuite) TestLargeResponsesAreCompressedWithGzip() {
+	recorder := suite.serve(suite.newHtmx(ginhtmx.HtmxConfig{}), "deflate;q=0.5, gzip", "large")
+
+	suite.Equal(http.StatusOK, recorder.Code)
+	suite.Equal("gzip", recorder.Header().Get("Content-Encoding"))
+	suite.Equal("Accept-Encoding", recorder.Header().Get("Vary"))
+	suite.Equal(strconv.Itoa(recorder.Body.Len()), recorder.Header().Get("Content-Length"))
+
+	reader, err := gzip.NewReader(recorder.Body)
+	suite.Require().NoError(err)
+
+	body, err := io.ReadAll(reader)
+	suite.Require().NoError(err)
+	suite.Equal(strings.Repeat("x", 2000), string(body))
+}
+
+func (suite *CompressionTestSuite) TestDeflateIsUsedWhenPreferred() {
+	recorder := suite.serve(suite.newHtmx(ginhtmx.HtmxConfig{}), "gzip;q=0.2, deflate", "large")
+
+	suite.Equal("deflate", recorder.Header().Get("Content-Encoding"))
+
+	reader, err := zlib.NewReader(recorder.Body)
+	suite.Require().NoError(err)
+
+	body, err := io.ReadAll(reader)
+	suite.Require().NoError(err)
+	suite.Equal(strings.Repeat("x", 2000), string(body))
+}
+
+func (suite *CompressionTestSuite) TestWildcardAcceptsGzip() {
+	recorder := suite.serve(suite.newHtmx(ginhtmx.HtmxConfig{}), "*", "large")
+
+	suite.Equal("gzip", recorder.Header().Get("Content-Encoding"))
+}
+
+func (suite *CompressionTestSuite) TestResponsesAreNotCompressedUnlessAccepted() {
+	for _, acceptEncoding := range []string{"", "br", "gzip;q=0, deflate;q=0", "gzip;q=bad"} {
+		recorder := suite.serve(suite.newHtmx(ginhtmx.HtmxConfig{}), acceptEncoding, "large")
+
+		suite.Empty(recorder.Header().Get("Content-Encoding"), acceptEncoding)
+		suite.Equal("Accept-Encoding", recorder.Header().Get("Vary"))
+		suite.Equal(2000, recorder.Body.Len())
+	}
+}
+
+func (suite *CompressionTestSuite) TestSmallFragmentsAreNotCompressed() {
+	recorder := suite.serve(suite.newHtmx(ginhtmx.HtmxConfig{}), "gzip", "small")
+	suite.Empty(recorder.Header().Get("Content-Encoding"))
+	suite.Equal("small", recorder.Body.String())
+
+	recorder = suite.serve(suite.newHtmx(ginhtmx.HtmxConfig{CompressionMinSize: 3}), "gzip", "small")
+	suite.Equal("gzip", recorder.Header().Get("Content-Encoding"))
+}
+
+func (suite *CompressionTestSuite) TestCompressedResponsesHaveTheirOwnETag() {
+	htmx := suite.newHtmx(ginhtmx.HtmxConfig{ETags: true})
+
+	compressed := suite.serve(htmx, "gzip", "large")
+	plain := suite.serve(htmx, "", "large")
+
+	suite.NotEqual(compressed.Header().Get("ETag"), plain.Header().Get("ETag"))
+	suite.Equal("Accept-Encoding", compressed.Header().Values("Vary")[0])
+}
+
+func (suite *CompressionTestSuite) TestResponsesAreNotCompressedUnlessEnabled() {
+	tmpl := template.Must(template.New("").Parse(`{{define "large"}}` + strings.Repeat("x", 2000) + `{{end}}`))
+	htmx := ginhtmx.NewHtmx(tmpl)
+
+	recorder := suite.serve(htmx, "gzip", "large")
+
+	suite.Empty(recorder.Header().Get("Content-Encoding"))
+	suite.Empty(recorder.Header().Get("Vary"))
+}
+
+func (suite *CompressionTestSuite) newHtmx(config ginhtmx.HtmxConfig) *ginhtmx.Htmx {
+	tmpl := template.Must(template.New("").Parse(`
+{{define "large"}}` + strings.Repeat("x", 2000) + `{{end}}
+{{define "small"}}small{{end}}
+`))
+
+	config.LayoutTemplateName = "layout"
+	config.ContentVariableName = "Content"
+	config.Compression = true
+
+	return ginhtmx.NewHtmxWithConfig(tmpl, config)
+}
+
+func (suite *CompressionTestSuite) serve(htmx *ginhtmx.Htmx, acceptEncoding string, name string) *httptest.ResponseRecorder {
+	recorder := httptest.NewRecorder()
+	testContext, _ := gin.CreateTestContext(recorder)
+	testContext.Request = httptest.NewRequest(http.MethodGet, "/", nil)
+	testContext.Request.Header.Set("Hx-Request", "true")
+
+	if acceptEncoding != "" {
+		testContext.Request.Header.Set("Accept-Encoding", acceptEncoding)
+	}
+
+	htmx.Render(testContext, gin.H{}, name)
+
+	return recorder
+}
+
+func TestCompressionTestSuite(t *testing.T) {
+	t.Parallel()
+	suite.Run(t, new(CompressionTestSuite))
+}
+
+type CompressionTestSuite struct {
+	suite.Suite
+}
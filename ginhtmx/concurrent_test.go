@@ -0,0 +1,112 @@
+package ginhtmx_test
+
+import (
+	"errors"
+	"html/template"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/jeffscottbrown/ginhtmxtemplates/ginhtmx"
+	"github.com/stretchr/testify/suite"
+)
+
+var errNotConcurrent = errors.New("templates were not rendered concurrently")
+
+func (suite *ConcurrentRenderingTestSuite) TestTemplatesAreRenderedConcurrentlyInOrder() {
+	var arrivals sync.WaitGroup
+
+	arrivals.Add(2)
+
+	// await only returns once both templates are executing at the same time
+	await := func() (string, error) {
+		arrivals.Done()
+
+		done := make(chan struct{})
+		go func() {
+			arrivals.Wait()
+			close(done)
+		}()
+
+		select {
+		case <-done:
+			return "", nil
+		case <-time.After(time.Second):
+			return "", errNotConcurrent
+		}
+	}
+
+	htmx := suite.newHtmx(template.FuncMap{"await": await}, `
+{{define "first"}}{{setTitle "First"}}First{{await}}{{end}}
+{{define "second"}}{{setTitle "Second"}}Second{{await}}{{end}}
+`)
+
+	recorder := suite.render(htmx, "first", "second")
+
+	suite.Equal(http.StatusOK, recorder.Code)
+	suite.Regexp(`^FirstSecond<title>(First|Second)</title>$`, recorder.Body.String())
+}
+
+func (suite *ConcurrentRenderingTestSuite) TestErrorsAreReportedForEachTemplate() {
+	htmx := suite.newHtmx(nil, `{{define "first"}}First{{end}}`)
+
+	recorder := suite.render(htmx, "missing", "first", "other")
+
+	suite.Equal(http.StatusInternalServerError, recorder.Code)
+	suite.Contains(recorder.Body.String(), `template not found: "missing"`)
+	suite.Contains(recorder.Body.String(), `template not found: "other"`)
+}
+
+func (suite *ConcurrentRenderingTestSuite) TestPanicsArePropagated() {
+	tmpl := template.Must(template.New("").Parse(`{{define "first"}}First{{end}}{{define "second"}}Second{{end}}`))
+	htmx := ginhtmx.NewHtmxWithConfig(tmpl, ginhtmx.HtmxConfig{
+		LayoutTemplateName:  "layout",
+		ContentVariableName: "Content",
+		Metrics:             panickingRecorder{},
+		ConcurrentRendering: true,
+	})
+
+	suite.PanicsWithValue("exploded", func() { suite.render(htmx, "first", "second") })
+}
+
+func (suite *ConcurrentRenderingTestSuite) newHtmx(funcs template.FuncMap, source string) *ginhtmx.Htmx {
+	tmpl := template.Must(template.New("").Funcs(ginhtmx.FuncMap()).Funcs(funcs).Parse(source))
+
+	return ginhtmx.NewHtmxWithConfig(tmpl, ginhtmx.HtmxConfig{
+		LayoutTemplateName:  "layout",
+		ContentVariableName: "Content",
+		Strict:              true,
+		ConcurrentRendering: true,
+	})
+}
+
+func (suite *ConcurrentRenderingTestSuite) render(htmx *ginhtmx.Htmx, names ...string) *httptest.ResponseRecorder {
+	recorder := httptest.NewRecorder()
+	testContext, _ := gin.CreateTestContext(recorder)
+	testContext.Request = httptest.NewRequest(http.MethodGet, "/", nil)
+	testContext.Request.Header.Set("Hx-Request", "true")
+
+	htmx.Render(testContext, gin.H{}, names...)
+
+	return recorder
+}
+
+type panickingRecorder struct{}
+
+func (panickingRecorder) ObserveTemplate(ginhtmx.TemplateObservation) { panic("exploded") }
+
+func (panickingRecorder) ObserveRender(ginhtmx.RenderObservation) {}
+
+func (panickingRecorder) ObserveCache(ginhtmx.CacheObservation) {}
+
+func TestConcurrentRenderingTestSuite(t *testing.T) {
+	t.Parallel()
+	suite.Run(t, new(ConcurrentRenderingTestSuite))
+}
+
+type ConcurrentRenderingTestSuite struct {
+	suite.Suite
+}
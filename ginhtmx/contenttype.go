@@ -0,0 +1,27 @@
+package ginhtmx
+
+const (
+	// DefaultContentType is the media type of rendered responses unless configured
+	// otherwise.
+	DefaultContentType = "text/html"
+
+	// DefaultCharset is the character set of rendered responses unless configured
+	// otherwise.
+	DefaultCharset = "utf-8"
+)
+
+// contentType returns the value of the Content-Type header of rendered responses,
+// for both fragments and full pages.
+func (htmx *Htmx) contentType() string {
+	mediaType := htmx.config.ContentType
+	if mediaType == "" {
+		mediaType = DefaultContentType
+	}
+
+	charset := htmx.config.Charset
+	if charset == "" {
+		charset = DefaultCharset
+	}
+
+	return mediaType + "; charset=" + charset
+}
@@ -0,0 +1,108 @@
+package ginhtmx_test
+
+import (
+	"html/template"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/jeffscottbrown/ginhtmxtemplates/ginhtmx"
+	"github.com/stretchr/testify/suite"
+)
+
+func (suite *ContentTypeTestSuite) TestResponsesAreHTMLByDefault() {
+	htmx := suite.newHtmx(ginhtmx.HtmxConfig{
+		LayoutTemplateName:  "layout",
+		ContentVariableName: "Content",
+	})
+
+	suite.Equal("text/html; charset=utf-8", suite.render(htmx, true).Header().Get("Content-Type"))
+	suite.Equal("text/html; charset=utf-8", suite.render(htmx, false).Header().Get("Content-Type"))
+}
+
+func (suite *ContentTypeTestSuite) TestContentTypeAndCharsetAreConfigurable() {
+	htmx := suite.newHtmx(ginhtmx.HtmxConfig{
+		LayoutTemplateName:  "layout",
+		ContentVariableName: "Content",
+		ContentType:         "application/xhtml+xml",
+		Charset:             "iso-8859-1",
+	})
+
+	fragment := suite.render(htmx, true)
+	suite.Equal("application/xhtml+xml; charset=iso-8859-1", fragment.Header().Get("Content-Type"))
+	suite.Equal("<p>Page</p>", fragment.Body.String())
+
+	page := suite.render(htmx, false)
+	suite.Equal("application/xhtml+xml; charset=iso-8859-1", page.Header().Get("Content-Type"))
+	suite.Equal("<html><p>Page</p></html>", page.Body.String())
+}
+
+func (suite *ContentTypeTestSuite) TestCharsetAloneIsConfigurable() {
+	htmx := suite.newHtmx(ginhtmx.HtmxConfig{
+		LayoutTemplateName:  "layout",
+		ContentVariableName: "Content",
+		Charset:             "windows-1252",
+	})
+
+	suite.Equal("text/html; charset=windows-1252", suite.render(htmx, true).Header().Get("Content-Type"))
+}
+
+func (suite *ContentTypeTestSuite) TestContentTypeIsUsedByEveryResponse() {
+	config := ginhtmx.HtmxConfig{
+		LayoutTemplateName:  "layout",
+		ContentVariableName: "Content",
+		ContentType:         "application/xhtml+xml",
+		Compression:         true,
+		CompressionMinSize:  1,
+	}
+
+	recorder := suite.render(suite.newHtmx(config), true)
+	suite.Equal("gzip", recorder.Header().Get("Content-Encoding"))
+	suite.Equal("application/xhtml+xml; charset=utf-8", recorder.Header().Get("Content-Type"))
+
+	config.Compression = false
+	config.StreamLayout = true
+	recorder = suite.render(suite.newHtmx(config), false)
+	suite.Equal("<html><p>Page</p></html>", recorder.Body.String())
+	suite.Equal("application/xhtml+xml; charset=utf-8", recorder.Header().Get("Content-Type"))
+
+	recorder = httptest.NewRecorder()
+	testContext, _ := gin.CreateTestContext(recorder)
+	testContext.Request = httptest.NewRequest(http.MethodGet, "/", nil)
+	suite.newHtmx(config).CloseModal(testContext, "modal")
+	suite.Equal("application/xhtml+xml; charset=utf-8", recorder.Header().Get("Content-Type"))
+}
+
+func (suite *ContentTypeTestSuite) render(htmx *ginhtmx.Htmx, fragment bool) *httptest.ResponseRecorder {
+	recorder := httptest.NewRecorder()
+	testContext, _ := gin.CreateTestContext(recorder)
+	testContext.Request = httptest.NewRequest(http.MethodGet, "/", nil)
+	testContext.Request.Header.Set("Accept-Encoding", "gzip")
+
+	if fragment {
+		testContext.Request.Header.Set("Hx-Request", "true")
+	}
+
+	htmx.Render(testContext, gin.H{}, "page")
+
+	return recorder
+}
+
+func (suite *ContentTypeTestSuite) newHtmx(config ginhtmx.HtmxConfig) *ginhtmx.Htmx {
+	tmpl := template.Must(template.New("").Parse(`
+{{define "layout"}}<html>{{.Content}}</html>{{end}}
+{{define "page"}}<p>Page</p>{{end}}
+`))
+
+	return ginhtmx.NewHtmxWithConfig(tmpl, config)
+}
+
+func TestContentTypeTestSuite(t *testing.T) {
+	t.Parallel()
+	suite.Run(t, new(ContentTypeTestSuite))
+}
+
+type ContentTypeTestSuite struct {
+	suite.Suite
+}
@@ -0,0 +1,54 @@
+package ginhtmx
+
+import (
+	"github.com/gin-gonic/gin"
+)
+
+// The following keys are set in the gin context after every call to Render or
+// RenderWithStatus so that middleware running after the handler can inspect the
+// outcome of the render without depending on anything else in this package.
+const (
+	// RenderedTemplatesKey holds the []string of template names that were rendered.
+	RenderedTemplatesKey = "ginhtmx.templates"
+
+	// RenderStatusKey holds the int HTTP status code written to the response.
+	RenderStatusKey = "ginhtmx.status"
+
+	// RenderFragmentKey holds a bool which is true when the templates were written
+	// as a fragment and false when they were wrapped in the layout.
+	RenderFragmentKey = "ginhtmx.fragment"
+
+	// RenderBytesKey holds the int number of bytes written to the response body.
+	RenderBytesKey = "ginhtmx.bytes"
+
+	// RenderDurationKey holds the time.Duration spent rendering and writing the response.
+	RenderDurationKey = "ginhtmx.duration"
+
+	// RenderErrorKey holds the error returned while executing the templates, or nil
+	// if every template rendered successfully.
+	RenderErrorKey = "ginhtmx.error"
+)
+
+// LocaleKey is the gin context key holding the locale of the current request as
+// a string. It is consulted by the translation template functions when no
+// LocaleResolver is configured.
+const LocaleKey = "ginhtmx.locale"
+
+func (htmx *Htmx) recordRenderOutcome(ginContext *gin.Context, observation RenderObservation) {
+	ginContext.Set(RenderedTemplatesKey, observation.Templates)
+	ginContext.Set(RenderStatusKey, observation.Status)
+	ginContext.Set(RenderFragmentKey, observation.Fragment)
+	ginContext.Set(RenderBytesKey, observation.Bytes)
+	ginContext.Set(RenderDurationKey, observation.Duration)
+	ginContext.Set(RenderErrorKey, observation.Err)
+
+	if observation.Err != nil {
+		_ = ginContext.Error(observation.Err)
+	}
+
+	if htmx.config.Metrics != nil {
+		htmx.config.Metrics.ObserveRender(observation)
+	}
+
+	htmx.logRender(ginContext, observation)
+}
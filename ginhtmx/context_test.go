@@ -0,0 +1,83 @@
+package ginhtmx_test
+
+import (
+	"html/template"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/jeffscottbrown/ginhtmxtemplates/ginhtmx"
+	"github.com/stretchr/testify/suite"
+)
+
+func (suite *RenderOutcomeTestSuite) TestOutcomeIsRecordedForFullPage() {
+	recorder := httptest.NewRecorder()
+	testContext, _ := gin.CreateTestContext(recorder)
+
+	testContext.Request = httptest.NewRequest(http.MethodGet, "/", nil)
+
+	suite.htmx.RenderWithStatus(testContext, gin.H{"Name": "Jerry"}, http.StatusCreated, "hello")
+
+	suite.Equal([]string{"hello"}, testContext.GetStringSlice(ginhtmx.RenderedTemplatesKey))
+	suite.Equal(http.StatusCreated, testContext.GetInt(ginhtmx.RenderStatusKey))
+	suite.False(testContext.GetBool(ginhtmx.RenderFragmentKey))
+	suite.Equal(recorder.Body.Len(), testContext.GetInt(ginhtmx.RenderBytesKey))
+	suite.Positive(testContext.GetDuration(ginhtmx.RenderDurationKey))
+
+	renderErr, exists := testContext.Get(ginhtmx.RenderErrorKey)
+	suite.True(exists)
+	suite.Nil(renderErr)
+}
+
+func (suite *RenderOutcomeTestSuite) TestOutcomeIsRecordedForFragment() {
+	recorder := httptest.NewRecorder()
+	testContext, _ := gin.CreateTestContext(recorder)
+
+	testContext.Request = httptest.NewRequest(http.MethodGet, "/", nil)
+	testContext.Request.Header.Set("Hx-Request", "true")
+
+	suite.htmx.Render(testContext, gin.H{"Name": "Jerry"}, "hello", "hello")
+
+	suite.Equal([]string{"hello", "hello"}, testContext.GetStringSlice(ginhtmx.RenderedTemplatesKey))
+	suite.Equal(http.StatusOK, testContext.GetInt(ginhtmx.RenderStatusKey))
+	suite.True(testContext.GetBool(ginhtmx.RenderFragmentKey))
+	suite.Equal(recorder.Body.Len(), testContext.GetInt(ginhtmx.RenderBytesKey))
+	suite.IsType(time.Duration(0), testContext.GetDuration(ginhtmx.RenderDurationKey))
+}
+
+func (suite *RenderOutcomeTestSuite) TestErrorIsRecordedForMissingTemplate() {
+	recorder := httptest.NewRecorder()
+	testContext, _ := gin.CreateTestContext(recorder)
+
+	testContext.Request = httptest.NewRequest(http.MethodGet, "/", nil)
+	testContext.Request.Header.Set("Hx-Request", "true")
+
+	suite.htmx.Render(testContext, gin.H{}, "missing")
+
+	renderErr, _ := testContext.Get(ginhtmx.RenderErrorKey)
+	suite.Require().Error(renderErr.(error))
+	suite.Contains(renderErr.(error).Error(), "missing")
+	suite.Len(testContext.Errors, 1)
+}
+
+func (suite *RenderOutcomeTestSuite) SetupSuite() {
+	templateContent := `
+{{define "layout"}}<html><body>{{.Content}}</body></html>{{end}}
+{{define "hello"}}<h1>Hello, {{.Name}}!</h1>{{end}}
+`
+	tmpl := template.Must(template.New("").Parse(templateContent))
+	suite.htmx = ginhtmx.NewHtmx(tmpl)
+}
+
+func TestRenderOutcomeTestSuite(t *testing.T) {
+	t.Parallel()
+	suite.Run(t, new(RenderOutcomeTestSuite))
+}
+
+type RenderOutcomeTestSuite struct {
+	suite.Suite
+
+	htmx *ginhtmx.Htmx
+}
@@ -0,0 +1,96 @@
+package ginhtmx
+
+import (
+	"cmp"
+	"errors"
+	"fmt"
+	"html/template"
+	"io/fs"
+	"path"
+	"strings"
+)
+
+// ErrDuplicateTemplate is returned by NewHtmxFromFS and ParseNamespacedFS when two
+// files would define templates with the same name.
+var ErrDuplicateTemplate = errors.New("duplicate template")
+
+// Conventions describes the layout of a directory of templates loaded by
+// NewHtmxFromFS. Empty fields take their default values.
+type Conventions struct {
+	// LayoutsDir holds the layouts. Defaults to "layouts".
+	LayoutsDir string
+
+	// PagesDir holds the pages rendered by handlers. Defaults to "pages".
+	PagesDir string
+
+	// PartialsDir holds the partials and components used by pages and layouts.
+	// Defaults to "partials".
+	PartialsDir string
+
+	// Extension is the extension of the template files. Defaults to ".html".
+	Extension string
+
+	// DefaultLayout is the name of the layout which wraps full pages, used as the
+	// LayoutTemplateName of the configuration. Defaults to "default", the name of
+	// the file layouts/default.html.
+	DefaultLayout string
+
+	// Config is the configuration of the Htmx. Its LayoutTemplateName is replaced
+	// by DefaultLayout and its ContentVariableName defaults to "Content".
+	Config HtmxConfig
+}
+
+// NewHtmxFromFS creates a new instance of Htmx with the templates in the layouts,
+// pages and partials directories of fsys, replacing the usual boilerplate of
+// creating and parsing the templates:
+//
+//	//go:embed templates
+//	var templates embed.FS
+//
+//	views, _ := fs.Sub(templates, "templates")
+//	htmx, err := ginhtmx.NewHtmxFromFS(views, ginhtmx.Conventions{})
+//
+// Each file defines a template named after its path relative to its directory
+// without the extension, so that pages/users/show.html is rendered as "users/show"
+// and partials/card.html is included with {{ template "card" . }}. The files may
+// also define further templates. The template functions of the package are added
+// to the templates. Missing directories are ignored, but an error is returned if
+// two files define templates with the same name, by their paths or with define and
+// block actions, or if the default layout is not defined.
+func NewHtmxFromFS(fsys fs.FS, conventions Conventions) (*Htmx, error) {
+	extension := cmp.Or(conventions.Extension, ".html")
+	tmpl := template.New("").Funcs(FuncMap())
+	files := newTemplateFiles(tmpl)
+
+	for _, dir := range []string{
+		cmp.Or(conventions.LayoutsDir, "layouts"),
+		cmp.Or(conventions.PagesDir, "pages"),
+		cmp.Or(conventions.PartialsDir, "partials"),
+	} {
+		err := fs.WalkDir(fsys, dir, func(fileName string, entry fs.DirEntry, err error) error {
+			switch {
+			case errors.Is(err, fs.ErrNotExist) && fileName == dir:
+				return fs.SkipDir
+			case err != nil:
+				return err
+			case entry.IsDir() || path.Ext(fileName) != extension:
+				return nil
+			}
+
+			return files.parse(fsys, fileName, strings.TrimSuffix(strings.TrimPrefix(fileName, dir+"/"), extension))
+		})
+		if err != nil {
+			return nil, fmt.Errorf("loading templates: %w", err)
+		}
+	}
+
+	config := conventions.Config
+	config.LayoutTemplateName = cmp.Or(conventions.DefaultLayout, "default")
+	config.ContentVariableName = cmp.Or(config.ContentVariableName, "Content")
+
+	if tmpl.Lookup(config.LayoutTemplateName) == nil {
+		return nil, fmt.Errorf("default layout %w: %q", ErrTemplateNotFound, config.LayoutTemplateName)
+	}
+
+	return NewHtmxWithConfig(tmpl, config), nil
+}
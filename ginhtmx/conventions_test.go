@@ -0,0 +1,110 @@
+package ginhtmx_test
+
+import (
+	"errors"
+	"io/fs"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"testing/fstest"
+
+	"github.com/gin-gonic/gin"
+	"github.com/jeffscottbrown/ginhtmxtemplates/ginhtmx"
+	"github.com/stretchr/testify/suite"
+)
+
+func (suite *ConventionsTestSuite) TestTemplatesAreNamedAfterTheirPaths() {
+	htmx, err := ginhtmx.NewHtmxFromFS(suite.files, ginhtmx.Conventions{})
+	suite.Require().NoError(err)
+
+	recorder := httptest.NewRecorder()
+	testContext, _ := gin.CreateTestContext(recorder)
+	testContext.Request = httptest.NewRequest(http.MethodGet, "/", nil)
+
+	htmx.Render(testContext, gin.H{"Name": "Jerry"}, "users/show")
+
+	suite.Equal("<html><h1>Jerry</h1><div class=\"card\">Jerry</div>\n</html>\n", recorder.Body.String())
+
+	rendered, err := htmx.RenderToString("home", nil)
+	suite.Require().NoError(err)
+	suite.Equal("<h1>Home</h1>\n", rendered)
+
+	_, err = htmx.RenderToString("notes", nil)
+	suite.Require().ErrorIs(err, ginhtmx.ErrTemplateNotFound)
+}
+
+func (suite *ConventionsTestSuite) TestConventionsCanBeCustomized() {
+	files := fstest.MapFS{
+		"views/frames/main.tmpl":  {Data: []byte(`<body>{{ .Body }}</body>`)},
+		"views/screens/home.tmpl": {Data: []byte(`Home`)},
+	}
+
+	htmx, err := ginhtmx.NewHtmxFromFS(files, ginhtmx.Conventions{
+		LayoutsDir:    "views/frames",
+		PagesDir:      "views/screens",
+		PartialsDir:   "views/parts",
+		Extension:     ".tmpl",
+		DefaultLayout: "main",
+		Config:        ginhtmx.HtmxConfig{ContentVariableName: "Body"},
+	})
+	suite.Require().NoError(err)
+
+	recorder := httptest.NewRecorder()
+	testContext, _ := gin.CreateTestContext(recorder)
+	testContext.Request = httptest.NewRequest(http.MethodGet, "/", nil)
+
+	htmx.Render(testContext, gin.H{}, "home")
+
+	suite.Equal("<body>Home</body>", recorder.Body.String())
+}
+
+func (suite *ConventionsTestSuite) TestInvalidTemplateDirectoriesAreReported() {
+	suite.files["pages/card.html"] = &fstest.MapFile{Data: []byte(`Card`)}
+	_, err := ginhtmx.NewHtmxFromFS(suite.files, ginhtmx.Conventions{})
+	suite.Require().ErrorIs(err, ginhtmx.ErrDuplicateTemplate)
+	suite.Require().ErrorContains(err, `"card" defined by pages/card.html and partials/card.html`)
+
+	delete(suite.files, "pages/card.html")
+
+	_, err = ginhtmx.NewHtmxFromFS(suite.files, ginhtmx.Conventions{DefaultLayout: "missing"})
+	suite.Require().ErrorIs(err, ginhtmx.ErrTemplateNotFound)
+
+	_, err = ginhtmx.NewHtmxFromFS(fstest.MapFS{"pages/broken.html": {Data: []byte(`{{ if }}`)}}, ginhtmx.Conventions{})
+	suite.Require().ErrorContains(err, "parsing pages/broken.html")
+
+	_, err = ginhtmx.NewHtmxFromFS(unreadableDirFS{MapFS: suite.files}, ginhtmx.Conventions{})
+	suite.Require().ErrorIs(err, errUnreadableDir)
+}
+
+func (suite *ConventionsTestSuite) SetupTest() {
+	suite.files = fstest.MapFS{
+		"layouts/default.html":  {Data: []byte("<html>{{ .Content }}</html>\n")},
+		"layouts/admin.html":    {Data: []byte("<html class=\"admin\">{{ .Content }}</html>\n")},
+		"pages/home.html":       {Data: []byte("<h1>Home</h1>\n")},
+		"pages/users/show.html": {Data: []byte("<h1>{{ .Name }}</h1>{{ template \"card\" . }}")},
+		"partials/card.html":    {Data: []byte("<div class=\"card\">{{ .Name }}</div>\n")},
+		"partials/notes.txt":    {Data: []byte("not a template")},
+	}
+}
+
+func TestConventionsTestSuite(t *testing.T) {
+	t.Parallel()
+	suite.Run(t, new(ConventionsTestSuite))
+}
+
+type ConventionsTestSuite struct {
+	suite.Suite
+
+	files fstest.MapFS
+}
+
+var errUnreadableDir = errors.New("unreadable directory")
+
+// unreadableDirFS is a file system whose directories cannot be read.
+type unreadableDirFS struct {
+	fstest.MapFS
+}
+
+func (unreadableDirFS) ReadDir(string) ([]fs.DirEntry, error) {
+	return nil, errUnreadableDir
+}
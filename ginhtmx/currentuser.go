@@ -0,0 +1,81 @@
+package ginhtmx
+
+import (
+	"reflect"
+
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	// CurrentUserModelKey is the key under which CurrentUserDecorator exposes the
+	// authenticated user to templates.
+	CurrentUserModelKey = "CurrentUser"
+
+	// CurrentUserKey is the gin context key holding the authenticated user of the
+	// request once CurrentUserDecorator has extracted it.
+	CurrentUserKey = "ginhtmx.currentUser"
+)
+
+// CurrentUserDecorator returns a ModelDecorator which exposes the authenticated user
+// of the request, as returned by extractor, to templates as .CurrentUser, so that
+// layouts can show the login or logout controls and the name of the user:
+//
+//	ModelDecorator: ginhtmx.CurrentUserDecorator(ginhtmx.UserFromContext("user")),
+//
+//	{{ if isAuthenticated }}{{ .CurrentUser.Name }} <a href="/logout">Log out</a>{{ end }}
+//
+// The extractor returns nil, or a nil pointer, for anonymous requests, in which case
+// .CurrentUser is not set. Combine it with other decorators using ModelDecorators.
+func CurrentUserDecorator(extractor func(ginContext *gin.Context) any) ModelDecorator {
+	return ModelDecoratorFunc(func(ginContext *gin.Context, model *gin.H) {
+		user := extractor(ginContext)
+		if isNilValue(user) {
+			return
+		}
+
+		ginContext.Set(CurrentUserKey, user)
+
+		if _, exists := (*model)[CurrentUserModelKey]; !exists {
+			(*model)[CurrentUserModelKey] = user
+		}
+	})
+}
+
+// UserFromContext returns an extractor for CurrentUserDecorator which returns the
+// value stored in the gin context under key, usually by authentication middleware
+// which runs before the handler.
+func UserFromContext(key string) func(ginContext *gin.Context) any {
+	return func(ginContext *gin.Context) any {
+		return ginContext.Value(key)
+	}
+}
+
+// isAuthenticated returns whether CurrentUserDecorator found an authenticated user
+// for the request being rendered:
+//
+//	{{ if isAuthenticated }}…{{ else }}<a href="/login">Log in</a>{{ end }}
+func (scope *requestScope) isAuthenticated() bool {
+	if scope.ginContext == nil {
+		return false
+	}
+
+	_, exists := scope.ginContext.Get(CurrentUserKey)
+
+	return exists
+}
+
+// isNilValue returns whether value is nil or a nil pointer, map, slice or interface.
+func isNilValue(value any) bool {
+	if value == nil {
+		return true
+	}
+
+	reflected := reflect.ValueOf(value)
+
+	switch reflected.Kind() {
+	case reflect.Pointer, reflect.Map, reflect.Slice, reflect.Interface:
+		return reflected.IsNil()
+	default:
+		return false
+	}
+}
@@ -0,0 +1,78 @@
+package ginhtmx_test
+
+import (
+	"html/template"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/jeffscottbrown/ginhtmxtemplates/ginhtmx"
+	"github.com/stretchr/testify/suite"
+)
+
+func (suite *CurrentUserTestSuite) TestCurrentUserIsExposedToTemplates() {
+	suite.Equal(`<nav>Jerry <a href="/logout">Log out</a></nav>`, suite.render(&currentUser{Name: "Jerry"}))
+
+	user, exists := suite.testContext.Get(ginhtmx.CurrentUserKey)
+	suite.True(exists)
+	suite.Equal(&currentUser{Name: "Jerry"}, user)
+}
+
+func (suite *CurrentUserTestSuite) TestAnonymousRequestsAreNotAuthenticated() {
+	suite.Equal(`<nav><a href="/login">Log in</a></nav>`, suite.render(nil))
+	suite.Equal(`<nav><a href="/login">Log in</a></nav>`, suite.render((*currentUser)(nil)))
+}
+
+func (suite *CurrentUserTestSuite) TestIsAuthenticatedOutsideOfRequests() {
+	var output strings.Builder
+
+	err := template.Must(template.New("").Funcs(ginhtmx.FuncMap()).Parse(`{{ isAuthenticated }}`)).Execute(&output, nil)
+	suite.Require().NoError(err)
+	suite.Equal("false", output.String())
+}
+
+func (suite *CurrentUserTestSuite) render(user any) string {
+	recorder := httptest.NewRecorder()
+	suite.testContext, _ = gin.CreateTestContext(recorder)
+	suite.testContext.Request = httptest.NewRequest(http.MethodGet, "/", nil)
+	suite.testContext.Request.Header.Set("Hx-Request", "true")
+
+	if user != nil {
+		suite.testContext.Set("user", user)
+	}
+
+	suite.htmx.Render(suite.testContext, gin.H{}, "nav")
+
+	return recorder.Body.String()
+}
+
+func (suite *CurrentUserTestSuite) SetupTest() {
+	tmpl := template.Must(template.New("").Funcs(ginhtmx.FuncMap()).Parse(`
+{{- define "layout"}}<html>{{ .Content }}</html>{{end -}}
+{{- define "nav"}}<nav>{{ if isAuthenticated }}{{ .CurrentUser.Name }} <a href="/logout">Log out</a>
+{{- else }}<a href="/login">Log in</a>{{ end }}</nav>{{end -}}
+`))
+	suite.htmx = ginhtmx.NewHtmxWithConfig(tmpl, ginhtmx.HtmxConfig{
+		LayoutTemplateName:  "layout",
+		ContentVariableName: "Content",
+		ModelDecorator:      ginhtmx.CurrentUserDecorator(ginhtmx.UserFromContext("user")),
+	})
+}
+
+func TestCurrentUserTestSuite(t *testing.T) {
+	t.Parallel()
+	suite.Run(t, new(CurrentUserTestSuite))
+}
+
+type CurrentUserTestSuite struct {
+	suite.Suite
+
+	htmx        *ginhtmx.Htmx
+	testContext *gin.Context
+}
+
+type currentUser struct {
+	Name string
+}
@@ -0,0 +1,124 @@
+package ginhtmx
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// IsDatastarRequest reports whether the request was made by Datastar, which sends
+// the Datastar-Request request header.
+func IsDatastarRequest(ginContext *gin.Context) bool {
+	return ginContext.GetHeader("Datastar-Request") != ""
+}
+
+// DatastarFragment is a template rendered as a fragment which Datastar merges into
+// the page, see MergeFragments.
+type DatastarFragment struct {
+	// Template is the name of the template which renders the fragment
+	Template string
+
+	// Data is the model of the template
+	Data any
+
+	// Selector selects the elements the fragment is merged into. If empty, Datastar
+	// merges the fragment into the elements with the ids of its top level elements.
+	Selector string
+
+	// MergeMode is how the fragment is merged, such as "outer" or "append". If
+	// empty, Datastar morphs the fragment into the page.
+	MergeMode string
+}
+
+// MergeFragments renders fragments and writes them to the response as a Datastar
+// datastar-merge-fragments server-sent event each, so that the templates used for
+// HTMX can also drive pages using Datastar. The response is started as an event
+// stream by the first call, and MergeFragments may be called again to send further
+// events for as long as the request lasts:
+//
+//	for update := range updates {
+//	  err := handler.htmx.MergeFragments(c, ginhtmx.DatastarFragment{Template: "counter", Data: update})
+//	  ...
+//	}
+//
+// Each template is rendered with its own data in the same way as the templates
+// passed to Render. Nothing is written if any of the templates fails to render, in
+// which case the error is returned, as is the error of writing the events, which
+// wraps ErrClientDisconnected if the client has gone away. Nothing is written either
+// if the request is denied any of the templates by the Guards of the configuration
+// or a permission required with RequirePermission, and the error returned wraps
+// ErrForbidden.
+func (htmx *Htmx) MergeFragments(ginContext *gin.Context, fragments ...DatastarFragment) error {
+	settings := htmx.renderSettings()
+
+	templateNames := make([]string, 0, len(fragments))
+	for _, fragment := range fragments {
+		templateNames = append(templateNames, fragment.Template)
+	}
+
+	err := htmx.authorize(ginContext, settings, templateNames)
+	if err != nil {
+		return err
+	}
+
+	scope := htmx.newRequestScope(ginContext)
+	scope.ctx = htmx.withGuards(ginContext.Request.Context(), ginContext)
+	scope.funcs = htmx.tenantFuncs(ginContext, settings.funcs)
+	scope.now = settings.now
+
+	tmpl, release, err := htmx.acquireTemplate(scope, settings.templateSet)
+	defer release()
+
+	errs := []error{err}
+
+	var events strings.Builder
+
+	for _, fragment := range fragments {
+		rendered, err := htmx.executeTemplate(scope.ctx, tmpl, fragment.Template, fragment.Data, true)
+		errs = append(errs, err)
+
+		writeMergeFragmentsEvent(&events, fragment, rendered)
+	}
+
+	err = errors.Join(errs...)
+	if err != nil {
+		return err
+	}
+
+	if !ginContext.Writer.Written() {
+		ginContext.Header("Content-Type", "text/event-stream")
+		ginContext.Header("Cache-Control", "no-cache")
+		ginContext.Status(http.StatusOK)
+	}
+
+	err = writeString(ginContext, events.String())
+	if err != nil {
+		return err
+	}
+
+	ginContext.Writer.Flush()
+
+	return nil
+}
+
+// writeMergeFragmentsEvent writes the datastar-merge-fragments event merging the
+// rendered fragment, with one data line for each of its lines.
+func writeMergeFragmentsEvent(events *strings.Builder, fragment DatastarFragment, rendered string) {
+	events.WriteString("event: datastar-merge-fragments\n")
+
+	if fragment.Selector != "" {
+		events.WriteString("data: selector " + fragment.Selector + "\n")
+	}
+
+	if fragment.MergeMode != "" {
+		events.WriteString("data: mergeMode " + fragment.MergeMode + "\n")
+	}
+
+	for line := range strings.Lines(rendered) {
+		events.WriteString("data: fragments " + strings.TrimRight(line, "\r\n") + "\n")
+	}
+
+	events.WriteString("\n")
+}
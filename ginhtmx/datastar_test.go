@@ -0,0 +1,101 @@
+package ginhtmx_test
+
+import (
+	"html/template"
+	"net/http"
+	"net/http/httptest"
+	"syscall"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/jeffscottbrown/ginhtmxtemplates/ginhtmx"
+	"github.com/stretchr/testify/suite"
+)
+
+func (suite *DatastarTestSuite) TestFragmentsAreWrittenAsMergeEvents() {
+	recorder, testContext := suite.newContext()
+	testContext.Request.Header.Set("Datastar-Request", "true")
+
+	err := suite.htmx.MergeFragments(testContext,
+		ginhtmx.DatastarFragment{Template: "counter", Data: 1, Selector: "", MergeMode: ""},
+		ginhtmx.DatastarFragment{Template: "list", Data: []string{"a", "b"}, Selector: "#items", MergeMode: "append"},
+	)
+	suite.Require().NoError(err)
+
+	err = suite.htmx.MergeFragments(testContext,
+		ginhtmx.DatastarFragment{Template: "counter", Data: 2, Selector: "", MergeMode: ""})
+	suite.Require().NoError(err)
+
+	suite.True(ginhtmx.IsDatastarRequest(testContext))
+	suite.Equal(http.StatusOK, recorder.Code)
+	suite.Equal("text/event-stream", recorder.Header().Get("Content-Type"))
+	suite.Equal("no-cache", recorder.Header().Get("Cache-Control"))
+	suite.Equal(`event: datastar-merge-fragments
+data: fragments <span id="counter">1</span>
+
+event: datastar-merge-fragments
+data: selector #items
+data: mergeMode append
+data: fragments <li>a</li>
+data: fragments <li>b</li>
+
+event: datastar-merge-fragments
+data: fragments <span id="counter">2</span>
+
+`, recorder.Body.String())
+}
+
+func (suite *DatastarTestSuite) TestNothingIsWrittenWhenAFragmentFails() {
+	recorder, testContext := suite.newContext()
+
+	err := suite.htmx.MergeFragments(testContext,
+		ginhtmx.DatastarFragment{Template: "counter", Data: 1, Selector: "", MergeMode: ""},
+		ginhtmx.DatastarFragment{Template: "missing", Data: nil, Selector: "", MergeMode: ""},
+	)
+
+	suite.Require().ErrorIs(err, ginhtmx.ErrTemplateNotFound)
+	suite.Empty(recorder.Body.String())
+	suite.False(ginhtmx.IsDatastarRequest(testContext))
+}
+
+func (suite *DatastarTestSuite) TestDisconnectedClientsAreReported() {
+	testContext, _ := gin.CreateTestContext(&failingResponseWriter{err: syscall.EPIPE, allowed: 0})
+	testContext.Request = httptest.NewRequest(http.MethodGet, "/updates", nil)
+
+	err := suite.htmx.MergeFragments(testContext,
+		ginhtmx.DatastarFragment{Template: "counter", Data: 1, Selector: "", MergeMode: ""})
+
+	suite.Require().ErrorIs(err, ginhtmx.ErrClientDisconnected)
+}
+
+func (suite *DatastarTestSuite) newContext() (*httptest.ResponseRecorder, *gin.Context) {
+	recorder := httptest.NewRecorder()
+	testContext, _ := gin.CreateTestContext(recorder)
+	testContext.Request = httptest.NewRequest(http.MethodGet, "/updates", nil)
+
+	return recorder, testContext
+}
+
+func (suite *DatastarTestSuite) SetupTest() {
+	tmpl := template.Must(template.New("").Parse(`
+{{define "layout"}}<html>{{.Content}}</html>{{end}}
+{{define "counter"}}<span id="counter">{{.}}</span>{{end}}
+{{define "list"}}{{range .}}<li>{{.}}</li>
+{{end}}{{end}}
+`))
+	suite.htmx = ginhtmx.NewHtmxWithConfig(tmpl, ginhtmx.HtmxConfig{
+		LayoutTemplateName:  "layout",
+		ContentVariableName: "Content",
+	})
+}
+
+func TestDatastarTestSuite(t *testing.T) {
+	t.Parallel()
+	suite.Run(t, new(DatastarTestSuite))
+}
+
+type DatastarTestSuite struct {
+	suite.Suite
+
+	htmx *ginhtmx.Htmx
+}
@@ -0,0 +1,268 @@
+package ginhtmx
+
+import (
+	"cmp"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// TimeZoneKey is the gin context key holding the *time.Location of the user of
+// the current request. When set it takes precedence over the TimeZone of the
+// HtmxConfig in the date and time template functions.
+const TimeZoneKey = "ginhtmx.timezone"
+
+// DateFormat holds the layouts, as used by time.Format, with which the "formatDate"
+// and "formatTime" template functions format times for a locale.
+type DateFormat struct {
+	// Date is the layout of dates, such as "02.01.2006".
+	Date string
+
+	// Time is the layout of times of day, such as "15:04".
+	Time string
+}
+
+// isoDateFormat is used for locales without a known format.
+var isoDateFormat = DateFormat{Date: "2006-01-02", Time: "15:04"}
+
+// defaultDateFormats are the formats of common locales, keyed by normalized locale.
+var defaultDateFormats = map[string]DateFormat{
+	"en":    {Date: "Jan 2, 2006", Time: "3:04 PM"},
+	"en-gb": {Date: "2 Jan 2006", Time: "15:04"},
+	"en-au": {Date: "2 Jan 2006", Time: "3:04 PM"},
+	"de":    {Date: "02.01.2006", Time: "15:04"},
+	"fr":    {Date: "02/01/2006", Time: "15:04"},
+	"es":    {Date: "02/01/2006", Time: "15:04"},
+	"it":    {Date: "02/01/2006", Time: "15:04"},
+	"pt":    {Date: "02/01/2006", Time: "15:04"},
+	"nl":    {Date: "02-01-2006", Time: "15:04"},
+	"ja":    {Date: "2006/01/02", Time: "15:04"},
+	"zh":    {Date: "2006/01/02", Time: "15:04"},
+}
+
+// timeUnits are the units used by "timeAgo", from the largest to the smallest.
+var timeUnits = []struct {
+	name     string
+	duration time.Duration
+}{
+	{name: "year", duration: 365 * 24 * time.Hour},
+	{name: "month", duration: 30 * 24 * time.Hour},
+	{name: "day", duration: 24 * time.Hour},
+	{name: "hour", duration: time.Hour},
+	{name: "minute", duration: time.Minute},
+}
+
+// formatDate formats a time.Time or *time.Time as a date in the format of the
+// locale of the request, after converting it to the time zone of the request:
+//
+//	<td>{{ formatDate .Order.PlacedAt }}</td>
+//
+// An optional layout overrides the format of the locale. Zero and nil times are
+// formatted as an empty string.
+func (scope *requestScope) formatDate(value any, layout ...string) (string, error) {
+	return scope.formatTimeValue(value, func(format DateFormat) string { return format.Date }, layout)
+}
+
+// formatTime formats a time.Time or *time.Time as a time of day in the format of
+// the locale of the request, after converting it to the time zone of the request.
+// An optional layout overrides the format of the locale.
+func (scope *requestScope) formatTime(value any, layout ...string) (string, error) {
+	return scope.formatTimeValue(value, func(format DateFormat) string { return format.Time }, layout)
+}
+
+func (scope *requestScope) formatTimeValue(value any, part func(DateFormat) string, layout []string) (string, error) {
+	moment, err := toTime(value)
+	if err != nil || moment.IsZero() {
+		return "", err
+	}
+
+	if location := scope.timeZone(); location != nil {
+		moment = moment.In(location)
+	}
+
+	if len(layout) > 0 {
+		return moment.Format(layout[0]), nil
+	}
+
+	return moment.Format(part(scope.dateFormat())), nil
+}
+
+// timeAgo describes how long ago, or how far in the future, a time.Time or
+// *time.Time is relative to now, such as "5 minutes ago" or "in 2 days":
+//
+//	<span title="{{ formatDate .Comment.Posted }}">{{ timeAgo .Comment.Posted }}</span>
+//
+// When a Translator is configured the descriptions are looked up as plural
+// messages with the keys "timeAgo.<unit>" and "timeUntil.<unit>", where the unit
+// is one of year, month, day, hour and minute, and times less than a minute away
+// as the message "timeAgo.now". The number of units is available to the messages
+// as {count}. Messages which are not found fall back to English.
+func (scope *requestScope) timeAgo(value any) (string, error) {
+	moment, err := toTime(value)
+	if err != nil || moment.IsZero() {
+		return "", err
+	}
+
+	elapsed := scope.currentTime().Sub(moment)
+	prefix := "timeAgo."
+
+	if elapsed < 0 {
+		elapsed = -elapsed
+		prefix = "timeUntil."
+	}
+
+	for _, unit := range timeUnits {
+		count := int(elapsed / unit.duration)
+		if count == 0 {
+			continue
+		}
+
+		if message := scope.pluralMessage(count, prefix+unit.name); message != "" {
+			return message, nil
+		}
+
+		name := unit.name
+		if count != 1 {
+			name += "s"
+		}
+
+		if prefix == "timeUntil." {
+			return fmt.Sprintf("in %d %s", count, name), nil
+		}
+
+		return fmt.Sprintf("%d %s ago", count, name), nil
+	}
+
+	if translator := scope.translator(); translator != nil {
+		if message := translator.Translate(scope.locale(), "timeAgo.now"); message != "timeAgo.now" {
+			return message, nil
+		}
+	}
+
+	return "just now", nil
+}
+
+// pluralMessage returns the translation of the plural message key for count, or an
+// empty string if there is no translator or it does not define the message.
+func (scope *requestScope) pluralMessage(count int, key string) string {
+	translator := scope.translator()
+	if translator == nil {
+		return ""
+	}
+
+	message := translator.Plural(scope.locale(), count, key)
+	if message == key {
+		return ""
+	}
+
+	return message
+}
+
+// timeZone returns the time zone of the request, or nil if times should be
+// formatted in their own location.
+func (scope *requestScope) timeZone() *time.Location {
+	if scope.ginContext != nil {
+		if location, ok := scope.ginContext.Value(TimeZoneKey).(*time.Location); ok && location != nil {
+			return location
+		}
+	}
+
+	if scope.htmx == nil {
+		return nil
+	}
+
+	return scope.htmx.config.TimeZone
+}
+
+// dateFormat returns the format for the locale of the request.
+func (scope *requestScope) dateFormat() DateFormat {
+	var configured map[string]DateFormat
+	if scope.htmx != nil {
+		configured = scope.htmx.config.DateFormats
+	}
+
+	return localeFormat(scope.locale(), isoDateFormat, defaultDateFormats, configured,
+		func(format DateFormat, override DateFormat) DateFormat {
+			return DateFormat{
+				Date: cmp.Or(override.Date, format.Date),
+				Time: cmp.Or(override.Time, format.Time),
+			}
+		})
+}
+
+// localeFormat returns the format for locale, starting from fallback and applying
+// the built-in formats and then the configured formats of its base language and of
+// the locale itself, so that the most specific format takes precedence. The
+// configured formats are merged into the format with merge, so that they may
+// override some of its fields only.
+func localeFormat[F any](locale string, fallback F, builtin, configured map[string]F, merge func(F, F) F) F {
+	locale = normalizeLocale(locale)
+	language, _, _ := strings.Cut(locale, "-")
+	candidates := []string{language, locale}
+
+	format := fallback
+
+	for _, candidate := range candidates {
+		if found, ok := builtin[candidate]; ok {
+			format = found
+		}
+	}
+
+	for _, candidate := range candidates {
+		for key, override := range configured {
+			if normalizeLocale(key) == candidate {
+				format = merge(format, override)
+			}
+		}
+	}
+
+	return format
+}
+
+// toTime converts a time.Time or *time.Time to a time.Time, with nil converted to
+// the zero time.
+func toTime(value any) (time.Time, error) {
+	switch moment := value.(type) {
+	case nil:
+		return time.Time{}, nil
+	case time.Time:
+		return moment, nil
+	case *time.Time:
+		if moment == nil {
+			return time.Time{}, nil
+		}
+
+		return *moment, nil
+	default:
+		return time.Time{}, fmt.Errorf("%w: %v is not a time", errInvalidArgument, value)
+	}
+}
+
+// Clock returns a RenderOption which makes the time functions, such as "timeAgo",
+// treat the time returned by now as the current time, so that templates describing
+// times relative to now render the same output whenever they are rendered. It also
+// decides whether output cached with StaleWhileRevalidate is fresh.
+func Clock(now func() time.Time) RenderOption {
+	return func(settings *renderSettings) {
+		settings.now = now
+	}
+}
+
+// currentTime returns the time the time functions treat as now.
+func (scope *requestScope) currentTime() time.Time {
+	return currentTime(scope.now)
+}
+
+// currentTime returns the time renders with settings treat as now.
+func (settings *renderSettings) currentTime() time.Time {
+	return currentTime(settings.now)
+}
+
+// currentTime returns the time returned by now, or the current time if now is nil.
+func currentTime(now func() time.Time) time.Time {
+	if now == nil {
+		return time.Now()
+	}
+
+	return now()
+}
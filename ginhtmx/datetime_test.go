@@ -0,0 +1,139 @@
+package ginhtmx_test
+
+import (
+	"html/template"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/jeffscottbrown/ginhtmxtemplates/ginhtmx"
+	"github.com/stretchr/testify/suite"
+)
+
+func (suite *DateTimeTestSuite) TestDatesAreFormattedForTheLocale() {
+	moment := time.Date(2025, time.March, 7, 18, 5, 0, 0, time.UTC)
+
+	suite.Equal("Mar 7, 2025 6:05 PM", suite.render(`{{ formatDate .Value }} {{ formatTime .Value }}`, moment, "en-US", nil))
+	suite.Equal("7 Mar 2025 18:05", suite.render(`{{ formatDate .Value }} {{ formatTime .Value }}`, moment, "en-GB", nil))
+	suite.Equal("07.03.2025 18:05", suite.render(`{{ formatDate .Value }} {{ formatTime .Value }}`, moment, "de_AT", nil))
+	suite.Equal("2025-03-07 18:05", suite.render(`{{ formatDate .Value }} {{ formatTime .Value }}`, moment, "fi", nil))
+	suite.Equal("2025-03-07 18:05", suite.render(`{{ formatDate .Value }} {{ formatTime .Value }}`, &moment, "", nil))
+	suite.Equal("Friday", suite.render(`{{ formatDate .Value "Monday" }}`, moment, "en", nil))
+	suite.Equal("18h05", suite.render(`{{ formatTime .Value "15h04" }}`, moment, "fr", nil))
+}
+
+func (suite *DateTimeTestSuite) TestConfiguredFormatsOverrideBuiltInFormats() {
+	moment := time.Date(2025, time.March, 7, 18, 5, 0, 0, time.UTC)
+
+	suite.Equal("7. Mar 2025 18:05", suite.render(`{{ formatDate .Value }} {{ formatTime .Value }}`, moment, "de-CH", nil))
+	suite.Equal("7 Mar 2025 18:05", suite.render(`{{ formatDate .Value }} {{ formatTime .Value }}`, moment, "en-AU", nil))
+}
+
+func (suite *DateTimeTestSuite) TestTimesAreConvertedToTheTimeZone() {
+	moment := time.Date(2025, time.March, 7, 23, 30, 0, 0, time.UTC)
+	tokyo := time.FixedZone("JST", 9*60*60)
+
+	suite.Equal("2025-03-07 23:30", suite.render(`{{ formatDate .Value }} {{ formatTime .Value }}`, moment, "", nil))
+	suite.Equal("2025-03-08 08:30", suite.render(`{{ formatDate .Value }} {{ formatTime .Value }}`, moment, "", tokyo))
+
+	suite.timeZone = time.FixedZone("EST", -5*60*60)
+	suite.Equal("2025-03-07 18:30", suite.render(`{{ formatDate .Value }} {{ formatTime .Value }}`, moment, "", nil))
+	suite.Equal("2025-03-08 08:30", suite.render(`{{ formatDate .Value }} {{ formatTime .Value }}`, moment, "", tokyo))
+}
+
+func (suite *DateTimeTestSuite) TestTimeAgoDescribesRelativeTimes() {
+	now := time.Now()
+
+	suite.Equal("just now", suite.render(`{{ timeAgo .Value }}`, now, "", nil))
+	suite.Equal("1 minute ago", suite.render(`{{ timeAgo .Value }}`, now.Add(-90*time.Second), "", nil))
+	suite.Equal("3 hours ago", suite.render(`{{ timeAgo .Value }}`, now.Add(-3*time.Hour-time.Minute), "", nil))
+	suite.Equal("2 years ago", suite.render(`{{ timeAgo .Value }}`, now.AddDate(-2, 0, -1), "", nil))
+	suite.Equal("in 2 days", suite.render(`{{ timeAgo .Value }}`, now.Add(49*time.Hour), "", nil))
+}
+
+func (suite *DateTimeTestSuite) TestClockFixesTheCurrentTime() {
+	now := time.Date(2025, time.March, 7, 18, 5, 0, 0, time.UTC)
+	suite.options = []ginhtmx.RenderOption{ginhtmx.Clock(func() time.Time { return now })}
+
+	suite.Equal("5 minutes ago", suite.render(`{{ timeAgo .Value }}`, now.Add(-5*time.Minute), "", nil))
+	suite.Equal("in 3 hours", suite.render(`{{ timeAgo .Value }}`, now.Add(3*time.Hour), "", nil))
+}
+
+func (suite *DateTimeTestSuite) TestTimeAgoIsTranslated() {
+	now := time.Now()
+
+	suite.Equal("gerade eben", suite.render(`{{ timeAgo .Value }}`, now, "de", nil))
+	suite.Equal("vor 5 Minuten", suite.render(`{{ timeAgo .Value }}`, now.Add(-5*time.Minute-time.Second), "de", nil))
+	suite.Equal("in 1 day", suite.render(`{{ timeAgo .Value }}`, now.Add(25*time.Hour), "de", nil))
+	suite.Equal("just now", suite.render(`{{ timeAgo .Value }}`, now, "en", nil))
+}
+
+func (suite *DateTimeTestSuite) TestMissingAndInvalidTimes() {
+	var missing *time.Time
+
+	suite.Empty(suite.render(`{{ formatDate .Value }}{{ formatTime .Value }}{{ timeAgo .Value }}`, missing, "", nil))
+	suite.Empty(suite.render(`{{ formatDate .Value }}{{ timeAgo .Value }}`, nil, "", nil))
+	suite.Empty(suite.render(`{{ formatDate .Value }}{{ timeAgo .Value }}`, time.Time{}, "", nil))
+
+	var output strings.Builder
+
+	err := template.Must(template.New("").Funcs(ginhtmx.FuncMap()).Parse(`{{ timeAgo . }}`)).Execute(&output, "yesterday")
+	suite.Require().ErrorContains(err, "invalid argument: yesterday is not a time")
+
+	err = template.Must(template.New("").Funcs(ginhtmx.FuncMap()).Parse(`{{ formatDate . }} {{ timeAgo . }}`)).Execute(&output, time.Now().Add(-time.Hour))
+	suite.Require().NoError(err)
+	suite.True(strings.HasSuffix(output.String(), " 1 hour ago"))
+}
+
+func (suite *DateTimeTestSuite) render(source string, value any, locale string, location *time.Location) string {
+	tmpl := template.Must(template.New("").Funcs(ginhtmx.FuncMap()).Parse(`{{define "moment"}}` + source + `{{end}}`))
+	catalog := ginhtmx.NewCatalog("en").AddMessages("de", map[string]string{
+		"timeAgo.now":          "gerade eben",
+		"timeAgo.minute.one":   "vor einer Minute",
+		"timeAgo.minute.other": "vor {count} Minuten",
+	})
+	htmx := ginhtmx.NewHtmxWithConfig(tmpl, ginhtmx.HtmxConfig{
+		LayoutTemplateName:  "layout",
+		ContentVariableName: "Content",
+		Translator:          catalog,
+		TimeZone:            suite.timeZone,
+		DateFormats: map[string]ginhtmx.DateFormat{
+			"en-AU": {Date: "", Time: "15:04"},
+			"de-CH": {Date: "2. Jan 2006", Time: ""},
+		},
+	})
+
+	recorder := httptest.NewRecorder()
+	testContext, _ := gin.CreateTestContext(recorder)
+	testContext.Request = httptest.NewRequest(http.MethodGet, "/", nil)
+	testContext.Request.Header.Set("Hx-Request", "true")
+	testContext.Set(ginhtmx.LocaleKey, locale)
+
+	if location != nil {
+		testContext.Set(ginhtmx.TimeZoneKey, location)
+	}
+
+	htmx.With(suite.options...).Render(testContext, gin.H{"Value": value}, "moment")
+
+	return recorder.Body.String()
+}
+
+func (suite *DateTimeTestSuite) SetupTest() {
+	suite.timeZone = nil
+	suite.options = nil
+}
+
+func TestDateTimeTestSuite(t *testing.T) {
+	t.Parallel()
+	suite.Run(t, new(DateTimeTestSuite))
+}
+
+type DateTimeTestSuite struct {
+	suite.Suite
+
+	timeZone *time.Location
+	options  []ginhtmx.RenderOption
+}
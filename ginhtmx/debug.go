@@ -0,0 +1,89 @@
+package ginhtmx
+
+import (
+	"fmt"
+	"maps"
+	"net/http"
+	"slices"
+
+	"github.com/gin-gonic/gin"
+)
+
+// DebugPath is the conventional path at which DebugHandler is mounted.
+const DebugPath = "/__ginhtmx/templates"
+
+// DebugInfo describes the templates and configuration of an Htmx instance.
+type DebugInfo struct {
+	// Templates are the names of the defined templates, including the built-in templates
+	Templates []string `json:"templates"`
+
+	// Layout is the name of the layout template
+	Layout string `json:"layout"`
+
+	// LayoutDefined reports whether the layout template is defined
+	LayoutDefined bool `json:"layoutDefined"`
+
+	// ContentVariable is the name of the variable which holds the content in the layout
+	ContentVariable string `json:"contentVariable"`
+
+	// Functions are the template functions provided by this package. Functions
+	// added to the templates by the application cannot be listed.
+	Functions []string `json:"functions"`
+
+	// ModelDecorator is the type of the configured ModelDecorator, if any
+	ModelDecorator string `json:"modelDecorator,omitempty"`
+
+	// Components are the names of the configured components
+	Components []string `json:"components"`
+
+	// Strict reports whether strict rendering is enabled
+	Strict bool `json:"strict"`
+
+	// Debug reports whether debug checks are enabled
+	Debug bool `json:"debug"`
+}
+
+// DebugInfo returns a description of the templates and configuration, which is
+// useful to find out why a render came out empty.
+func (htmx *Htmx) DebugInfo() DebugInfo {
+	templates := htmx.currentTemplate()
+
+	var names []string
+
+	for _, tmpl := range templates.Templates() {
+		if tmpl.Name() != "" {
+			names = append(names, tmpl.Name())
+		}
+	}
+
+	slices.Sort(names)
+
+	var decorator string
+	if htmx.config.ModelDecorator != nil {
+		decorator = fmt.Sprintf("%T", htmx.config.ModelDecorator)
+	}
+
+	return DebugInfo{
+		Templates:       names,
+		Layout:          htmx.config.LayoutTemplateName,
+		LayoutDefined:   htmx.lookupTemplate(templates, htmx.config.LayoutTemplateName) != nil,
+		ContentVariable: htmx.contentVariable(),
+		Functions:       slices.Sorted(maps.Keys(FuncMap())),
+		ModelDecorator:  decorator,
+		Components:      slices.Sorted(maps.Keys(htmx.config.Components)),
+		Strict:          htmx.config.Strict,
+		Debug:           htmx.config.Debug,
+	}
+}
+
+// DebugHandler returns a handler which responds with the DebugInfo as JSON. It
+// exposes the internals of the application and is intended for development only:
+//
+//	if gin.Mode() == gin.DebugMode {
+//	  router.GET(ginhtmx.DebugPath, htmx.DebugHandler())
+//	}
+func (htmx *Htmx) DebugHandler() gin.HandlerFunc {
+	return func(ginContext *gin.Context) {
+		ginContext.IndentedJSON(http.StatusOK, htmx.DebugInfo())
+	}
+}
@@ -0,0 +1,107 @@
+package ginhtmx_test
+
+import (
+	"encoding/json"
+	"html/template"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/jeffscottbrown/ginhtmxtemplates/ginhtmx"
+	"github.com/stretchr/testify/suite"
+)
+
+func (suite *DebugTestSuite) TestDebugInfoDescribesTemplatesAndConfiguration() {
+	htmx := ginhtmx.NewHtmxWithConfig(template.Must(template.New("").Parse(`
+{{define "page"}}<html>{{.Body}}</html>{{end}}
+{{define "home"}}Home{{end}}
+`)), ginhtmx.HtmxConfig{
+		LayoutTemplateName:  "page",
+		ContentVariableName: "Body",
+		ModelDecorator:      ginhtmx.ModelDecoratorFunc(func(*gin.Context, *gin.H) {}),
+		Components:          ginhtmx.Components{"card": {Template: "home"}},
+		Strict:              true,
+	})
+
+	info := htmx.DebugInfo()
+
+	suite.Contains(info.Templates, "home")
+	suite.Contains(info.Templates, "page")
+	suite.Contains(info.Templates, "ginhtmx/pagination")
+	suite.IsIncreasing(info.Templates)
+	suite.Equal("page", info.Layout)
+	suite.True(info.LayoutDefined)
+	suite.Equal("Body", info.ContentVariable)
+	suite.Contains(info.Functions, "partial")
+	suite.Equal("ginhtmx.ModelDecoratorFunc", info.ModelDecorator)
+	suite.Equal([]string{"card"}, info.Components)
+	suite.True(info.Strict)
+	suite.False(info.Debug)
+}
+
+func (suite *DebugTestSuite) TestMissingLayoutIsReported() {
+	htmx := ginhtmx.NewHtmx(template.Must(template.New("").Parse(`{{define "home"}}Home{{end}}`)))
+
+	info := htmx.DebugInfo()
+
+	suite.False(info.LayoutDefined)
+	suite.Empty(info.ModelDecorator)
+}
+
+func (suite *DebugTestSuite) TestDebugHandlerRespondsWithJSON() {
+	htmx := ginhtmx.NewHtmx(template.Must(template.New("").Parse(`{{define "home"}}Home{{end}}`)))
+
+	router := gin.New()
+	router.GET(ginhtmx.DebugPath, htmx.DebugHandler())
+
+	recorder := httptest.NewRecorder()
+	router.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/__ginhtmx/templates", nil))
+
+	var info ginhtmx.DebugInfo
+
+	suite.Equal(http.StatusOK, recorder.Code)
+	suite.Require().NoError(json.Unmarshal(recorder.Body.Bytes(), &info))
+	suite.Equal(htmx.DebugInfo(), info)
+}
+
+func (suite *DebugTestSuite) TestTemplatesAreMarkedInDebugMode() {
+	body := suite.render(true)
+
+	suite.Equal(`<html><!-- begin:home -->Home <!-- begin:item -->Item<!-- end:item --><!-- end:home -->`+
+		`<!-- begin:footer -->Footer<!-- end:footer --></html>`, body)
+}
+
+func (suite *DebugTestSuite) TestTemplatesAreNotMarkedOutsideDebugMode() {
+	suite.Equal(`<html>Home ItemFooter</html>`, suite.render(false))
+}
+
+func (suite *DebugTestSuite) render(debug bool) string {
+	htmx := ginhtmx.NewHtmxWithConfig(template.Must(template.New("").Funcs(ginhtmx.FuncMap()).Parse(`
+{{define "layout"}}<html>{{.Content}}</html>{{end}}
+{{define "home"}}Home {{partial "item" .}}{{end}}
+{{define "item"}}Item{{end}}
+{{define "footer"}}Footer{{end}}
+`)), ginhtmx.HtmxConfig{
+		LayoutTemplateName:  "layout",
+		ContentVariableName: "Content",
+		Debug:               debug,
+	})
+
+	recorder := httptest.NewRecorder()
+	testContext, _ := gin.CreateTestContext(recorder)
+	testContext.Request = httptest.NewRequest(http.MethodGet, "/", nil)
+
+	htmx.Render(testContext, gin.H{}, "home", "footer")
+
+	return recorder.Body.String()
+}
+
+func TestDebugTestSuite(t *testing.T) {
+	t.Parallel()
+	suite.Run(t, new(DebugTestSuite))
+}
+
+type DebugTestSuite struct {
+	suite.Suite
+}
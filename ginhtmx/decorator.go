@@ -7,3 +7,24 @@ import "github.com/gin-gonic/gin"
 type ModelDecorator interface {
 	DecorateModel(ginContext *gin.Context, model *gin.H)
 }
+
+// ModelDecoratorFunc is an adapter which allows an ordinary function to be used
+// as a ModelDecorator.
+type ModelDecoratorFunc func(ginContext *gin.Context, model *gin.H)
+
+// DecorateModel calls decorator(ginContext, model).
+func (decorator ModelDecoratorFunc) DecorateModel(ginContext *gin.Context, model *gin.H) {
+	decorator(ginContext, model)
+}
+
+// ModelDecorators combines several decorators into a single ModelDecorator which
+// calls each of them in order. Nil decorators are skipped.
+func ModelDecorators(decorators ...ModelDecorator) ModelDecorator {
+	return ModelDecoratorFunc(func(ginContext *gin.Context, model *gin.H) {
+		for _, decorator := range decorators {
+			if decorator != nil {
+				decorator.DecorateModel(ginContext, model)
+			}
+		}
+	})
+}
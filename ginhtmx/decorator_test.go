@@ -0,0 +1,40 @@
+package ginhtmx_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/jeffscottbrown/ginhtmxtemplates/ginhtmx"
+	"github.com/stretchr/testify/suite"
+)
+
+func (suite *DecoratorTestSuite) TestModelDecoratorsAreAppliedInOrder() {
+	decorator := ginhtmx.ModelDecorators(
+		ginhtmx.ModelDecoratorFunc(func(_ *gin.Context, model *gin.H) {
+			(*model)["Order"] = "first"
+		}),
+		nil,
+		ginhtmx.ModelDecoratorFunc(func(_ *gin.Context, model *gin.H) {
+			(*model)["Order"] = (*model)["Order"].(string) + ",second"
+		}),
+	)
+
+	testContext, _ := gin.CreateTestContext(httptest.NewRecorder())
+	testContext.Request = httptest.NewRequest(http.MethodGet, "/", nil)
+
+	model := gin.H{}
+	decorator.DecorateModel(testContext, &model)
+
+	suite.Equal("first,second", model["Order"])
+}
+
+func TestDecoratorTestSuite(t *testing.T) {
+	t.Parallel()
+	suite.Run(t, new(DecoratorTestSuite))
+}
+
+type DecoratorTestSuite struct {
+	suite.Suite
+}
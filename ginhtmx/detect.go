@@ -0,0 +1,77 @@
+package ginhtmx
+
+import (
+	"slices"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+// DefaultHTMXRequestHeader is the request header which identifies HTMX requests
+// unless HTMXRequestHeaders is configured.
+const DefaultHTMXRequestHeader = "HX-Request"
+
+// IsHTMXRequest reports whether the request was made by HTMX, which it is if any
+// of the HTMXRequestHeaders of the configuration is present.
+func (htmx *Htmx) IsHTMXRequest(ginContext *gin.Context) bool {
+	for _, name := range htmx.htmxRequestHeaders() {
+		if ginContext.GetHeader(name) != "" {
+			return true
+		}
+	}
+
+	return false
+}
+
+// htmxRequestHeaders returns the names of the request headers which identify HTMX
+// requests.
+func (htmx *Htmx) htmxRequestHeaders() []string {
+	if len(htmx.config.HTMXRequestHeaders) == 0 {
+		return []string{DefaultHTMXRequestHeader}
+	}
+
+	return htmx.config.HTMXRequestHeaders
+}
+
+// fragmentRequestHeaders returns the names of the request headers which decide
+// whether a response is rendered without the layout, for the Vary header.
+func (htmx *Htmx) fragmentRequestHeaders() []string {
+	headers := append(slices.Clone(htmx.htmxRequestHeaders()), "Turbo-Frame", "X-Up-Target")
+
+	if htmx.config.AlpineAJAX {
+		headers = append(headers, "X-Alpine-Request")
+	}
+
+	return headers
+}
+
+// applyLayoutQuery renders the layout as the FragmentQueryParam and the
+// LayoutQueryParam of the request ask, unless an option has already decided whether
+// it is rendered. Values which are not booleans are ignored.
+func (htmx *Htmx) applyLayoutQuery(ginContext *gin.Context, settings *renderSettings) {
+	if settings.layout != nil {
+		return
+	}
+
+	if fragment, ok := queryBool(ginContext, htmx.config.FragmentQueryParam); ok {
+		layout := !fragment
+		settings.layout = &layout
+	} else if layout, ok := queryBool(ginContext, htmx.config.LayoutQueryParam); ok {
+		settings.layout = &layout
+	}
+}
+
+// queryBool returns the value of the named query parameter as a boolean, reporting
+// false if the name is empty or the parameter is missing or not a boolean.
+func queryBool(ginContext *gin.Context, name string) (bool, bool) {
+	if name == "" {
+		return false, false
+	}
+
+	value, err := strconv.ParseBool(ginContext.Query(name))
+	if err != nil {
+		return false, false
+	}
+
+	return value, true
+}
@@ -0,0 +1,127 @@
+package ginhtmx_test
+
+import (
+	"html/template"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/jeffscottbrown/ginhtmxtemplates/ginhtmx"
+	"github.com/stretchr/testify/suite"
+)
+
+func (suite *DetectionTestSuite) TestFragmentQueryParamDecidesTheLayout() {
+	htmx := suite.newHtmx(ginhtmx.HtmxConfig{
+		LayoutTemplateName:  "layout",
+		ContentVariableName: "Content",
+		FragmentQueryParam:  "fragment",
+	})
+
+	suite.Equal(`<p>Page</p>`, suite.render(htmx, "/?fragment=1", false))
+	suite.Equal(`<html><p>Page</p></html>`, suite.render(htmx, "/?fragment=false", true))
+	suite.Equal(`<p>Page</p>`, suite.render(htmx, "/?fragment=maybe", true))
+	suite.Equal(`<html><p>Page</p></html>`, suite.render(htmx, "/", false))
+	suite.Equal(`<html><p>Page</p></html>`, suite.render(htmx.With(ginhtmx.ForceFullPage()), "/?fragment=1", false))
+}
+
+func (suite *DetectionTestSuite) TestLayoutQueryParamDecidesTheLayout() {
+	htmx := suite.newHtmx(ginhtmx.HtmxConfig{
+		LayoutTemplateName:  "layout",
+		ContentVariableName: "Content",
+		FragmentQueryParam:  "fragment",
+		LayoutQueryParam:    "layout",
+	})
+
+	suite.Equal(`<p>Page</p>`, suite.render(htmx, "/?layout=0", false))
+	suite.Equal(`<html><p>Page</p></html>`, suite.render(htmx, "/?layout=true", true))
+	suite.Equal(`<p>Page</p>`, suite.render(htmx, "/?layout=1&fragment=1", false))
+}
+
+func (suite *DetectionTestSuite) TestQueryParamsAreIgnoredUnlessConfigured() {
+	htmx := suite.newHtmx(ginhtmx.HtmxConfig{
+		LayoutTemplateName:  "layout",
+		ContentVariableName: "Content",
+	})
+
+	suite.Equal(`<html><p>Page</p></html>`, suite.render(htmx, "/?fragment=1&layout=0", false))
+}
+
+func (suite *DetectionTestSuite) TestHTMXRequestHeadersAreConfigurable() {
+	htmx := suite.newHtmx(ginhtmx.HtmxConfig{
+		LayoutTemplateName:  "layout",
+		ContentVariableName: "Content",
+		HTMXRequestHeaders:  []string{"X-HX-Request", "HX-Request"},
+		ETags:               true,
+	})
+
+	recorder, testContext := suite.renderWithHeader(htmx, "X-HX-Request")
+	suite.Equal(`<p>Page</p>`, recorder.Body.String())
+	suite.True(htmx.IsHTMXRequest(testContext))
+	suite.Equal([]string{"X-HX-Request", "HX-Request", "Turbo-Frame", "X-Up-Target"}, recorder.Header().Values("Vary"))
+
+	recorder, _ = suite.renderWithHeader(htmx, "HX-Request")
+	suite.Equal(`<p>Page</p>`, recorder.Body.String())
+
+	recorder, testContext = suite.renderWithHeader(htmx, "X-Requested-With")
+	suite.Equal(`<html><p>Page</p></html>`, recorder.Body.String())
+	suite.False(htmx.IsHTMXRequest(testContext))
+}
+
+func (suite *DetectionTestSuite) TestHXRequestIdentifiesHTMXRequestsByDefault() {
+	htmx := suite.newHtmx(ginhtmx.HtmxConfig{
+		LayoutTemplateName:  "layout",
+		ContentVariableName: "Content",
+	})
+
+	_, testContext := suite.renderWithHeader(htmx, "HX-Request")
+	suite.True(htmx.IsHTMXRequest(testContext))
+
+	_, testContext = suite.renderWithHeader(htmx, "X-HX-Request")
+	suite.False(htmx.IsHTMXRequest(testContext))
+}
+
+func (suite *DetectionTestSuite) renderWithHeader(
+	htmx *ginhtmx.Htmx, header string,
+) (*httptest.ResponseRecorder, *gin.Context) {
+	recorder := httptest.NewRecorder()
+	testContext, _ := gin.CreateTestContext(recorder)
+	testContext.Request = httptest.NewRequest(http.MethodGet, "/", nil)
+	testContext.Request.Header.Set(header, "true")
+
+	htmx.Render(testContext, gin.H{}, "page")
+
+	return recorder, testContext
+}
+
+func (suite *DetectionTestSuite) render(htmx *ginhtmx.Htmx, url string, htmxRequest bool) string {
+	recorder := httptest.NewRecorder()
+	testContext, _ := gin.CreateTestContext(recorder)
+	testContext.Request = httptest.NewRequest(http.MethodGet, url, nil)
+
+	if htmxRequest {
+		testContext.Request.Header.Set("HX-Request", "true")
+	}
+
+	htmx.Render(testContext, gin.H{}, "page")
+
+	return recorder.Body.String()
+}
+
+func (suite *DetectionTestSuite) newHtmx(config ginhtmx.HtmxConfig) *ginhtmx.Htmx {
+	tmpl := template.Must(template.New("").Parse(`
+{{define "layout"}}<html>{{.Content}}</html>{{end}}
+{{define "page"}}<p>Page</p>{{end}}
+`))
+
+	return ginhtmx.NewHtmxWithConfig(tmpl, config)
+}
+
+func TestDetectionTestSuite(t *testing.T) {
+	t.Parallel()
+	suite.Run(t, new(DetectionTestSuite))
+}
+
+type DetectionTestSuite struct {
+	suite.Suite
+}
@@ -15,6 +15,12 @@
 // NewHtmxWithConfig function takes a HtmxConfig struct which allows you to
 // specify the layout template name and body variable name.
 //
+// The layout is rendered with a copy of the model of the page holding the
+// content. Setting SeparateLayoutData renders it with a LayoutData instead, which
+// holds the content, the model of the page and the values added with LayoutModel
+// as .Content, .Page and .Layout, so that keys of pages cannot collide with those
+// used by the layout.
+//
 // Here is an example of using ginhtmx in a simple Gin application:
 //
 //	package server
@@ -119,4 +125,69 @@
 // In this case, if the request is an HTMX request, then both the "home"
 // and "notifications" templates would be rendered and concatenated together
 // in the response.
+//
+// The package provides a number of template functions. To use them, add the
+// functions returned by FuncMap to your templates before parsing them:
+//
+//	tmpl := template.Must(template.New("").Funcs(ginhtmx.FuncMap()).ParseFS(embeddedHTMLFiles, "templates/*.html"))
+//
+// The "t" and "plural" functions look up translated messages for the locale of
+// the current request. Configure a Translator, such as a Catalog, in your
+// HtmxConfig and store the locale in the gin context under LocaleKey or provide
+// a LocaleResolver:
+//
+//	<h1>{{ t "greeting" "name" .Name }}</h1>
+//	<p>{{ plural .Count "items" }}</p>
+//
+// A Catalog chooses the form of a plural message by the CLDR plural rules of the
+// language of the locale. The "plural" function also accepts the singular and
+// plural forms, which are used, as in English, when no translation of the message
+// is available:
+//
+//	<p>{{ .Count }} {{ plural .Count "item" "items" }}</p>
+//
+// The "formatDate", "formatTime" and "timeAgo" functions format times for the
+// locale of the current request, converted to the TimeZone of your HtmxConfig or
+// to a time zone stored in the gin context under TimeZoneKey:
+//
+//	<time title="{{ formatDate .Posted }} {{ formatTime .Posted }}">{{ timeAgo .Posted }}</time>
+//
+// Similarly "formatNumber", "formatCurrency" and "formatPercent" format numbers
+// with the separators and currency symbols of the locale:
+//
+//	<td>{{ formatCurrency .Total "EUR" }}</td><td>{{ formatPercent .Discount }}</td>
+//
+// Rather than passing the gin context to templates, the "currentPath",
+// "queryParam", "header" and "isHtmxRequest" functions give read only access to the
+// parts of the current request templates commonly need:
+//
+//	<a href="{{ currentPath }}?page={{ queryParam "page" }}"{{ if isHtmxRequest }} hx-boost="true"{{ end }}>
+//
+// Reusable components may be registered in the Components of your HtmxConfig and
+// rendered from handlers with RenderComponent or from templates with the
+// "component" function, using "dict" to build their properties:
+//
+//	{{ component "avatar" (dict "User" .User "Size" 32) }}
+//
+// The "partial" function renders another template with the current data merged
+// with additional keys and values:
+//
+//	{{ partial "user_row" . "Editable" true }}
+//
+// The package also provides templates for common user interface patterns, such as
+// "ginhtmx/pagination" which renders the links of a Paginator. They are added to
+// your templates by NewHtmxWithConfig unless you define a template with the same
+// name, which allows you to override any of them.
+//
+// After every render the outcome is recorded in the gin context under the
+// RenderedTemplatesKey, RenderStatusKey, RenderFragmentKey, RenderBytesKey,
+// RenderDurationKey and RenderErrorKey keys, so that your own middleware can
+// act on the result once the handler has returned:
+//
+//	router.Use(func(c *gin.Context) {
+//	  c.Next()
+//	  if err, _ := c.Get(ginhtmx.RenderErrorKey); err != nil {
+//	    log.Printf("render of %v failed: %v", c.GetStringSlice(ginhtmx.RenderedTemplatesKey), err)
+//	  }
+//	})
 package ginhtmx
@@ -0,0 +1,62 @@
+package ginhtmx
+
+import (
+	"html/template"
+	"io"
+)
+
+// TemplateEngine renders templates written for a template engine other than
+// html/template, see the Engine of HtmxConfig. Pongo2Engine, JetEngine and
+// QuickTemplates adapt pongo2, jet and quicktemplate, and other engines are
+// adapted by implementing the two methods.
+type TemplateEngine interface {
+	// Execute renders the named template with data to writer.
+	Execute(name string, data any, writer io.Writer) error
+
+	// Lookup reports whether the engine defines the named template.
+	Lookup(name string) bool
+}
+
+// NewHtmxWithEngine creates a new instance of Htmx which renders the templates of
+// engine, including the layout. The html/template templates of the instance only
+// hold the built-in templates, so every template of the application is rendered
+// by engine:
+//
+//	set := pongo2.NewSet("views", pongo2.MustNewLocalFileSystemLoader("views"))
+//	htmx := ginhtmx.NewHtmxWithEngine(ginhtmx.Pongo2Engine(set), ginhtmx.HtmxConfig{
+//	  LayoutTemplateName:  "layout.html",
+//	  ContentVariableName: "Content",
+//	})
+func NewHtmxWithEngine(engine TemplateEngine, config HtmxConfig) *Htmx {
+	config.Engine = engine
+
+	return NewHtmxWithConfig(template.New(""), config)
+}
+
+// engineDefines reports whether the configured engine defines the named template.
+func (htmx *Htmx) engineDefines(name string) bool {
+	return htmx.config.Engine != nil && htmx.config.Engine.Lookup(name)
+}
+
+// lookupTemplate returns the executor of the named template, which is the
+// configured engine if it defines the template and tmpl otherwise, or nil if
+// neither defines the template.
+func (htmx *Htmx) lookupTemplate(tmpl *template.Template, name string) templateExecutor {
+	switch {
+	case htmx.engineDefines(name):
+		return engineExecutor{engine: htmx.config.Engine}
+	case tmpl.Lookup(name) != nil:
+		return tmpl
+	default:
+		return nil
+	}
+}
+
+// engineExecutor executes the templates of a TemplateEngine.
+type engineExecutor struct {
+	engine TemplateEngine
+}
+
+func (executor engineExecutor) ExecuteTemplate(writer io.Writer, name string, data any) error {
+	return executor.engine.Execute(name, data, writer) //nolint:wrapcheck
+}
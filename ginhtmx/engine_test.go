@@ -0,0 +1,196 @@
+package ginhtmx_test
+
+import (
+	"bytes"
+	"html/template"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"testing/fstest"
+
+	"github.com/CloudyKit/jet/v6"
+	"github.com/flosch/pongo2/v6"
+	"github.com/gin-gonic/gin"
+	"github.com/jeffscottbrown/ginhtmxtemplates/ginhtmx"
+	"github.com/stretchr/testify/suite"
+)
+
+func (suite *EngineTestSuite) TestPongo2TemplatesAreWrappedInThePongo2Layout() {
+	htmx := ginhtmx.NewHtmxWithEngine(ginhtmx.Pongo2Engine(suite.pongo2), ginhtmx.HtmxConfig{
+		LayoutTemplateName:  "layout.html",
+		ContentVariableName: "Content",
+	})
+
+	recorder, _ := suite.render(htmx, false, gin.H{"Name": "<Ada>"}, "user.html")
+	suite.Equal(`<html><p>&lt;Ada&gt;</p></html>`, recorder.Body.String())
+
+	recorder, _ = suite.render(htmx, true, gin.H{"Name": "<Ada>"}, "user.html")
+	suite.Equal(`<p>&lt;Ada&gt;</p>`, recorder.Body.String())
+
+	suite.Require().NoError(htmx.Validate("user.html"))
+}
+
+func (suite *EngineTestSuite) TestPongo2ModelsWhichAreNotMapsAreAvailableAsModel() {
+	rendered, err := ginhtmx.NewHtmxWithEngine(ginhtmx.Pongo2Engine(suite.pongo2), ginhtmx.HtmxConfig{
+		LayoutTemplateName:  "layout.html",
+		ContentVariableName: "Content",
+	}).RenderToString("model.html", struct{ Name string }{Name: "Ada"})
+
+	suite.Require().NoError(err)
+	suite.Equal(`<p>Ada</p>`, rendered)
+
+	var buffer bytes.Buffer
+	suite.Require().NoError(ginhtmx.Pongo2Engine(suite.pongo2).Execute(
+		"user.html", map[string]any{"Name": template.HTML("<b>Ada</b>")}, &buffer))
+	suite.Equal(`<p><b>Ada</b></p>`, buffer.String())
+}
+
+func (suite *EngineTestSuite) TestPongo2ErrorsAreReported() {
+	htmx := ginhtmx.NewHtmxWithEngine(ginhtmx.Pongo2Engine(suite.pongo2), ginhtmx.HtmxConfig{
+		LayoutTemplateName:  "layout.html",
+		ContentVariableName: "Content",
+	})
+
+	_, testContext := suite.render(htmx, true, gin.H{}, "missing.html")
+	renderErr, _ := testContext.Value(ginhtmx.RenderErrorKey).(error)
+	suite.Require().ErrorIs(renderErr, ginhtmx.ErrTemplateNotFound)
+
+	_, testContext = suite.render(htmx, true, gin.H{}, "broken.html")
+	renderErr, _ = testContext.Value(ginhtmx.RenderErrorKey).(error)
+	suite.Require().Error(renderErr)
+	suite.NotErrorIs(renderErr, ginhtmx.ErrTemplateNotFound)
+
+	suite.Require().ErrorIs(ginhtmx.Pongo2Engine(suite.pongo2).Execute("missing.html", nil, io.Discard),
+		ginhtmx.ErrTemplateNotFound)
+}
+
+func (suite *EngineTestSuite) TestJetTemplatesAreWrappedInTheJetLayout() {
+	htmx := ginhtmx.NewHtmxWithEngine(ginhtmx.JetEngine(suite.jet), ginhtmx.HtmxConfig{
+		LayoutTemplateName:  "layout.jet",
+		ContentVariableName: "Content",
+	})
+
+	recorder, _ := suite.render(htmx, false, gin.H{"Name": "<Ada>"}, "users/show.jet")
+	suite.Equal(`<html><p>&lt;Ada&gt;</p></html>`, recorder.Body.String())
+
+	recorder, _ = suite.render(htmx, true, gin.H{"Name": "<Ada>"}, "users/show.jet")
+	suite.Equal(`<p>&lt;Ada&gt;</p>`, recorder.Body.String())
+
+	rendered, err := htmx.RenderToString("users/show.jet", struct{ Name string }{Name: "Ada"})
+	suite.Require().NoError(err)
+	suite.Equal(`<p>Ada</p>`, rendered)
+
+	var buffer bytes.Buffer
+	suite.Require().NoError(ginhtmx.JetEngine(suite.jet).Execute(
+		"users/show.jet", map[string]any{"Name": template.HTML("<b>Ada</b>")}, &buffer))
+	suite.Equal(`<p><b>Ada</b></p>`, buffer.String())
+
+	suite.Require().NoError(htmx.Validate("users/show.jet"))
+}
+
+func (suite *EngineTestSuite) TestJetErrorsAreReported() {
+	htmx := ginhtmx.NewHtmxWithEngine(ginhtmx.JetEngine(suite.jet), ginhtmx.HtmxConfig{
+		LayoutTemplateName:  "layout.jet",
+		ContentVariableName: "Content",
+	})
+
+	_, testContext := suite.render(htmx, true, gin.H{}, "missing.jet")
+	renderErr, _ := testContext.Value(ginhtmx.RenderErrorKey).(error)
+	suite.Require().ErrorIs(renderErr, ginhtmx.ErrTemplateNotFound)
+
+	_, testContext = suite.render(htmx, true, gin.H{}, "broken.jet")
+	renderErr, _ = testContext.Value(ginhtmx.RenderErrorKey).(error)
+	suite.Require().Error(renderErr)
+	suite.NotErrorIs(renderErr, ginhtmx.ErrTemplateNotFound)
+
+	suite.Require().ErrorIs(ginhtmx.JetEngine(suite.jet).Execute("missing.jet", nil, io.Discard),
+		ginhtmx.ErrTemplateNotFound)
+}
+
+func (suite *EngineTestSuite) TestTemplatesTheEngineDoesNotDefineAreRenderedByHTMLTemplate() {
+	tmpl := template.Must(template.New("").Parse(`
+{{define "layout"}}<html>{{.Content}}</html>{{end}}
+{{define "legacy"}}<p>legacy {{.Name}}</p>{{end}}
+{{define "fallback"}}<p>missing {{.MissingTemplate}}</p>{{end}}
+{{define "dark/user.html"}}<p>dark</p>{{end}}
+`))
+	htmx := ginhtmx.NewHtmxWithConfig(tmpl, ginhtmx.HtmxConfig{
+		LayoutTemplateName:  "layout",
+		ContentVariableName: "Content",
+		FallbackTemplate:    "fallback",
+		Engine:              ginhtmx.Pongo2Engine(suite.pongo2),
+		ThemeResolver:       func(*gin.Context) string { return "dark" },
+	})
+
+	recorder, _ := suite.render(htmx, false, gin.H{"Name": "Ada"}, "user.html", "legacy", "gone")
+	suite.Equal(`<html><p>dark</p><p>legacy Ada</p><p>missing gone</p></html>`, recorder.Body.String())
+
+	suite.Require().EqualError(htmx.Validate("user.html", "legacy", "gone"), `template not found: "gone"`)
+}
+
+func (suite *EngineTestSuite) TestQuickTemplatesAreRendered() {
+	templates := ginhtmx.QuickTemplates{
+		"layout": func(w io.Writer, data any) {
+			content, _ := data.(gin.H)["Content"].(template.HTML)
+			_, _ = io.WriteString(w, "<html>"+string(content)+"</html>")
+		},
+		"user": func(w io.Writer, data any) {
+			name, _ := data.(gin.H)["Name"].(string)
+			_, _ = io.WriteString(w, "<p>"+template.HTMLEscapeString(name)+"</p>")
+		},
+	}
+	htmx := ginhtmx.NewHtmxWithEngine(templates, ginhtmx.HtmxConfig{
+		LayoutTemplateName:  "layout",
+		ContentVariableName: "Content",
+	})
+
+	recorder, _ := suite.render(htmx, false, gin.H{"Name": "<Ada>"}, "user")
+	suite.Equal(`<html><p>&lt;Ada&gt;</p></html>`, recorder.Body.String())
+
+	suite.False(templates.Lookup("missing"))
+	suite.Require().ErrorIs(templates.Execute("missing", nil, io.Discard), ginhtmx.ErrTemplateNotFound)
+}
+
+func (suite *EngineTestSuite) render(
+	htmx *ginhtmx.Htmx, htmxRequest bool, data gin.H, templateNames ...string,
+) (*httptest.ResponseRecorder, *gin.Context) {
+	recorder := httptest.NewRecorder()
+	testContext, _ := gin.CreateTestContext(recorder)
+	testContext.Request = httptest.NewRequest(http.MethodGet, "/", nil)
+
+	if htmxRequest {
+		testContext.Request.Header.Set("Hx-Request", "true")
+	}
+
+	htmx.Render(testContext, data, templateNames...)
+
+	return recorder, testContext
+}
+
+func (suite *EngineTestSuite) SetupTest() {
+	suite.pongo2 = pongo2.NewSet("test", pongo2.NewFSLoader(fstest.MapFS{
+		"layout.html": {Data: []byte(`<html>{{ Content }}</html>`)},
+		"user.html":   {Data: []byte(`<p>{{ Name }}</p>`)},
+		"model.html":  {Data: []byte(`<p>{{ Model.Name }}</p>`)},
+		"broken.html": {Data: []byte(`<p>{% if %}</p>`)},
+	}))
+
+	loader := jet.NewInMemLoader()
+	loader.Set("layout.jet", `<html>{{ .Content }}</html>`)
+	loader.Set("users/show.jet", `<p>{{ .Name }}</p>`)
+	loader.Set("broken.jet", `<p>{{ if }}</p>`)
+	suite.jet = jet.NewSet(loader)
+}
+
+func TestEngineTestSuite(t *testing.T) {
+	t.Parallel()
+	suite.Run(t, new(EngineTestSuite))
+}
+
+type EngineTestSuite struct {
+	suite.Suite
+
+	pongo2 *pongo2.TemplateSet
+	jet    *jet.Set
+}
@@ -0,0 +1,97 @@
+package ginhtmx
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ErrTemplateNotFound is returned when a template to be rendered is not defined.
+var ErrTemplateNotFound = errors.New("template not found")
+
+var errInvalidArgument = errors.New("invalid argument")
+
+// HTTPError is an error with the HTTP status of the response which reports it and
+// a message which may be shown to the user.
+type HTTPError struct {
+	// Status is the HTTP status code of the response
+	Status int
+
+	// Message is shown to the user. If empty, the text of the status is shown.
+	Message string
+
+	// Err is the underlying error, which is recorded but not shown to the user
+	Err error
+}
+
+// NewHTTPError returns an HTTPError with status and message wrapping err, which
+// may be nil.
+func NewHTTPError(status int, message string, err error) *HTTPError {
+	return &HTTPError{Status: status, Message: message, Err: err}
+}
+
+// Error returns the message, followed by the underlying error if there is one.
+func (err *HTTPError) Error() string {
+	message := err.userMessage()
+	if err.Err != nil {
+		message += ": " + err.Err.Error()
+	}
+
+	return message
+}
+
+// Unwrap returns the underlying error.
+func (err *HTTPError) Unwrap() error {
+	return err.Err
+}
+
+func (err *HTTPError) userMessage() string {
+	if err.Message != "" {
+		return err.Message
+	}
+
+	return http.StatusText(err.Status)
+}
+
+// ErrorModel holds the data the error templates are rendered with.
+type ErrorModel struct {
+	// Status is the HTTP status code of the response
+	Status int
+
+	// Message describes the error to the user
+	Message string
+
+	// RequestID is the ID of the request, which the user may quote as a reference
+	// to find the log entries of the error, see Htmx.RequestID
+	RequestID string
+}
+
+// RenderError renders the error template configured in ErrorTemplates for the
+// status of err, which is 500 Internal Server Error unless err is or wraps an
+// HTTPError. The template is rendered with an ErrorModel under the "Error" key.
+// To avoid leaking internal details only the message of an HTTPError is shown to
+// the user, while the error itself is added to the errors of the gin context. If
+// no template is configured for the status, the text of the status is written.
+func (htmx *Htmx) RenderError(ginContext *gin.Context, err error) {
+	model := ErrorModel{Status: http.StatusInternalServerError, Message: "", RequestID: htmx.RequestID(ginContext)}
+
+	var httpErr *HTTPError
+	if errors.As(err, &httpErr) {
+		model.Status = httpErr.Status
+		model.Message = httpErr.userMessage()
+	} else {
+		model.Message = http.StatusText(model.Status)
+	}
+
+	_ = ginContext.Error(err)
+
+	name, found := htmx.config.ErrorTemplates[model.Status]
+	if !found {
+		ginContext.String(model.Status, model.Message)
+
+		return
+	}
+
+	htmx.RenderWithStatus(ginContext, gin.H{"Error": model}, model.Status, name)
+}
@@ -0,0 +1,72 @@
+package ginhtmx
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// etagLength is the number of hex characters of the content hash used in an ETag.
+const etagLength = 32
+
+// writeNotModified sets the ETag header for a successful GET or HEAD response and
+// reports whether a 304 Not Modified response was written because the request's
+// If-None-Match header matched it. The tag of a fragment differs from the tag of
+// the same content wrapped in the layout, and the tag of a compressed response
+// differs from the tag of the same content with another encoding, so the variants
+// are never confused. The response varies by the request headers named by vary.
+func writeNotModified(
+	ginContext *gin.Context, status int, fragment bool, encoding string, body []byte, vary []string,
+) bool {
+	method := ginContext.Request.Method
+	if method != http.MethodGet && method != http.MethodHead || status < 200 || status >= 300 {
+		return false
+	}
+
+	etag := computeETag(fragment, encoding, body)
+
+	ginContext.Header("ETag", etag)
+
+	for _, name := range vary {
+		ginContext.Writer.Header().Add("Vary", name)
+	}
+
+	if !etagMatches(ginContext.GetHeader("If-None-Match"), etag) {
+		return false
+	}
+
+	ginContext.Status(http.StatusNotModified)
+	ginContext.Writer.WriteHeaderNow()
+
+	return true
+}
+
+func computeETag(fragment bool, encoding string, body []byte) string {
+	variant := "page"
+	if fragment {
+		variant = "fragment"
+	}
+
+	hash := sha256.New()
+	hash.Write([]byte(variant))
+	hash.Write([]byte(encoding))
+	hash.Write(body)
+
+	return `"` + hex.EncodeToString(hash.Sum(nil))[:etagLength] + `"`
+}
+
+// etagMatches reports whether the If-None-Match header value matches etag using
+// the weak comparison required for If-None-Match.
+func etagMatches(ifNoneMatch string, etag string) bool {
+	for candidate := range strings.SplitSeq(ifNoneMatch, ",") {
+		candidate = strings.TrimPrefix(strings.TrimSpace(candidate), "W/")
+		if candidate == "*" || candidate == etag {
+			return true
+		}
+	}
+
+	return false
+}
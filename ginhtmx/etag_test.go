@@ -0,0 +1,94 @@
+package ginhtmx_test
+
+import (
+	"html/template"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/jeffscottbrown/ginhtmxtemplates/ginhtmx"
+	"github.com/stretchr/testify/suite"
+)
+
+func (suite *ETagTestSuite) TestMatchingETagReturnsNotModified() {
+	first := suite.serve(http.MethodGet, "/", "", false)
+	suite.Equal(http.StatusOK, first.Code)
+
+	etag := first.Header().Get("ETag")
+	suite.Regexp(`^"[0-9a-f]{32}"$`, etag)
+	suite.Equal("HX-Request", first.Header().Get("Vary"))
+
+	second := suite.serve(http.MethodGet, "/", `"other", W/`+etag, false)
+	suite.Equal(http.StatusNotModified, second.Code)
+	suite.Empty(second.Body.String())
+
+	wildcard := suite.serve(http.MethodGet, "/", "*", false)
+	suite.Equal(http.StatusNotModified, wildcard.Code)
+}
+
+func (suite *ETagTestSuite) TestFragmentAndPageHaveDifferentETags() {
+	page := suite.serve(http.MethodGet, "/", "", false)
+	fragment := suite.serve(http.MethodGet, "/", "", true)
+
+	suite.NotEqual(page.Header().Get("ETag"), fragment.Header().Get("ETag"))
+
+	mismatched := suite.serve(http.MethodGet, "/", page.Header().Get("ETag"), true)
+	suite.Equal(http.StatusOK, mismatched.Code)
+	suite.Equal("<h1>Hello</h1>", mismatched.Body.String())
+}
+
+func (suite *ETagTestSuite) TestETagIsOnlyUsedForSuccessfulReads() {
+	post := suite.serve(http.MethodPost, "/", "*", false)
+	suite.Equal(http.StatusOK, post.Code)
+	suite.Empty(post.Header().Get("ETag"))
+
+	notFound := suite.serve(http.MethodGet, "/missing", "*", false)
+	suite.Equal(http.StatusNotFound, notFound.Code)
+	suite.Empty(notFound.Header().Get("ETag"))
+}
+
+func (suite *ETagTestSuite) serve(method string, target string, ifNoneMatch string, fragment bool) *httptest.ResponseRecorder {
+	recorder := httptest.NewRecorder()
+	request := httptest.NewRequest(method, target, nil)
+
+	if ifNoneMatch != "" {
+		request.Header.Set("If-None-Match", ifNoneMatch)
+	}
+
+	if fragment {
+		request.Header.Set("Hx-Request", "true")
+	}
+
+	suite.router.ServeHTTP(recorder, request)
+
+	return recorder
+}
+
+func (suite *ETagTestSuite) SetupSuite() {
+	tmpl := template.Must(template.New("").Parse(`
+{{define "layout"}}<html><body>{{.Content}}</body></html>{{end}}
+{{define "hello"}}<h1>Hello</h1>{{end}}
+`))
+	htmx := ginhtmx.NewHtmxWithConfig(tmpl, ginhtmx.HtmxConfig{
+		LayoutTemplateName:  "layout",
+		ContentVariableName: "Content",
+		ETags:               true,
+	})
+
+	suite.router = gin.New()
+	suite.router.Handle(http.MethodGet, "/", func(c *gin.Context) { htmx.Render(c, gin.H{}, "hello") })
+	suite.router.Handle(http.MethodPost, "/", func(c *gin.Context) { htmx.Render(c, gin.H{}, "hello") })
+	suite.router.GET("/missing", func(c *gin.Context) { htmx.RenderWithStatus(c, gin.H{}, http.StatusNotFound, "hello") })
+}
+
+func TestETagTestSuite(t *testing.T) {
+	t.Parallel()
+	suite.Run(t, new(ETagTestSuite))
+}
+
+type ETagTestSuite struct {
+	suite.Suite
+
+	router *gin.Engine
+}
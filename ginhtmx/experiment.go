@@ -0,0 +1,158 @@
+package ginhtmx
+
+import (
+	"context"
+	"crypto/rand"
+	"hash/fnv"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// DefaultExperimentCookie is the name of the cookie which identifies visitors for
+// the assignment of experiment variants when ExperimentCookie is not configured.
+const DefaultExperimentCookie = "ginhtmx_visitor"
+
+// ExperimentsModelKey is the key under which the variants assigned to the request
+// are exposed to templates, as a map of template names to variant names.
+const ExperimentsModelKey = "Experiments"
+
+// ExperimentsKey is the gin context key holding the map[string]string of template
+// names to the names of the variants assigned to the request.
+const ExperimentsKey = "ginhtmx.experiments"
+
+// ExperimentEvent is the event added to the HX-Trigger header of responses which
+// render templates with variants, with the assigned variants as its detail, so that
+// analytics scripts can record them.
+const ExperimentEvent = "ginhtmx:experiment"
+
+// experimentCookieMaxAge is the lifetime of the visitor cookie in seconds.
+const experimentCookieMaxAge = 365 * 24 * 60 * 60
+
+// Variant is one of the variants of a template in an A/B test, see the Experiments
+// of the HtmxConfig.
+type Variant struct {
+	// Name identifies the variant in the model and in analytics events.
+	Name string
+
+	// Template is the name of the template rendered for the variant. If empty, the
+	// template of the experiment itself is rendered, which makes the variant the
+	// control.
+	Template string
+
+	// Weight is the share of visitors assigned the variant relative to the weights
+	// of the other variants. Variants with a weight of zero are never assigned.
+	Weight int
+}
+
+// templateVariantsKey is the context key of the templates assigned to a render in
+// place of the requested templates.
+type templateVariantsKey struct{}
+
+// assignExperiments assigns a variant of each experiment whose template is rendered,
+// or whose template is the layout of a full page, to the visitor of the request.
+// Visitors are identified by a cookie so that they are consistently assigned the
+// same variants. The assignments are exposed in the model, in the gin context and as
+// an HX-Trigger event, and the returned context renders the variant templates in
+// place of the templates of the experiments.
+func (htmx *Htmx) assignExperiments(
+	ctx context.Context, ginContext *gin.Context, data gin.H, templateNames []string, fragment bool,
+) context.Context {
+	if len(htmx.config.Experiments) == 0 {
+		return ctx
+	}
+
+	if !fragment {
+		templateNames = append(templateNames[:len(templateNames):len(templateNames)], htmx.config.LayoutTemplateName)
+	}
+
+	assigned := map[string]string{}
+	templates := map[string]string{}
+
+	var visitor string
+
+	for _, name := range templateNames {
+		variants := htmx.config.Experiments[name]
+		if len(variants) == 0 {
+			continue
+		}
+
+		if visitor == "" {
+			visitor = htmx.experimentVisitor(ginContext)
+		}
+
+		variant, ok := chooseVariant(visitor, name, variants)
+		if !ok {
+			continue
+		}
+
+		assigned[name] = variant.Name
+		if variant.Template != "" {
+			templates[name] = variant.Template
+		}
+	}
+
+	if len(assigned) == 0 {
+		return ctx
+	}
+
+	data[ExperimentsModelKey] = assigned
+	ginContext.Set(ExperimentsKey, assigned)
+
+	// the assignments always encode, so this can only fail when the header was set
+	// to an invalid value, which is then left as it is
+	_ = AddTrigger(ginContext, ExperimentEvent, assigned)
+
+	return context.WithValue(ctx, templateVariantsKey{}, templates)
+}
+
+// experimentVisitor returns the identifier of the visitor of the request, setting
+// the cookie which holds it if the visitor does not have one yet.
+func (htmx *Htmx) experimentVisitor(ginContext *gin.Context) string {
+	cookieName := htmx.config.ExperimentCookie
+	if cookieName == "" {
+		cookieName = DefaultExperimentCookie
+	}
+
+	if visitor, err := ginContext.Cookie(cookieName); err == nil && visitor != "" {
+		return visitor
+	}
+
+	visitor := rand.Text()
+
+	ginContext.SetSameSite(http.SameSiteLaxMode)
+	ginContext.SetCookie(cookieName, visitor, experimentCookieMaxAge, "/", "", false, true)
+
+	return visitor
+}
+
+// chooseVariant deterministically chooses one of variants for visitor, in
+// proportion to their weights. The name of the experiment is part of the choice so
+// that the assignments of a visitor to different experiments are independent.
+func chooseVariant(visitor string, experiment string, variants []Variant) (Variant, bool) {
+	total := 0
+	for _, variant := range variants {
+		total += max(variant.Weight, 0)
+	}
+
+	if total == 0 {
+		return Variant{}, false
+	}
+
+	hash := fnv.New64a()
+	_, _ = hash.Write([]byte(experiment + "\x00" + visitor))
+	bucket := int(hash.Sum64() % uint64(total)) //nolint:gosec
+
+	chosen := variants[0]
+
+	for _, variant := range variants {
+		chosen = variant
+
+		bucket -= max(variant.Weight, 0)
+		if bucket < 0 {
+			break
+		}
+	}
+
+	return chosen, true
+}
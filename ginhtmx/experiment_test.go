@@ -0,0 +1,131 @@
+package ginhtmx_test
+
+import (
+	"fmt"
+	"html/template"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/jeffscottbrown/ginhtmxtemplates/ginhtmx"
+	"github.com/stretchr/testify/suite"
+)
+
+func (suite *ExperimentTestSuite) TestAssignedVariantIsRendered() {
+	htmx := suite.newHtmx(map[string][]ginhtmx.Variant{
+		"hero": {
+			{Name: "control", Template: "", Weight: 0},
+			{Name: "bold", Template: "hero_bold", Weight: 1},
+		},
+	}, "")
+
+	recorder, testContext := suite.render(htmx, "", true, "hero")
+
+	suite.Equal(`<h1><b>Welcome</b></h1> bold`, recorder.Body.String())
+	suite.JSONEq(`{"ginhtmx:experiment":{"hero":"bold"}}`, recorder.Header().Get("HX-Trigger"))
+	suite.Equal(map[string]string{"hero": "bold"}, testContext.Value(ginhtmx.ExperimentsKey))
+
+	cookies := recorder.Result().Cookies()
+	suite.Require().Len(cookies, 1)
+	suite.Equal(ginhtmx.DefaultExperimentCookie, cookies[0].Name)
+	suite.NotEmpty(cookies[0].Value)
+	suite.True(cookies[0].HttpOnly)
+}
+
+func (suite *ExperimentTestSuite) TestVisitorsAreConsistentlyAssignedVariants() {
+	htmx := suite.newHtmx(map[string][]ginhtmx.Variant{
+		"hero": {
+			{Name: "control", Template: "", Weight: 1},
+			{Name: "bold", Template: "hero_bold", Weight: 1},
+		},
+	}, "bucket")
+
+	counts := map[string]int{}
+
+	for visitor := range 100 {
+		recorder, _ := suite.render(htmx, fmt.Sprint(visitor), true, "hero")
+		again, _ := suite.render(htmx, fmt.Sprint(visitor), true, "hero")
+
+		suite.Equal(recorder.Body.String(), again.Body.String())
+		suite.Empty(recorder.Result().Cookies())
+
+		counts[recorder.Body.String()]++
+	}
+
+	suite.Len(counts, 2)
+	suite.Positive(counts[`<h1>Welcome</h1> control`])
+	suite.Positive(counts[`<h1><b>Welcome</b></h1> bold`])
+}
+
+func (suite *ExperimentTestSuite) TestLayoutVariantsWrapFullPages() {
+	htmx := suite.newHtmx(map[string][]ginhtmx.Variant{
+		"layout": {{Name: "wide", Template: "layout_wide", Weight: 1}},
+		"hero":   {{Name: "control", Template: "", Weight: 1}},
+	}, "")
+
+	recorder, _ := suite.render(htmx, "visitor", false, "hero")
+	suite.Equal(`<main class="wide"><h1>Welcome</h1> control</main>`, recorder.Body.String())
+	suite.JSONEq(`{"ginhtmx:experiment":{"hero":"control","layout":"wide"}}`, recorder.Header().Get("HX-Trigger"))
+
+	recorder, _ = suite.render(htmx, "visitor", true, "other")
+	suite.Equal(`Other`, recorder.Body.String())
+	suite.Empty(recorder.Header().Get("HX-Trigger"))
+}
+
+func (suite *ExperimentTestSuite) TestExperimentsWithoutWeightsAreIgnored() {
+	htmx := suite.newHtmx(map[string][]ginhtmx.Variant{
+		"hero": {{Name: "bold", Template: "hero_bold", Weight: 0}},
+	}, "")
+
+	recorder, _ := suite.render(htmx, "visitor", true, "hero")
+	suite.Equal(`<h1>Welcome</h1> `, recorder.Body.String())
+	suite.Empty(recorder.Header().Get("HX-Trigger"))
+}
+
+func (suite *ExperimentTestSuite) render(
+	htmx *ginhtmx.Htmx, visitor string, fragment bool, templateNames ...string,
+) (*httptest.ResponseRecorder, *gin.Context) {
+	recorder := httptest.NewRecorder()
+	testContext, _ := gin.CreateTestContext(recorder)
+	testContext.Request = httptest.NewRequest(http.MethodGet, "/", nil)
+
+	if visitor != "" {
+		testContext.Request.AddCookie(&http.Cookie{Name: "bucket", Value: visitor})
+		testContext.Request.AddCookie(&http.Cookie{Name: ginhtmx.DefaultExperimentCookie, Value: visitor})
+	}
+
+	if fragment {
+		testContext.Request.Header.Set("Hx-Request", "true")
+	}
+
+	htmx.Render(testContext, nil, templateNames...)
+
+	return recorder, testContext
+}
+
+func (suite *ExperimentTestSuite) newHtmx(experiments map[string][]ginhtmx.Variant, cookie string) *ginhtmx.Htmx {
+	tmpl := template.Must(template.New("").Funcs(ginhtmx.FuncMap()).Parse(`
+{{- define "layout"}}<main>{{ .Content }}</main>{{end -}}
+{{- define "layout_wide"}}<main class="wide">{{ .Content }}</main>{{end -}}
+{{- define "hero"}}<h1>Welcome</h1> {{ .Experiments.hero }}{{end -}}
+{{- define "hero_bold"}}<h1><b>Welcome</b></h1> {{ .Experiments.hero }}{{end -}}
+{{- define "other"}}Other{{end -}}
+`))
+
+	return ginhtmx.NewHtmxWithConfig(tmpl, ginhtmx.HtmxConfig{
+		LayoutTemplateName:  "layout",
+		ContentVariableName: "Content",
+		Experiments:         experiments,
+		ExperimentCookie:    cookie,
+	})
+}
+
+func TestExperimentTestSuite(t *testing.T) {
+	t.Parallel()
+	suite.Run(t, new(ExperimentTestSuite))
+}
+
+type ExperimentTestSuite struct {
+	suite.Suite
+}
@@ -0,0 +1,48 @@
+package ginhtmx
+
+import (
+	"context"
+	"fmt"
+	"html/template"
+	"io"
+	"log/slog"
+
+	"github.com/gin-gonic/gin"
+)
+
+// MissingTemplateModelKey is the key of the model of the FallbackTemplate holding
+// the name of the template which is not defined.
+const MissingTemplateModelKey = "MissingTemplate"
+
+// executeFallback renders the FallbackTemplate of the configuration in place of the
+// named template, which is not defined. The model of the fallback is the data of
+// the template with the name added under MissingTemplateModelKey, or only the name
+// if the data is not a map. An error wrapping ErrTemplateNotFound is returned
+// instead if there is no fallback or strict rendering is enabled.
+func (htmx *Htmx) executeFallback(
+	ctx context.Context, writer io.Writer, tmpl *template.Template, name string, data any,
+) error {
+	fallback := htmx.config.FallbackTemplate
+	executor := htmx.lookupTemplate(tmpl, fallback)
+
+	if fallback == "" || fallback == name || htmx.config.Strict || executor == nil {
+		return fmt.Errorf("%w: %q", ErrTemplateNotFound, name)
+	}
+
+	if htmx.config.Logger != nil {
+		htmx.config.Logger.WarnContext(ctx, "ginhtmx: rendering fallback for missing template",
+			slog.String("template", name), slog.String("fallback", fallback))
+	}
+
+	model, err := mergeData(data, gin.H{MissingTemplateModelKey: name})
+	if err != nil {
+		model = gin.H{MissingTemplateModelKey: name}
+	}
+
+	err = executeRecovering(writer, executor, fallback, model)
+	if err != nil {
+		return fmt.Errorf("rendering fallback for %q: %w", name, err)
+	}
+
+	return nil
+}
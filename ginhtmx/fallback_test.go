@@ -0,0 +1,108 @@
+package ginhtmx_test
+
+import (
+	"bytes"
+	"html/template"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/jeffscottbrown/ginhtmxtemplates/ginhtmx"
+	"github.com/stretchr/testify/suite"
+)
+
+func (suite *FallbackTestSuite) TestFallbackIsRenderedForMissingTemplates() {
+	recorder, testContext := suite.render(suite.htmx, true, "missing")
+
+	suite.Equal(http.StatusOK, recorder.Code)
+	suite.Equal(`<p>Sorry Jerry, "missing" is not available</p>`, recorder.Body.String())
+	suite.Nil(testContext.Value(ginhtmx.RenderErrorKey))
+	suite.Contains(suite.logs.String(), `msg="ginhtmx: rendering fallback for missing template" template=missing fallback=fallback`)
+
+	recorder, _ = suite.render(suite.htmx, false, "page")
+	suite.Equal(`<html><main><p>Sorry Jerry, "sidebar" is not available</p></main></html>`, recorder.Body.String())
+}
+
+func (suite *FallbackTestSuite) TestFallbackOnlyReceivesTheNameOfNonMapModels() {
+	rendered, err := suite.htmx.RenderToString("missing", struct{ Name string }{Name: "Jerry"})
+	suite.Require().NoError(err)
+	suite.Equal(`<p>Sorry , "missing" is not available</p>`, rendered)
+}
+
+func (suite *FallbackTestSuite) TestFallbackIsNotUsedInStrictMode() {
+	htmx := ginhtmx.NewHtmxWithConfig(suite.template, ginhtmx.HtmxConfig{
+		LayoutTemplateName:  "layout",
+		ContentVariableName: "Content",
+		FallbackTemplate:    "fallback",
+		Strict:              true,
+	})
+
+	recorder, testContext := suite.render(htmx, true, "missing")
+
+	suite.Equal(http.StatusInternalServerError, recorder.Code)
+
+	renderError, _ := testContext.Value(ginhtmx.RenderErrorKey).(error)
+	suite.Require().ErrorIs(renderError, ginhtmx.ErrTemplateNotFound)
+}
+
+func (suite *FallbackTestSuite) TestMissingFallbacksAreReported() {
+	htmx := ginhtmx.NewHtmxWithConfig(suite.template, ginhtmx.HtmxConfig{
+		LayoutTemplateName:  "layout",
+		ContentVariableName: "Content",
+		FallbackTemplate:    "undefined",
+	})
+
+	_, err := htmx.RenderToString("missing", nil)
+	suite.Require().EqualError(err, `template not found: "missing"`)
+
+	_, err = suite.htmx.RenderToString("broken", nil)
+	suite.Require().ErrorContains(err, `rendering fallback for "broken"`)
+	suite.Require().ErrorContains(err, "index out of range: 10")
+}
+
+func (suite *FallbackTestSuite) render(
+	htmx *ginhtmx.Htmx, fragment bool, templateNames ...string,
+) (*httptest.ResponseRecorder, *gin.Context) {
+	recorder := httptest.NewRecorder()
+	testContext, _ := gin.CreateTestContext(recorder)
+	testContext.Request = httptest.NewRequest(http.MethodGet, "/", nil)
+
+	if fragment {
+		testContext.Request.Header.Set("Hx-Request", "true")
+	}
+
+	htmx.Render(testContext, gin.H{"Name": "Jerry"}, templateNames...)
+
+	return recorder, testContext
+}
+
+func (suite *FallbackTestSuite) SetupTest() {
+	suite.template = template.Must(template.New("").Funcs(ginhtmx.FuncMap()).Parse(`
+{{- define "layout"}}<html>{{ .Content }}</html>{{end -}}
+{{- define "page"}}<main>{{ partial "sidebar" . }}</main>{{end -}}
+{{- define "fallback"}}{{ if eq .MissingTemplate "broken" }}{{ index .MissingTemplate 10 }}{{ end -}}
+<p>Sorry {{ .Name }}, "{{ .MissingTemplate }}" is not available</p>{{end -}}
+`))
+	suite.logs = &bytes.Buffer{}
+	suite.htmx = ginhtmx.NewHtmxWithConfig(suite.template, ginhtmx.HtmxConfig{
+		LayoutTemplateName:  "layout",
+		ContentVariableName: "Content",
+		FallbackTemplate:    "fallback",
+		Logger:              slog.New(slog.NewTextHandler(suite.logs, nil)),
+	})
+}
+
+func TestFallbackTestSuite(t *testing.T) {
+	t.Parallel()
+	suite.Run(t, new(FallbackTestSuite))
+}
+
+type FallbackTestSuite struct {
+	suite.Suite
+
+	template *template.Template
+	htmx     *ginhtmx.Htmx
+	logs     *bytes.Buffer
+}
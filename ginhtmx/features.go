@@ -0,0 +1,70 @@
+package ginhtmx
+
+import (
+	"context"
+
+	"github.com/gin-gonic/gin"
+)
+
+// FeatureFlagsKey is the gin context key holding the FeatureFlags of the HtmxConfig
+// while a render is in progress, see FeatureEnabled.
+const FeatureFlagsKey = "ginhtmx.features"
+
+// FeatureFlags is implemented by types which decide whether features are enabled,
+// usually adapters for a feature flag service. The context is that of the request,
+// so implementations may use values stored in it to decide per user.
+type FeatureFlags interface {
+	Enabled(ctx context.Context, flag string) bool
+}
+
+// FeatureFlagSet is a FeatureFlags with a fixed set of flags, such as flags read
+// from the configuration of the application. Flags which are not in the set are
+// disabled.
+type FeatureFlagSet map[string]bool
+
+// Enabled returns whether flag is in the set and enabled.
+func (flags FeatureFlagSet) Enabled(_ context.Context, flag string) bool {
+	return flags[flag]
+}
+
+// withFeatureFlags stores the FeatureFlags of the configuration in the gin context
+// so that model decorators and model providers can use FeatureEnabled.
+func (htmx *Htmx) withFeatureFlags(ginContext *gin.Context) {
+	if htmx.config.FeatureFlags != nil {
+		ginContext.Set(FeatureFlagsKey, htmx.config.FeatureFlags)
+	}
+}
+
+// FeatureEnabled returns whether flag is enabled by the FeatureFlags of the Htmx
+// rendering the request. It is intended for model decorators and model providers,
+// which are called by Render but have no access to its configuration:
+//
+//	func (decorator navigation) DecorateModel(c *gin.Context, model *gin.H) {
+//	  (*model)["ShowReports"] = ginhtmx.FeatureEnabled(c, "reports")
+//	}
+//
+// False is returned outside of a render or when no FeatureFlags are configured.
+func FeatureEnabled(ginContext *gin.Context, flag string) bool {
+	value, _ := ginContext.Get(FeatureFlagsKey)
+
+	flags, ok := value.(FeatureFlags)
+	if !ok {
+		return false
+	}
+
+	return flags.Enabled(ginContext.Request.Context(), flag)
+}
+
+// feature returns whether flag is enabled, so that templates can include features
+// which are not yet available to everyone:
+//
+//	{{ if feature "new-checkout" }}{{ partial "checkout_v2" . }}{{ end }}
+//
+// False is returned when no FeatureFlags are configured.
+func (scope *requestScope) feature(flag string) bool {
+	if scope.htmx == nil || scope.htmx.config.FeatureFlags == nil {
+		return false
+	}
+
+	return scope.htmx.config.FeatureFlags.Enabled(scope.context(), flag)
+}
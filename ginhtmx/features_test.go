@@ -0,0 +1,85 @@
+package ginhtmx_test
+
+import (
+	"context"
+	"html/template"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/jeffscottbrown/ginhtmxtemplates/ginhtmx"
+	"github.com/stretchr/testify/suite"
+)
+
+func (suite *FeaturesTestSuite) TestTemplatesIncludeEnabledFeatures() {
+	htmx := suite.newHtmx(ginhtmx.FeatureFlagSet{"beta": true, "retired": false})
+
+	suite.Equal("<nav>Home Beta</nav>", suite.render(htmx, ""))
+}
+
+func (suite *FeaturesTestSuite) TestFlagsAreDecidedWithTheRequestContext() {
+	htmx := suite.newHtmx(testerFlags{})
+
+	suite.Equal("<nav>Home Beta Retired Reports</nav>", suite.render(htmx, "tester"))
+	suite.Equal("<nav>Home</nav>", suite.render(htmx, ""))
+}
+
+func (suite *FeaturesTestSuite) TestFeaturesAreDisabledWithoutFlags() {
+	suite.Equal("<nav>Home</nav>", suite.render(suite.newHtmx(nil), "tester"))
+
+	var output strings.Builder
+
+	err := template.Must(template.New("").Funcs(ginhtmx.FuncMap()).Parse(`{{ feature "beta" }}`)).Execute(&output, nil)
+	suite.Require().NoError(err)
+	suite.Equal("false", output.String())
+
+	testContext, _ := gin.CreateTestContext(httptest.NewRecorder())
+	suite.False(ginhtmx.FeatureEnabled(testContext, "beta"))
+}
+
+func (suite *FeaturesTestSuite) render(htmx *ginhtmx.Htmx, user string) string {
+	recorder := httptest.NewRecorder()
+	testContext, _ := gin.CreateTestContext(recorder)
+	testContext.Request = httptest.NewRequest(http.MethodGet, "/", nil)
+	testContext.Request = testContext.Request.WithContext(context.WithValue(context.Background(), userKey{}, user))
+	testContext.Request.Header.Set("Hx-Request", "true")
+
+	htmx.Render(testContext, gin.H{}, "nav")
+
+	return recorder.Body.String()
+}
+
+func (suite *FeaturesTestSuite) newHtmx(flags ginhtmx.FeatureFlags) *ginhtmx.Htmx {
+	tmpl := template.Must(template.New("").Funcs(ginhtmx.FuncMap()).Parse(
+		`{{define "nav"}}<nav>Home{{ if feature "beta" }} Beta{{ end }}{{ if feature "retired" }} Retired{{ end }}` +
+			`{{ if .ShowReports }} Reports{{ end }}</nav>{{end}}`))
+
+	return ginhtmx.NewHtmxWithConfig(tmpl, ginhtmx.HtmxConfig{
+		LayoutTemplateName:  "layout",
+		ContentVariableName: "Content",
+		FeatureFlags:        flags,
+		ModelDecorator: ginhtmx.ModelDecoratorFunc(func(ginContext *gin.Context, model *gin.H) {
+			(*model)["ShowReports"] = ginhtmx.FeatureEnabled(ginContext, "reports")
+		}),
+	})
+}
+
+func TestFeaturesTestSuite(t *testing.T) {
+	t.Parallel()
+	suite.Run(t, new(FeaturesTestSuite))
+}
+
+type FeaturesTestSuite struct {
+	suite.Suite
+}
+
+type userKey struct{}
+
+// testerFlags enables every feature for the user "tester".
+type testerFlags struct{}
+
+func (testerFlags) Enabled(ctx context.Context, _ string) bool {
+	return ctx.Value(userKey{}) == "tester"
+}
@@ -0,0 +1,98 @@
+package ginhtmx
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/gin-gonic/gin"
+)
+
+// flashesSessionKey is the key under which pending flashes are stored in the session.
+const flashesSessionKey = "ginhtmx.flashes"
+
+// ErrSessionsDisabled is returned by Flash when the request has no Session because
+// no SessionResolver is configured or it returned nil.
+var ErrSessionsDisabled = errors.New("sessions are not configured")
+
+// flash is a toast stored in the session until the next render.
+type flash struct {
+	Level   string `json:"level"`
+	Message string `json:"message"`
+}
+
+// Flash stores a toast in the Session of the request, so that it is shown by the
+// next render for the user, even if that is a later request. This is intended for
+// responses which do not render, such as a redirect after a form is submitted:
+//
+//	if err := htmx.Flash(c, ginhtmx.ToastSuccess, "User saved"); err != nil {
+//	  return err
+//	}
+//	htmx.Redirect(c, "/users")
+//
+// The next render removes the flashes from the session and adds them as toasts,
+// see Toast. The flashes are stored as a JSON string so that they can be saved by
+// any session store.
+func (htmx *Htmx) Flash(ginContext *gin.Context, level string, message string) error {
+	session := htmx.session(ginContext)
+	if session == nil {
+		return ErrSessionsDisabled
+	}
+
+	flashes, _ := readFlashes(session)
+
+	encoded, err := json.Marshal(append(flashes, flash{Level: level, Message: message}))
+	if err != nil {
+		return fmt.Errorf("storing flash: %w", err)
+	}
+
+	session.Set(flashesSessionKey, string(encoded))
+
+	return saveSession(session)
+}
+
+// applyFlashes removes the flashes from the Session of the request, if any, and
+// adds them to the toasts of the render.
+func (htmx *Htmx) applyFlashes(ginContext *gin.Context) error {
+	session := htmx.session(ginContext)
+	if session == nil || session.Get(flashesSessionKey) == nil {
+		return nil
+	}
+
+	flashes, err := readFlashes(session)
+
+	for _, flash := range flashes {
+		htmx.Toast(ginContext, flash.Level, flash.Message)
+	}
+
+	session.Delete(flashesSessionKey)
+
+	return errors.Join(err, saveSession(session))
+}
+
+// readFlashes returns the flashes stored in session.
+func readFlashes(session Session) ([]flash, error) {
+	encoded, _ := session.Get(flashesSessionKey).(string)
+	if encoded == "" {
+		return nil, nil
+	}
+
+	var flashes []flash
+
+	err := json.Unmarshal([]byte(encoded), &flashes)
+	if err != nil {
+		return nil, fmt.Errorf("reading flashes: %w", err)
+	}
+
+	return flashes, nil
+}
+
+// saveSession saves session, wrapping the error if it fails.
+func saveSession(session Session) error {
+	err := session.Save()
+	if err != nil {
+		return fmt.Errorf("saving session: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,91 @@
+package ginhtmx
+
+import (
+	"bytes"
+	"context"
+	"html/template"
+	"sync"
+	"sync/atomic"
+)
+
+// maxPooledFragmentSize is the capacity above which the buffer of a fragment is
+// not returned to the pool, so that an occasional large fragment does not keep
+// its memory alive for the lifetime of the application.
+const maxPooledFragmentSize = 64 << 10
+
+// fragmentBuffers provides the buffers which single fragments are rendered into.
+// It remembers the size of the output of each template, so that the buffer of the
+// next render of that template is allocated once at the size it will need rather
+// than growing as the template writes to it.
+type fragmentBuffers struct {
+	pool sync.Pool
+	// sizes maps the names of the templates to an *atomic.Int64 with the expected
+	// size of their output
+	sizes sync.Map
+}
+
+func newFragmentBuffers() *fragmentBuffers {
+	buffers := &fragmentBuffers{pool: sync.Pool{}, sizes: sync.Map{}}
+	buffers.pool.New = func() any { return new(bytes.Buffer) }
+
+	return buffers
+}
+
+// acquire returns an empty buffer large enough for the expected output of the
+// named template.
+func (buffers *fragmentBuffers) acquire(name string) *bytes.Buffer {
+	buffer, _ := buffers.pool.Get().(*bytes.Buffer)
+
+	if size, found := buffers.sizes.Load(name); found {
+		buffer.Grow(int(size.(*atomic.Int64).Load())) //nolint:forcetypeassert
+	}
+
+	return buffer
+}
+
+// release records the size of the output of the named template held by buffer and
+// returns buffer to the pool. The expected size follows growth immediately and
+// decays slowly, so that a template whose output varies is not reallocated on
+// every other render.
+func (buffers *fragmentBuffers) release(name string, buffer *bytes.Buffer) {
+	size := int64(buffer.Len())
+	stored, _ := buffers.sizes.LoadOrStore(name, new(atomic.Int64))
+	expected := stored.(*atomic.Int64) //nolint:forcetypeassert
+
+	for {
+		previous := expected.Load()
+		next := max(size, previous-(previous-size)/8)
+
+		if next == previous || expected.CompareAndSwap(previous, next) {
+			break
+		}
+	}
+
+	if buffer.Cap() <= maxPooledFragmentSize {
+		buffer.Reset()
+		buffers.pool.Put(buffer)
+	}
+}
+
+// rendersFragmentDirectly reports whether the response is a single fragment which
+// can be rendered straight into a pooled buffer and written from it, without the
+// string concatenation needed to cache, wrap, append to or check the content.
+func (htmx *Htmx) rendersFragmentDirectly(fragment bool, settings *renderSettings, templateNames []string) bool {
+	return fragment &&
+		len(templateNames) == 1 &&
+		(settings.cache == nil || htmx.config.Cache == nil) &&
+		len(settings.appended) == 0 &&
+		len(settings.wrappers) == 0 &&
+		(htmx.config.ErrorBoundary == "" || htmx.config.Strict) &&
+		!htmx.config.Debug
+}
+
+// renderFragment renders the named template into a pooled buffer, which must be
+// returned with release once the response has been written.
+func (htmx *Htmx) renderFragment(
+	ctx context.Context, tmpl *template.Template, name string, data any,
+) (*bytes.Buffer, error) {
+	buffer := htmx.fragments.acquire(name)
+
+	return buffer, htmx.executeTemplateTo(ctx, buffer, tmpl, name, data, true)
+}
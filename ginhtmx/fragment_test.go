@@ -0,0 +1,97 @@
+package ginhtmx_test
+
+import (
+	"html/template"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/jeffscottbrown/ginhtmxtemplates/ginhtmx"
+	"github.com/stretchr/testify/suite"
+)
+
+func (suite *FragmentTestSuite) TestFragmentsOfVaryingSizesAreRenderedIntact() {
+	for _, count := range []int{3, 40000, 1, 200, 0, 70000, 5} {
+		items := strings.Repeat("x", count)
+
+		recorder := suite.render(gin.H{"Items": items}, "items")
+
+		suite.Equal(http.StatusOK, recorder.Code)
+		suite.Equal("<ul>"+items+"</ul>", recorder.Body.String())
+	}
+}
+
+func (suite *FragmentTestSuite) TestFragmentsIncludeTheTitle() {
+	recorder := suite.render(gin.H{}, "titled")
+
+	suite.Equal(`<p>Orders</p><title>Orders &amp; Returns</title>`, recorder.Body.String())
+}
+
+func (suite *FragmentTestSuite) TestFragmentErrorsAreRecorded() {
+	recorder := httptest.NewRecorder()
+	testContext, _ := gin.CreateTestContext(recorder)
+	testContext.Request = httptest.NewRequest(http.MethodGet, "/", nil)
+	testContext.Request.Header.Set("Hx-Request", "true")
+
+	suite.htmx.Render(testContext, gin.H{}, "missing")
+
+	renderErr, _ := testContext.Value(ginhtmx.RenderErrorKey).(error)
+	suite.Require().ErrorIs(renderErr, ginhtmx.ErrTemplateNotFound)
+}
+
+func (suite *FragmentTestSuite) TestConcurrentFragmentsDoNotShareBuffers() {
+	done := make(chan string)
+
+	for index := range 20 {
+		go func() {
+			items := strings.Repeat("abcdefghij"[index%10:index%10+1], 1000+index)
+			body := suite.render(gin.H{"Items": items}, "items").Body.String()
+
+			if body != "<ul>"+items+"</ul>" {
+				done <- body
+			} else {
+				done <- ""
+			}
+		}()
+	}
+
+	for range 20 {
+		suite.Empty(<-done)
+	}
+}
+
+func (suite *FragmentTestSuite) render(data gin.H, templateNames ...string) *httptest.ResponseRecorder {
+	recorder := httptest.NewRecorder()
+	testContext, _ := gin.CreateTestContext(recorder)
+	testContext.Request = httptest.NewRequest(http.MethodGet, "/", nil)
+	testContext.Request.Header.Set("Hx-Request", "true")
+
+	suite.htmx.Render(testContext, data, templateNames...)
+
+	return recorder
+}
+
+func (suite *FragmentTestSuite) SetupTest() {
+	tmpl := template.Must(template.New("").Funcs(ginhtmx.FuncMap()).Parse(`
+{{define "layout"}}<html>{{.Content}}</html>{{end}}
+{{define "items"}}<ul>{{.Items}}</ul>{{end}}
+{{define "titled"}}{{ setTitle "Orders & Returns" }}<p>Orders</p>{{end}}
+`))
+	suite.htmx = ginhtmx.NewHtmxWithConfig(tmpl, ginhtmx.HtmxConfig{
+		LayoutTemplateName:  "layout",
+		ContentVariableName: "Content",
+	})
+}
+
+func TestFragmentTestSuite(t *testing.T) {
+	t.Parallel()
+	suite.Run(t, new(FragmentTestSuite))
+}
+
+type FragmentTestSuite struct {
+	suite.Suite
+
+	htmx *ginhtmx.Htmx
+}
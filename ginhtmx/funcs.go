@@ -0,0 +1,186 @@
+package ginhtmx
+
+import (
+	"context"
+	"fmt"
+	"html/template"
+	"maps"
+	"sync"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// FuncMap returns the template functions provided by this package. The functions
+// must be added to your templates before they are parsed so that the templates may
+// refer to them:
+//
+//	tmpl := template.Must(template.New("").Funcs(ginhtmx.FuncMap()).ParseFS(embeddedHTMLFiles, "templates/*.html"))
+//
+// Functions which depend on the current request, such as "t", are bound to that
+// request each time Htmx renders. When called outside of a render they fall back
+// to sensible defaults.
+func FuncMap() template.FuncMap {
+	return (&requestScope{
+		htmx: nil, ginContext: nil, ctx: nil, template: nil, mutex: sync.Mutex{}, meta: PageMeta{}, funcs: nil, now: nil,
+	}).funcMap()
+}
+
+// requestScope holds the state that template functions bound to a single render need.
+type requestScope struct {
+	htmx       *Htmx
+	ginContext *gin.Context
+	// ctx is the context of the render, nil outside of a render
+	ctx context.Context //nolint:containedctx
+	// template is the template the functions are bound to, used to render nested templates
+	template *template.Template
+	// mutex guards meta, which may be set by templates rendered concurrently
+	mutex sync.Mutex
+	// meta is the title and description of the page
+	meta PageMeta
+	// funcs are template functions which override those bound by funcMap
+	funcs template.FuncMap
+	// now returns the current time used by the time functions, nil means time.Now
+	now func() time.Time
+}
+
+func (htmx *Htmx) newRequestScope(ginContext *gin.Context) *requestScope {
+	return &requestScope{
+		htmx:       htmx,
+		ginContext: ginContext,
+		ctx:        nil,
+		template:   nil,
+		mutex:      sync.Mutex{},
+		meta:       PageMeta{},
+		funcs:      nil,
+		now:        nil,
+	}
+}
+
+func (scope *requestScope) funcMap() template.FuncMap {
+	funcs := template.FuncMap{
+		"t":         scope.translate,
+		"plural":    scope.plural,
+		"asset":     scope.asset,
+		"dict":      dict,
+		"component": scope.component,
+		"partial":   scope.partial,
+		"wrap":      scope.wrap,
+		"hxVals":    hxVals,
+		"hxHeaders": hxHeaders,
+		"hxAttr":    hxAttr,
+		"isCurrent": scope.isCurrent,
+		"isActive":  scope.isActive,
+		"urlFor":    scope.urlFor,
+		"markdown":  scope.markdown,
+		"sanitize":  scope.sanitize,
+		"theme":     scope.theme,
+		"tenant":    scope.tenant,
+		"feature":   scope.feature,
+
+		"isAuthenticated": scope.isAuthenticated,
+		"requestID":       scope.requestID,
+
+		"currentPath":   scope.currentPath,
+		"queryParam":    scope.queryParam,
+		"header":        scope.header,
+		"isHtmxRequest": scope.isHTMXRequest,
+
+		"formatDate": scope.formatDate,
+		"formatTime": scope.formatTime,
+		"timeAgo":    scope.timeAgo,
+
+		"formatNumber":   scope.formatNumber,
+		"formatCurrency": scope.formatCurrency,
+		"formatPercent":  scope.formatPercent,
+
+		"setTitle":        scope.setTitle,
+		"setDescription":  scope.setDescription,
+		"pageTitle":       scope.pageTitle,
+		"pageDescription": scope.pageDescription,
+		"openGraph":       scope.openGraph,
+		"canonicalURL":    scope.canonicalURL,
+		"jsonLD":          scope.jsonLD,
+	}
+
+	maps.Copy(funcs, scope.funcs)
+
+	return funcs
+}
+
+// acquireTemplate returns a template with the functions of scope bound to it along
+// with a function which must be called once rendering has finished. Templates are
+// cloned so that concurrent requests never observe each other's functions.
+func (htmx *Htmx) acquireTemplate(scope *requestScope, setName string) (*template.Template, func(), error) {
+	set, err := htmx.templates.loadNamed(setName)
+
+	clone, ok := set.clones.Get().(*template.Template)
+	if !ok {
+		// The template could not be cloned, which happens when it has already
+		// been executed outside of Htmx. Fall back to the shared template.
+		return set.template, func() {}, err
+	}
+
+	scope.template = clone
+	clone.Funcs(scope.funcMap())
+
+	if len(scope.funcs) > 0 {
+		// The overriding functions would outlive the render in a pooled clone, so
+		// the clone is discarded instead.
+		return clone, func() {}, err
+	}
+
+	return clone, func() { set.clones.Put(clone) }, err
+}
+
+// context returns the context of the render, or the background context outside of
+// a render.
+func (scope *requestScope) context() context.Context {
+	if scope.ctx == nil {
+		return context.Background()
+	}
+
+	return scope.ctx
+}
+
+// Funcs returns a RenderOption which adds funcs to the template functions of each
+// render, replacing any functions with the same names, including those provided by
+// this package. Functions whose results differ between renders, such as one
+// returning a Content-Security-Policy nonce, may be replaced with stable versions
+// so that the output can be compared in tests:
+//
+//	htmx.With(ginhtmx.Funcs(template.FuncMap{"nonce": func() string { return "test-nonce" }}))
+//
+// The functions must already be known to the templates when they are parsed.
+func Funcs(funcs template.FuncMap) RenderOption {
+	return func(settings *renderSettings) {
+		if settings.funcs == nil {
+			settings.funcs = template.FuncMap{}
+		}
+
+		maps.Copy(settings.funcs, funcs)
+	}
+}
+
+// dict returns a map built from alternating keys and values, allowing several values
+// to be passed to a template or component:
+//
+//	{{ component "avatar" (dict "User" .User "Size" 32) }}
+func dict(pairs ...any) (gin.H, error) {
+	if len(pairs)%2 != 0 {
+		return nil, fmt.Errorf("%w: dict requires an even number of arguments", errInvalidArgument)
+	}
+
+	values := make(gin.H, len(pairs)/2)
+
+	for index := 0; index < len(pairs); index += 2 {
+		key, ok := pairs[index].(string)
+		if !ok {
+			return nil, fmt.Errorf("%w: dict key %v is not a string", errInvalidArgument, pairs[index])
+		}
+
+		values[key] = pairs[index+1]
+	}
+
+	return values, nil
+}
@@ -0,0 +1,80 @@
+package ginhtmx_test
+
+import (
+	"html/template"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/jeffscottbrown/ginhtmxtemplates/ginhtmx"
+	"github.com/stretchr/testify/suite"
+)
+
+func (suite *FuncsTestSuite) TestFuncsReplaceTemplateFunctions() {
+	htmx := suite.htmx.With(ginhtmx.Funcs(template.FuncMap{"nonce": func() string { return "fixed" }}))
+
+	suite.Equal(`<script nonce="fixed"></script>`, suite.render(htmx))
+	suite.Equal(`<script nonce="random"></script>`, suite.render(suite.htmx))
+}
+
+func (suite *FuncsTestSuite) TestFuncsReplaceFunctionsProvidedByThePackage() {
+	htmx := suite.htmx.With(
+		ginhtmx.Funcs(template.FuncMap{"nonce": func() string { return "first" }}),
+		ginhtmx.Funcs(template.FuncMap{"t": func(key string, _ ...any) string { return "[" + key + "]" }}),
+	)
+
+	suite.Equal(`<script nonce="first"></script>[greeting]`, suite.render(htmx, "script", "greeting"))
+}
+
+func (suite *FuncsTestSuite) TestFuncsApplyToMergedFragments() {
+	recorder := httptest.NewRecorder()
+	testContext, _ := gin.CreateTestContext(recorder)
+	testContext.Request = httptest.NewRequest(http.MethodGet, "/", nil)
+
+	err := suite.htmx.With(ginhtmx.Funcs(template.FuncMap{"nonce": func() string { return "fixed" }})).
+		MergeFragments(testContext, ginhtmx.DatastarFragment{Template: "script", Data: nil, Selector: "", MergeMode: ""})
+
+	suite.Require().NoError(err)
+	suite.Contains(recorder.Body.String(), `<script nonce="fixed"></script>`)
+}
+
+func (suite *FuncsTestSuite) render(htmx *ginhtmx.Htmx, templateNames ...string) string {
+	if len(templateNames) == 0 {
+		templateNames = []string{"script"}
+	}
+
+	recorder := httptest.NewRecorder()
+	testContext, _ := gin.CreateTestContext(recorder)
+	testContext.Request = httptest.NewRequest(http.MethodGet, "/", nil)
+	testContext.Request.Header.Set("HX-Request", "true")
+
+	htmx.Render(testContext, gin.H{}, templateNames...)
+
+	return recorder.Body.String()
+}
+
+func (suite *FuncsTestSuite) SetupTest() {
+	tmpl := template.Must(template.New("").Funcs(ginhtmx.FuncMap()).Funcs(template.FuncMap{
+		"nonce": func() string { return "random" },
+	}).Parse(`
+{{define "layout"}}<html>{{.Content}}</html>{{end}}
+{{define "script"}}<script nonce="{{nonce}}"></script>{{end}}
+{{define "greeting"}}{{t "greeting"}}{{end}}
+`))
+	suite.htmx = ginhtmx.NewHtmxWithConfig(tmpl, ginhtmx.HtmxConfig{
+		LayoutTemplateName:  "layout",
+		ContentVariableName: "Content",
+	})
+}
+
+func TestFuncsTestSuite(t *testing.T) {
+	t.Parallel()
+	suite.Run(t, new(FuncsTestSuite))
+}
+
+type FuncsTestSuite struct {
+	suite.Suite
+
+	htmx *ginhtmx.Htmx
+}
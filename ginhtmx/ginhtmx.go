@@ -1,16 +1,53 @@
 package ginhtmx
 
 import (
+	"context"
+	"errors"
+	"fmt"
 	"html/template"
+	"io"
+	"log/slog"
 	"net/http"
+	"slices"
+	"strings"
+	"sync"
+	texttemplate "text/template"
+	"time"
 
 	"github.com/gin-gonic/gin"
+	"github.com/microcosm-cc/bluemonday"
+	"github.com/yuin/goldmark"
+	"go.opentelemetry.io/otel/trace"
 )
 
 // Htmx provides functionality to render HTML templates with optional layout decoration.
 type Htmx struct {
-	template *template.Template
-	config   HtmxConfig
+	config HtmxConfig
+
+	// templates holds the templates, which may be replaced by Reload
+	templates *templateStore
+
+	// reloads notifies browsers when the templates are reloaded, see LiveReload
+	reloads *reloadHub
+
+	// sitemap holds the URLs served by SitemapHandler
+	sitemap *sitemap
+
+	// options are applied to every render, see With
+	options []RenderOption
+
+	// usage counts renders of each template for ExportTelemetry
+	usage *usageRecorder
+
+	// fragments provides the buffers of fragments which are rendered directly
+	fragments *fragmentBuffers
+
+	// revalidations holds the cache keys of the stale output being rendered again
+	// in the background, see StaleWhileRevalidate
+	revalidations *sync.Map
+
+	// tracer creates the spans around template execution
+	tracer trace.Tracer
 }
 
 // HtmxConfig holds configuration options for the Htmx instance.
@@ -24,28 +61,369 @@ type HtmxConfig struct {
 	// ModelDecorator is an optional interface that can be implemented to modify the model.
 	// If provided, the DecorateModel method will be called before rendering any templates.
 	ModelDecorator ModelDecorator
+
+	// Translator is an optional source of translated messages used by the "t" and
+	// "plural" template functions. See Catalog for a simple implementation.
+	Translator Translator
+
+	// LocaleResolver is an optional function which determines the locale to use for
+	// a request. If not provided, the locale stored in the gin context under the
+	// LocaleKey key is used.
+	LocaleResolver func(ginContext *gin.Context) string
+
+	// Assets is an optional manifest used by the "asset" template function to
+	// produce cache-busted URLs for static assets.
+	Assets *AssetManifest
+
+	// ETags enables strong ETag headers computed from the rendered response. Requests
+	// with a matching If-None-Match header receive a 304 Not Modified response.
+	// The templates are only executed for HEAD requests when ETags are enabled, so
+	// that the ETag can be computed, and the body is discarded.
+	ETags bool
+
+	// Debug enables additional checks and diagnostics intended for use during
+	// development, such as verifying the targets declared with ExpectSelect. The
+	// output of every template other than the layout is surrounded by
+	// <!-- begin:name --> and <!-- end:name --> comments, so that the template
+	// which produced an element can be found with the browser developer tools, and
+	// fragments rendered with CacheFor end with a comment such as
+	// <!-- ginhtmx cache: HIT key=... age=12s --> showing whether their output was
+	// served from the Cache. As the key includes the discriminator, which may
+	// identify the user, Debug must not be enabled in production.
+	Debug bool
+
+	// Metrics is an optional recorder which receives measurements of every template
+	// execution, every render and every lookup of cached output.
+	Metrics MetricsRecorder
+
+	// TracerProvider is an optional OpenTelemetry tracer provider. When provided, a
+	// span is created for every render, with a child span for every template
+	// executed, as children of the span in the request context.
+	TracerProvider trace.TracerProvider
+
+	// Logger is an optional logger to which failed renders, including renders of
+	// templates which are not defined, are logged along with the request details.
+	Logger *slog.Logger
+
+	// SlowRenderThreshold is the duration above which a render is logged as slow.
+	// Slow renders are not logged if it is zero or no Logger is configured.
+	SlowRenderThreshold time.Duration
+
+	// Strict makes rendering fail loudly. Templates are executed with the
+	// "missingkey=error" option so that referring to a key which is not in the
+	// model is an error, and any render error, including rendering a template which
+	// is not defined, results in a 500 response describing the error instead of
+	// the partial output of the templates.
+	Strict bool
+
+	// NegotiateJSON enables content negotiation. Requests whose Accept header
+	// prefers application/json to text/html receive the model serialized as JSON,
+	// with the same status, instead of the rendered templates. This allows a single
+	// handler to serve both the HTMX user interface and API clients.
+	NegotiateJSON bool
+
+	// JSONPredicate is an optional function which decides whether the model should
+	// be serialized as JSON for a request. When provided, it is used instead of the
+	// Accept header and NegotiateJSON is ignored.
+	JSONPredicate func(ginContext *gin.Context) bool
+
+	// Components are the components which may be rendered with RenderComponent and
+	// the "component" template function.
+	Components Components
+
+	// BaseURL is the scheme and host, and optionally a path prefix, used to make
+	// canonical URLs absolute, such as "https://example.com". If not provided, the
+	// host of the request is used.
+	BaseURL string
+
+	// Preload lists the critical resources of the layout, which are announced with
+	// Link preload headers on full page responses and, when EarlyHintsMiddleware
+	// is used, with 103 Early Hints responses.
+	Preload []PreloadLink
+
+	// ModalTarget is the selector of the element RenderModal adds modals to. Defaults
+	// to DefaultModalTarget.
+	ModalTarget string
+
+	// ModalSwap is the swap strategy RenderModal uses to add modals. Defaults to
+	// DefaultModalSwap.
+	ModalSwap string
+
+	// ToastContainer is the selector of the element Toast adds toasts to. Defaults to
+	// DefaultToastContainer.
+	ToastContainer string
+
+	// AutoPushURL pushes the URL of GET requests into the browser history when
+	// rendering fragments, so that the history stays correct when elements load
+	// content with hx-get. It has no effect on renders which use the PushURL or
+	// ReplaceURL options.
+	AutoPushURL bool
+
+	// ConcurrentRendering renders the templates passed to a single render
+	// concurrently, each into its own buffer, and concatenates the results in the
+	// order the templates were named. This reduces the latency of renders whose
+	// templates call slow template functions. Template functions and model values
+	// used by the templates must be safe for concurrent use.
+	ConcurrentRendering bool
+
+	// StreamLayout streams full page responses. The layout is rendered before the
+	// page templates and the part of it before the content variable, which usually
+	// holds the head of the document, is flushed to the browser immediately. The
+	// output of the page templates is then written as they are rendered, followed
+	// by the rest of the layout. As the head of the document has already been sent,
+	// titles set by page templates with "setTitle" are not shown and render errors
+	// cannot change the status of the response. StreamLayout has no effect when
+	// Strict or ETags is enabled, or when the layout does not output the content
+	// variable as HTML exactly once.
+	StreamLayout bool
+
+	// RenderTimeout is the longest a render may take. Template execution is
+	// abandoned at the next output of a template once the timeout has elapsed or
+	// the client has disconnected, and the response is aborted, with a 503 Service
+	// Unavailable status on timeout, instead of writing partial output. The error is
+	// reported like any other render error. If zero, renders are only abandoned when
+	// the client disconnects.
+	RenderTimeout time.Duration
+
+	// Compression compresses rendered responses with gzip or deflate when the
+	// request accepts one of them, setting the Content-Encoding, Content-Length and
+	// Vary headers. Responses smaller than CompressionMinSize, streamed pages and
+	// responses already encoded by other middleware are not compressed.
+	Compression bool
+
+	// CompressionMinSize is the size in bytes below which responses are not
+	// compressed. Defaults to DefaultCompressionMinSize.
+	CompressionMinSize int
+
+	// Cache stores the output of renders which use the CacheFor option. If not
+	// provided, CacheFor has no effect.
+	Cache Cache
+
+	// ErrorTemplates maps HTTP status codes to the templates RenderError renders
+	// for them, such as {404: "not_found", 500: "server_error"}. The template for
+	// 500 also replaces the partial output of a render in which a template panics,
+	// unless an ErrorBoundary is configured.
+	ErrorTemplates map[int]string
+
+	// FallbackTemplate is rendered in place of any template which is not defined,
+	// such as a page whose name is misspelled, instead of an empty body. Its model
+	// is the model of the missing template with the name of that template added
+	// under MissingTemplateModelKey. A warning is logged to the Logger each time it
+	// is rendered. It is not used when Strict is enabled, so that missing templates
+	// result in 500 responses.
+	FallbackTemplate string
+
+	// Routes names the routes of the application for URLFor and the "urlFor"
+	// template function.
+	Routes Routes
+
+	// Markdown enables the "markdown" template function, which converts trusted
+	// markdown to HTML with it. It is usually created with goldmark.New, with any
+	// extensions such as extension.GFM. If not provided, the function returns
+	// ErrMarkdownDisabled.
+	Markdown goldmark.Markdown
+
+	// Sanitizer is the policy used by the "sanitize" template function and the
+	// SanitizedHTML render option to remove unsafe elements and attributes from
+	// user supplied HTML. Defaults to bluemonday.UGCPolicy.
+	Sanitizer *bluemonday.Policy
+
+	// TimeZone is the time zone to which the date and time template functions
+	// convert times before formatting them, unless the request has a time zone
+	// stored under TimeZoneKey. If not provided, times are formatted in their own
+	// location.
+	TimeZone *time.Location
+
+	// DateFormats maps locales, such as "en-GB" or "de", to the formats used by the
+	// "formatDate" and "formatTime" template functions, overriding the built-in
+	// formats for common locales. Empty layouts are taken from the built-in format.
+	DateFormats map[string]DateFormat
+
+	// NumberFormats maps locales, such as "en-GB" or "de", to the formats used by
+	// the "formatNumber", "formatCurrency" and "formatPercent" template functions,
+	// overriding the built-in formats for common locales. Empty fields are taken
+	// from the built-in format.
+	NumberFormats map[string]NumberFormat
+
+	// Currency is the ISO 4217 code, such as "USD" or "EUR", of the currency used by
+	// the "formatCurrency" template function when none is specified.
+	Currency string
+
+	// ThemeResolver returns the theme of a request, such as "dark" or the name of a
+	// brand. Templates named with the theme followed by a slash, such as
+	// "dark/card", are then rendered in place of the templates without it, such as
+	// "card", when they are rendered by Render, "partial", "component" or "wrap".
+	// Templates invoked with the template action are not affected. The theme is
+	// stored in the gin context under ThemeKey and returned by the "theme" template
+	// function.
+	ThemeResolver func(ginContext *gin.Context) string
+
+	// TenantResolver returns the tenant of a request, such as a customer whose
+	// pages are branded differently, see TenantFromHost and TenantFromContext.
+	// Templates named with the tenant followed by a slash, such as "acme/header",
+	// are then preferred over the templates of the theme and the shared templates,
+	// as described for ThemeResolver, and "acme/dark/header" is preferred over all
+	// of them when the theme is "dark". The tenant is stored in the gin context
+	// under TenantKey and returned by the "tenant" template function.
+	TenantResolver func(ginContext *gin.Context) string
+
+	// Tenants maps the tenants returned by the TenantResolver to the template
+	// functions and default model values with which their requests are rendered,
+	// such as a "supportURL" function or the name and logo of their brand, so that
+	// a single binary can serve several white-label sites.
+	Tenants map[string]Tenant
+
+	// Experiments maps the names of templates, including the layout, to the variants
+	// of an A/B test among which visitors are divided in proportion to their
+	// weights. When one of the templates is rendered by Render, or the layout wraps
+	// a full page, the template of the variant assigned to the visitor is rendered
+	// in its place. Visitors are identified by the ExperimentCookie, so they are
+	// always assigned the same variants. The assignments are exposed to templates
+	// as .Experiments, stored in the gin context under ExperimentsKey and sent as
+	// the detail of the ExperimentEvent in the HX-Trigger header for analytics.
+	Experiments map[string][]Variant
+
+	// ExperimentCookie is the name of the cookie which identifies visitors for the
+	// assignment of variants. Defaults to DefaultExperimentCookie.
+	ExperimentCookie string
+
+	// FeatureFlags decides which features are enabled for the "feature" template
+	// function and for FeatureEnabled, which model decorators and model providers
+	// may use. If not provided, every feature is disabled.
+	FeatureFlags FeatureFlags
+
+	// SessionResolver returns the Session of a request, which backs Flash and is
+	// available to model decorators and model providers through SessionFrom. Use
+	// GinContribSession for gin-contrib/sessions or GorillaSessions for
+	// gorilla/sessions. If not provided, Flash returns ErrSessionsDisabled.
+	SessionResolver func(ginContext *gin.Context) Session
+
+	// Authorizer decides whether the request may render templates requiring a
+	// permission with RequirePermission. If not provided, every permission is
+	// denied.
+	Authorizer func(ginContext *gin.Context, permission string) bool
+
+	// Guards maps the names of templates to functions which decide whether the
+	// request may render them, so that sensitive templates are protected however
+	// they are rendered in response to a request: by Render and its variants,
+	// Turbo Stream actions, appended templates and MergeFragments, through aliases,
+	// themes and experiment variants, and from the fragment cache. Requests which
+	// are denied by a guard or the Authorizer receive a 403 Forbidden response,
+	// rendered with RenderError, and MergeFragments returns the error instead.
+	// Guards do not apply to the templates included by other templates, nor to
+	// renders outside of a request such as RenderToString.
+	Guards map[string]func(ginContext *gin.Context) bool
+
+	// ErrorBoundary is rendered in place of any of the templates passed to Render
+	// which fails or panics, so that the other templates of the response are still
+	// shown, like the error boundaries of component frameworks. It is rendered with
+	// the model of the template and a FragmentError under the "Error" key, and the
+	// error of the template is still recorded as an error of the render. It is not
+	// used when Strict is enabled.
+	ErrorBoundary string
+
+	// ServerTiming adds a Server-Timing header to rendered responses with the time
+	// taken by each template, the layout and the whole render, so that the browser
+	// developer tools show where the server spent its time.
+	ServerTiming bool
+
+	// ContentType is the media type of the Content-Type header of rendered
+	// responses, for example "application/xhtml+xml". Defaults to
+	// DefaultContentType.
+	ContentType string
+
+	// Charset is the character set declared in the Content-Type header of rendered
+	// responses. Defaults to DefaultCharset. The output of the templates is not
+	// converted, so templates rendered with another character set must only write
+	// text which is valid in it.
+	Charset string
+
+	// TextTemplates are the templates rendered by renders using the Text option,
+	// with text/template rather than html/template.
+	TextTemplates *texttemplate.Template
+
+	// Engine renders the templates it defines in place of the html/template
+	// templates, with the same layout, HTMX detection, model decorators and model
+	// providers, so that the templates of another template engine can be used,
+	// or an application migrated to or from one a template at a time. Templates
+	// the engine does not define, including the built-in templates, are rendered
+	// from the html/template templates. Template functions, Strict and the checks
+	// of Lint only apply to html/template templates. See Pongo2Engine and
+	// QuickTemplates.
+	Engine TemplateEngine
+
+	// AlpineAJAX renders the requests of Alpine AJAX, which send the
+	// X-Alpine-Request header, without the layout like HTMX requests. Alpine AJAX
+	// replaces the elements of the page with the ids listed in the X-Alpine-Target
+	// header by the elements of the response with the same ids, so when Debug is
+	// also enabled an ErrSelectTargetMissing render error is recorded for each of
+	// them missing from the response, as if they were declared with ExpectSelect.
+	AlpineAJAX bool
+
+	// FragmentQueryParam is the name of a query parameter which, when it is true,
+	// such as ?fragment=1, renders the response without the layout and, when it is
+	// false, renders it with the layout, whatever the request headers. It is
+	// useful behind proxies which remove the HX-Request header and for viewing
+	// fragments in the browser. Render options such as ForceFullPage take
+	// precedence over it. If empty, no query parameter is used.
+	FragmentQueryParam string
+
+	// LayoutQueryParam is the name of a query parameter which, when it is false,
+	// such as ?layout=0, renders the response without the layout and, when it is
+	// true, renders it with the layout, like FragmentQueryParam with the opposite
+	// meaning. FragmentQueryParam takes precedence over it.
+	LayoutQueryParam string
+
+	// HTMXRequestHeaders are the names of the request headers, any of which
+	// identifies an HTMX request, for gateways which rename the HX-Request header,
+	// for example to X-HX-Request. Defaults to DefaultHTMXRequestHeader.
+	HTMXRequestHeaders []string
+
+	// RequestIDHeader is the name of the request header holding the ID of the
+	// request, which is also returned in the response header of the same name, see
+	// RequestID. Defaults to DefaultRequestIDHeader.
+	RequestIDHeader string
+
+	// SeparateLayoutData renders the layout template with a LayoutData holding the
+	// content as .Content, the model of the page as .Page and the values added with
+	// LayoutModel as .Layout, instead of with a copy of the model of the page with
+	// the content added under ContentVariableName, which is then ignored. This
+	// keeps the keys of the pages from colliding with those used by the layout.
+	SeparateLayoutData bool
 }
 
 // NewHtmxWithConfig creates a new instance of Htmx with the provided HTML templates and configuration.
 func NewHtmxWithConfig(template *template.Template, config HtmxConfig) *Htmx {
-	return &Htmx{
-		config:   config,
-		template: template,
+	htmx := &Htmx{
+		config:        config,
+		templates:     nil,
+		reloads:       newReloadHub(),
+		sitemap:       newSitemap(),
+		options:       nil,
+		usage:         newUsageRecorder(),
+		fragments:     newFragmentBuffers(),
+		revalidations: &sync.Map{},
+		tracer:        newTracer(config.TracerProvider),
 	}
+	htmx.prepareTemplate(template)
+	htmx.templates = newTemplateStore(template)
+
+	if config.Strict && config.TextTemplates != nil {
+		config.TextTemplates.Option("missingkey=error")
+	}
+
+	return htmx
 }
 
 // NewHtmx creates a new instance of Htmx with the provided HTML templates and
 // configuration. The default configuration uses "layout" as the layout
 // template name and "Content" as the body variable name.
 func NewHtmx(template *template.Template) *Htmx {
-	return &Htmx{
-		config: HtmxConfig{
-			LayoutTemplateName:  "layout",
-			ContentVariableName: "Content",
-			ModelDecorator:      nil,
-		},
-		template: template,
-	}
+	return NewHtmxWithConfig(template, HtmxConfig{
+		LayoutTemplateName:  "layout",
+		ContentVariableName: "Content",
+		ModelDecorator:      nil,
+	})
 }
 
 // RenderWithStatus renders the specified templates with the provided data, concatenates the
@@ -53,27 +431,187 @@ func NewHtmx(template *template.Template) *Htmx {
 // The templates are rendered and concatenated together in the order they are provided.
 // If the request does not inlcude the "Hx-Request" header indicating this is an HTMX request
 // then the contents will be wrapped in the layout page.
+// Once the response has been written the outcome of the render is recorded in the gin
+// context using the keys described in context.go.
 func (htmx *Htmx) RenderWithStatus(ginContext *gin.Context, data gin.H, status int, templateNames ...string) {
+	start := time.Now()
+	sizeBefore := max(ginContext.Writer.Size(), 0)
+
+	settings := htmx.renderSettings()
+
+	if err := htmx.authorize(ginContext, settings, templateNames); err != nil {
+		htmx.With(asErrorResponse()).RenderError(ginContext, err)
+
+		return
+	}
+
+	htmx.applyLayoutQuery(ginContext, settings)
+	htmx.applyAlpineRequest(ginContext, settings)
+
+	isHTMX := settings.isFragment(htmx, ginContext)
+
+	if isHTMX && htmx.config.AutoPushURL {
+		pushRequestURL(ginContext, settings)
+	}
+
+	settings.writeHeaders(ginContext)
 	ginContext.Status(status)
-	isHTMX := ginContext.GetHeader("HX-Request") != ""
+
+	ctx, span := htmx.startRenderSpan(ginContext.Request.Context(), templateNames, isHTMX)
+	ctx = htmx.withServerTimings(ctx)
+	ctx = htmx.withTheme(ctx, ginContext)
+	ctx = htmx.withTenant(ctx, ginContext)
+	ctx = htmx.withGuards(ctx, ginContext)
+
+	ctx, cancel := htmx.withRenderTimeout(ctx)
+	defer cancel()
+
+	if data == nil {
+		data = gin.H{}
+	}
+
+	ctx = htmx.assignExperiments(ctx, ginContext, data, templateNames, isHTMX)
+	htmx.withFeatureFlags(ginContext)
+
+	// the flashes and toasts are left for a request which shows them, as are the
+	// side effects of the model providers and the model decorator
+	if htmx.skipsBody(ginContext, settings) {
+		writeHead(ginContext, status, settings.contentType(htmx))
+		htmx.finishRender(ginContext, span, RenderObservation{
+			Templates: templateNames,
+			Status:    ginContext.Writer.Status(),
+			Fragment:  isHTMX,
+			Bytes:     0,
+			Duration:  time.Since(start),
+			Err:       errors.Join(settings.errors...),
+		})
+
+		return
+	}
+
+	renderErrors := append(slices.Clip(settings.errors), htmx.applyFlashes(ginContext))
+	renderErrors = append(renderErrors, applyModelProviders(ginContext, data)...)
+	applyToasts(ginContext, settings, data, isHTMX)
 
 	if htmx.config.ModelDecorator != nil {
 		htmx.config.ModelDecorator.DecorateModel(ginContext, &data)
 	}
 
-	// Concatenate the rendered templates
-	var content string
-	for _, name := range templateNames {
-		content += htmx.renderTemplateToString(name, data)
+	renderErrors = append(renderErrors, htmx.sanitizeModel(settings, data)...)
+
+	if settings.textContentType != "" {
+		data = htmx.exposeRequestID(ginContext, data)
+		data = htmx.applyTenantData(ginContext, data)
+
+		renderErr := htmx.renderText(ctx, ginContext, status, settings.textContentType, data, templateNames)
+		htmx.finishRender(ginContext, span, RenderObservation{
+			Templates: templateNames,
+			Status:    ginContext.Writer.Status(),
+			Fragment:  isHTMX,
+			Bytes:     max(ginContext.Writer.Size(), 0) - sizeBefore,
+			Duration:  time.Since(start),
+			Err:       errors.Join(append(renderErrors, renderErr)...),
+		})
+
+		return
+	}
+
+	if htmx.wantsJSON(ginContext) {
+		ginContext.JSON(status, data)
+		htmx.finishRender(ginContext, span, RenderObservation{
+			Templates: templateNames,
+			Status:    ginContext.Writer.Status(),
+			Fragment:  false,
+			Bytes:     max(ginContext.Writer.Size(), 0) - sizeBefore,
+			Duration:  time.Since(start),
+			Err:       errors.Join(renderErrors...),
+		})
+
+		return
+	}
+
+	data = htmx.exposeRequestID(ginContext, data)
+	data = htmx.applyTenantData(ginContext, data)
+
+	scope := htmx.newRequestScope(ginContext)
+	scope.ctx = ctx
+	scope.meta = settings.meta
+	scope.funcs = htmx.tenantFuncs(ginContext, settings.funcs)
+	scope.now = settings.now
+
+	tmpl, release, err := htmx.acquireTemplate(scope, settings.templateSet)
+	defer release()
+
+	renderErrors = append(renderErrors, err)
+
+	if !isHTMX && htmx.streamsLayout() && settings.templ == nil {
+		if head, tail, ok := htmx.splitLayout(ctx, tmpl, data, settings); ok {
+			htmx.preloadHeaders(ginContext)
+			htmx.writeServerTiming(ctx, ginContext, start)
+
+			errs := htmx.streamPage(ctx, ginContext, tmpl, data, settings, templateNames, head, tail)
+			htmx.finishRender(ginContext, span, RenderObservation{
+				Templates: templateNames,
+				Status:    ginContext.Writer.Status(),
+				Fragment:  false,
+				Bytes:     max(ginContext.Writer.Size(), 0) - sizeBefore,
+				Duration:  time.Since(start),
+				Err:       errors.Join(append(renderErrors, errs...)...),
+			})
+
+			return
+		}
 	}
 
-	if isHTMX {
-		ginContext.Data(http.StatusOK, "text/html; charset=utf-8", []byte(content))
+	var body []byte
+
+	if htmx.rendersFragmentDirectly(isHTMX, settings, templateNames) {
+		buffer, err := htmx.renderFragment(ctx, tmpl, templateNames[0], data)
+		defer htmx.fragments.release(templateNames[0], buffer)
+
+		buffer.WriteString(scope.titleElement())
+		renderErrors = append(renderErrors, err)
+		body = buffer.Bytes()
 	} else {
-		//nolint:gosec
-		data[htmx.config.ContentVariableName] = template.HTML(content)
-		_ = htmx.template.ExecuteTemplate(ginContext.Writer, htmx.config.LayoutTemplateName, data)
+		var errs []error
+
+		body, errs = htmx.renderBody(ctx, ginContext, scope, tmpl, data, settings, templateNames, isHTMX)
+		renderErrors = append(renderErrors, errs...)
 	}
+
+	renderErr := errors.Join(renderErrors...)
+
+	htmx.writeServerTiming(ctx, ginContext, start)
+
+	switch {
+	case ctx.Err() != nil:
+		renderErr = abortRender(ginContext, ctx.Err(), renderErr)
+	case !settings.errorResponse && errors.Is(renderErr, ErrForbidden):
+		// a guard denied a template which was only known once the names were resolved
+		htmx.With(asErrorResponse()).RenderError(ginContext, renderErr)
+	case htmx.config.Strict && renderErr != nil:
+		writeStrictError(ginContext, renderErr)
+	case htmx.config.ErrorBoundary == "" && !settings.errorResponse && errors.Is(renderErr, ErrTemplatePanic):
+		// replace the partial output of the templates with a clean error response
+		htmx.With(asErrorResponse()).RenderError(ginContext, NewHTTPError(http.StatusInternalServerError, "", renderErr))
+	default:
+		renderErr = errors.Join(renderErr, htmx.writeResponse(ginContext, status, settings.contentType(htmx), isHTMX, body))
+	}
+
+	htmx.finishRender(ginContext, span, RenderObservation{
+		Templates: templateNames,
+		Status:    ginContext.Writer.Status(),
+		Fragment:  isHTMX,
+		Bytes:     max(ginContext.Writer.Size(), 0) - sizeBefore,
+		Duration:  time.Since(start),
+		Err:       renderErr,
+	})
+}
+
+// finishRender ends the render span and records the outcome of the render.
+func (htmx *Htmx) finishRender(ginContext *gin.Context, span trace.Span, observation RenderObservation) {
+	endRenderSpan(span, observation)
+	htmx.recordRenderOutcome(ginContext, observation)
 }
 
 // Render renders the specified templates with the provided data, concatenates the
@@ -85,21 +623,217 @@ func (htmx *Htmx) Render(c *gin.Context, data gin.H, templateNames ...string) {
 	htmx.RenderWithStatus(c, data, http.StatusOK, templateNames...)
 }
 
-func (htmx *Htmx) renderTemplateToString(name string, data any) string {
-	var buf []byte
+// renderBody renders the named templates, and the layout around them if the
+// response is not a fragment, into the body of the response.
+func (htmx *Htmx) renderBody(
+	ctx context.Context, ginContext *gin.Context, scope *requestScope, tmpl *template.Template, data gin.H,
+	settings *renderSettings, templateNames []string, fragment bool,
+) ([]byte, []error) {
+	content, errs := htmx.renderContent(ctx, ginContext, tmpl, data, settings, templateNames, fragment)
+
+	if htmx.config.Debug {
+		errs = append(errs, checkSelectTargets(content, settings.selectIDs)...)
+	}
+
+	if fragment {
+		return []byte(content + scope.titleElement()), errs
+	}
+
+	htmx.preloadHeaders(ginContext)
+
+	page, err := htmx.renderLayout(ctx, tmpl, data, settings, content)
+
+	return []byte(htmx.injectLiveReload(page)), append(errs, err)
+}
+
+// renderContent renders the named templates followed by the templ component, the
+// templates appended by the render options and the Turbo Stream actions, wrapped in
+// the wrappers of the render options.
+func (htmx *Htmx) renderContent(
+	ctx context.Context, ginContext *gin.Context, tmpl *template.Template, data gin.H, settings *renderSettings,
+	templateNames []string, fragment bool,
+) (string, []error) {
+	content, errs := htmx.renderCachedTemplates(ctx, ginContext, tmpl, data, settings, templateNames, fragment)
+
+	if settings.templ != nil {
+		rendered, err := renderTempl(ctx, settings.templ)
+		content += rendered
+		errs = append(errs, err)
+	}
+
+	for _, appended := range settings.appended {
+		rendered, err := htmx.executeTemplate(ctx, tmpl, appended.name, appended.data, fragment)
+		content += rendered
+		errs = append(errs, err)
+	}
+
+	if len(settings.turboStream) > 0 {
+		rendered, streamErrs := htmx.renderTurboStream(ctx, tmpl, settings.turboStream)
+		content += rendered
+		errs = append(errs, streamErrs...)
+	}
+
+	content, wrapErrs := htmx.renderWrappers(ctx, tmpl, data, content, settings.wrappers, fragment)
+
+	return content, append(errs, wrapErrs...)
+}
+
+// renderTemplates renders each of the named templates and concatenates the results.
+func (htmx *Htmx) renderTemplates(
+	ctx context.Context, tmpl *template.Template, data gin.H, templateNames []string, fragment bool,
+) (string, []error) {
+	if htmx.config.ConcurrentRendering && len(templateNames) > 1 {
+		return htmx.renderTemplatesConcurrently(ctx, tmpl, data, templateNames, fragment)
+	}
 
-	writer := &buffer{&buf}
-	_ = htmx.template.ExecuteTemplate(writer, name, data)
+	var content string
+
+	var errs []error
+
+	for _, name := range templateNames {
+		rendered, err := htmx.executeBoundedTemplate(ctx, tmpl, name, data, fragment)
+		content += rendered
+		errs = append(errs, err)
+
+		// the remaining templates would only fail in the same way
+		if ctx.Err() != nil {
+			break
+		}
+	}
+
+	return content, errs
+}
+
+// renderTemplatesConcurrently renders each of the named templates in its own
+// goroutine and concatenates the results in order. A panic in any template is
+// propagated to the calling goroutine once every template has finished, so that it
+// is handled by the recovery middleware of the request.
+func (htmx *Htmx) renderTemplatesConcurrently(
+	ctx context.Context, tmpl *template.Template, data gin.H, templateNames []string, fragment bool,
+) (string, []error) {
+	results := make([]string, len(templateNames))
+	errs := make([]error, len(templateNames))
+	panics := make([]any, len(templateNames))
+
+	var group sync.WaitGroup
+
+	for index, name := range templateNames {
+		group.Go(func() {
+			defer func() { panics[index] = recover() }()
+
+			results[index], errs[index] = htmx.executeBoundedTemplate(ctx, tmpl, name, data, fragment)
+		})
+	}
+
+	group.Wait()
+
+	for _, recovered := range panics {
+		if recovered != nil {
+			panic(recovered)
+		}
+	}
+
+	return strings.Join(results, ""), errs
+}
+
+// renderLayout renders the layout template with content in the content variable.
+func (htmx *Htmx) renderLayout(
+	ctx context.Context, tmpl *template.Template, data gin.H, settings *renderSettings, content string,
+) (string, error) {
+	return htmx.executeTemplate(ctx, tmpl, htmx.config.LayoutTemplateName, htmx.layoutData(data, settings, content), false)
+}
+
+// executeTemplate renders the named template to a string and records the usage
+// and metrics of the execution.
+func (htmx *Htmx) executeTemplate(
+	ctx context.Context, tmpl *template.Template, name string, data any, fragment bool,
+) (string, error) {
+	var rendered strings.Builder
+
+	err := htmx.executeTemplateTo(ctx, &rendered, tmpl, name, data, fragment)
+
+	return rendered.String(), err
+}
+
+// executeTemplateTo renders the named template to writer and records the usage and
+// metrics of the execution.
+func (htmx *Htmx) executeTemplateTo(
+	ctx context.Context, writer io.Writer, tmpl *template.Template, name string, data any, fragment bool,
+) error {
+	start := time.Now()
+	requested := name
+	name = htmx.resolveTemplateName(ctx, tmpl, name)
+	span := htmx.startTemplateSpan(ctx, name)
+	counter := &countingWriter{writer: &contextWriter{ctx: ctx, writer: writer}, bytes: 0}
+	marked := htmx.config.Debug && requested != htmx.config.LayoutTemplateName
+	executor := htmx.lookupTemplate(tmpl, name)
+
+	err := htmx.guardTemplate(ctx, requested, name)
+
+	if marked && err == nil {
+		_, err = io.WriteString(writer, "<!-- begin:"+name+" -->")
+	}
+
+	switch {
+	case err != nil:
+	case ctx.Err() != nil:
+		err = fmt.Errorf("rendering %q: %w", name, ctx.Err())
+	case executor == nil:
+		err = htmx.executeFallback(ctx, counter, tmpl, name, data)
+	default:
+		err = executeRecovering(counter, executor, name, data)
+	}
+
+	if marked && err == nil {
+		_, err = io.WriteString(writer, "<!-- end:"+name+" -->")
+	}
+
+	endTemplateSpan(span, counter.bytes, err)
+	recordServerTiming(ctx, requested, time.Since(start))
+
+	htmx.usage.record(name, err)
+
+	if htmx.config.Metrics != nil {
+		htmx.config.Metrics.ObserveTemplate(TemplateObservation{
+			Template: name,
+			Fragment: fragment,
+			Bytes:    counter.bytes,
+			Duration: time.Since(start),
+			Err:      err,
+		})
+	}
+
+	return err
+}
+
+// writeResponse writes the rendered body, which has the specified content type, to
+// the response and returns the error of the write, which wraps
+// ErrClientDisconnected if the client has gone away.
+func (htmx *Htmx) writeResponse(
+	ginContext *gin.Context, status int, contentType string, fragment bool, body []byte,
+) error {
+	encoding := htmx.responseEncoding(ginContext, status, body)
+
+	if htmx.config.ETags && writeNotModified(ginContext, status, fragment, encoding, body, htmx.fragmentRequestHeaders()) {
+		return nil
+	}
+
+	if encoding != "" {
+		return writeCompressed(ginContext, status, contentType, encoding, body)
+	}
 
-	return string(*writer.buf)
+	return writeData(ginContext, status, contentType, body)
 }
 
-type buffer struct {
-	buf *[]byte
+// countingWriter counts the bytes written to the underlying writer.
+type countingWriter struct {
+	writer io.Writer
+	bytes  int
 }
 
-func (w *buffer) Write(p []byte) (int, error) {
-	*w.buf = append(*w.buf, p...)
+func (writer *countingWriter) Write(p []byte) (int, error) {
+	written, err := writer.writer.Write(p)
+	writer.bytes += written
 
-	return len(p), nil
+	return written, err //nolint:wrapcheck
 }
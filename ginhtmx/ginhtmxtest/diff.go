@@ -0,0 +1,52 @@
+package ginhtmxtest
+
+import (
+	"fmt"
+	"maps"
+	"net/http"
+	"net/http/httptest"
+
+	"github.com/gin-gonic/gin"
+	"github.com/jeffscottbrown/ginhtmxtemplates/ginhtmx"
+)
+
+// DiffRender renders the specified templates with the provided data using both
+// Htmx instances and returns the structural differences between the two results,
+// as described by ginhtmx.DiffHTML. It is intended to validate that template
+// refactors or upgrades of the template set do not change the generated markup.
+//
+// The templates are rendered as fragments through a test gin context so that any
+// configured model decorators are applied. Each instance receives its own copy of
+// data. An empty result means the two renders are structurally identical.
+func DiffRender(before *ginhtmx.Htmx, after *ginhtmx.Htmx, data gin.H, templateNames ...string) ([]string, error) {
+	beforeHTML, err := renderForDiff(before, data, templateNames)
+	if err != nil {
+		return nil, fmt.Errorf("rendering with the first instance: %w", err)
+	}
+
+	afterHTML, err := renderForDiff(after, data, templateNames)
+	if err != nil {
+		return nil, fmt.Errorf("rendering with the second instance: %w", err)
+	}
+
+	return ginhtmx.DiffHTML(beforeHTML, afterHTML) //nolint:wrapcheck
+}
+
+func renderForDiff(htmx *ginhtmx.Htmx, data gin.H, templateNames []string) (string, error) {
+	recorder := httptest.NewRecorder()
+	testContext, _ := gin.CreateTestContext(recorder)
+	testContext.Request = httptest.NewRequest(http.MethodGet, "/", nil)
+
+	model := maps.Clone(data)
+	if model == nil {
+		model = gin.H{}
+	}
+
+	htmx.With(ginhtmx.ForceFragment()).Render(testContext, model, templateNames...)
+
+	if renderErr, _ := testContext.Value(ginhtmx.RenderErrorKey).(error); renderErr != nil {
+		return "", renderErr
+	}
+
+	return recorder.Body.String(), nil
+}
@@ -0,0 +1,66 @@
+package ginhtmxtest_test
+
+import (
+	"html/template"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/jeffscottbrown/ginhtmxtemplates/ginhtmx"
+	"github.com/jeffscottbrown/ginhtmxtemplates/ginhtmx/ginhtmxtest"
+	"github.com/stretchr/testify/suite"
+)
+
+func (suite *DiffTestSuite) TestDiffRenderComparesTwoInstances() {
+	before := ginhtmx.NewHtmx(template.Must(template.New("").Parse(
+		`{{define "hello"}}<h1>Hello, {{.Name}}!</h1>{{end}}`)))
+	same := ginhtmx.NewHtmx(template.Must(template.New("").Parse(
+		`{{define "hello"}}
+		  <h1>Hello, {{.Name}}!</h1>
+		{{end}}`)))
+	changed := ginhtmx.NewHtmx(template.Must(template.New("").Parse(
+		`{{define "hello"}}<h2>Hello, {{.Name}}!</h2>{{end}}`)))
+
+	differences, err := ginhtmxtest.DiffRender(before, same, gin.H{"Name": "Jerry"}, "hello")
+	suite.Require().NoError(err)
+	suite.Empty(differences)
+
+	differences, err = ginhtmxtest.DiffRender(before, changed, gin.H{"Name": "Jerry"}, "hello")
+	suite.Require().NoError(err)
+	suite.Equal([]string{"/html[0]/body[1]/h1[0]: <h1> changed to <h2>"}, differences)
+}
+
+func (suite *DiffTestSuite) TestDiffRenderReportsRenderErrors() {
+	valid := ginhtmx.NewHtmx(template.Must(template.New("").Parse(`{{define "hello"}}Hello{{end}}`)))
+	empty := ginhtmx.NewHtmx(template.Must(template.New("").Parse(``)))
+
+	_, err := ginhtmxtest.DiffRender(empty, valid, gin.H{}, "hello")
+	suite.Require().ErrorContains(err, "first instance")
+
+	_, err = ginhtmxtest.DiffRender(valid, empty, gin.H{}, "hello")
+	suite.Require().ErrorContains(err, "second instance")
+}
+
+func (suite *DiffTestSuite) TestDiffRenderUsesConfiguredHTMXRequestHeaders() {
+	tmpl := template.Must(template.New("").Parse(
+		`{{define "layout"}}<main>{{.Content}}</main>{{end}}{{define "hello"}}<p>Hello</p>{{end}}`))
+	config := ginhtmx.HtmxConfig{
+		LayoutTemplateName:  "layout",
+		ContentVariableName: "Content",
+		HTMXRequestHeaders:  []string{"X-HX-Request"},
+	}
+
+	configured := ginhtmx.NewHtmxWithConfig(tmpl, config)
+
+	differences, err := ginhtmxtest.DiffRender(configured, ginhtmx.NewHtmx(tmpl), nil, "hello")
+	suite.Require().NoError(err)
+	suite.Empty(differences)
+}
+
+func TestDiffTestSuite(t *testing.T) {
+	t.Parallel()
+	suite.Run(t, new(DiffTestSuite))
+}
+
+type DiffTestSuite struct {
+	suite.Suite
+}
@@ -0,0 +1,72 @@
+// Package ginhtmxtest provides helpers for testing the templates rendered by
+// ginhtmx. Each helper renders templates through a real gin test context in the
+// same way as a handler would, fails the test if rendering fails and returns the
+// response parsed as a goquery document so that tests can query it:
+//
+//	func TestUserList(t *testing.T) {
+//	  doc := ginhtmxtest.RenderFragment(t, htmx, "user_list", gin.H{"Users": users})
+//	  if got := doc.Find("li.user").Length(); got != len(users) {
+//	    t.Errorf("rendered %d users, want %d", got, len(users))
+//	  }
+//	}
+package ginhtmxtest
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/PuerkitoBio/goquery"
+	"github.com/gin-gonic/gin"
+	"github.com/jeffscottbrown/ginhtmxtemplates/ginhtmx"
+)
+
+// RenderFragment renders the named template as the fragment returned for an HTMX
+// request and returns the parsed response. The response is parsed as an HTML
+// document, so elements which are only valid within others, such as a bare <tr>,
+// are dropped by the parser and should be rendered within their parent to be
+// found. The fragment is rendered even if HTMXRequestHeaders is configured without
+// the default header.
+func RenderFragment(t testing.TB, htmx *ginhtmx.Htmx, name string, data gin.H) *goquery.Document {
+	t.Helper()
+
+	request := httptest.NewRequest(http.MethodGet, "/", nil)
+	request.Header.Set(ginhtmx.DefaultHTMXRequestHeader, "true")
+
+	return Render(t, htmx.With(ginhtmx.ForceFragment()), request, data, name)
+}
+
+// RenderPage renders the named template as the full page, wrapped in the layout,
+// returned for a request which is not made by HTMX and returns the parsed response.
+func RenderPage(t testing.TB, htmx *ginhtmx.Htmx, name string, data gin.H) *goquery.Document {
+	t.Helper()
+
+	return Render(t, htmx, httptest.NewRequest(http.MethodGet, "/", nil), data, name)
+}
+
+// Render renders the templates in response to request and returns the parsed
+// response, allowing tests to control the headers and query parameters which
+// decide how the templates are rendered. The test fails if rendering the
+// templates returns an error or the response cannot be parsed.
+func Render(
+	t testing.TB, htmx *ginhtmx.Htmx, request *http.Request, data gin.H, templateNames ...string,
+) *goquery.Document {
+	t.Helper()
+
+	recorder := httptest.NewRecorder()
+	testContext, _ := gin.CreateTestContext(recorder)
+	testContext.Request = request
+
+	htmx.Render(testContext, data, templateNames...)
+
+	if renderErr, _ := testContext.Value(ginhtmx.RenderErrorKey).(error); renderErr != nil {
+		t.Fatalf("rendering %v failed: %v", templateNames, renderErr)
+	}
+
+	doc, err := goquery.NewDocumentFromReader(recorder.Body)
+	if err != nil {
+		t.Fatalf("parsing the response of %v failed: %v", templateNames, err)
+	}
+
+	return doc
+}
@@ -0,0 +1,122 @@
+package ginhtmxtest_test
+
+import (
+	"fmt"
+	"html/template"
+	"net/http"
+	"net/http/httptest"
+	"runtime"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/jeffscottbrown/ginhtmxtemplates/ginhtmx"
+	"github.com/jeffscottbrown/ginhtmxtemplates/ginhtmx/ginhtmxtest"
+	"github.com/stretchr/testify/suite"
+)
+
+func (suite *GinhtmxtestTestSuite) TestRenderFragmentRendersWithoutTheLayout() {
+	doc := ginhtmxtest.RenderFragment(suite.T(), suite.htmx, "users", gin.H{"Users": []string{"ann", "bob"}})
+
+	suite.Equal(2, doc.Find("ul.users li").Length())
+	suite.Equal("bob", doc.Find("li").Last().Text())
+	suite.Zero(doc.Find("nav").Length())
+}
+
+func (suite *GinhtmxtestTestSuite) TestRenderFragmentUsesConfiguredHTMXRequestHeaders() {
+	htmx := ginhtmx.NewHtmxWithConfig(suite.template, ginhtmx.HtmxConfig{
+		LayoutTemplateName:  "layout",
+		ContentVariableName: "Content",
+		HTMXRequestHeaders:  []string{"X-HX-Request"},
+	})
+
+	doc := ginhtmxtest.RenderFragment(suite.T(), htmx, "users", gin.H{"Users": []string{"ann"}})
+
+	suite.Zero(doc.Find("nav").Length())
+}
+
+func (suite *GinhtmxtestTestSuite) TestRenderPageRendersWithTheLayout() {
+	doc := ginhtmxtest.RenderPage(suite.T(), suite.htmx, "users", gin.H{"Users": []string{"ann"}})
+
+	suite.Equal("Menu", doc.Find("body > nav").Text())
+	suite.Equal("ann", doc.Find("main ul.users li").Text())
+}
+
+func (suite *GinhtmxtestTestSuite) TestRenderUsesTheRequest() {
+	request := httptest.NewRequest(http.MethodGet, "/users", nil)
+	request.Header.Set("Turbo-Frame", "users")
+
+	doc := ginhtmxtest.Render(suite.T(), suite.htmx, request, gin.H{"Users": []string{}}, "users")
+
+	suite.Zero(doc.Find("nav").Length())
+	suite.Equal(1, doc.Find("ul.users").Length())
+}
+
+func (suite *GinhtmxtestTestSuite) TestRenderErrorsFailTheTest() {
+	recorder := &recordingTB{TB: suite.T(), name: "", failures: nil}
+
+	runHelper(func() {
+		ginhtmxtest.RenderFragment(recorder, suite.htmx, "missing", gin.H{})
+	})
+
+	suite.Require().Len(recorder.failures, 1)
+	suite.Contains(recorder.failures[0], "rendering [missing] failed:")
+}
+
+func (suite *GinhtmxtestTestSuite) SetupTest() {
+	suite.template = template.Must(template.New("").Parse(`
+{{define "layout"}}<html><body><nav>Menu</nav><main>{{.Content}}</main></body></html>{{end}}
+{{define "users"}}<ul class="users">{{range .Users}}<li>{{.}}</li>{{end}}</ul>{{end}}
+`))
+	suite.htmx = ginhtmx.NewHtmxWithConfig(suite.template, ginhtmx.HtmxConfig{
+		LayoutTemplateName:  "layout",
+		ContentVariableName: "Content",
+	})
+}
+
+// runHelper runs helper on its own goroutine and waits for it to return, as
+// recordingTB.Fatalf stops the goroutine which calls it.
+func runHelper(helper func()) {
+	done := make(chan struct{})
+
+	go func() {
+		defer close(done)
+		helper()
+	}()
+	<-done
+}
+
+func TestGinhtmxtestTestSuite(t *testing.T) {
+	t.Parallel()
+	suite.Run(t, new(GinhtmxtestTestSuite))
+}
+
+type GinhtmxtestTestSuite struct {
+	suite.Suite
+
+	template *template.Template
+	htmx     *ginhtmx.Htmx
+}
+
+// recordingTB records the failures reported by the helpers instead of failing the
+// test running them.
+type recordingTB struct {
+	testing.TB
+
+	name     string
+	failures []string
+}
+
+func (tb *recordingTB) Helper() {}
+
+func (tb *recordingTB) Name() string {
+	return tb.name
+}
+
+func (tb *recordingTB) Errorf(format string, args ...any) {
+	tb.failures = append(tb.failures, fmt.Sprintf(format, args...))
+}
+
+func (tb *recordingTB) Fatalf(format string, args ...any) {
+	tb.Errorf(format, args...)
+	runtime.Goexit()
+}
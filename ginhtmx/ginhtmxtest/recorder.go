@@ -0,0 +1,113 @@
+package ginhtmxtest
+
+import (
+	"maps"
+	"net/http"
+	"slices"
+	"sync"
+
+	"github.com/gin-gonic/gin"
+)
+
+// RenderCall is a render recorded by a RecorderRenderer.
+type RenderCall struct {
+	// Templates are the names of the templates which would have been rendered
+	Templates []string
+
+	// Data is the model passed to the render
+	Data gin.H
+
+	// Status is the status code of the response
+	Status int
+
+	// Headers are the response headers set when the render was requested, such as
+	// those added by ginhtmx.AddUpEvent
+	Headers http.Header
+}
+
+// RecorderRenderer is a ginhtmx.Renderer which records each render instead of
+// executing templates, so that unit tests of handlers can assert what was rendered
+// without parsing HTML:
+//
+//	renderer := &ginhtmxtest.RecorderRenderer{}
+//	handler := &userHandler{renderer: renderer}
+//	handler.list(c)
+//
+//	call := renderer.Last()
+//	assert.Equal(t, []string{"user_list"}, call.Templates)
+//	assert.Len(t, call.Data["Users"], 3)
+//
+// The status of each render is written to the response, which is otherwise left
+// empty. The zero value is ready to use and a RecorderRenderer may be used by
+// concurrent requests.
+type RecorderRenderer struct {
+	mutex sync.Mutex
+	calls []RenderCall
+}
+
+// Render records a render of the templates with data and a 200 status code.
+func (renderer *RecorderRenderer) Render(ginContext *gin.Context, data gin.H, templateNames ...string) {
+	renderer.RenderWithStatus(ginContext, data, http.StatusOK, templateNames...)
+}
+
+// RenderWithStatus records a render of the templates with data and the status code.
+func (renderer *RecorderRenderer) RenderWithStatus(
+	ginContext *gin.Context, data gin.H, status int, templateNames ...string,
+) {
+	renderer.mutex.Lock()
+	renderer.calls = append(renderer.calls, RenderCall{
+		Templates: slices.Clone(templateNames),
+		Data:      maps.Clone(data),
+		Status:    status,
+		Headers:   ginContext.Writer.Header().Clone(),
+	})
+	renderer.mutex.Unlock()
+
+	ginContext.Status(status)
+	ginContext.Writer.WriteHeaderNow()
+}
+
+// Calls returns the renders recorded so far, in the order they were requested.
+func (renderer *RecorderRenderer) Calls() []RenderCall {
+	renderer.mutex.Lock()
+	defer renderer.mutex.Unlock()
+
+	return slices.Clone(renderer.calls)
+}
+
+// Last returns the most recently recorded render, or the zero RenderCall if
+// nothing has been rendered.
+func (renderer *RecorderRenderer) Last() RenderCall {
+	renderer.mutex.Lock()
+	defer renderer.mutex.Unlock()
+
+	if len(renderer.calls) == 0 {
+		return RenderCall{Templates: nil, Data: nil, Status: 0, Headers: nil}
+	}
+
+	return renderer.calls[len(renderer.calls)-1]
+}
+
+// Rendered returns the recorded renders which included the named template.
+func (renderer *RecorderRenderer) Rendered(name string) []RenderCall {
+	renderer.mutex.Lock()
+	defer renderer.mutex.Unlock()
+
+	var calls []RenderCall
+
+	for _, call := range renderer.calls {
+		if slices.Contains(call.Templates, name) {
+			calls = append(calls, call)
+		}
+	}
+
+	return calls
+}
+
+// Reset discards the recorded renders.
+func (renderer *RecorderRenderer) Reset() {
+	renderer.mutex.Lock()
+	defer renderer.mutex.Unlock()
+
+	renderer.calls = nil
+}
@@ -0,0 +1,87 @@
+package ginhtmxtest_test
+
+import (
+	"html/template"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/jeffscottbrown/ginhtmxtemplates/ginhtmx"
+	"github.com/jeffscottbrown/ginhtmxtemplates/ginhtmx/ginhtmxtest"
+	"github.com/stretchr/testify/suite"
+)
+
+func (suite *RecorderTestSuite) TestRendersAreRecordedWithoutExecutingTemplates() {
+	recorder := suite.handle(suite.listUsers(suite.renderer))
+
+	call := suite.renderer.Last()
+	suite.Equal([]string{"user_list"}, call.Templates)
+	suite.Len(call.Data["Users"], 3)
+	suite.Equal(http.StatusOK, call.Status)
+	suite.Equal(`[{"type":"users:listed"}]`, call.Headers.Get("X-Up-Events"))
+
+	suite.Equal(http.StatusOK, recorder.Code)
+	suite.Empty(recorder.Body.String())
+}
+
+func (suite *RecorderTestSuite) TestRendersAreRecordedInOrder() {
+	suite.handle(suite.listUsers(suite.renderer))
+	recorder := suite.handle(func(c *gin.Context) {
+		var renderer ginhtmx.Renderer = suite.renderer
+
+		renderer.RenderWithStatus(c, gin.H{"Error": "missing"}, http.StatusNotFound, "error", "user_list")
+	})
+
+	suite.Equal(http.StatusNotFound, recorder.Code)
+	suite.Len(suite.renderer.Calls(), 2)
+	suite.Equal(http.StatusNotFound, suite.renderer.Last().Status)
+	suite.Len(suite.renderer.Rendered("user_list"), 2)
+	suite.Len(suite.renderer.Rendered("error"), 1)
+	suite.Empty(suite.renderer.Rendered("user"))
+
+	suite.renderer.Reset()
+	suite.Empty(suite.renderer.Calls())
+	suite.Zero(suite.renderer.Last().Status)
+}
+
+func (suite *RecorderTestSuite) TestHtmxIsARenderer() {
+	tmpl := template.Must(template.New("").Parse(`{{define "user_list"}}{{len .Users}} users{{end}}`))
+	htmx := ginhtmx.NewHtmxWithConfig(tmpl, ginhtmx.HtmxConfig{LayoutTemplateName: "", ContentVariableName: ""})
+
+	recorder := suite.handle(suite.listUsers(htmx.With(ginhtmx.ForceFragment())))
+
+	suite.Equal("3 users", recorder.Body.String())
+}
+
+func (suite *RecorderTestSuite) listUsers(renderer ginhtmx.Renderer) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		_ = ginhtmx.AddUpEvent(c, "users:listed", nil)
+		renderer.Render(c, gin.H{"Users": []string{"ann", "bob", "cat"}}, "user_list")
+	}
+}
+
+func (suite *RecorderTestSuite) handle(handler gin.HandlerFunc) *httptest.ResponseRecorder {
+	recorder := httptest.NewRecorder()
+	testContext, _ := gin.CreateTestContext(recorder)
+	testContext.Request = httptest.NewRequest(http.MethodGet, "/users", nil)
+
+	handler(testContext)
+
+	return recorder
+}
+
+func (suite *RecorderTestSuite) SetupTest() {
+	suite.renderer = &ginhtmxtest.RecorderRenderer{}
+}
+
+func TestRecorderTestSuite(t *testing.T) {
+	t.Parallel()
+	suite.Run(t, new(RecorderTestSuite))
+}
+
+type RecorderTestSuite struct {
+	suite.Suite
+
+	renderer *ginhtmxtest.RecorderRenderer
+}
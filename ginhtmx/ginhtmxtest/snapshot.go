@@ -0,0 +1,166 @@
+package ginhtmxtest
+
+import (
+	"errors"
+	"flag"
+	"html/template"
+	"io/fs"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/jeffscottbrown/ginhtmxtemplates/ginhtmx"
+	"github.com/pmezard/go-difflib/difflib"
+)
+
+// SnapshotTime is the time treated as now by snapshot renders.
+var SnapshotTime = time.Date(2025, time.January, 1, 12, 0, 0, 0, time.UTC)
+
+const (
+	// SnapshotNonce is the value returned by the "nonce" function during snapshot renders.
+	SnapshotNonce = "snapshot-nonce"
+
+	// SnapshotRequestID is the ID of the requests of snapshot renders, see
+	// ginhtmx.Htmx.RequestID.
+	SnapshotRequestID = "snapshot-request-id"
+)
+
+// update is set by running the tests with -update, which rewrites the golden files
+// with the current output instead of comparing against them.
+var update = flag.Bool("update", false, "update the golden files of ginhtmxtest snapshots")
+
+var (
+	// tagBoundary matches the whitespace between two tags.
+	tagBoundary = regexp.MustCompile(`>\s*<`)
+	// whitespace matches runs of whitespace.
+	whitespace = regexp.MustCompile(`\s+`)
+)
+
+// SnapshotFragment renders the named template as the fragment returned for an HTMX
+// request and compares the output with the golden file of the test, as described
+// by Snapshot.
+func SnapshotFragment(t testing.TB, htmx *ginhtmx.Htmx, name string, data gin.H) {
+	t.Helper()
+
+	request := httptest.NewRequest(http.MethodGet, "/", nil)
+	request.Header.Set(ginhtmx.DefaultHTMXRequestHeader, "true")
+
+	Snapshot(t, htmx.With(ginhtmx.ForceFragment()), request, data, name)
+}
+
+// SnapshotPage renders the named template as the full page, wrapped in the layout,
+// and compares the output with the golden file of the test, as described by
+// Snapshot.
+func SnapshotPage(t testing.TB, htmx *ginhtmx.Htmx, name string, data gin.H) {
+	t.Helper()
+
+	Snapshot(t, htmx, httptest.NewRequest(http.MethodGet, "/", nil), data, name)
+}
+
+// Snapshot renders the templates in response to request and compares the output
+// with the golden file testdata/<test name>.golden, failing the test with a diff of
+// the two when they differ, so that refactoring templates shows exactly how the
+// markup changed. Running the tests with -update writes the output to the golden
+// files instead:
+//
+//	go test ./... -update
+//
+// The templates are rendered deterministically. The time functions, such as
+// "timeAgo", treat SnapshotTime as now, the template functions "now" and "nonce"
+// are replaced with functions returning SnapshotTime and SnapshotNonce, and the ID
+// of the request is SnapshotRequestID. Other functions may be replaced using
+// ginhtmx.Funcs. The output is normalized before it is compared by placing each
+// tag on its own line and collapsing runs of whitespace, so changes to
+// insignificant whitespace do not fail the test.
+func Snapshot(t testing.TB, htmx *ginhtmx.Htmx, request *http.Request, data gin.H, templateNames ...string) {
+	t.Helper()
+
+	stable := htmx.With(
+		ginhtmx.Clock(func() time.Time { return SnapshotTime }),
+		ginhtmx.Funcs(template.FuncMap{
+			"now":   func() time.Time { return SnapshotTime },
+			"nonce": func() string { return SnapshotNonce },
+		}),
+	)
+
+	recorder := httptest.NewRecorder()
+	testContext, _ := gin.CreateTestContext(recorder)
+	testContext.Request = request
+	testContext.Set(ginhtmx.RequestIDKey, SnapshotRequestID)
+
+	stable.Render(testContext, data, templateNames...)
+
+	if renderErr, _ := testContext.Value(ginhtmx.RenderErrorKey).(error); renderErr != nil {
+		t.Fatalf("rendering %v failed: %v", templateNames, renderErr)
+	}
+
+	compareGolden(t, filepath.Join("testdata", filepath.FromSlash(t.Name())+".golden"),
+		NormalizeHTML(recorder.Body.String()))
+}
+
+// NormalizeHTML returns markup with each tag on its own line and runs of whitespace
+// collapsed to a single space, which is the form in which snapshots are compared.
+// Whitespace within elements such as <pre> is collapsed too.
+func NormalizeHTML(markup string) string {
+	var normalized strings.Builder
+
+	for line := range strings.Lines(tagBoundary.ReplaceAllString(markup, ">\n<")) {
+		line = strings.TrimSpace(whitespace.ReplaceAllString(line, " "))
+		if line != "" {
+			normalized.WriteString(line + "\n")
+		}
+	}
+
+	return normalized.String()
+}
+
+// compareGolden fails the test if actual differs from the golden file at path, or
+// writes actual to the file when the tests are run with -update.
+func compareGolden(t testing.TB, path string, actual string) {
+	t.Helper()
+
+	if *update {
+		err := os.MkdirAll(filepath.Dir(path), 0o750)
+		if err == nil {
+			err = os.WriteFile(path, []byte(actual), 0o600)
+		}
+
+		if err != nil {
+			t.Fatalf("updating golden file %s: %v", path, err)
+		}
+
+		return
+	}
+
+	expected, err := os.ReadFile(path) //nolint:gosec
+	if errors.Is(err, fs.ErrNotExist) {
+		t.Fatalf("golden file %s does not exist, run the tests with -update to create it", path)
+	}
+
+	if err != nil {
+		t.Fatalf("reading golden file %s: %v", path, err)
+	}
+
+	if string(expected) == actual {
+		return
+	}
+
+	diff, _ := difflib.GetUnifiedDiffString(difflib.UnifiedDiff{
+		A:        difflib.SplitLines(string(expected)),
+		FromFile: path,
+		FromDate: "",
+		B:        difflib.SplitLines(actual),
+		ToFile:   "rendered",
+		ToDate:   "",
+		Eol:      "",
+		Context:  3,
+	})
+
+	t.Errorf("rendered output differs from golden file %s, run the tests with -update to accept it:\n%s", path, diff)
+}
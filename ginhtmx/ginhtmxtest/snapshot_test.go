@@ -0,0 +1,133 @@
+package ginhtmxtest_test
+
+import (
+	"flag"
+	"html/template"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/jeffscottbrown/ginhtmxtemplates/ginhtmx"
+	"github.com/jeffscottbrown/ginhtmxtemplates/ginhtmx/ginhtmxtest"
+	"github.com/stretchr/testify/suite"
+)
+
+func (suite *SnapshotTestSuite) TestSnapshotFragmentMatchesTheGoldenFile() {
+	ginhtmxtest.SnapshotFragment(suite.T(), suite.htmx, "comment", suite.comment())
+}
+
+func (suite *SnapshotTestSuite) TestSnapshotPageMatchesTheGoldenFile() {
+	ginhtmxtest.SnapshotPage(suite.T(), suite.htmx, "comment", suite.comment())
+}
+
+func (suite *SnapshotTestSuite) TestDifferencesFailWithADiff() {
+	recorder := &recordingTB{TB: suite.T(), name: "TestSnapshotTestSuite/changed", failures: nil}
+
+	runHelper(func() {
+		ginhtmxtest.SnapshotFragment(recorder, suite.htmx, "comment", suite.comment())
+	})
+
+	suite.Require().Len(recorder.failures, 1)
+	suite.Contains(recorder.failures[0],
+		"rendered output differs from golden file testdata/TestSnapshotTestSuite/changed.golden")
+	suite.Contains(recorder.failures[0], `
+-<p class="body">Hello</p>
++<p class="body">Hi there</p>
+`)
+}
+
+func (suite *SnapshotTestSuite) TestMissingGoldenFilesFail() {
+	recorder := &recordingTB{TB: suite.T(), name: "TestSnapshotTestSuite/missing", failures: nil}
+
+	runHelper(func() {
+		ginhtmxtest.SnapshotFragment(recorder, suite.htmx, "comment", suite.comment())
+	})
+
+	suite.Equal([]string{
+		"golden file testdata/TestSnapshotTestSuite/missing.golden does not exist, " +
+			"run the tests with -update to create it",
+	}, recorder.failures)
+}
+
+func (suite *SnapshotTestSuite) TestRenderErrorsFail() {
+	recorder := &recordingTB{TB: suite.T(), name: "TestSnapshotTestSuite/missing", failures: nil}
+
+	runHelper(func() {
+		ginhtmxtest.SnapshotPage(recorder, suite.htmx, "missing", gin.H{})
+	})
+
+	suite.Require().Len(recorder.failures, 1)
+	suite.Contains(recorder.failures[0], "rendering [missing] failed:")
+}
+
+func (suite *SnapshotTestSuite) TestNormalizeHTMLPlacesTagsOnTheirOwnLines() {
+	suite.Equal("<ul>\n<li>a b</li>\n<li>\nc\n</li>\n</ul>\n",
+		ginhtmxtest.NormalizeHTML("  <ul><li>a   b</li>\n\n  <li>\n  c\n  </li></ul>\n"))
+}
+
+func (suite *SnapshotTestSuite) comment() gin.H {
+	return gin.H{"Author": "ann", "Body": "Hi there", "Posted": ginhtmxtest.SnapshotTime.Add(-3 * time.Hour)}
+}
+
+func (suite *SnapshotTestSuite) SetupTest() {
+	suite.htmx = newSnapshotHtmx()
+}
+
+// TestUpdateWritesTheGoldenFiles changes the working directory and the -update
+// flag, so it does not run in parallel with the other tests.
+func TestUpdateWritesTheGoldenFiles(t *testing.T) { //nolint:paralleltest
+	t.Chdir(t.TempDir())
+
+	err := flag.Set("update", "true")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	defer func() { _ = flag.Set("update", "false") }()
+
+	ginhtmxtest.SnapshotFragment(t, newSnapshotHtmx(), "comment", gin.H{"Author": "bob", "Body": "Hello"})
+
+	golden, err := os.ReadFile(filepath.Join("testdata", "TestUpdateWritesTheGoldenFiles.golden"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if got := string(golden); got != "<article>\n<h2>bob</h2>\n<p class=\"body\">Hello</p>\n</article>\n" {
+		t.Errorf("golden file contains %q", got)
+	}
+}
+
+func newSnapshotHtmx() *ginhtmx.Htmx {
+	tmpl := template.Must(template.New("").Funcs(ginhtmx.FuncMap()).Funcs(template.FuncMap{
+		"now":   time.Now,
+		"nonce": func() string { return "random" },
+	}).Parse(`
+{{define "layout"}}<html>
+  <head><script nonce="{{nonce}}"></script></head>
+  <body>{{.Content}}<footer>{{now.Year}} {{requestID}}</footer></body>
+</html>{{end}}
+{{define "comment"}}<article>
+  <h2>{{.Author}}</h2>
+  <p class="body">{{.Body}}</p>
+  {{with .Posted}}<time>{{timeAgo .}}</time>{{end}}
+</article>{{end}}
+`))
+
+	return ginhtmx.NewHtmxWithConfig(tmpl, ginhtmx.HtmxConfig{
+		LayoutTemplateName:  "layout",
+		ContentVariableName: "Content",
+	})
+}
+
+func TestSnapshotTestSuite(t *testing.T) {
+	t.Parallel()
+	suite.Run(t, new(SnapshotTestSuite))
+}
+
+type SnapshotTestSuite struct {
+	suite.Suite
+
+	htmx *ginhtmx.Htmx
+}
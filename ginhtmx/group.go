@@ -0,0 +1,77 @@
+package ginhtmx
+
+import (
+	"slices"
+
+	"github.com/gin-gonic/gin"
+)
+
+// GroupConfig holds the settings of a section of the application, see Group.
+type GroupConfig struct {
+	// Layout is the name of the layout template of the section. If empty, the
+	// layout of the parent is used.
+	Layout string
+
+	// ContentVariable is the name of the variable which holds the content in the
+	// layout of the section. If empty, the content variable of the parent is used.
+	ContentVariable string
+
+	// Decorators modify the model of every render in the section, after the
+	// ModelDecorator of the parent.
+	Decorators []ModelDecorator
+
+	// Options are applied to every render in the section, after the options of
+	// the parent.
+	Options []RenderOption
+
+	// TemplateSet is the name of the template set rendered by the section, see
+	// AddTemplateSet. If empty, the template set of the parent is used.
+	TemplateSet string
+}
+
+// HtmxGroup renders the templates of a section of the application, such as an
+// admin area, with its own layout and decorators. It is an Htmx which shares the
+// templates of the Htmx it was created from, along with the router group of the
+// section.
+type HtmxGroup struct {
+	*Htmx
+
+	// Router is the router group of the section.
+	Router *gin.RouterGroup
+}
+
+// Group returns an HtmxGroup for the section of the application served by router,
+// whose renders use the layout, decorators and options of config:
+//
+//	admin := htmx.Group(router.Group("/admin"), ginhtmx.GroupConfig{
+//	  Layout:     "admin_layout",
+//	  Decorators: []ginhtmx.ModelDecorator{adminMenu},
+//	})
+//	admin.Router.GET("/users", func(c *gin.Context) {
+//	  admin.Render(c, gin.H{"Users": users}, "users")
+//	})
+//
+// Groups may be nested by calling Group on a group.
+func (htmx *Htmx) Group(router *gin.RouterGroup, config GroupConfig) *HtmxGroup {
+	derived := *htmx
+	derived.options = append(slices.Clip(htmx.options), config.Options...)
+
+	if config.TemplateSet != "" {
+		derived.options = append(derived.options, TemplateSet(config.TemplateSet))
+	}
+
+	if config.Layout != "" {
+		derived.config.LayoutTemplateName = config.Layout
+	}
+
+	if config.ContentVariable != "" {
+		derived.config.ContentVariableName = config.ContentVariable
+	}
+
+	if len(config.Decorators) > 0 {
+		derived.config.ModelDecorator = ModelDecorators(append([]ModelDecorator{htmx.config.ModelDecorator},
+			config.Decorators...)...)
+	}
+
+	return &HtmxGroup{Htmx: &derived, Router: router}
+}
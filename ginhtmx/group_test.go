@@ -0,0 +1,78 @@
+package ginhtmx_test
+
+import (
+	"html/template"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/jeffscottbrown/ginhtmxtemplates/ginhtmx"
+	"github.com/stretchr/testify/suite"
+)
+
+func (suite *GroupTestSuite) TestGroupsUseTheirOwnLayoutAndDecorators() {
+	router := gin.New()
+	admin := suite.htmx.Group(router.Group("/admin"), ginhtmx.GroupConfig{
+		Layout:          "admin_layout",
+		ContentVariable: "Body",
+		Decorators:      []ginhtmx.ModelDecorator{suite.set("Section", "Admin")},
+		Options:         []ginhtmx.RenderOption{ginhtmx.Title("Admin")},
+	})
+
+	admin.Router.GET("/users", func(c *gin.Context) { admin.Render(c, gin.H{}, "page") })
+	router.GET("/", func(c *gin.Context) { suite.htmx.Render(c, gin.H{}, "page") })
+
+	suite.Equal("<admin><title>Admin</title>Site Admin</admin>", suite.serve(router, "/admin/users"))
+	suite.Equal("<main>Site </main>", suite.serve(router, "/"))
+}
+
+func (suite *GroupTestSuite) TestGroupsMayBeNested() {
+	router := gin.New()
+	admin := suite.htmx.Group(router.Group("/admin"), ginhtmx.GroupConfig{Layout: "admin_layout", ContentVariable: "Body"})
+	reports := admin.Group(admin.Router.Group("/reports"), ginhtmx.GroupConfig{
+		Decorators: []ginhtmx.ModelDecorator{suite.set("Section", "Reports")},
+	})
+
+	reports.Router.GET("/", func(c *gin.Context) { reports.Render(c, gin.H{}, "page") })
+
+	suite.Equal("<admin><title></title>Site Reports</admin>", suite.serve(router, "/admin/reports/"))
+}
+
+func (suite *GroupTestSuite) set(key string, value string) ginhtmx.ModelDecorator {
+	return ginhtmx.ModelDecoratorFunc(func(_ *gin.Context, model *gin.H) {
+		(*model)[key] = value
+	})
+}
+
+func (suite *GroupTestSuite) serve(router *gin.Engine, path string) string {
+	recorder := httptest.NewRecorder()
+	router.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, path, nil))
+
+	return recorder.Body.String()
+}
+
+func (suite *GroupTestSuite) SetupTest() {
+	tmpl := template.Must(template.New("").Funcs(ginhtmx.FuncMap()).Parse(`
+{{define "layout"}}<main>{{.Content}}</main>{{end}}
+{{define "admin_layout"}}<admin><title>{{pageTitle}}</title>{{.Body}}</admin>{{end}}
+{{define "page"}}{{.Site}} {{.Section}}{{end}}
+`))
+
+	suite.htmx = ginhtmx.NewHtmxWithConfig(tmpl, ginhtmx.HtmxConfig{
+		LayoutTemplateName:  "layout",
+		ContentVariableName: "Content",
+		ModelDecorator:      suite.set("Site", "Site"),
+	})
+}
+
+func TestGroupTestSuite(t *testing.T) {
+	t.Parallel()
+	suite.Run(t, new(GroupTestSuite))
+}
+
+type GroupTestSuite struct {
+	suite.Suite
+
+	htmx *ginhtmx.Htmx
+}
@@ -0,0 +1,107 @@
+package ginhtmx
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"slices"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ErrForbidden is wrapped by the error reported when a render is denied by a guard
+// or the Authorizer of the configuration.
+var ErrForbidden = errors.New("forbidden")
+
+// RequirePermission returns a RenderOption which renders the templates only if the
+// Authorizer of the configuration grants permission to the request, and otherwise
+// renders a 403 Forbidden response. It is most useful for groups of routes:
+//
+//	admin := htmx.Group(router.Group("/admin"), ginhtmx.GroupConfig{
+//	  Options: []ginhtmx.RenderOption{ginhtmx.RequirePermission("admin")},
+//	})
+func RequirePermission(permission string) RenderOption {
+	return func(settings *renderSettings) {
+		settings.permissions = append(settings.permissions, permission)
+	}
+}
+
+// guardedRequestKey is the context key of the gin context of the request whose
+// templates are checked against the Guards of the configuration.
+type guardedRequestKey struct{}
+
+// authorize returns an HTTPError with the status 403 if the request is denied a
+// permission required by the settings or by the guard of one of the templates,
+// including the templates of Turbo Stream actions and appended templates, and the
+// templates they are aliases of. Guards of the variants of templates chosen by
+// themes and experiments are checked by guardTemplate as the templates are
+// rendered.
+func (htmx *Htmx) authorize(ginContext *gin.Context, settings *renderSettings, templateNames []string) error {
+	if settings.errorResponse {
+		return nil
+	}
+
+	for _, permission := range settings.permissions {
+		if htmx.config.Authorizer == nil || !htmx.config.Authorizer(ginContext, permission) {
+			return NewHTTPError(http.StatusForbidden, "",
+				fmt.Errorf("%w: permission %q is required", ErrForbidden, permission))
+		}
+	}
+
+	names := slices.Clone(templateNames)
+
+	for _, appended := range settings.appended {
+		names = append(names, appended.name)
+	}
+
+	for _, action := range settings.turboStream {
+		names = append(names, action.Template)
+	}
+
+	for _, name := range names {
+		err := htmx.checkGuards(ginContext, name, htmx.templates.alias(name))
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// withGuards returns a context in which the templates rendered are checked against
+// the Guards of the configuration for the request of ginContext.
+func (htmx *Htmx) withGuards(ctx context.Context, ginContext *gin.Context) context.Context {
+	if len(htmx.config.Guards) == 0 || ginContext == nil {
+		return ctx
+	}
+
+	return context.WithValue(ctx, guardedRequestKey{}, ginContext)
+}
+
+// guardTemplate returns an HTTPError with the status 403 if the request rendered by
+// ctx is denied the requested template, the variant of it chosen by an experiment,
+// the template that is an alias of, or the template it resolved to.
+func (htmx *Htmx) guardTemplate(ctx context.Context, requested string, resolved string) error {
+	ginContext, _ := ctx.Value(guardedRequestKey{}).(*gin.Context)
+	if ginContext == nil {
+		return nil
+	}
+
+	variant, target := htmx.unthemedTemplateName(ctx, requested)
+
+	return htmx.checkGuards(ginContext, requested, variant, target, resolved)
+}
+
+// checkGuards returns an HTTPError with the status 403 if the request is denied any
+// of the named templates by its guard.
+func (htmx *Htmx) checkGuards(ginContext *gin.Context, names ...string) error {
+	for _, name := range names {
+		guard, guarded := htmx.config.Guards[name]
+		if guarded && !guard(ginContext) {
+			return NewHTTPError(http.StatusForbidden, "", fmt.Errorf("%w: rendering %q", ErrForbidden, name))
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,189 @@
+package ginhtmx_test
+
+import (
+	"html/template"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/jeffscottbrown/ginhtmxtemplates/ginhtmx"
+	"github.com/stretchr/testify/suite"
+)
+
+func (suite *GuardTestSuite) TestPermittedRequestsAreRendered() {
+	recorder, _ := suite.render(suite.htmx.With(ginhtmx.RequirePermission("admin")), "admin", true, "users")
+
+	suite.Equal(http.StatusOK, recorder.Code)
+	suite.Equal(`<p>users</p>`, recorder.Body.String())
+}
+
+func (suite *GuardTestSuite) TestRequestsWithoutPermissionAreForbidden() {
+	htmx := suite.htmx.With(ginhtmx.RequirePermission("admin"))
+
+	recorder, testContext := suite.render(htmx, "viewer", true, "users")
+	suite.Equal(http.StatusForbidden, recorder.Code)
+	suite.Equal(`<p class="error">403 Forbidden</p>`, recorder.Body.String())
+	suite.Require().ErrorIs(testContext.Errors.Last(), ginhtmx.ErrForbidden)
+	suite.Require().EqualError(testContext.Errors.Last(), `Forbidden: forbidden: permission "admin" is required`)
+
+	recorder, _ = suite.render(htmx, "viewer", false, "users")
+	suite.Equal(http.StatusForbidden, recorder.Code)
+	suite.Equal(`<html><p class="error">403 Forbidden</p></html>`, recorder.Body.String())
+}
+
+func (suite *GuardTestSuite) TestTemplatesMayBeGuarded() {
+	recorder, _ := suite.render(suite.htmx, "viewer", true, "users", "billing")
+	suite.Equal(http.StatusForbidden, recorder.Code)
+
+	recorder, _ = suite.render(suite.htmx, "accountant", true, "users", "billing")
+	suite.Equal(`<p>users</p><p>billing</p>`, recorder.Body.String())
+}
+
+func (suite *GuardTestSuite) TestAliasesOfGuardedTemplatesAreGuarded() {
+	suite.htmx.Alias("invoices", "billing")
+
+	recorder, _ := suite.render(suite.htmx, "viewer", true, "invoices")
+	suite.Equal(http.StatusForbidden, recorder.Code)
+
+	recorder, _ = suite.render(suite.htmx, "accountant", true, "invoices")
+	suite.Equal(`<p>billing</p>`, recorder.Body.String())
+}
+
+func (suite *GuardTestSuite) TestTurboStreamActionsAreGuarded() {
+	recorder := httptest.NewRecorder()
+	testContext, _ := gin.CreateTestContext(recorder)
+	testContext.Request = httptest.NewRequest(http.MethodPost, "/", nil)
+	testContext.Set("role", "viewer")
+
+	suite.htmx.RenderTurboStream(testContext, ginhtmx.TurboReplace("billing", "billing", gin.H{}))
+
+	suite.Equal(http.StatusForbidden, recorder.Code)
+	suite.NotContains(recorder.Body.String(), "<p>billing</p>")
+}
+
+func (suite *GuardTestSuite) TestMergedFragmentsAreGuarded() {
+	recorder := httptest.NewRecorder()
+	testContext, _ := gin.CreateTestContext(recorder)
+	testContext.Request = httptest.NewRequest(http.MethodGet, "/", nil)
+	testContext.Set("role", "viewer")
+
+	err := suite.htmx.MergeFragments(testContext,
+		ginhtmx.DatastarFragment{Template: "users", Data: nil, Selector: "", MergeMode: ""},
+		ginhtmx.DatastarFragment{Template: "billing", Data: nil, Selector: "", MergeMode: ""})
+
+	suite.Require().ErrorIs(err, ginhtmx.ErrForbidden)
+	suite.Empty(recorder.Body.String())
+
+	testContext.Set("role", "accountant")
+
+	err = suite.htmx.MergeFragments(testContext,
+		ginhtmx.DatastarFragment{Template: "billing", Data: nil, Selector: "", MergeMode: ""})
+
+	suite.Require().NoError(err)
+	suite.Contains(recorder.Body.String(), "data: fragments <p>billing</p>")
+}
+
+func (suite *GuardTestSuite) TestThemedVariantsAreGuardedWhenRendered() {
+	htmx := suite.themedHtmx(nil)
+
+	recorder, testContext := suite.render(htmx, "viewer", true, "users")
+	suite.Equal(http.StatusForbidden, recorder.Code)
+	suite.Equal(`<p class="error">403 Forbidden</p>`, recorder.Body.String())
+	suite.Require().ErrorIs(testContext.Errors.Last(), ginhtmx.ErrForbidden)
+
+	recorder, _ = suite.render(htmx, "accountant", true, "users")
+	suite.Equal(`<p>dark users</p>`, recorder.Body.String())
+}
+
+func (suite *GuardTestSuite) TestCachedOutputOfGuardedTemplatesIsNotServed() {
+	htmx := suite.themedHtmx(ginhtmx.NewMemoryCache()).With(ginhtmx.CacheFor(time.Minute, ""))
+
+	recorder, _ := suite.render(htmx, "accountant", true, "users")
+	suite.Equal(`<p>dark users</p>`, recorder.Body.String())
+
+	recorder, _ = suite.render(htmx, "viewer", true, "users")
+	suite.Equal(http.StatusForbidden, recorder.Code)
+	suite.NotContains(recorder.Body.String(), "dark users")
+}
+
+func (suite *GuardTestSuite) TestPermissionsAreDeniedWithoutAnAuthorizer() {
+	htmx := ginhtmx.NewHtmx(template.Must(template.New("").Parse(`{{define "users"}}users{{end}}`)))
+
+	recorder, _ := suite.render(htmx.With(ginhtmx.RequirePermission("admin")), "admin", true, "users")
+
+	suite.Equal(http.StatusForbidden, recorder.Code)
+	suite.Equal(`Forbidden`, recorder.Body.String())
+}
+
+func (suite *GuardTestSuite) render(
+	htmx *ginhtmx.Htmx, role string, fragment bool, templateNames ...string,
+) (*httptest.ResponseRecorder, *gin.Context) {
+	recorder := httptest.NewRecorder()
+	testContext, _ := gin.CreateTestContext(recorder)
+	testContext.Request = httptest.NewRequest(http.MethodGet, "/", nil)
+	testContext.Set("role", role)
+
+	if fragment {
+		testContext.Request.Header.Set("Hx-Request", "true")
+	}
+
+	htmx.Render(testContext, gin.H{}, templateNames...)
+
+	return recorder, testContext
+}
+
+// themedHtmx returns an Htmx rendering the "dark" theme, whose variant of the
+// "users" template is guarded.
+func (suite *GuardTestSuite) themedHtmx(cache ginhtmx.Cache) *ginhtmx.Htmx {
+	tmpl := template.Must(template.New("").Parse(`
+{{- define "users"}}<p>users</p>{{end -}}
+{{- define "dark/users"}}<p>dark users</p>{{end -}}
+{{- define "forbidden"}}<p class="error">{{ .Error.Status }} {{ .Error.Message }}</p>{{end -}}
+`))
+
+	return ginhtmx.NewHtmxWithConfig(tmpl, ginhtmx.HtmxConfig{
+		ErrorTemplates: map[int]string{http.StatusForbidden: "forbidden"},
+		ThemeResolver:  func(*gin.Context) string { return "dark" },
+		Cache:          cache,
+		Guards: map[string]func(*gin.Context) bool{
+			"dark/users": func(ginContext *gin.Context) bool {
+				return ginContext.GetString("role") == "accountant"
+			},
+		},
+	})
+}
+
+func (suite *GuardTestSuite) SetupTest() {
+	tmpl := template.Must(template.New("").Parse(`
+{{- define "layout"}}<html>{{ .Content }}</html>{{end -}}
+{{- define "users"}}<p>users</p>{{end -}}
+{{- define "billing"}}<p>billing</p>{{end -}}
+{{- define "forbidden"}}<p class="error">{{ .Error.Status }} {{ .Error.Message }}</p>{{end -}}
+`))
+	suite.htmx = ginhtmx.NewHtmxWithConfig(tmpl, ginhtmx.HtmxConfig{
+		LayoutTemplateName:  "layout",
+		ContentVariableName: "Content",
+		ErrorTemplates:      map[int]string{http.StatusForbidden: "forbidden"},
+		Authorizer: func(ginContext *gin.Context, permission string) bool {
+			return ginContext.GetString("role") == permission
+		},
+		Guards: map[string]func(*gin.Context) bool{
+			"billing": func(ginContext *gin.Context) bool {
+				return ginContext.GetString("role") == "accountant"
+			},
+		},
+	})
+}
+
+func TestGuardTestSuite(t *testing.T) {
+	t.Parallel()
+	suite.Run(t, new(GuardTestSuite))
+}
+
+type GuardTestSuite struct {
+	suite.Suite
+
+	htmx *ginhtmx.Htmx
+}
@@ -0,0 +1,32 @@
+package ginhtmx
+
+import (
+	"github.com/gin-gonic/gin"
+)
+
+// Handler returns a gin handler which renders the named template with the data
+// returned by provider, for the common case of a handler which loads data and
+// renders a single template:
+//
+//	router.GET("/users/:id", htmx.Handler("user", func(c *gin.Context) (gin.H, error) {
+//	  user, err := users.Find(c.Param("id"))
+//	  if errors.Is(err, ErrNoSuchUser) {
+//	    return nil, ginhtmx.NewHTTPError(http.StatusNotFound, "No such user", err)
+//	  }
+//
+//	  return gin.H{"User": user}, err
+//	}))
+//
+// If provider returns an error it is rendered with RenderError instead.
+func (htmx *Htmx) Handler(templateName string, provider func(ginContext *gin.Context) (gin.H, error)) gin.HandlerFunc {
+	return func(ginContext *gin.Context) {
+		data, err := provider(ginContext)
+		if err != nil {
+			htmx.RenderError(ginContext, err)
+
+			return
+		}
+
+		htmx.Render(ginContext, data, templateName)
+	}
+}
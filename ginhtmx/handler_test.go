@@ -0,0 +1,87 @@
+package ginhtmx_test
+
+import (
+	"errors"
+	"html/template"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/jeffscottbrown/ginhtmxtemplates/ginhtmx"
+	"github.com/stretchr/testify/suite"
+)
+
+var errNoSuchUser = errors.New("no such user")
+
+func (suite *HandlerTestSuite) TestDataIsRendered() {
+	recorder, _ := suite.serve(func(*gin.Context) (gin.H, error) {
+		return gin.H{"Name": "Jerry"}, nil
+	})
+
+	suite.Equal(http.StatusOK, recorder.Code)
+	suite.Equal("<p>Jerry</p>", recorder.Body.String())
+}
+
+func (suite *HandlerTestSuite) TestHTTPErrorsRenderTheTemplateForTheirStatus() {
+	recorder, testContext := suite.serve(func(*gin.Context) (gin.H, error) {
+		return nil, ginhtmx.NewHTTPError(http.StatusNotFound, "No such user", errNoSuchUser)
+	})
+
+	suite.Equal(http.StatusNotFound, recorder.Code)
+	suite.Equal("<h1>404 No such user</h1>", recorder.Body.String())
+	suite.Require().Len(testContext.Errors, 1)
+	suite.Require().ErrorIs(testContext.Errors[0], errNoSuchUser)
+	suite.EqualError(testContext.Errors[0].Err, "No such user: no such user")
+}
+
+func (suite *HandlerTestSuite) TestOtherErrorsDoNotRevealTheirMessage() {
+	recorder, testContext := suite.serve(func(*gin.Context) (gin.H, error) {
+		return nil, errNoSuchUser
+	})
+
+	suite.Equal(http.StatusInternalServerError, recorder.Code)
+	suite.Equal("<h1>500 Internal Server Error</h1>", recorder.Body.String())
+	suite.ErrorIs(testContext.Errors.Last(), errNoSuchUser)
+}
+
+func (suite *HandlerTestSuite) TestStatusTextIsWrittenWithoutATemplate() {
+	recorder, _ := suite.serve(func(*gin.Context) (gin.H, error) {
+		return nil, ginhtmx.NewHTTPError(http.StatusForbidden, "", nil)
+	})
+
+	suite.Equal(http.StatusForbidden, recorder.Code)
+	suite.Equal("Forbidden", recorder.Body.String())
+}
+
+func (suite *HandlerTestSuite) serve(
+	provider func(*gin.Context) (gin.H, error),
+) (*httptest.ResponseRecorder, *gin.Context) {
+	tmpl := template.Must(template.New("").Parse(`
+{{define "user"}}<p>{{.Name}}</p>{{end}}
+{{define "error"}}<h1>{{.Error.Status}} {{.Error.Message}}</h1>{{end}}
+`))
+	htmx := ginhtmx.NewHtmxWithConfig(tmpl, ginhtmx.HtmxConfig{
+		LayoutTemplateName:  "layout",
+		ContentVariableName: "Content",
+		ErrorTemplates:      map[int]string{http.StatusNotFound: "error", http.StatusInternalServerError: "error"},
+	})
+
+	recorder := httptest.NewRecorder()
+	testContext, _ := gin.CreateTestContext(recorder)
+	testContext.Request = httptest.NewRequest(http.MethodGet, "/", nil)
+	testContext.Request.Header.Set("Hx-Request", "true")
+
+	htmx.Handler("user", provider)(testContext)
+
+	return recorder, testContext
+}
+
+func TestHandlerTestSuite(t *testing.T) {
+	t.Parallel()
+	suite.Run(t, new(HandlerTestSuite))
+}
+
+type HandlerTestSuite struct {
+	suite.Suite
+}
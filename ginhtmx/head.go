@@ -0,0 +1,30 @@
+package ginhtmx
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// skipsBody reports whether the templates of a render need not be executed because
+// the request is a HEAD request, whose response has no body. The templates are
+// still executed when ETags are enabled, as the ETag is computed from the body,
+// and when the response is negotiated as JSON, which gin writes itself. When the
+// body is skipped, flashes are not consumed from the session and neither the model
+// providers nor the model decorator run, so that HEAD requests such as those of
+// link prefetching and monitoring do not take the flashes of the next page.
+func (htmx *Htmx) skipsBody(ginContext *gin.Context, settings *renderSettings) bool {
+	if ginContext.Request.Method != http.MethodHead || htmx.config.ETags {
+		return false
+	}
+
+	return settings.textContentType != "" || !htmx.wantsJSON(ginContext)
+}
+
+// writeHead writes the status and Content-Type of the response to a HEAD request
+// without a body.
+func writeHead(ginContext *gin.Context, status int, contentType string) {
+	ginContext.Status(status)
+	ginContext.Header("Content-Type", contentType)
+	ginContext.Writer.WriteHeaderNow()
+}
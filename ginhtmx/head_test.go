@@ -0,0 +1,136 @@
+package ginhtmx_test
+
+import (
+	"html/template"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync/atomic"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/jeffscottbrown/ginhtmxtemplates/ginhtmx"
+	"github.com/stretchr/testify/suite"
+)
+
+func (suite *HeadTestSuite) TestHeadRequestsSkipRendering() {
+	htmx := suite.newHtmx(ginhtmx.HtmxConfig{
+		LayoutTemplateName:  "layout",
+		ContentVariableName: "Content",
+	})
+
+	recorder, testContext := suite.serve(htmx.With(ginhtmx.Trigger("loaded")), http.MethodHead, "")
+
+	suite.Equal(http.StatusOK, recorder.Code)
+	suite.Equal("text/html; charset=utf-8", recorder.Header().Get("Content-Type"))
+	suite.JSONEq(`{"loaded": null}`, recorder.Header().Get("HX-Trigger"))
+	suite.Empty(recorder.Body.String())
+	suite.Zero(suite.executions.Load())
+	suite.Nil(testContext.Value(ginhtmx.RenderErrorKey))
+
+	recorder, _ = suite.serve(htmx.With(ginhtmx.Text("text/csv")), http.MethodHead, "")
+	suite.Equal("text/csv", recorder.Header().Get("Content-Type"))
+	suite.Zero(suite.executions.Load())
+}
+
+func (suite *HeadTestSuite) TestHeadRequestsDoNotConsumeFlashes() {
+	session := &mapSession{values: map[any]any{}, saveErr: nil}
+	htmx := suite.newHtmx(ginhtmx.HtmxConfig{
+		LayoutTemplateName:  "layout",
+		ContentVariableName: "Content",
+		SessionResolver:     func(*gin.Context) ginhtmx.Session { return session },
+	})
+
+	_, testContext := suite.serve(htmx, http.MethodPost, "")
+	suite.Require().NoError(htmx.Flash(testContext, ginhtmx.ToastSuccess, "Saved"))
+
+	suite.serve(htmx, http.MethodHead, "")
+	suite.NotNil(session.Get("ginhtmx.flashes"))
+
+	suite.serve(htmx, http.MethodGet, "")
+	suite.Nil(session.Get("ginhtmx.flashes"))
+}
+
+func (suite *HeadTestSuite) TestHeadRequestsAreRenderedToComputeTheETag() {
+	htmx := suite.newHtmx(ginhtmx.HtmxConfig{
+		LayoutTemplateName:  "layout",
+		ContentVariableName: "Content",
+		ETags:               true,
+	})
+
+	get, _ := suite.serve(htmx, http.MethodGet, "")
+	head, _ := suite.serve(htmx, http.MethodHead, "")
+
+	suite.Equal(http.StatusOK, head.Code)
+	suite.Equal(get.Header().Get("ETag"), head.Header().Get("ETag"))
+	suite.Equal(strconv.Itoa(get.Body.Len()), head.Header().Get("Content-Length"))
+	suite.Equal("text/html; charset=utf-8", head.Header().Get("Content-Type"))
+	suite.Empty(head.Body.String())
+	suite.Equal(int64(2), suite.executions.Load())
+
+	notModified, _ := suite.serve(htmx, http.MethodHead, get.Header().Get("ETag"))
+	suite.Equal(http.StatusNotModified, notModified.Code)
+}
+
+func (suite *HeadTestSuite) TestHeadRequestsNegotiatedAsJSONAreLeftToGin() {
+	htmx := suite.newHtmx(ginhtmx.HtmxConfig{
+		LayoutTemplateName:  "layout",
+		ContentVariableName: "Content",
+		NegotiateJSON:       true,
+	})
+
+	recorder := httptest.NewRecorder()
+	testContext, _ := gin.CreateTestContext(recorder)
+	testContext.Request = httptest.NewRequest(http.MethodHead, "/", nil)
+	testContext.Request.Header.Set("Accept", "application/json")
+
+	htmx.Render(testContext, gin.H{}, "page")
+
+	suite.Equal("application/json; charset=utf-8", recorder.Header().Get("Content-Type"))
+}
+
+func (suite *HeadTestSuite) serve(
+	htmx *ginhtmx.Htmx, method string, ifNoneMatch string,
+) (*httptest.ResponseRecorder, *gin.Context) {
+	recorder := httptest.NewRecorder()
+	testContext, _ := gin.CreateTestContext(recorder)
+	testContext.Request = httptest.NewRequest(method, "/", nil)
+
+	if ifNoneMatch != "" {
+		testContext.Request.Header.Set("If-None-Match", ifNoneMatch)
+	}
+
+	htmx.Render(testContext, gin.H{}, "page")
+
+	return recorder, testContext
+}
+
+func (suite *HeadTestSuite) newHtmx(config ginhtmx.HtmxConfig) *ginhtmx.Htmx {
+	tmpl := template.Must(template.New("").Funcs(template.FuncMap{
+		"executed": func() string {
+			suite.executions.Add(1)
+
+			return ""
+		},
+	}).Parse(`
+{{define "layout"}}<html>{{.Content}}</html>{{end}}
+{{define "page"}}{{executed}}<p>Page</p>{{end}}
+`))
+
+	return ginhtmx.NewHtmxWithConfig(tmpl, config)
+}
+
+func (suite *HeadTestSuite) SetupTest() {
+	suite.executions.Store(0)
+}
+
+func TestHeadTestSuite(t *testing.T) {
+	t.Parallel()
+	suite.Run(t, new(HeadTestSuite))
+}
+
+type HeadTestSuite struct {
+	suite.Suite
+
+	executions atomic.Int64
+}
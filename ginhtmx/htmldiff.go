@@ -0,0 +1,126 @@
+package ginhtmx
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"golang.org/x/net/html"
+)
+
+// DiffHTML compares two HTML documents or fragments structurally and returns a
+// description of each difference found. Whitespace which is not significant to the
+// rendered document is ignored, as is the order in which attributes are declared.
+// An empty result means the two documents are structurally identical. The
+// ginhtmxtest package uses it to compare the output of two Htmx instances.
+func DiffHTML(before string, after string) ([]string, error) {
+	beforeNode, err := html.Parse(strings.NewReader(before))
+	if err != nil {
+		return nil, fmt.Errorf("parsing first document: %w", err)
+	}
+
+	afterNode, err := html.Parse(strings.NewReader(after))
+	if err != nil {
+		return nil, fmt.Errorf("parsing second document: %w", err)
+	}
+
+	var differences []string
+
+	diffNodes("", normalizeNode(beforeNode, false), normalizeNode(afterNode, false), &differences)
+
+	return differences, nil
+}
+
+// diffNode is a simplified representation of an html.Node which only retains
+// the details that are significant when comparing documents.
+type diffNode struct {
+	name     string
+	label    string
+	children []*diffNode
+}
+
+func normalizeNode(node *html.Node, preserveWhitespace bool) *diffNode {
+	normalized := &diffNode{name: nodeName(node), label: describeNode(node, preserveWhitespace)}
+
+	preserve := preserveWhitespace || node.Type == html.ElementNode &&
+		(node.Data == "pre" || node.Data == "textarea")
+
+	for child := node.FirstChild; child != nil; child = child.NextSibling {
+		if child.Type == html.TextNode && !preserve && strings.TrimSpace(child.Data) == "" {
+			continue
+		}
+
+		normalized.children = append(normalized.children, normalizeNode(child, preserve))
+	}
+
+	return normalized
+}
+
+func nodeName(node *html.Node) string {
+	switch node.Type {
+	case html.ElementNode:
+		return node.Data
+	case html.TextNode:
+		return "#text"
+	case html.CommentNode:
+		return "#comment"
+	default:
+		return "#node"
+	}
+}
+
+func describeNode(node *html.Node, preserveWhitespace bool) string {
+	switch node.Type {
+	case html.TextNode:
+		if preserveWhitespace {
+			return fmt.Sprintf("text %q", node.Data)
+		}
+
+		return fmt.Sprintf("text %q", strings.Join(strings.Fields(node.Data), " "))
+	case html.CommentNode:
+		return fmt.Sprintf("comment %q", strings.TrimSpace(node.Data))
+	case html.DoctypeNode:
+		return "doctype " + node.Data
+	case html.ElementNode:
+		attributes := make([]string, 0, len(node.Attr))
+		for _, attribute := range node.Attr {
+			attributes = append(attributes, fmt.Sprintf("%s=%q", attribute.Key, attribute.Val))
+		}
+
+		sort.Strings(attributes)
+
+		return strings.TrimSpace("<"+node.Data+" "+strings.Join(attributes, " ")) + ">"
+	default:
+		return ""
+	}
+}
+
+func diffNodes(path string, before *diffNode, after *diffNode, differences *[]string) {
+	if before.label != after.label {
+		*differences = append(*differences, fmt.Sprintf("%s: %s changed to %s", pathOrRoot(path), before.label, after.label))
+
+		return
+	}
+
+	for index := range max(len(before.children), len(after.children)) {
+		switch {
+		case index >= len(after.children):
+			childPath := fmt.Sprintf("%s/%s[%d]", path, before.children[index].name, index)
+			*differences = append(*differences, fmt.Sprintf("%s: %s removed", childPath, before.children[index].label))
+		case index >= len(before.children):
+			childPath := fmt.Sprintf("%s/%s[%d]", path, after.children[index].name, index)
+			*differences = append(*differences, fmt.Sprintf("%s: %s added", childPath, after.children[index].label))
+		default:
+			childPath := fmt.Sprintf("%s/%s[%d]", path, before.children[index].name, index)
+			diffNodes(childPath, before.children[index], after.children[index], differences)
+		}
+	}
+}
+
+func pathOrRoot(path string) string {
+	if path == "" {
+		return "/"
+	}
+
+	return path
+}
@@ -0,0 +1,54 @@
+package ginhtmx_test
+
+import (
+	"testing"
+
+	"github.com/jeffscottbrown/ginhtmxtemplates/ginhtmx"
+	"github.com/stretchr/testify/suite"
+)
+
+func (suite *HTMLDiffTestSuite) TestWhitespaceAndAttributeOrderAreIgnored() {
+	differences, err := ginhtmx.DiffHTML(
+		`<div class="a" id="b">  <p>Hello,   World</p>
+		</div>`,
+		`<div id="b" class="a"><p>Hello, World</p></div>`)
+
+	suite.Require().NoError(err)
+	suite.Empty(differences)
+}
+
+func (suite *HTMLDiffTestSuite) TestWhitespaceIsSignificantInsidePre() {
+	differences, err := ginhtmx.DiffHTML(`<pre>a  b</pre>`, `<pre>a b</pre>`)
+
+	suite.Require().NoError(err)
+	suite.Len(differences, 1)
+}
+
+func (suite *HTMLDiffTestSuite) TestDifferencesAreReported() {
+	differences, err := ginhtmx.DiffHTML(
+		`<ul><li>One</li><li>Two</li></ul><!-- note -->`,
+		`<ul><li class="x">One</li></ul><p>Three</p>`)
+
+	suite.Require().NoError(err)
+	suite.Equal([]string{
+		`/html[0]/body[1]/ul[0]/li[0]: <li> changed to <li class="x">`,
+		`/html[0]/body[1]/ul[0]/li[1]: <li> removed`,
+		`/html[0]/body[1]/#comment[1]: comment "note" changed to <p>`,
+	}, differences)
+}
+
+func (suite *HTMLDiffTestSuite) TestAddedNodesAreReported() {
+	differences, err := ginhtmx.DiffHTML(`<!DOCTYPE html><p>One</p>`, `<!DOCTYPE html><p>One</p><p>Two</p>`)
+
+	suite.Require().NoError(err)
+	suite.Equal([]string{`/html[1]/body[1]/p[1]: <p> added`}, differences)
+}
+
+func TestHTMLDiffTestSuite(t *testing.T) {
+	t.Parallel()
+	suite.Run(t, new(HTMLDiffTestSuite))
+}
+
+type HTMLDiffTestSuite struct {
+	suite.Suite
+}
@@ -0,0 +1,111 @@
+package ginhtmx
+
+import (
+	"encoding/json"
+	"fmt"
+	"html"
+	"html/template"
+	"reflect"
+	"regexp"
+	"strings"
+)
+
+// attributeNamePattern matches the names hxAttr accepts, so that a name can never
+// break out of the attribute.
+var attributeNamePattern = regexp.MustCompile(`^[a-zA-Z][a-zA-Z0-9:._-]*$`)
+
+// hxVals returns the JSON for an hx-vals attribute. It accepts either a single map
+// or struct, or alternating string keys and values. The result is escaped by html/template
+// when used as an attribute value:
+//
+//	<button hx-post="/cart" hx-vals="{{ hxVals "id" .ID "quantity" 1 }}">Add</button>
+func hxVals(values ...any) (string, error) {
+	return encodeAttributeJSON("hxVals", values)
+}
+
+// hxHeaders returns the JSON for an hx-headers attribute, accepting the same
+// arguments as hxVals:
+//
+//	<div hx-get="/feed" hx-headers="{{ hxHeaders "X-Feed" .Feed }}"></div>
+func hxHeaders(values ...any) (string, error) {
+	return encodeAttributeJSON("hxHeaders", values)
+}
+
+// hxAttr returns hx-* attributes built from alternating names and values. Names are
+// prefixed with "hx-" unless they already start with it. Maps, slices and structs
+// are encoded as JSON and every value is escaped:
+//
+//	<a {{ hxAttr "get" (printf "/users/%d" .ID) "target" "#details" "push-url" true }}>{{ .Name }}</a>
+func hxAttr(pairs ...any) (template.HTMLAttr, error) {
+	if len(pairs)%2 != 0 {
+		return "", fmt.Errorf("%w: hxAttr requires an even number of arguments", errInvalidArgument)
+	}
+
+	attributes := make([]string, 0, len(pairs)/2)
+
+	for index := 0; index < len(pairs); index += 2 {
+		name, ok := pairs[index].(string)
+		if !ok || !attributeNamePattern.MatchString(name) {
+			return "", fmt.Errorf("%w: %v is not a valid attribute name", errInvalidArgument, pairs[index])
+		}
+
+		if !strings.HasPrefix(name, "hx-") {
+			name = "hx-" + name
+		}
+
+		value, err := attributeValue(pairs[index+1])
+		if err != nil {
+			return "", fmt.Errorf("attribute %s: %w", name, err)
+		}
+
+		attributes = append(attributes, name+`="`+html.EscapeString(value)+`"`)
+	}
+
+	//nolint:gosec
+	return template.HTMLAttr(strings.Join(attributes, " ")), nil
+}
+
+// encodeAttributeJSON encodes a single value, or a map built from alternating keys
+// and values, as JSON.
+func encodeAttributeJSON(function string, values []any) (string, error) {
+	var value any
+
+	if len(values) == 1 && !isString(values[0]) {
+		value = values[0]
+	} else {
+		pairs, err := dict(values...)
+		if err != nil {
+			return "", fmt.Errorf("%s: %w", function, err)
+		}
+
+		value = pairs
+	}
+
+	encoded, err := json.Marshal(value)
+	if err != nil {
+		return "", fmt.Errorf("%s: %w", function, err)
+	}
+
+	return string(encoded), nil
+}
+
+// attributeValue converts a value to the text of an attribute.
+func attributeValue(value any) (string, error) {
+	switch reflect.Indirect(reflect.ValueOf(value)).Kind() {
+	case reflect.Map, reflect.Slice, reflect.Array, reflect.Struct:
+		encoded, err := json.Marshal(value)
+		if err != nil {
+			return "", fmt.Errorf("encoding attribute value: %w", err)
+		}
+
+		return string(encoded), nil
+	default:
+		return fmt.Sprint(value), nil
+	}
+}
+
+func isString(value any) bool {
+	_, ok := value.(string)
+
+	return ok
+}
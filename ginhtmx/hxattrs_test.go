@@ -0,0 +1,73 @@
+package ginhtmx_test
+
+import (
+	"bytes"
+	"html/template"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/jeffscottbrown/ginhtmxtemplates/ginhtmx"
+	"github.com/stretchr/testify/suite"
+)
+
+func (suite *HxAttributesTestSuite) TestHxValsFromPairs() {
+	output := suite.render(`<button hx-vals="{{ hxVals "id" .ID "note" .Note }}">`,
+		gin.H{"ID": 3, "Note": `it's "quoted" </script>`})
+
+	suite.Equal(
+		`<button hx-vals="{&#34;id&#34;:3,&#34;note&#34;:&#34;it&#39;s \&#34;quoted\&#34; \u003c/script\u003e&#34;}">`,
+		output)
+}
+
+func (suite *HxAttributesTestSuite) TestHxHeadersFromMap() {
+	output := suite.render(`<div hx-headers='{{ hxHeaders .Headers }}'>`, gin.H{"Headers": map[string]string{"X-Feed": "news"}})
+
+	suite.Equal(`<div hx-headers='{&#34;X-Feed&#34;:&#34;news&#34;}'>`, output)
+}
+
+func (suite *HxAttributesTestSuite) TestHxAttr() {
+	output := suite.render(`<a {{ hxAttr "get" "/users?a=1&b=2" "hx-target" "#details" "push-url" true "vals" .Vals }}>`,
+		gin.H{"Vals": gin.H{"id": `"3"`}})
+
+	suite.Equal(
+		`<a hx-get="/users?a=1&amp;b=2" hx-target="#details" hx-push-url="true" hx-vals="{&#34;id&#34;:&#34;\&#34;3\&#34;&#34;}">`,
+		output)
+}
+
+func (suite *HxAttributesTestSuite) TestEmptyValues() {
+	suite.Equal(`<div hx-vals="{}">`, suite.render(`<div hx-vals="{{ hxVals }}">`, nil))
+}
+
+func (suite *HxAttributesTestSuite) TestErrors() {
+	for _, source := range []string{
+		`{{ hxVals "id" }}`,
+		`{{ hxHeaders .Func }}`,
+		`{{ hxVals "id" .Func }}`,
+		`<a {{ hxAttr "get" }}>`,
+		`<a {{ hxAttr "get\" onclick=\"alert(1)" "x" }}>`,
+		`<a {{ hxAttr 1 "x" }}>`,
+		`<a {{ hxAttr "vals" .Funcs }}>`,
+	} {
+		tmpl := template.Must(template.New("").Funcs(ginhtmx.FuncMap()).Parse(source))
+		err := tmpl.Execute(&bytes.Buffer{}, gin.H{"Func": func() {}, "Funcs": []any{func() {}}})
+		suite.Require().Error(err, source)
+	}
+}
+
+func (suite *HxAttributesTestSuite) render(source string, data any) string {
+	var output bytes.Buffer
+
+	tmpl := template.Must(template.New("").Funcs(ginhtmx.FuncMap()).Parse(source))
+	suite.Require().NoError(tmpl.Execute(&output, data))
+
+	return output.String()
+}
+
+func TestHxAttributesTestSuite(t *testing.T) {
+	t.Parallel()
+	suite.Run(t, new(HxAttributesTestSuite))
+}
+
+type HxAttributesTestSuite struct {
+	suite.Suite
+}
@@ -0,0 +1,60 @@
+package ginhtmx
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"golang.org/x/net/html"
+)
+
+// ErrSelectTargetMissing is recorded as a render error in debug mode when the
+// rendered output does not contain an element declared with ExpectSelect.
+var ErrSelectTargetMissing = errors.New("hx-select target missing from rendered output")
+
+// ExpectSelect declares the ids of the elements the client is expected to extract
+// from the response with hx-select. A leading "#" is ignored. When the Debug
+// configuration option is enabled the rendered output is checked for each of
+// the ids and an ErrSelectTargetMissing render error is recorded for any which
+// are missing, catching mismatches between the server templates and the
+// selection attributes used by the client.
+func ExpectSelect(ids ...string) RenderOption {
+	return func(settings *renderSettings) {
+		for _, id := range ids {
+			settings.selectIDs = append(settings.selectIDs, strings.TrimPrefix(id, "#"))
+		}
+	}
+}
+
+// checkSelectTargets returns an error for each of ids which is not the id of an
+// element in content.
+func checkSelectTargets(content string, ids []string) []error {
+	if len(ids) == 0 {
+		return nil
+	}
+
+	present := map[string]bool{}
+
+	tokenizer := html.NewTokenizer(strings.NewReader(content))
+	for tokenType := tokenizer.Next(); tokenType != html.ErrorToken; tokenType = tokenizer.Next() {
+		if tokenType != html.StartTagToken && tokenType != html.SelfClosingTagToken {
+			continue
+		}
+
+		for _, attribute := range tokenizer.Token().Attr {
+			if attribute.Key == "id" {
+				present[attribute.Val] = true
+			}
+		}
+	}
+
+	var errs []error
+
+	for _, id := range ids {
+		if !present[id] {
+			errs = append(errs, fmt.Errorf("%w: #%s", ErrSelectTargetMissing, id))
+		}
+	}
+
+	return errs
+}
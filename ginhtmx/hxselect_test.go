@@ -0,0 +1,80 @@
+package ginhtmx_test
+
+import (
+	"html/template"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/jeffscottbrown/ginhtmxtemplates/ginhtmx"
+	"github.com/stretchr/testify/suite"
+)
+
+func (suite *ExpectSelectTestSuite) TestPresentTargetsAreAccepted() {
+	htmx := suite.newHtmx(true)
+
+	renderErr := suite.render(htmx.With(ginhtmx.ExpectSelect("#user-row", "user-name")))
+
+	suite.NoError(renderErr)
+}
+
+func (suite *ExpectSelectTestSuite) TestMissingTargetIsReportedInDebugMode() {
+	htmx := suite.newHtmx(true)
+
+	renderErr := suite.render(htmx.With(ginhtmx.ExpectSelect("user-row")).With(ginhtmx.ExpectSelect("#user-list")))
+
+	suite.Require().ErrorIs(renderErr, ginhtmx.ErrSelectTargetMissing)
+	suite.Contains(renderErr.Error(), "#user-list")
+	suite.NotContains(renderErr.Error(), "#user-row")
+}
+
+func (suite *ExpectSelectTestSuite) TestTargetsAreNotCheckedOutsideDebugMode() {
+	htmx := suite.newHtmx(false)
+
+	renderErr := suite.render(htmx.With(ginhtmx.ExpectSelect("user-list")))
+
+	suite.NoError(renderErr)
+}
+
+func (suite *ExpectSelectTestSuite) TestWithDoesNotModifyOriginal() {
+	htmx := suite.newHtmx(true)
+	_ = htmx.With(ginhtmx.ExpectSelect("user-list"))
+
+	suite.NoError(suite.render(htmx))
+}
+
+func (suite *ExpectSelectTestSuite) render(htmx *ginhtmx.Htmx) error {
+	testContext, _ := gin.CreateTestContext(httptest.NewRecorder())
+	testContext.Request = httptest.NewRequest(http.MethodGet, "/", nil)
+	testContext.Request.Header.Set("Hx-Request", "true")
+
+	htmx.Render(testContext, gin.H{}, "user")
+
+	renderErr, _ := testContext.Get(ginhtmx.RenderErrorKey)
+	if renderErr == nil {
+		return nil
+	}
+
+	return renderErr.(error)
+}
+
+func (suite *ExpectSelectTestSuite) newHtmx(debug bool) *ginhtmx.Htmx {
+	tmpl := template.Must(template.New("").Parse(
+		`{{define "user"}}<table><tr id="user-row"><td id='user-name'>Jerry</td><br/></tr></table>{{end}}`))
+
+	return ginhtmx.NewHtmxWithConfig(tmpl, ginhtmx.HtmxConfig{
+		LayoutTemplateName:  "layout",
+		ContentVariableName: "Content",
+		Debug:               debug,
+	})
+}
+
+func TestExpectSelectTestSuite(t *testing.T) {
+	t.Parallel()
+	suite.Run(t, new(ExpectSelectTestSuite))
+}
+
+type ExpectSelectTestSuite struct {
+	suite.Suite
+}
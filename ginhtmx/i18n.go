@@ -0,0 +1,360 @@
+package ginhtmx
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/fs"
+	"path"
+	"reflect"
+	"strings"
+	"sync"
+)
+
+// Translator is implemented by types which provide translated messages for the
+// "t" and "plural" template functions. Catalog is a simple implementation backed
+// by maps, and adapters for other libraries such as go-i18n can implement it too.
+type Translator interface {
+	// Translate returns the message identified by key for locale. The args are
+	// alternating name and value pairs which may be substituted into the message.
+	Translate(locale string, key string, args ...any) string
+
+	// Plural returns the form of the message identified by key appropriate for count.
+	Plural(locale string, count int, key string, args ...any) string
+}
+
+// Catalog is a Translator which holds messages for a number of locales in memory.
+//
+// Messages may contain placeholders of the form {name} which are replaced by the
+// arguments passed to Translate or Plural. Plural messages are stored under the key
+// suffixed with the CLDR plural category of the count in the language of the
+// locale, ".zero", ".one", ".two", ".few", ".many" or ".other", and the count is
+// available to them as the {count} placeholder:
+//
+//	catalog := ginhtmx.NewCatalog("en").
+//	  AddMessages("en", map[string]string{
+//	    "greeting":    "Hello, {name}!",
+//	    "items.one":   "One item",
+//	    "items.other": "{count} items",
+//	  }).
+//	  AddMessages("pl", map[string]string{
+//	    "items.one":   "Jeden element",
+//	    "items.few":   "{count} elementy",
+//	    "items.many":  "{count} elementów",
+//	  })
+//
+// The categories are chosen by the rules of CLDR for common languages, such as
+// Polish, Russian, Arabic and French, and by the English rule, in which only one
+// is singular, for any other language unless a rule is provided with
+// SetPluralRule.
+//
+// Templates refer to the messages with the "t" and "plural" functions:
+//
+//	<h1>{{ t "greeting" "name" .Name }}</h1>
+//	<p>{{ plural .Count "items" }}</p>
+//
+// When a message is not found for the requested locale the base language (for
+// example "en" for "en-GB") and then the default locale are consulted. If the
+// message is still not found the key itself is returned.
+type Catalog struct {
+	mutex         sync.RWMutex
+	defaultLocale string
+	messages      map[string]map[string]string
+	pluralRules   map[string]PluralRule
+}
+
+// NewCatalog creates an empty Catalog which falls back to defaultLocale when a
+// message is not available in the requested locale.
+func NewCatalog(defaultLocale string) *Catalog {
+	return &Catalog{
+		mutex:         sync.RWMutex{},
+		defaultLocale: defaultLocale,
+		messages:      map[string]map[string]string{},
+		pluralRules:   map[string]PluralRule{},
+	}
+}
+
+// LoadCatalogFS creates a Catalog from the JSON files in fsys matching pattern.
+// Each file must contain a flat object of message keys to messages and is named
+// after the locale it provides, for example "messages/en.json" or "messages/de-AT.json".
+func LoadCatalogFS(fsys fs.FS, defaultLocale string, pattern string) (*Catalog, error) {
+	fileNames, err := fs.Glob(fsys, pattern)
+	if err != nil {
+		return nil, fmt.Errorf("matching message files: %w", err)
+	}
+
+	catalog := NewCatalog(defaultLocale)
+
+	for _, fileName := range fileNames {
+		content, err := fs.ReadFile(fsys, fileName)
+		if err != nil {
+			return nil, fmt.Errorf("reading message file: %w", err)
+		}
+
+		var messages map[string]string
+
+		err = json.Unmarshal(content, &messages)
+		if err != nil {
+			return nil, fmt.Errorf("parsing message file %s: %w", fileName, err)
+		}
+
+		catalog.AddMessages(strings.TrimSuffix(path.Base(fileName), path.Ext(fileName)), messages)
+	}
+
+	return catalog, nil
+}
+
+// AddMessages adds messages for locale to the catalog, replacing any existing
+// messages with the same keys. The catalog is returned to allow chaining.
+func (catalog *Catalog) AddMessages(locale string, messages map[string]string) *Catalog {
+	catalog.mutex.Lock()
+	defer catalog.mutex.Unlock()
+
+	locale = normalizeLocale(locale)
+	if catalog.messages[locale] == nil {
+		catalog.messages[locale] = map[string]string{}
+	}
+
+	for key, message := range messages {
+		catalog.messages[locale][key] = message
+	}
+
+	return catalog
+}
+
+// SetPluralRule sets the rule which chooses the plural category of a count for
+// locale, such as "cy" or "en-GB", replacing the CLDR or English rule for it. A
+// rule set for a language also applies to its regional locales. The catalog is
+// returned to allow chaining.
+func (catalog *Catalog) SetPluralRule(locale string, rule PluralRule) *Catalog {
+	catalog.mutex.Lock()
+	defer catalog.mutex.Unlock()
+
+	catalog.pluralRules[normalizeLocale(locale)] = rule
+
+	return catalog
+}
+
+// DefaultLocale returns the locale used when a message is not available in the
+// requested locale.
+func (catalog *Catalog) DefaultLocale() string {
+	return catalog.defaultLocale
+}
+
+// Translate returns the message identified by key for locale with the {name}
+// placeholders replaced by the alternating name and value pairs in args.
+func (catalog *Catalog) Translate(locale string, key string, args ...any) string {
+	message, found := catalog.lookup(locale, key)
+	if !found {
+		return key
+	}
+
+	return substitute(message, args)
+}
+
+// Plural returns the message identified by key for locale in the form appropriate
+// for count, which is the form of its plural category in the language of the
+// locale providing the message, see SetPluralRule. The "zero" form is also used
+// for a count of zero when it is defined in languages without a zero category, and
+// the "other" form when the form of the category is not defined.
+func (catalog *Catalog) Plural(locale string, count int, key string, args ...any) string {
+	args = append([]any{"count", count}, args...)
+
+	catalog.mutex.RLock()
+	defer catalog.mutex.RUnlock()
+
+	// Use the first locale which defines any form of the message so that forms
+	// from different languages are never mixed.
+	for _, candidate := range catalog.candidates(locale) {
+		messages := catalog.messages[candidate]
+		if !hasAnyKey(messages, pluralKeys(key, pluralCategories...)...) {
+			continue
+		}
+
+		forms := []string{catalog.pluralRule(candidate)(max(count, -count)), PluralOther}
+		if count == 0 {
+			forms = append([]string{PluralZero}, forms...)
+		}
+
+		for _, form := range pluralKeys(key, forms...) {
+			if message, found := messages[form]; found {
+				return substitute(message, args)
+			}
+		}
+	}
+
+	return key
+}
+
+// pluralRule returns the rule which chooses the plural categories of locale.
+func (catalog *Catalog) pluralRule(locale string) PluralRule {
+	language, _, _ := strings.Cut(locale, "-")
+
+	for _, rules := range []map[string]PluralRule{catalog.pluralRules, pluralRules} {
+		if rule, found := rules[locale]; found {
+			return rule
+		}
+
+		if rule, found := rules[language]; found {
+			return rule
+		}
+	}
+
+	return pluralEnglish
+}
+
+// pluralKeys returns the keys of the forms of the plural message key for categories.
+func pluralKeys(key string, categories ...string) []string {
+	keys := make([]string, 0, len(categories))
+	for _, category := range categories {
+		keys = append(keys, key+"."+category)
+	}
+
+	return keys
+}
+
+func (catalog *Catalog) lookup(locale string, key string) (string, bool) {
+	catalog.mutex.RLock()
+	defer catalog.mutex.RUnlock()
+
+	for _, candidate := range catalog.candidates(locale) {
+		if message, found := catalog.messages[candidate][key]; found {
+			return message, true
+		}
+	}
+
+	return "", false
+}
+
+// candidates returns the locales consulted, in order, when looking up a message for locale.
+func (catalog *Catalog) candidates(locale string) []string {
+	locale = normalizeLocale(locale)
+	language, _, _ := strings.Cut(locale, "-")
+
+	return []string{locale, language, normalizeLocale(catalog.defaultLocale)}
+}
+
+func hasAnyKey(messages map[string]string, keys ...string) bool {
+	for _, key := range keys {
+		if _, found := messages[key]; found {
+			return true
+		}
+	}
+
+	return false
+}
+
+func normalizeLocale(locale string) string {
+	return strings.ToLower(strings.ReplaceAll(strings.TrimSpace(locale), "_", "-"))
+}
+
+func substitute(message string, args []any) string {
+	if len(args) < 2 || !strings.Contains(message, "{") {
+		return message
+	}
+
+	replacements := make([]string, 0, len(args))
+	for index := 0; index+1 < len(args); index += 2 {
+		replacements = append(replacements, "{"+fmt.Sprint(args[index])+"}", fmt.Sprint(args[index+1]))
+	}
+
+	return strings.NewReplacer(replacements...).Replace(message)
+}
+
+// locale returns the locale of the request being rendered.
+func (scope *requestScope) locale() string {
+	if scope.ginContext == nil || scope.htmx == nil {
+		return ""
+	}
+
+	if scope.htmx.config.LocaleResolver != nil {
+		return scope.htmx.config.LocaleResolver(scope.ginContext)
+	}
+
+	return scope.ginContext.GetString(LocaleKey)
+}
+
+func (scope *requestScope) translator() Translator {
+	if scope.htmx == nil {
+		return nil
+	}
+
+	return scope.htmx.config.Translator
+}
+
+func (scope *requestScope) translate(key string, args ...any) string {
+	translator := scope.translator()
+	if translator == nil {
+		return key
+	}
+
+	return translator.Translate(scope.locale(), key, args...)
+}
+
+// plural returns the form of a message appropriate for count. With a Translator
+// the plural message identified by key is looked up. Templates may instead provide
+// the singular form as the key followed by the plural form, which are used when
+// there is no Translator or it does not define the message:
+//
+//	{{ .Count }} {{ plural .Count "item" "items" }}
+//	{{ plural .Count "{count} item" "{count} items" }}
+//
+// Both forms may contain the {count} placeholder, as well as placeholders for the
+// alternating name and value pairs which follow them. When only key is provided
+// and the message is not defined, key itself is returned.
+func (scope *requestScope) plural(count any, key string, args ...any) (string, error) {
+	number, err := toInt(count)
+	if err != nil {
+		return "", err
+	}
+
+	other, args := pluralForm(args)
+
+	if translator := scope.translator(); translator != nil {
+		message := translator.Plural(scope.locale(), number, key, args...)
+		if message != key || other == "" {
+			return message, nil
+		}
+	}
+
+	if other == "" {
+		return key, nil
+	}
+
+	form := other
+	if number == 1 {
+		form = key
+	}
+
+	return substitute(form, append([]any{"count", number}, args...)), nil
+}
+
+// pluralForm separates the plural form from the arguments of the "plural" template
+// function. As the remaining arguments are pairs, an odd number of arguments means
+// that the first of them is the plural form.
+func pluralForm(args []any) (string, []any) {
+	if len(args)%2 == 0 {
+		return "", args
+	}
+
+	form, ok := args[0].(string)
+	if !ok {
+		return "", args
+	}
+
+	return form, args[1:]
+}
+
+func toInt(value any) (int, error) {
+	reflected := reflect.ValueOf(value)
+
+	switch {
+	case reflected.CanInt():
+		return int(reflected.Int()), nil
+	case reflected.CanUint():
+		return int(reflected.Uint()), nil //nolint:gosec
+	case reflected.CanFloat():
+		return int(reflected.Float()), nil
+	default:
+		return 0, fmt.Errorf("%w: %v is not a number", errInvalidArgument, value)
+	}
+}
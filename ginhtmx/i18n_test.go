@@ -0,0 +1,238 @@
+package ginhtmx_test
+
+import (
+	"html/template"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"testing/fstest"
+
+	"github.com/gin-gonic/gin"
+	"github.com/jeffscottbrown/ginhtmxtemplates/ginhtmx"
+	"github.com/stretchr/testify/suite"
+)
+
+func (suite *I18nTestSuite) TestCatalogTranslatesWithFallbacks() {
+	suite.Equal("Hallo, Jerry!", suite.catalog.Translate("de-AT", "greeting", "name", "Jerry"))
+	suite.Equal("Hello, Jerry!", suite.catalog.Translate("fr", "greeting", "name", "Jerry"))
+	suite.Equal("unknown", suite.catalog.Translate("de", "unknown"))
+	suite.Equal("en", suite.catalog.DefaultLocale())
+}
+
+func (suite *I18nTestSuite) TestCatalogPluralForms() {
+	suite.Equal("No items", suite.catalog.Plural("en", 0, "items"))
+	suite.Equal("One item", suite.catalog.Plural("en", 1, "items"))
+	suite.Equal("3 items", suite.catalog.Plural("en", 3, "items"))
+	suite.Equal("0 Dinge", suite.catalog.Plural("de", 0, "items"))
+	suite.Equal("Ein Ding", suite.catalog.Plural("de", 1, "items"))
+	suite.Equal("missing", suite.catalog.Plural("en", 2, "missing"))
+}
+
+func (suite *I18nTestSuite) TestCatalogPluralFormsFollowTheRulesOfTheLanguage() {
+	categories := map[string]string{}
+	for _, category := range []string{"zero", "one", "two", "few", "many", "other"} {
+		categories["items."+category] = category
+	}
+
+	catalog := ginhtmx.NewCatalog("en")
+	for _, locale := range []string{
+		"en", "ja", "fr", "pt", "ru", "hr", "cs", "pl", "lt", "lv", "ro", "sl", "ar", "he", "ga", "cy",
+	} {
+		catalog.AddMessages(locale, categories)
+	}
+
+	catalog.SetPluralRule("cy", func(count int) string {
+		if count == 2 {
+			return ginhtmx.PluralTwo
+		}
+
+		return ginhtmx.PluralOther
+	})
+
+	expected := map[string]map[int]string{
+		"en":    {0: "zero", 1: "one", 2: "other", -1: "one"},
+		"de":    {1: "one", 21: "other"},
+		"ja":    {1: "other"},
+		"fr":    {1: "one", 2: "other"},
+		"pt-BR": {1: "one", 2: "other"},
+		"ru":    {1: "one", 21: "one", 11: "many", 3: "few", 13: "many", 5: "many"},
+		"hr":    {21: "one", 22: "few", 25: "other"},
+		"cs":    {1: "one", 3: "few", 5: "other"},
+		"pl":    {1: "one", 21: "many", 22: "few", 12: "many"},
+		"lt":    {21: "one", 11: "other", 9: "few", 10: "other"},
+		"lv":    {10: "zero", 13: "zero", 21: "one", 2: "other"},
+		"ro":    {1: "one", 19: "few", 20: "other"},
+		"sl":    {101: "one", 102: "two", 3: "few", 5: "other"},
+		"ar":    {1: "one", 2: "two", 3: "few", 11: "many", 100: "other"},
+		"he":    {1: "one", 2: "two", 3: "other"},
+		"ga":    {1: "one", 2: "two", 3: "few", 7: "many", 11: "other"},
+		"cy":    {2: "two", 3: "other"},
+		"cy-GB": {2: "two"},
+	}
+
+	for locale, counts := range expected {
+		for count, category := range counts {
+			suite.Equal(category, catalog.Plural(locale, count, "items"), "%s %d", locale, count)
+		}
+	}
+
+	englishOnly := ginhtmx.NewCatalog("en").AddMessages("en", map[string]string{
+		"items.one": "One item", "items.other": "{count} items",
+	})
+	suite.Equal("3 items", englishOnly.Plural("pl", 3, "items"))
+	suite.Equal("21 items", englishOnly.Plural("ru", 21, "items"))
+}
+
+func (suite *I18nTestSuite) TestLoadCatalogFS() {
+	fsys := fstest.MapFS{
+		"messages/en.json":    {Data: []byte(`{"greeting": "Hello"}`)},
+		"messages/pt_BR.json": {Data: []byte(`{"greeting": "Olá"}`)},
+	}
+
+	catalog, err := ginhtmx.LoadCatalogFS(fsys, "en", "messages/*.json")
+	suite.Require().NoError(err)
+	suite.Equal("Olá", catalog.Translate("pt-BR", "greeting"))
+	suite.Equal("Hello", catalog.Translate("pt", "greeting"))
+
+	_, err = ginhtmx.LoadCatalogFS(fstest.MapFS{"en.json": {Data: []byte(`[]`)}}, "en", "*.json")
+	suite.Require().ErrorContains(err, "en.json")
+
+	_, err = ginhtmx.LoadCatalogFS(fsys, "en", "[")
+	suite.Require().Error(err)
+}
+
+func (suite *I18nTestSuite) TestTemplateFunctionsUseLocaleFromContext() {
+	recorder := httptest.NewRecorder()
+	testContext, _ := gin.CreateTestContext(recorder)
+
+	testContext.Request = httptest.NewRequest(http.MethodGet, "/", nil)
+	testContext.Request.Header.Set("Hx-Request", "true")
+	testContext.Set(ginhtmx.LocaleKey, "de")
+
+	suite.htmx.Render(testContext, gin.H{"Name": "Jerry", "Count": 2}, "greeting")
+
+	suite.Equal("Hallo, Jerry! 2 Dinge", strings.TrimSpace(recorder.Body.String()))
+}
+
+func (suite *I18nTestSuite) TestTemplateFunctionsUseLocaleResolver() {
+	tmpl := template.Must(template.New("").Funcs(ginhtmx.FuncMap()).Parse(
+		`{{define "greeting"}}{{ t "greeting" "name" .Name }} {{ plural .Count "items" }}{{end}}`))
+	htmx := ginhtmx.NewHtmxWithConfig(tmpl, ginhtmx.HtmxConfig{
+		LayoutTemplateName:  "layout",
+		ContentVariableName: "Content",
+		ModelDecorator:      nil,
+		Translator:          suite.catalog,
+		LocaleResolver:      func(c *gin.Context) string { return c.Query("lang") },
+	})
+
+	recorder := httptest.NewRecorder()
+	testContext, _ := gin.CreateTestContext(recorder)
+
+	testContext.Request = httptest.NewRequest(http.MethodGet, "/?lang=en", nil)
+	testContext.Request.Header.Set("Hx-Request", "true")
+
+	htmx.Render(testContext, gin.H{"Name": "Jerry", "Count": uint(1)}, "greeting")
+
+	suite.Equal("Hello, Jerry! One item", recorder.Body.String())
+}
+
+func (suite *I18nTestSuite) TestTemplateFunctionsWithoutTranslator() {
+	tmpl := template.Must(template.New("").Funcs(ginhtmx.FuncMap()).Parse(
+		`{{define "greeting"}}{{ t "greeting" }} {{ plural .Count "items" }}{{end}}`))
+	htmx := ginhtmx.NewHtmx(tmpl)
+
+	recorder := httptest.NewRecorder()
+	testContext, _ := gin.CreateTestContext(recorder)
+
+	testContext.Request = httptest.NewRequest(http.MethodGet, "/", nil)
+	testContext.Request.Header.Set("Hx-Request", "true")
+
+	htmx.Render(testContext, gin.H{"Count": 2.0}, "greeting")
+	suite.Equal("greeting items", recorder.Body.String())
+
+	recorder = httptest.NewRecorder()
+	testContext, _ = gin.CreateTestContext(recorder)
+
+	testContext.Request = httptest.NewRequest(http.MethodGet, "/", nil)
+	testContext.Request.Header.Set("Hx-Request", "true")
+
+	htmx.Render(testContext, gin.H{"Count": "two"}, "greeting")
+
+	renderErr, _ := testContext.Get(ginhtmx.RenderErrorKey)
+	suite.Require().ErrorContains(renderErr.(error), "not a number")
+}
+
+func (suite *I18nTestSuite) TestPluralWithSingularAndPluralForms() {
+	tmpl := template.Must(template.New("").Funcs(ginhtmx.FuncMap()).Parse(`
+{{define "inline"}}{{ .Count }} {{ plural .Count "item" "items" }}{{end}}
+{{define "translated"}}{{ plural .Count "items" "things" }}{{end}}
+{{define "placeholders"}}{{ plural .Count "{count} {kind}" "{count} {kind}s" "kind" "file" }}{{end}}
+`))
+	htmx := ginhtmx.NewHtmxWithConfig(tmpl, ginhtmx.HtmxConfig{
+		LayoutTemplateName:  "layout",
+		ContentVariableName: "Content",
+		Translator:          suite.catalog,
+	})
+
+	for _, test := range []struct {
+		template string
+		count    int
+		expected string
+	}{
+		{template: "inline", count: 1, expected: "1 item"},
+		{template: "inline", count: 3, expected: "3 items"},
+		{template: "translated", count: 3, expected: "3 items"},
+		{template: "placeholders", count: 1, expected: "1 file"},
+		{template: "placeholders", count: 2, expected: "2 files"},
+	} {
+		rendered, err := htmx.RenderToString(test.template, gin.H{"Count": test.count})
+		suite.Require().NoError(err)
+		suite.Equal(test.expected, rendered)
+	}
+
+	var output strings.Builder
+
+	err := template.Must(template.New("").Funcs(ginhtmx.FuncMap()).Parse(
+		`{{ plural 1 "item" "items" }}, {{ plural 2 "item" "items" }}, {{ plural 2 "item" 3 }}`)).Execute(&output, nil)
+	suite.Require().NoError(err)
+	suite.Equal("item, items, item", output.String())
+}
+
+func (suite *I18nTestSuite) SetupSuite() {
+	suite.catalog = ginhtmx.NewCatalog("en").
+		AddMessages("en", map[string]string{
+			"greeting":    "Hello, {name}!",
+			"items.zero":  "No items",
+			"items.one":   "One item",
+			"items.other": "{count} items",
+		}).
+		AddMessages("de", map[string]string{
+			"greeting":    "Hallo, {name}!",
+			"items.one":   "Ein Ding",
+			"items.other": "{count} Dinge",
+		})
+
+	tmpl := template.Must(template.New("").Funcs(ginhtmx.FuncMap()).Parse(`
+{{define "greeting"}}{{ t "greeting" "name" .Name }} {{ plural .Count "items" }}{{end}}
+`))
+	suite.htmx = ginhtmx.NewHtmxWithConfig(tmpl, ginhtmx.HtmxConfig{
+		LayoutTemplateName:  "layout",
+		ContentVariableName: "Content",
+		ModelDecorator:      nil,
+		Translator:          suite.catalog,
+		LocaleResolver:      nil,
+	})
+}
+
+func TestI18nTestSuite(t *testing.T) {
+	t.Parallel()
+	suite.Run(t, new(I18nTestSuite))
+}
+
+type I18nTestSuite struct {
+	suite.Suite
+
+	catalog *ginhtmx.Catalog
+	htmx    *ginhtmx.Htmx
+}
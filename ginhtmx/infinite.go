@@ -0,0 +1,54 @@
+package ginhtmx
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// loadMoreTemplate is the name of the built-in template which renders the sentinel.
+const loadMoreTemplate = builtinTemplatePrefix + "load_more"
+
+// LoadMore describes the sentinel element which loads the next page of items when
+// it is scrolled into view. It is rendered with the built-in "ginhtmx/load_more"
+// template, which may be overridden.
+type LoadMore struct {
+	// URL is the URL of the next page
+	URL string
+
+	// Element is the element of the sentinel, "div", "li" or "tr". Defaults to "div".
+	Element string
+
+	// Columns is the number of columns the sentinel spans when the element is "tr"
+	Columns int
+}
+
+// AppendLoadMore returns a RenderOption which appends the sentinel described by
+// loadMore to the rendered templates. The sentinel has hx-trigger="revealed" and
+// replaces itself with the next page, which in turn ends with the next sentinel.
+// Nothing is appended if the URL of loadMore is empty, which marks the last page.
+func AppendLoadMore(loadMore LoadMore) RenderOption {
+	return func(settings *renderSettings) {
+		if loadMore.URL == "" {
+			return
+		}
+
+		if loadMore.Element == "" {
+			loadMore.Element = "div"
+		}
+
+		loadMore.Columns = max(loadMore.Columns, 1)
+		settings.appended = append(settings.appended, appendedTemplate{name: loadMoreTemplate, data: loadMore})
+	}
+}
+
+// RenderInfinite renders a page of items for infinite scrolling. The specified
+// templates are rendered followed by a "div" sentinel which loads nextURL when it is
+// revealed. On the last page, pass an empty nextURL to omit the sentinel:
+//
+//	paginator := ginhtmx.NewPaginator(c, total, 20)
+//	handler.htmx.RenderInfinite(c, gin.H{"Items": items}, paginator.NextPageURL(), "items")
+func (htmx *Htmx) RenderInfinite(ginContext *gin.Context, data gin.H, nextURL string, templateNames ...string) {
+	htmx.With(AppendLoadMore(LoadMore{URL: nextURL, Element: "", Columns: 0})).
+		RenderWithStatus(ginContext, data, http.StatusOK, templateNames...)
+}
@@ -0,0 +1,78 @@
+package ginhtmx_test
+
+import (
+	"html/template"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/jeffscottbrown/ginhtmxtemplates/ginhtmx"
+	"github.com/stretchr/testify/suite"
+)
+
+func (suite *InfiniteScrollTestSuite) TestSentinelFollowsItems() {
+	recorder, testContext := suite.newContext("/items?page=1")
+	paginator := ginhtmx.NewPaginator(testContext, 4, 2)
+
+	suite.htmx.RenderInfinite(testContext, gin.H{"Items": []string{"a", "b"}}, paginator.NextPageURL(), "items")
+
+	suite.Equal(`<div>a</div><div>b</div>`+
+		`<div class="load-more" hx-get="/items?page=2" hx-trigger="revealed" hx-swap="outerHTML">Loading&hellip;</div>`,
+		recorder.Body.String())
+}
+
+func (suite *InfiniteScrollTestSuite) TestSentinelIsOmittedOnLastPage() {
+	recorder, testContext := suite.newContext("/items?page=2")
+	paginator := ginhtmx.NewPaginator(testContext, 4, 2)
+
+	suite.htmx.RenderInfinite(testContext, gin.H{"Items": []string{"c", "d"}}, paginator.NextPageURL(), "items")
+
+	suite.Equal(`<div>c</div><div>d</div>`, recorder.Body.String())
+}
+
+func (suite *InfiniteScrollTestSuite) TestTableAndListSentinels() {
+	recorder, testContext := suite.newContext("/items")
+
+	suite.htmx.With(ginhtmx.AppendLoadMore(ginhtmx.LoadMore{URL: "/rows?page=2", Element: "tr", Columns: 3})).
+		Render(testContext, gin.H{}, "empty")
+
+	suite.Equal(`<tr class="load-more" hx-get="/rows?page=2" hx-trigger="revealed" hx-swap="outerHTML">`+
+		`<td colspan="3">Loading&hellip;</td></tr>`, recorder.Body.String())
+
+	recorder, testContext = suite.newContext("/items")
+
+	suite.htmx.With(ginhtmx.AppendLoadMore(ginhtmx.LoadMore{URL: "/list?page=2", Element: "li"})).
+		Render(testContext, gin.H{}, "empty")
+
+	suite.Equal(`<li class="load-more" hx-get="/list?page=2" hx-trigger="revealed" hx-swap="outerHTML">Loading&hellip;</li>`,
+		recorder.Body.String())
+}
+
+func (suite *InfiniteScrollTestSuite) newContext(target string) (*httptest.ResponseRecorder, *gin.Context) {
+	recorder := httptest.NewRecorder()
+	testContext, _ := gin.CreateTestContext(recorder)
+	testContext.Request = httptest.NewRequest(http.MethodGet, target, nil)
+	testContext.Request.Header.Set("Hx-Request", "true")
+
+	return recorder, testContext
+}
+
+func (suite *InfiniteScrollTestSuite) SetupSuite() {
+	tmpl := template.Must(template.New("").Parse(`
+{{- define "items"}}{{range .Items}}<div>{{.}}</div>{{end}}{{end}}
+{{- define "empty"}}{{end}}
+`))
+	suite.htmx = ginhtmx.NewHtmx(tmpl)
+}
+
+func TestInfiniteScrollTestSuite(t *testing.T) {
+	t.Parallel()
+	suite.Run(t, new(InfiniteScrollTestSuite))
+}
+
+type InfiniteScrollTestSuite struct {
+	suite.Suite
+
+	htmx *ginhtmx.Htmx
+}
@@ -0,0 +1,87 @@
+package ginhtmx
+
+import (
+	"fmt"
+	"html/template"
+	"io"
+	"path"
+
+	"github.com/CloudyKit/jet/v6"
+	"github.com/gin-gonic/gin"
+)
+
+// JetEngine returns a TemplateEngine which renders the templates of set, named by
+// their paths, such as "users/show.jet". Templates are parsed once and cached by
+// set, unless it is in development mode.
+//
+// The model of a template is its context, so that the values of a model which is
+// a map are available by their keys, such as {{ .User.Name }}, and the fields of
+// any other model by their names. Values of the model which are template.HTML, such
+// as the content given to the layout, are not escaped.
+func JetEngine(set *jet.Set) TemplateEngine {
+	return jetEngine{set: set}
+}
+
+type jetEngine struct {
+	set *jet.Set
+}
+
+func (engine jetEngine) Lookup(name string) bool {
+	_, err := engine.set.GetTemplate(name)
+
+	// templates which fail to parse are defined, so that rendering them reports why
+	return !isJetNotFound(name, err)
+}
+
+func (engine jetEngine) Execute(name string, data any, writer io.Writer) error {
+	tmpl, err := engine.set.GetTemplate(name)
+
+	switch {
+	case isJetNotFound(name, err):
+		return fmt.Errorf("%w: %q", ErrTemplateNotFound, name)
+	case err != nil:
+		return err //nolint:wrapcheck
+	}
+
+	return tmpl.Execute(writer, nil, jetContext(data)) //nolint:wrapcheck
+}
+
+// isJetNotFound reports whether err is the error of jet for the named template
+// which could not be loaded. Jet reports it without a type of its own, so it is
+// recognized by its message, which names the template by its absolute path.
+func isJetNotFound(name string, err error) bool {
+	return err != nil && err.Error() == "template "+path.Join("/", name)+" could not be found"
+}
+
+// jetContext returns data as the context of a jet template.
+func jetContext(data any) any {
+	var values map[string]any
+
+	switch data := data.(type) {
+	case gin.H:
+		values = data
+	case map[string]any:
+		values = data
+	default:
+		return data
+	}
+
+	context := make(map[string]any, len(values))
+
+	for key, value := range values {
+		if html, ok := value.(template.HTML); ok {
+			context[key] = jetHTML(html)
+		} else {
+			context[key] = value
+		}
+	}
+
+	return context
+}
+
+// jetHTML returns a value which jet writes as html without escaping it.
+func jetHTML(html template.HTML) jet.RendererFunc {
+	return func(runtime *jet.Runtime) {
+		_, _ = io.WriteString(runtime.Writer, string(html))
+	}
+}
@@ -0,0 +1,50 @@
+package ginhtmx
+
+import (
+	"encoding/json"
+	"fmt"
+	"html/template"
+	"strings"
+)
+
+// StructuredData returns a RenderOption which attaches structured data objects, such
+// as maps describing a schema.org Article, to the page. The layout renders them with
+// the "jsonLD" template function:
+//
+//	handler.htmx.With(ginhtmx.StructuredData(gin.H{
+//	  "@context": "https://schema.org",
+//	  "@type":    "Person",
+//	  "name":     user.Name,
+//	})).Render(c, data, "user")
+//
+//	<head>{{ jsonLD }}</head>
+func StructuredData(objects ...any) RenderOption {
+	return func(settings *renderSettings) {
+		settings.meta.StructuredData = append(settings.meta.StructuredData, objects...)
+	}
+}
+
+// jsonLD renders the structured data of the page as application/ld+json script
+// elements. The JSON encoder escapes "<", ">" and "&", so values can never close
+// the script element.
+func (scope *requestScope) jsonLD() (template.HTML, error) {
+	scope.mutex.Lock()
+	objects := scope.meta.StructuredData
+	scope.mutex.Unlock()
+
+	var scripts strings.Builder
+
+	for _, object := range objects {
+		encoded, err := json.Marshal(object)
+		if err != nil {
+			return "", fmt.Errorf("encoding structured data: %w", err)
+		}
+
+		scripts.WriteString(`<script type="application/ld+json">`)
+		scripts.Write(encoded)
+		scripts.WriteString(`</script>`)
+	}
+
+	//nolint:gosec
+	return template.HTML(scripts.String()), nil
+}
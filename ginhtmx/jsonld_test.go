@@ -0,0 +1,66 @@
+package ginhtmx_test
+
+import (
+	"html/template"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/jeffscottbrown/ginhtmxtemplates/ginhtmx"
+	"github.com/stretchr/testify/suite"
+)
+
+func (suite *StructuredDataTestSuite) TestObjectsAreRenderedAsScripts() {
+	body, testContext := suite.render(suite.htmx.With(
+		ginhtmx.StructuredData(gin.H{"@type": "Person", "name": "</script><script>alert(1)</script>"}),
+		ginhtmx.StructuredData(map[string]string{"@type": "WebSite"}),
+	))
+
+	suite.Equal(`<head>`+
+		`<script type="application/ld+json">{"@type":"Person","name":"\u003c/script\u003e\u003cscript\u003ealert(1)\u003c/script\u003e"}</script>`+
+		`<script type="application/ld+json">{"@type":"WebSite"}</script>`+
+		`</head>`, body)
+	suite.Nil(testContext.Value(ginhtmx.RenderErrorKey))
+}
+
+func (suite *StructuredDataTestSuite) TestNothingIsRenderedWithoutObjects() {
+	body, _ := suite.render(suite.htmx)
+
+	suite.Equal(`<head></head>`, body)
+}
+
+func (suite *StructuredDataTestSuite) TestEncodingErrorsAreReported() {
+	_, testContext := suite.render(suite.htmx.With(ginhtmx.StructuredData(func() {})))
+
+	suite.NotNil(testContext.Value(ginhtmx.RenderErrorKey))
+}
+
+func (suite *StructuredDataTestSuite) render(htmx *ginhtmx.Htmx) (string, *gin.Context) {
+	recorder := httptest.NewRecorder()
+	testContext, _ := gin.CreateTestContext(recorder)
+	testContext.Request = httptest.NewRequest(http.MethodGet, "/", nil)
+
+	htmx.Render(testContext, gin.H{}, "page")
+
+	return recorder.Body.String(), testContext
+}
+
+func (suite *StructuredDataTestSuite) SetupSuite() {
+	tmpl := template.Must(template.New("").Funcs(ginhtmx.FuncMap()).Parse(`
+{{- define "layout"}}<head>{{jsonLD}}</head>{{.Content}}{{end}}
+{{- define "page"}}{{end}}
+`))
+	suite.htmx = ginhtmx.NewHtmx(tmpl)
+}
+
+func TestStructuredDataTestSuite(t *testing.T) {
+	t.Parallel()
+	suite.Run(t, new(StructuredDataTestSuite))
+}
+
+type StructuredDataTestSuite struct {
+	suite.Suite
+
+	htmx *ginhtmx.Htmx
+}
@@ -0,0 +1,81 @@
+package ginhtmx
+
+import (
+	"errors"
+	"fmt"
+	"html/template"
+	"io/fs"
+	"path"
+)
+
+// ErrNoTemplateFiles is returned by ParseLayeredFS when none of the sources contain
+// a file matching the patterns.
+var ErrNoTemplateFiles = errors.New("no template files match the patterns")
+
+// ParseLayeredFS parses the files matching patterns in each of sources into tmpl,
+// in order, so that the templates of later sources replace the templates of earlier
+// sources with the same name. As with template.ParseFS, each file defines a
+// template named after its base name in addition to the templates it defines. This
+// allows a library to embed default templates which an application overrides
+// individually from a directory on disk:
+//
+//	tmpl, err := ginhtmx.ParseLayeredFS(template.New("").Funcs(ginhtmx.FuncMap()),
+//	  []string{"templates/*.html"}, library.Templates, os.DirFS("."))
+//
+// A source need not contain files matching every pattern, but ErrNoTemplateFiles is
+// returned if no source contains any.
+func ParseLayeredFS(tmpl *template.Template, patterns []string, sources ...fs.FS) (*template.Template, error) {
+	parsed := 0
+
+	for _, source := range sources {
+		for _, pattern := range patterns {
+			fileNames, err := fs.Glob(source, pattern)
+			if err != nil {
+				return nil, fmt.Errorf("matching template files: %w", err)
+			}
+
+			for _, fileName := range fileNames {
+				err = parseTemplateFile(tmpl, source, fileName, path.Base(fileName))
+				if err != nil {
+					return nil, err
+				}
+
+				parsed++
+			}
+		}
+	}
+
+	if parsed == 0 {
+		return nil, fmt.Errorf("%w: %v", ErrNoTemplateFiles, patterns)
+	}
+
+	return tmpl, nil
+}
+
+// NewHtmxFromLayers creates a new instance of Htmx with the provided configuration
+// and the templates parsed from the layered sources, see ParseLayeredFS. The
+// template functions of the package are added to the templates.
+func NewHtmxFromLayers(config HtmxConfig, patterns []string, sources ...fs.FS) (*Htmx, error) {
+	tmpl, err := ParseLayeredFS(template.New("").Funcs(FuncMap()), patterns, sources...)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewHtmxWithConfig(tmpl, config), nil
+}
+
+// parseTemplateFile parses the file named fileName in fsys into tmpl as the
+// template called name.
+func parseTemplateFile(tmpl *template.Template, fsys fs.FS, fileName string, name string) error {
+	content, err := fs.ReadFile(fsys, fileName)
+	if err != nil {
+		return fmt.Errorf("reading %s: %w", fileName, err)
+	}
+
+	_, err = tmpl.New(name).Parse(string(content))
+	if err != nil {
+		return fmt.Errorf("parsing %s: %w", fileName, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,65 @@
+package ginhtmx_test
+
+import (
+	"html/template"
+	"path"
+	"testing"
+	"testing/fstest"
+
+	"github.com/gin-gonic/gin"
+	"github.com/jeffscottbrown/ginhtmxtemplates/ginhtmx"
+	"github.com/stretchr/testify/suite"
+)
+
+func (suite *LayersTestSuite) TestLaterSourcesOverrideEarlierSources() {
+	overrides := fstest.MapFS{
+		"templates/card.html": {Data: []byte(`{{define "card"}}<div class="custom">{{ .Title }}</div>{{end}}`)},
+	}
+
+	htmx, err := ginhtmx.NewHtmxFromLayers(ginhtmx.HtmxConfig{
+		LayoutTemplateName:  "layout",
+		ContentVariableName: "Content",
+	}, []string{"templates/*.html", "partials/*.html"}, suite.defaults, overrides)
+	suite.Require().NoError(err)
+
+	rendered, err := htmx.RenderToString("page", gin.H{"Title": "Hello"})
+	suite.Require().NoError(err)
+	suite.Equal(`<main><div class="custom">Hello</div><footer>Default footer</footer></main>`, rendered)
+}
+
+func (suite *LayersTestSuite) TestErrorsAreReported() {
+	_, err := ginhtmx.NewHtmxFromLayers(ginhtmx.HtmxConfig{}, []string{"missing/*.html"}, suite.defaults)
+	suite.Require().ErrorIs(err, ginhtmx.ErrNoTemplateFiles)
+
+	_, err = ginhtmx.ParseLayeredFS(template.New(""), []string{"templates/[*.html"}, suite.defaults)
+	suite.Require().ErrorIs(err, path.ErrBadPattern)
+
+	_, err = ginhtmx.ParseLayeredFS(template.New(""), []string{"templates/*.html"}, suite.defaults,
+		fstest.MapFS{"templates/broken.html": {Data: []byte(`{{define "broken"}}`)}})
+	suite.Require().ErrorContains(err, "parsing templates/broken.html")
+
+	_, err = ginhtmx.ParseLayeredFS(template.New(""), []string{"templates/*"}, fstest.MapFS{
+		"templates/nested/card.html": {Data: []byte(`card`)},
+	})
+	suite.Require().ErrorContains(err, "reading templates/nested")
+}
+
+func (suite *LayersTestSuite) SetupTest() {
+	suite.defaults = fstest.MapFS{
+		"templates/page.html":   {Data: []byte(`{{define "page"}}<main>{{ template "card" . }}{{ template "footer" }}</main>{{end}}`)},
+		"templates/card.html":   {Data: []byte(`{{define "card"}}<div>{{ .Title }}</div>{{end}}`)},
+		"partials/footer.html":  {Data: []byte(`{{define "footer"}}<footer>Default footer</footer>{{end}}`)},
+		"templates/layout.html": {Data: []byte(`{{define "layout"}}<html>{{ .Content }}</html>{{end}}`)},
+	}
+}
+
+func TestLayersTestSuite(t *testing.T) {
+	t.Parallel()
+	suite.Run(t, new(LayersTestSuite))
+}
+
+type LayersTestSuite struct {
+	suite.Suite
+
+	defaults fstest.MapFS
+}
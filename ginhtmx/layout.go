@@ -0,0 +1,81 @@
+package ginhtmx
+
+import (
+	"html/template"
+	"maps"
+
+	"github.com/gin-gonic/gin"
+)
+
+// layoutContentField is the field of LayoutData holding the content.
+const layoutContentField = "Content"
+
+// LayoutData is the data the layout template is rendered with when SeparateLayoutData
+// is enabled, which keeps the model of the page apart from the data of the layout:
+//
+//	<title>{{ pageTitle }}</title>
+//	<nav>{{ range .Layout.Menu }}…{{ end }}</nav>
+//	<main>{{ .Content }}</main>
+//	{{ with .Page.User }}<footer>Signed in as {{ .Name }}</footer>{{ end }}
+type LayoutData struct {
+	// Content is the output of the rendered templates
+	Content template.HTML
+
+	// Page is the model the templates were rendered with
+	Page gin.H
+
+	// Layout holds the values added for the layout with LayoutModel
+	Layout gin.H
+}
+
+// LayoutModel returns a RenderOption which adds values to the data of the layout
+// template only, such as the items of its menu, so that they do not need to be
+// added to the model of every page:
+//
+//	handler.htmx.With(ginhtmx.LayoutModel(gin.H{"Menu": menu})).Render(c, data, "users")
+//
+// With SeparateLayoutData the values are available to the layout as .Layout,
+// otherwise they are merged into the copy of the model the layout is rendered
+// with, replacing page values with the same keys.
+func LayoutModel(values gin.H) RenderOption {
+	return func(settings *renderSettings) {
+		if settings.layoutModel == nil {
+			settings.layoutModel = gin.H{}
+		}
+
+		maps.Copy(settings.layoutModel, values)
+	}
+}
+
+// layoutData returns the data the layout is rendered with around content. The
+// model of the page is never modified.
+func (htmx *Htmx) layoutData(data gin.H, settings *renderSettings, content string) any {
+	//nolint:gosec
+	html := template.HTML(content)
+
+	if htmx.config.SeparateLayoutData {
+		layout := maps.Clone(settings.layoutModel)
+		if layout == nil {
+			layout = gin.H{}
+		}
+
+		return LayoutData{Content: html, Page: data, Layout: layout}
+	}
+
+	model := make(gin.H, len(data)+len(settings.layoutModel)+1)
+	maps.Copy(model, data)
+	maps.Copy(model, settings.layoutModel)
+	model[htmx.config.ContentVariableName] = html
+
+	return model
+}
+
+// contentVariable returns the name of the variable holding the content in the
+// data of the layout.
+func (htmx *Htmx) contentVariable() string {
+	if htmx.config.SeparateLayoutData {
+		return layoutContentField
+	}
+
+	return htmx.config.ContentVariableName
+}
@@ -0,0 +1,118 @@
+package ginhtmx_test
+
+import (
+	"html/template"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/jeffscottbrown/ginhtmxtemplates/ginhtmx"
+	"github.com/stretchr/testify/suite"
+)
+
+func (suite *LayoutDataTestSuite) TestTheContentIsNotAddedToTheModelOfThePage() {
+	data := gin.H{"Title": "Users"}
+
+	suite.Equal("<title>Users</title><main><p>Users</p></main>", suite.render(suite.shared(), data))
+	suite.NotContains(data, "Content")
+}
+
+func (suite *LayoutDataTestSuite) TestStreamedPagesDoNotAddTheContentToTheModel() {
+	data := gin.H{"Title": "Users"}
+	htmx := ginhtmx.NewHtmxWithConfig(suite.sharedTemplate(), ginhtmx.HtmxConfig{
+		LayoutTemplateName:  "layout",
+		ContentVariableName: "Content",
+		StreamLayout:        true,
+	})
+
+	suite.Equal("<title>Users</title><main><p>Users</p></main>", suite.render(htmx, data))
+	suite.NotContains(data, "Content")
+}
+
+func (suite *LayoutDataTestSuite) TestLayoutModelReplacesPageValues() {
+	htmx := suite.shared().With(ginhtmx.LayoutModel(gin.H{"Title": "Site"}))
+
+	suite.Equal("<title>Site</title><main><p>Users</p></main>", suite.render(htmx, gin.H{"Title": "Users"}))
+}
+
+func (suite *LayoutDataTestSuite) TestSeparatedLayoutsReceiveLayoutData() {
+	htmx := suite.separated().With(ginhtmx.LayoutModel(gin.H{"Title": "Site"}))
+
+	suite.Equal("<title>Site | Users</title><main><p>Users</p></main>",
+		suite.render(htmx, gin.H{"Title": "Users", "Content": "ignored"}))
+}
+
+func (suite *LayoutDataTestSuite) TestSeparatedLayoutsWithoutLayoutModel() {
+	suite.Equal("<title> | Users</title><main><p>Users</p></main>",
+		suite.render(suite.separated(), gin.H{"Title": "Users"}))
+}
+
+func (suite *LayoutDataTestSuite) TestSeparatedLayoutsWhenWritingToWriters() {
+	var output strings.Builder
+
+	err := suite.separated().RenderToWriter(&output, gin.H{"Title": "Users"}, true, "page")
+
+	suite.Require().NoError(err)
+	suite.Equal("<title> | Users</title><main><p>Users</p></main>", output.String())
+}
+
+func (suite *LayoutDataTestSuite) TestSeparatedLayoutsMustReferToContent() {
+	tmpl := template.Must(template.New("").Parse(`
+{{define "layout"}}<main>{{.Body}}</main>{{end}}
+{{define "page"}}<p>{{.Title}}</p>{{end}}`))
+	htmx := ginhtmx.NewHtmxWithConfig(tmpl, ginhtmx.HtmxConfig{
+		LayoutTemplateName:  "layout",
+		ContentVariableName: "Body",
+		SeparateLayoutData:  true,
+	})
+
+	suite.ErrorIs(htmx.Validate("page"), ginhtmx.ErrContentVariableMissing)
+	suite.Require().Len(htmx.Lint(), 1)
+	suite.Equal("layout does not refer to the content variable .Content", htmx.Lint()[0].Message)
+}
+
+func (suite *LayoutDataTestSuite) render(htmx *ginhtmx.Htmx, data gin.H) string {
+	recorder := httptest.NewRecorder()
+	testContext, _ := gin.CreateTestContext(recorder)
+	testContext.Request = httptest.NewRequest(http.MethodGet, "/", nil)
+
+	htmx.Render(testContext, data, "page")
+
+	renderErr, _ := testContext.Value(ginhtmx.RenderErrorKey).(error)
+	suite.Require().NoError(renderErr)
+
+	return recorder.Body.String()
+}
+
+func (suite *LayoutDataTestSuite) shared() *ginhtmx.Htmx {
+	return ginhtmx.NewHtmx(suite.sharedTemplate())
+}
+
+func (suite *LayoutDataTestSuite) sharedTemplate() *template.Template {
+	return template.Must(template.New("").Parse(`
+{{define "layout"}}<title>{{.Title}}</title><main>{{.Content}}</main>{{end}}
+{{define "page"}}<p>{{.Title}}</p>{{end}}`))
+}
+
+func (suite *LayoutDataTestSuite) separated() *ginhtmx.Htmx {
+	tmpl := template.Must(template.New("").Parse(`
+{{define "layout"}}<title>{{.Layout.Title}} | {{.Page.Title}}</title><main>{{.Content}}</main>{{end}}
+{{define "page"}}<p>{{.Title}}</p>{{end}}`))
+
+	return ginhtmx.NewHtmxWithConfig(tmpl, ginhtmx.HtmxConfig{
+		LayoutTemplateName:  "layout",
+		ContentVariableName: "Body",
+		SeparateLayoutData:  true,
+	})
+}
+
+func TestLayoutDataTestSuite(t *testing.T) {
+	t.Parallel()
+	suite.Run(t, new(LayoutDataTestSuite))
+}
+
+type LayoutDataTestSuite struct {
+	suite.Suite
+}
@@ -0,0 +1,93 @@
+package ginhtmx
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"text/template/parse"
+)
+
+// LintIssue describes a problem found in the template set by Lint.
+type LintIssue struct {
+	// Template is the name of the template in which the problem was found.
+	Template string
+
+	// Message describes the problem.
+	Message string
+}
+
+// String returns the issue in the form "template: message".
+func (issue LintIssue) String() string {
+	return fmt.Sprintf("%s: %s", issue.Template, issue.Message)
+}
+
+// Lint statically inspects the parsed templates and reports problems with the
+// contract between the layout and the page templates:
+//
+//   - the layout is not defined or does not refer to the content variable
+//   - a template invokes a template which is not defined
+//   - a page template invokes the layout itself, so full page renders would
+//     contain the layout twice
+//   - a template has a name which differs from the layout name only by case,
+//     shadowing the layout in a way that is easy to mistake for it
+//
+// Lint is intended to be run from a test:
+//
+//	func TestTemplates(t *testing.T) {
+//	  assert.Empty(t, htmx.Lint())
+//	}
+//
+// The issues are sorted by template name.
+func (htmx *Htmx) Lint() []LintIssue {
+	var issues []LintIssue
+
+	templates := htmx.currentTemplate()
+	layout := htmx.config.LayoutTemplateName
+
+	if templates.Lookup(layout) == nil {
+		issues = append(issues, LintIssue{Template: layout, Message: "layout template is not defined"})
+	} else if !referencesField(templates, layout, htmx.contentVariable()) {
+		issues = append(issues, LintIssue{
+			Template: layout,
+			Message:  fmt.Sprintf("layout does not refer to the content variable .%s", htmx.contentVariable()),
+		})
+	}
+
+	for _, tmpl := range templates.Templates() {
+		name := tmpl.Name()
+		if name == "" {
+			continue
+		}
+
+		if name != layout && strings.EqualFold(name, layout) {
+			issues = append(issues, LintIssue{
+				Template: name,
+				Message:  fmt.Sprintf("template name shadows the layout %q", layout),
+			})
+		}
+
+		walkTemplate(tmpl, func(node parse.Node) {
+			invoked, ok := node.(*parse.TemplateNode)
+			if !ok {
+				return
+			}
+
+			switch {
+			case templates.Lookup(invoked.Name) == nil:
+				issues = append(issues, LintIssue{
+					Template: name,
+					Message:  fmt.Sprintf("invokes undefined template %q", invoked.Name),
+				})
+			case invoked.Name == layout && name != layout:
+				issues = append(issues, LintIssue{
+					Template: name,
+					Message:  fmt.Sprintf("invokes the layout %q, which wraps full page renders automatically", layout),
+				})
+			}
+		})
+	}
+
+	sort.SliceStable(issues, func(i, j int) bool { return issues[i].Template < issues[j].Template })
+
+	return issues
+}
@@ -0,0 +1,61 @@
+package ginhtmx_test
+
+import (
+	"html/template"
+	"testing"
+
+	"github.com/jeffscottbrown/ginhtmxtemplates/ginhtmx"
+	"github.com/stretchr/testify/suite"
+)
+
+func (suite *LintTestSuite) TestCleanTemplates() {
+	htmx := ginhtmx.NewHtmx(template.Must(template.New("").Parse(`
+{{define "layout"}}<html>{{template "nav" .}}{{.Content}}</html>{{end}}
+{{define "nav"}}<nav></nav>{{end}}
+{{define "home"}}{{if .User}}{{template "nav" .}}{{end}}{{end}}
+`)))
+
+	suite.Empty(htmx.Lint())
+}
+
+func (suite *LintTestSuite) TestIssuesAreReported() {
+	htmx := ginhtmx.NewHtmx(template.Must(template.New("").Parse(`
+{{define "layout"}}<html>{{.Body}}</html>{{end}}
+{{define "Layout"}}<html>{{.Content}}</html>{{end}}
+{{define "home"}}{{range .Items}}{{template "item" .}}{{end}}{{end}}
+{{define "about"}}{{template "layout" .}}{{end}}
+`)))
+
+	issues := htmx.Lint()
+
+	suite.Equal([]string{
+		`Layout: template name shadows the layout "layout"`,
+		`about: invokes the layout "layout", which wraps full page renders automatically`,
+		`home: invokes undefined template "item"`,
+		`layout: layout does not refer to the content variable .Content`,
+	}, issueStrings(issues))
+}
+
+func (suite *LintTestSuite) TestMissingLayoutIsReported() {
+	htmx := ginhtmx.NewHtmx(template.Must(template.New("").Parse(`{{define "home"}}Home{{end}}`)))
+
+	suite.Equal([]string{"layout: layout template is not defined"}, issueStrings(htmx.Lint()))
+}
+
+func issueStrings(issues []ginhtmx.LintIssue) []string {
+	descriptions := make([]string, 0, len(issues))
+	for _, issue := range issues {
+		descriptions = append(descriptions, issue.String())
+	}
+
+	return descriptions
+}
+
+func TestLintTestSuite(t *testing.T) {
+	t.Parallel()
+	suite.Run(t, new(LintTestSuite))
+}
+
+type LintTestSuite struct {
+	suite.Suite
+}
@@ -0,0 +1,224 @@
+package ginhtmx
+
+import (
+	"context"
+	"fmt"
+	"html/template"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	// LiveReloadPath is the path of the server-sent events endpoint which notifies
+	// browsers that the templates have changed.
+	LiveReloadPath = "/__ginhtmx/reload"
+
+	// DefaultLiveReloadInterval is how often the template files are checked for
+	// changes unless configured otherwise.
+	DefaultLiveReloadInterval = 500 * time.Millisecond
+
+	// liveReloadScript is injected into full page responses while live reload is running.
+	liveReloadScript = `<script>new EventSource("` + LiveReloadPath +
+		`").addEventListener("reload", function () { location.reload() })</script>`
+)
+
+// LiveReload configures reloading templates during development.
+type LiveReload struct {
+	// Load parses the templates from disk
+	Load func() (*template.Template, error)
+
+	// Patterns are the glob patterns of the template files, such as "templates/*.html"
+	Patterns []string
+
+	// Interval is how often the files are checked for changes. Defaults to
+	// DefaultLiveReloadInterval.
+	Interval time.Duration
+}
+
+// LiveReload reloads the templates whenever the files matching the patterns of
+// reload change, until ctx is done. It registers a server-sent events endpoint at
+// LiveReloadPath on router and, while it is running, a small script is injected
+// into full page responses which refreshes the browser once the templates have
+// been reloaded. It is intended for development only:
+//
+//	if gin.Mode() == gin.DebugMode {
+//	  err := htmx.LiveReload(ctx, router, ginhtmx.LiveReload{
+//	    Load:     func() (*template.Template, error) { return template.ParseGlob("templates/*.html") },
+//	    Patterns: []string{"templates/*.html"},
+//	  })
+//	}
+//
+// If the templates fail to load, the error is logged to the Logger of the
+// configuration and the previous templates remain in use. An error is returned if
+// a pattern is malformed.
+//
+// LiveReload may be called again, for example to watch other patterns or after ctx
+// is done, but the endpoint is only registered on the router of the first call, as
+// gin does not allow a route to be registered twice.
+func (htmx *Htmx) LiveReload(ctx context.Context, router gin.IRoutes, reload LiveReload) error {
+	signature, err := fileSignature(reload.Patterns)
+	if err != nil {
+		return err
+	}
+
+	interval := reload.Interval
+	if interval <= 0 {
+		interval = DefaultLiveReloadInterval
+	}
+
+	htmx.reloads.route.Do(func() { router.GET(LiveReloadPath, htmx.reloads.handler) })
+	htmx.reloads.enabled.Add(1)
+
+	go func() {
+		defer htmx.reloads.enabled.Add(-1)
+
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				current, _ := fileSignature(reload.Patterns)
+				if current != signature {
+					signature = current
+					htmx.reloadTemplates(ctx, reload.Load)
+				}
+			}
+		}
+	}()
+
+	return nil
+}
+
+// reloadTemplates loads and installs the templates and notifies the browsers.
+func (htmx *Htmx) reloadTemplates(ctx context.Context, load func() (*template.Template, error)) {
+	tmpl, err := load()
+	if err != nil {
+		if htmx.config.Logger != nil {
+			htmx.config.Logger.ErrorContext(ctx, "ginhtmx: reloading templates failed", "error", err)
+		}
+
+		return
+	}
+
+	htmx.Reload(tmpl)
+	htmx.reloads.broadcast()
+}
+
+// fileSignature describes the names, sizes and modification times of the files
+// matching the patterns, so that a change to any of them changes the signature.
+func fileSignature(patterns []string) (string, error) {
+	var signature strings.Builder
+
+	for _, pattern := range patterns {
+		matches, err := filepath.Glob(pattern)
+		if err != nil {
+			return "", fmt.Errorf("watching %q: %w", pattern, err)
+		}
+
+		for _, match := range matches {
+			info, err := os.Stat(match)
+			if err != nil {
+				continue
+			}
+
+			fmt.Fprintf(&signature, "%s:%d:%d;", match, info.Size(), info.ModTime().UnixNano())
+		}
+	}
+
+	return signature.String(), nil
+}
+
+// injectLiveReload adds the live reload script to a full page, before the closing
+// body tag if there is one.
+func (htmx *Htmx) injectLiveReload(page string) string {
+	if htmx.reloads.enabled.Load() == 0 {
+		return page
+	}
+
+	index := strings.LastIndex(strings.ToLower(page), "</body>")
+	if index < 0 {
+		return page + liveReloadScript
+	}
+
+	return page[:index] + liveReloadScript + page[index:]
+}
+
+// reloadHub notifies the connected browsers that the templates have been reloaded.
+// It is shared by the copies of an Htmx instance made by With.
+type reloadHub struct {
+	enabled     atomic.Int32
+	route       sync.Once
+	mutex       sync.Mutex
+	subscribers map[chan struct{}]struct{}
+}
+
+func newReloadHub() *reloadHub {
+	return &reloadHub{
+		enabled:     atomic.Int32{},
+		route:       sync.Once{},
+		mutex:       sync.Mutex{},
+		subscribers: map[chan struct{}]struct{}{},
+	}
+}
+
+func (hub *reloadHub) subscribe() chan struct{} {
+	hub.mutex.Lock()
+	defer hub.mutex.Unlock()
+
+	subscriber := make(chan struct{}, 1)
+	hub.subscribers[subscriber] = struct{}{}
+
+	return subscriber
+}
+
+func (hub *reloadHub) unsubscribe(subscriber chan struct{}) {
+	hub.mutex.Lock()
+	defer hub.mutex.Unlock()
+
+	delete(hub.subscribers, subscriber)
+}
+
+func (hub *reloadHub) broadcast() {
+	hub.mutex.Lock()
+	defer hub.mutex.Unlock()
+
+	for subscriber := range hub.subscribers {
+		select {
+		case subscriber <- struct{}{}:
+		default:
+		}
+	}
+}
+
+// handler streams a reload event to the browser each time the templates are reloaded.
+func (hub *reloadHub) handler(ginContext *gin.Context) {
+	subscriber := hub.subscribe()
+	defer hub.unsubscribe(subscriber)
+
+	ginContext.Header("Content-Type", "text/event-stream")
+	ginContext.Header("Cache-Control", "no-cache")
+	ginContext.Status(http.StatusOK)
+	ginContext.Writer.Flush()
+
+	ginContext.Stream(func(writer io.Writer) bool {
+		select {
+		case <-ginContext.Request.Context().Done():
+			return false
+		case <-subscriber:
+			_, err := io.WriteString(writer, "event: reload\ndata: reload\n\n")
+
+			return err == nil
+		}
+	})
+}
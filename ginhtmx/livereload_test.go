@@ -0,0 +1,197 @@
+package ginhtmx_test
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"errors"
+	"html/template"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/jeffscottbrown/ginhtmxtemplates/ginhtmx"
+	"github.com/stretchr/testify/suite"
+)
+
+var errBrokenTemplates = errors.New("broken templates")
+
+func (suite *LiveReloadTestSuite) TestReloadReplacesTemplatesOfCopies() {
+	htmx := ginhtmx.NewHtmx(template.Must(template.New("").Parse(`{{define "home"}}before{{end}}`)))
+	derived := htmx.With(ginhtmx.ForceFragment())
+
+	htmx.Reload(template.Must(template.New("").Parse(`{{define "home"}}after{{end}}`)))
+
+	suite.Equal("after", suite.render(derived, "/"))
+}
+
+func (suite *LiveReloadTestSuite) TestTemplatesAreReloadedWhenFilesChange() {
+	directory := suite.T().TempDir()
+	path := filepath.Join(directory, "home.html")
+	suite.writeTemplate(path, "first")
+
+	load := func() (*template.Template, error) {
+		return template.ParseGlob(filepath.Join(directory, "*.html"))
+	}
+
+	tmpl, err := load()
+	suite.Require().NoError(err)
+
+	htmx := ginhtmx.NewHtmx(tmpl)
+	router := gin.New()
+	router.GET("/", func(c *gin.Context) { htmx.Render(c, gin.H{}, "home") })
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	err = htmx.LiveReload(ctx, router, ginhtmx.LiveReload{
+		Load:     load,
+		Patterns: []string{filepath.Join(directory, "*.html")},
+		Interval: 10 * time.Millisecond,
+	})
+	suite.Require().NoError(err)
+
+	suite.Equal(`<html><body>first<script>new EventSource("/__ginhtmx/reload")`+
+		`.addEventListener("reload", function () { location.reload() })</script></body></html>`,
+		suite.serve(router, "/"))
+
+	server := httptest.NewServer(router)
+	defer server.Close()
+
+	response, err := http.Get(server.URL + ginhtmx.LiveReloadPath) //nolint:noctx
+	suite.Require().NoError(err)
+
+	defer response.Body.Close()
+
+	suite.Equal("text/event-stream", response.Header.Get("Content-Type"))
+
+	suite.writeTemplate(path, "second version")
+
+	line, err := bufio.NewReader(response.Body).ReadString('\n')
+	suite.Require().NoError(err)
+	suite.Equal("event: reload\n", line)
+	suite.Contains(suite.serve(router, "/"), "second version")
+
+	cancel()
+	suite.Eventually(func() bool {
+		return !strings.Contains(suite.serve(router, "/"), "EventSource")
+	}, time.Second, 10*time.Millisecond)
+}
+
+func (suite *LiveReloadTestSuite) TestLoadErrorsKeepPreviousTemplates() {
+	directory := suite.T().TempDir()
+	path := filepath.Join(directory, "home.html")
+	suite.writeTemplate(path, "first")
+
+	var logs lockedBuffer
+
+	htmx := ginhtmx.NewHtmxWithConfig(template.Must(template.ParseFiles(path)), ginhtmx.HtmxConfig{
+		LayoutTemplateName:  "layout",
+		ContentVariableName: "Content",
+		Logger:              slog.New(slog.NewTextHandler(&logs, nil)),
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	loaded := make(chan struct{}, 1)
+	err := htmx.LiveReload(ctx, gin.New(), ginhtmx.LiveReload{
+		Load: func() (*template.Template, error) {
+			loaded <- struct{}{}
+
+			return nil, errBrokenTemplates
+		},
+		Patterns: []string{path},
+		Interval: 10 * time.Millisecond,
+	})
+	suite.Require().NoError(err)
+
+	suite.writeTemplate(path, "second version")
+	<-loaded
+
+	suite.Eventually(func() bool { return strings.Contains(logs.String(), "broken templates") }, time.Second, time.Millisecond)
+	suite.Contains(suite.render(htmx.With(ginhtmx.ForceFragment()), "/"), "first")
+}
+
+func (suite *LiveReloadTestSuite) TestMalformedPatternsAreRejected() {
+	htmx := ginhtmx.NewHtmx(template.New(""))
+
+	err := htmx.LiveReload(context.Background(), gin.New(), ginhtmx.LiveReload{Patterns: []string{"["}})
+
+	suite.Require().ErrorIs(err, filepath.ErrBadPattern)
+}
+
+func (suite *LiveReloadTestSuite) TestTheEndpointIsRegisteredOnce() {
+	htmx := ginhtmx.NewHtmx(template.New(""))
+	router := gin.New()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	for range 2 {
+		suite.NotPanics(func() {
+			suite.Require().NoError(htmx.LiveReload(ctx, router, ginhtmx.LiveReload{Patterns: []string{"*.html"}}))
+		})
+	}
+
+	suite.Len(router.Routes(), 1)
+}
+
+func (suite *LiveReloadTestSuite) writeTemplate(path string, content string) {
+	source := `{{define "layout"}}<html><body>{{.Content}}</body></html>{{end}}{{define "home"}}` + content + `{{end}}`
+	suite.Require().NoError(os.WriteFile(path, []byte(source), 0o600))
+}
+
+func (suite *LiveReloadTestSuite) serve(router *gin.Engine, path string) string {
+	recorder := httptest.NewRecorder()
+	router.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, path, nil))
+
+	return recorder.Body.String()
+}
+
+func (suite *LiveReloadTestSuite) render(htmx *ginhtmx.Htmx, path string) string {
+	recorder := httptest.NewRecorder()
+	testContext, _ := gin.CreateTestContext(recorder)
+	testContext.Request = httptest.NewRequest(http.MethodGet, path, nil)
+
+	htmx.Render(testContext, gin.H{}, "home")
+
+	return recorder.Body.String()
+}
+
+// lockedBuffer is a bytes.Buffer which may be written by the reload goroutine
+// while the test reads it.
+type lockedBuffer struct {
+	mutex  sync.Mutex
+	buffer bytes.Buffer
+}
+
+func (buffer *lockedBuffer) Write(p []byte) (int, error) {
+	buffer.mutex.Lock()
+	defer buffer.mutex.Unlock()
+
+	return buffer.buffer.Write(p)
+}
+
+func (buffer *lockedBuffer) String() string {
+	buffer.mutex.Lock()
+	defer buffer.mutex.Unlock()
+
+	return buffer.buffer.String()
+}
+
+func TestLiveReloadTestSuite(t *testing.T) {
+	t.Parallel()
+	suite.Run(t, new(LiveReloadTestSuite))
+}
+
+type LiveReloadTestSuite struct {
+	suite.Suite
+}
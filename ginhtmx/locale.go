@@ -0,0 +1,165 @@
+package ginhtmx
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// LocaleDetector resolves the locale of a request from a query parameter, a
+// cookie or the Accept-Language header, in that order, falling back to Default.
+//
+// It may be used as gin middleware, which stores the detected locale in the gin
+// context under LocaleKey for the translation template functions, and as a
+// ModelDecorator, which exposes the locale to templates as .Locale so that
+// layouts can set the lang attribute:
+//
+//	detector := &ginhtmx.LocaleDetector{
+//	  Supported:  []string{"en", "de", "fr"},
+//	  Default:    "en",
+//	  CookieName: "locale",
+//	  QueryParam: "lang",
+//	}
+//	router.Use(detector.Middleware())
+//	htmx := ginhtmx.NewHtmxWithConfig(tmpl, ginhtmx.HtmxConfig{
+//	  LayoutTemplateName:  "layout",
+//	  ContentVariableName: "Content",
+//	  ModelDecorator:      detector,
+//	  Translator:          catalog,
+//	})
+type LocaleDetector struct {
+	// Supported lists the locales the application supports. Requested locales
+	// which are not supported are ignored. If empty, any locale is accepted.
+	Supported []string
+
+	// Default is the locale used when no supported locale was requested.
+	Default string
+
+	// CookieName is the name of a cookie which may hold the locale. If empty,
+	// cookies are not consulted.
+	CookieName string
+
+	// QueryParam is the name of a query parameter which may hold the locale. If
+	// empty, the query string is not consulted.
+	QueryParam string
+}
+
+// LocaleModelKey is the key under which LocaleDetector exposes the locale to templates.
+const LocaleModelKey = "Locale"
+
+// Middleware returns gin middleware which stores the detected locale in the gin
+// context under LocaleKey.
+func (detector *LocaleDetector) Middleware() gin.HandlerFunc {
+	return func(ginContext *gin.Context) {
+		ginContext.Set(LocaleKey, detector.Detect(ginContext))
+		ginContext.Next()
+	}
+}
+
+// DecorateModel exposes the locale of the request to templates as .Locale. The
+// locale stored in the gin context by Middleware is used if present, otherwise the
+// locale is detected.
+func (detector *LocaleDetector) DecorateModel(ginContext *gin.Context, model *gin.H) {
+	locale := ginContext.GetString(LocaleKey)
+	if locale == "" {
+		locale = detector.Detect(ginContext)
+	}
+
+	(*model)[LocaleModelKey] = locale
+}
+
+// Detect returns the locale for the request.
+func (detector *LocaleDetector) Detect(ginContext *gin.Context) string {
+	if detector.QueryParam != "" {
+		if locale, ok := detector.match(ginContext.Query(detector.QueryParam)); ok {
+			return locale
+		}
+	}
+
+	if detector.CookieName != "" {
+		if cookie, err := ginContext.Cookie(detector.CookieName); err == nil {
+			if locale, ok := detector.match(cookie); ok {
+				return locale
+			}
+		}
+	}
+
+	for _, requested := range parseAcceptLanguage(ginContext.GetHeader("Accept-Language")) {
+		if locale, ok := detector.match(requested); ok {
+			return locale
+		}
+	}
+
+	return detector.Default
+}
+
+// match returns the supported locale which best matches requested. A supported
+// locale matches if it is the same locale or shares the same base language.
+func (detector *LocaleDetector) match(requested string) (string, bool) {
+	requested = normalizeLocale(requested)
+	if requested == "" || requested == "*" {
+		return "", false
+	}
+
+	if len(detector.Supported) == 0 {
+		return requested, true
+	}
+
+	for _, supported := range detector.Supported {
+		if normalizeLocale(supported) == requested {
+			return supported, true
+		}
+	}
+
+	language, _, _ := strings.Cut(requested, "-")
+	for _, supported := range detector.Supported {
+		supportedLanguage, _, _ := strings.Cut(normalizeLocale(supported), "-")
+		if supportedLanguage == language {
+			return supported, true
+		}
+	}
+
+	return "", false
+}
+
+// parseAcceptLanguage returns the locales listed in an Accept-Language header
+// ordered by descending quality.
+func parseAcceptLanguage(header string) []string {
+	type weightedLocale struct {
+		locale  string
+		quality float64
+	}
+
+	var weighted []weightedLocale
+
+	for part := range strings.SplitSeq(header, ",") {
+		locale, params, _ := strings.Cut(strings.TrimSpace(part), ";")
+		if locale == "" {
+			continue
+		}
+
+		quality := 1.0
+
+		if value, found := strings.CutPrefix(strings.TrimSpace(params), "q="); found {
+			parsed, err := strconv.ParseFloat(value, 64)
+			if err != nil || parsed <= 0 {
+				continue
+			}
+
+			quality = parsed
+		}
+
+		weighted = append(weighted, weightedLocale{locale: locale, quality: quality})
+	}
+
+	sort.SliceStable(weighted, func(i, j int) bool { return weighted[i].quality > weighted[j].quality })
+
+	locales := make([]string, 0, len(weighted))
+	for _, entry := range weighted {
+		locales = append(locales, entry.locale)
+	}
+
+	return locales
+}
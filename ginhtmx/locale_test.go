@@ -0,0 +1,121 @@
+package ginhtmx_test
+
+import (
+	"html/template"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/PuerkitoBio/goquery"
+	"github.com/gin-gonic/gin"
+	"github.com/jeffscottbrown/ginhtmxtemplates/ginhtmx"
+	"github.com/stretchr/testify/suite"
+)
+
+func (suite *LocaleTestSuite) TestDetectionOrder() {
+	testCases := []struct {
+		target         string
+		cookie         string
+		acceptLanguage string
+		expected       string
+	}{
+		{target: "/?lang=fr", cookie: "de", acceptLanguage: "de", expected: "fr"},
+		{target: "/?lang=xx", cookie: "de", acceptLanguage: "fr", expected: "de"},
+		{target: "/", cookie: "", acceptLanguage: "es;q=0.9, fr-CA;q=0.8, de;q=0.7", expected: "fr"},
+		{target: "/", cookie: "", acceptLanguage: "de;q=0, *", expected: "en-US"},
+		{target: "/", cookie: "", acceptLanguage: "en-GB,en;q=0.5", expected: "en-US"},
+		{target: "/", cookie: "", acceptLanguage: "DE_de;q=bogus, ,de-CH", expected: "de"},
+	}
+
+	for _, testCase := range testCases {
+		testContext, _ := gin.CreateTestContext(httptest.NewRecorder())
+		testContext.Request = httptest.NewRequest(http.MethodGet, testCase.target, nil)
+		testContext.Request.Header.Set("Accept-Language", testCase.acceptLanguage)
+
+		if testCase.cookie != "" {
+			testContext.Request.AddCookie(&http.Cookie{Name: "locale", Value: testCase.cookie})
+		}
+
+		suite.Equal(testCase.expected, suite.detector.Detect(testContext), testCase)
+	}
+}
+
+func (suite *LocaleTestSuite) TestAnyLocaleIsAcceptedWhenNoneAreListed() {
+	detector := &ginhtmx.LocaleDetector{Supported: nil, Default: "en", CookieName: "", QueryParam: ""}
+
+	testContext, _ := gin.CreateTestContext(httptest.NewRecorder())
+	testContext.Request = httptest.NewRequest(http.MethodGet, "/?lang=fr", nil)
+	testContext.Request.Header.Set("Accept-Language", "pt-BR")
+
+	suite.Equal("pt-br", detector.Detect(testContext))
+}
+
+func (suite *LocaleTestSuite) TestMiddlewareAndDecorator() {
+	router := gin.New()
+	router.Use(suite.detector.Middleware())
+	router.GET("/", func(c *gin.Context) {
+		suite.htmx.Render(c, gin.H{}, "hello")
+	})
+
+	recorder := httptest.NewRecorder()
+	request := httptest.NewRequest(http.MethodGet, "/?lang=de", nil)
+	router.ServeHTTP(recorder, request)
+
+	doc, err := goquery.NewDocumentFromReader(recorder.Body)
+	suite.Require().NoError(err)
+
+	lang, _ := doc.Find("html").Attr("lang")
+	suite.Equal("de", lang)
+	suite.Equal("Hallo", doc.Find("h1").Text())
+}
+
+func (suite *LocaleTestSuite) TestDecoratorDetectsWithoutMiddleware() {
+	recorder := httptest.NewRecorder()
+	testContext, _ := gin.CreateTestContext(recorder)
+	testContext.Request = httptest.NewRequest(http.MethodGet, "/", nil)
+	testContext.Request.Header.Set("Accept-Language", "fr")
+
+	suite.htmx.Render(testContext, gin.H{}, "hello")
+
+	doc, err := goquery.NewDocumentFromReader(recorder.Body)
+	suite.Require().NoError(err)
+
+	lang, _ := doc.Find("html").Attr("lang")
+	suite.Equal("fr", lang)
+}
+
+func (suite *LocaleTestSuite) SetupSuite() {
+	suite.detector = &ginhtmx.LocaleDetector{
+		Supported:  []string{"en-US", "de", "fr"},
+		Default:    "en-US",
+		CookieName: "locale",
+		QueryParam: "lang",
+	}
+
+	templateContent := `
+{{define "layout"}}<html lang="{{.Locale}}"><body>{{.Content}}</body></html>{{end}}
+{{define "hello"}}<h1>{{ t "hello" }}</h1>{{end}}
+`
+	tmpl := template.Must(template.New("").Funcs(ginhtmx.FuncMap()).Parse(templateContent))
+	suite.htmx = ginhtmx.NewHtmxWithConfig(tmpl, ginhtmx.HtmxConfig{
+		LayoutTemplateName:  "layout",
+		ContentVariableName: "Content",
+		ModelDecorator:      suite.detector,
+		Translator: ginhtmx.NewCatalog("en").
+			AddMessages("en", map[string]string{"hello": "Hello"}).
+			AddMessages("de", map[string]string{"hello": "Hallo"}),
+		LocaleResolver: nil,
+	})
+}
+
+func TestLocaleTestSuite(t *testing.T) {
+	t.Parallel()
+	suite.Run(t, new(LocaleTestSuite))
+}
+
+type LocaleTestSuite struct {
+	suite.Suite
+
+	detector *ginhtmx.LocaleDetector
+	htmx     *ginhtmx.Htmx
+}
@@ -0,0 +1,39 @@
+package ginhtmx
+
+import (
+	"errors"
+	"log/slog"
+
+	"github.com/gin-gonic/gin"
+)
+
+// logRender logs failed and slow renders to the configured logger.
+func (htmx *Htmx) logRender(ginContext *gin.Context, observation RenderObservation) {
+	logger := htmx.config.Logger
+	if logger == nil {
+		return
+	}
+
+	attributes := []any{
+		slog.String("method", ginContext.Request.Method),
+		slog.String("path", ginContext.Request.URL.Path),
+		slog.String("request_id", htmx.RequestID(ginContext)),
+		slog.Any("templates", observation.Templates),
+		slog.String("variant", observation.Variant()),
+		slog.Int("status", observation.Status),
+		slog.Duration("duration", observation.Duration),
+	}
+
+	ctx := ginContext.Request.Context()
+
+	switch {
+	case errors.Is(observation.Err, ErrClientDisconnected):
+		logger.DebugContext(ctx, "ginhtmx: client disconnected", append(attributes, slog.Any("error", observation.Err))...)
+	case errors.Is(observation.Err, ErrTemplateNotFound):
+		logger.ErrorContext(ctx, "ginhtmx: template not found", append(attributes, slog.Any("error", observation.Err))...)
+	case observation.Err != nil:
+		logger.ErrorContext(ctx, "ginhtmx: render failed", append(attributes, slog.Any("error", observation.Err))...)
+	case htmx.config.SlowRenderThreshold > 0 && observation.Duration > htmx.config.SlowRenderThreshold:
+		logger.WarnContext(ctx, "ginhtmx: slow render", attributes...)
+	}
+}
@@ -0,0 +1,110 @@
+package ginhtmx_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"html/template"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/jeffscottbrown/ginhtmxtemplates/ginhtmx"
+	"github.com/stretchr/testify/suite"
+)
+
+func (suite *LoggingTestSuite) TestMissingTemplateIsLogged() {
+	var output bytes.Buffer
+
+	htmx := suite.newHtmx(&output, 0)
+	suite.render(htmx, "missing")
+
+	entries := suite.entries(output)
+	suite.Require().Len(entries, 1)
+	suite.Equal("ERROR", entries[0]["level"])
+	suite.Equal("ginhtmx: template not found", entries[0]["msg"])
+	suite.Equal("/users", entries[0]["path"])
+	suite.Equal(http.MethodGet, entries[0]["method"])
+	suite.Contains(entries[0]["error"], `"missing"`)
+}
+
+func (suite *LoggingTestSuite) TestRenderErrorIsLogged() {
+	var output bytes.Buffer
+
+	htmx := suite.newHtmx(&output, 0)
+	suite.render(htmx, "broken")
+
+	entries := suite.entries(output)
+	suite.Require().Len(entries, 1)
+	suite.Equal("ginhtmx: render failed", entries[0]["msg"])
+	suite.Equal([]any{"broken"}, entries[0]["templates"])
+}
+
+func (suite *LoggingTestSuite) TestSlowRenderIsLogged() {
+	var output bytes.Buffer
+
+	htmx := suite.newHtmx(&output, time.Nanosecond)
+	suite.render(htmx, "slow")
+
+	entries := suite.entries(output)
+	suite.Require().Len(entries, 1)
+	suite.Equal("WARN", entries[0]["level"])
+	suite.Equal("ginhtmx: slow render", entries[0]["msg"])
+	suite.Equal("htmx", entries[0]["variant"])
+}
+
+func (suite *LoggingTestSuite) TestSuccessfulRenderIsNotLogged() {
+	var output bytes.Buffer
+
+	htmx := suite.newHtmx(&output, time.Hour)
+	suite.render(htmx, "slow")
+
+	suite.Empty(output.String())
+}
+
+func (suite *LoggingTestSuite) render(htmx *ginhtmx.Htmx, templateNames ...string) {
+	testContext, _ := gin.CreateTestContext(httptest.NewRecorder())
+	testContext.Request = httptest.NewRequest(http.MethodGet, "/users?page=2", nil)
+	testContext.Request.Header.Set("Hx-Request", "true")
+
+	htmx.Render(testContext, gin.H{}, templateNames...)
+}
+
+func (suite *LoggingTestSuite) entries(output bytes.Buffer) []map[string]any {
+	var entries []map[string]any
+
+	for line := range strings.Lines(output.String()) {
+		var entry map[string]any
+		suite.Require().NoError(json.Unmarshal([]byte(line), &entry))
+
+		entries = append(entries, entry)
+	}
+
+	return entries
+}
+
+func (suite *LoggingTestSuite) newHtmx(output *bytes.Buffer, threshold time.Duration) *ginhtmx.Htmx {
+	tmpl := template.Must(template.New("").Parse(`
+{{define "slow"}}<p>Slow</p>{{end}}
+{{define "broken"}}{{ template "nested" }}{{end}}
+`))
+
+	return ginhtmx.NewHtmxWithConfig(tmpl, ginhtmx.HtmxConfig{
+		LayoutTemplateName:  "layout",
+		ContentVariableName: "Content",
+		Logger:              slog.New(slog.NewJSONHandler(output, nil)),
+		SlowRenderThreshold: threshold,
+	})
+}
+
+func TestLoggingTestSuite(t *testing.T) {
+	t.Parallel()
+	suite.Run(t, new(LoggingTestSuite))
+}
+
+type LoggingTestSuite struct {
+	suite.Suite
+}
@@ -0,0 +1,36 @@
+package ginhtmx
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"html/template"
+)
+
+// ErrMarkdownDisabled is returned by the "markdown" template function when no
+// Markdown converter is configured.
+var ErrMarkdownDisabled = errors.New("markdown rendering is not enabled")
+
+// markdown converts trusted markdown, such as the content of a page managed by the
+// authors of the application, to HTML using the configured Markdown converter:
+//
+//	<article>{{ markdown .Page.Body }}</article>
+//
+// Unless the converter was created with the html.WithUnsafe renderer option, raw
+// HTML in the markdown is omitted and links with dangerous schemes such as
+// javascript: are removed, so the result is safe to output as template.HTML.
+func (scope *requestScope) markdown(source string) (template.HTML, error) {
+	if scope.htmx == nil || scope.htmx.config.Markdown == nil {
+		return "", ErrMarkdownDisabled
+	}
+
+	var buffer bytes.Buffer
+
+	err := scope.htmx.config.Markdown.Convert([]byte(source), &buffer)
+	if err != nil {
+		return "", fmt.Errorf("converting markdown: %w", err)
+	}
+
+	//nolint:gosec
+	return template.HTML(buffer.String()), nil
+}
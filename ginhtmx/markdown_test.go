@@ -0,0 +1,79 @@
+package ginhtmx_test
+
+import (
+	"errors"
+	"html/template"
+	"io"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/jeffscottbrown/ginhtmxtemplates/ginhtmx"
+	"github.com/stretchr/testify/suite"
+	"github.com/yuin/goldmark"
+	"github.com/yuin/goldmark/parser"
+)
+
+func (suite *MarkdownTestSuite) TestMarkdownIsConvertedToHTML() {
+	htmx := suite.newHtmx(goldmark.New())
+
+	rendered, err := htmx.RenderToString("article", gin.H{"Body": "# Title\n\nSome *emphasis* and a [link](/about)."})
+	suite.Require().NoError(err)
+	suite.Equal("<article><h1>Title</h1>\n<p>Some <em>emphasis</em> and a <a href=\"/about\">link</a>.</p>\n</article>",
+		rendered)
+}
+
+func (suite *MarkdownTestSuite) TestUnsafeMarkdownIsSanitized() {
+	htmx := suite.newHtmx(goldmark.New())
+
+	rendered, err := htmx.RenderToString("article", gin.H{
+		"Body": "<script>alert(1)</script>\n\n[click](javascript:alert(1))",
+	})
+	suite.Require().NoError(err)
+	suite.NotContains(rendered, "<script>")
+	suite.NotContains(rendered, "javascript:")
+}
+
+func (suite *MarkdownTestSuite) TestMarkdownMustBeEnabled() {
+	_, err := suite.newHtmx(nil).RenderToString("article", gin.H{"Body": "# Title"})
+	suite.Require().ErrorIs(err, ginhtmx.ErrMarkdownDisabled)
+
+	err = template.Must(template.New("").Funcs(ginhtmx.FuncMap()).Parse(`{{ markdown "# Title" }}`)).Execute(
+		io.Discard, nil)
+	suite.Require().ErrorIs(err, ginhtmx.ErrMarkdownDisabled)
+}
+
+func (suite *MarkdownTestSuite) TestConversionErrorsAreReturned() {
+	_, err := suite.newHtmx(failingMarkdown{Markdown: goldmark.New()}).RenderToString("article", gin.H{"Body": "# Title"})
+	suite.Require().ErrorIs(err, errConversion)
+}
+
+func (suite *MarkdownTestSuite) newHtmx(markdown goldmark.Markdown) *ginhtmx.Htmx {
+	tmpl := template.Must(template.New("").Funcs(ginhtmx.FuncMap()).Parse(
+		`{{define "article"}}<article>{{ markdown .Body }}</article>{{end}}`))
+
+	return ginhtmx.NewHtmxWithConfig(tmpl, ginhtmx.HtmxConfig{
+		LayoutTemplateName:  "layout",
+		ContentVariableName: "Content",
+		Markdown:            markdown,
+	})
+}
+
+func TestMarkdownTestSuite(t *testing.T) {
+	t.Parallel()
+	suite.Run(t, new(MarkdownTestSuite))
+}
+
+type MarkdownTestSuite struct {
+	suite.Suite
+}
+
+var errConversion = errors.New("conversion failed")
+
+// failingMarkdown is a converter which fails to convert anything.
+type failingMarkdown struct {
+	goldmark.Markdown
+}
+
+func (failingMarkdown) Convert([]byte, io.Writer, ...parser.ParseOption) error {
+	return errConversion
+}
@@ -0,0 +1,149 @@
+package ginhtmx
+
+import "html"
+
+// PageMeta holds the title and description of the page being rendered.
+type PageMeta struct {
+	// Title is the title of the page
+	Title string
+
+	// Description is the meta description of the page
+	Description string
+
+	// OpenGraph is the social metadata of the page
+	OpenGraph OpenGraph
+
+	// StructuredData are the objects rendered as JSON-LD
+	StructuredData []any
+}
+
+// OpenGraph holds the Open Graph metadata used when a page is shared on social
+// media. The layout renders it with the built-in "ginhtmx/open_graph" template,
+// which may be overridden:
+//
+//	<head>{{ template "ginhtmx/open_graph" openGraph }}</head>
+type OpenGraph struct {
+	// Title is the title of the page. Defaults to the title of the page.
+	Title string
+
+	// Description is the description of the page. Defaults to the description of the page.
+	Description string
+
+	// Image is the absolute URL of an image representing the page
+	Image string
+
+	// Type is the type of the page, such as "article". Defaults to "website".
+	Type string
+
+	// URL is the absolute canonical URL of the page
+	URL string
+
+	// SiteName is the name of the site
+	SiteName string
+}
+
+// WithOpenGraph returns a RenderOption which sets the Open Graph metadata of the page.
+func WithOpenGraph(openGraph OpenGraph) RenderOption {
+	return func(settings *renderSettings) {
+		settings.meta.OpenGraph = openGraph
+	}
+}
+
+// Title returns a RenderOption which sets the title of the page. The layout reads
+// it with the "pageTitle" template function.
+func Title(title string) RenderOption {
+	return func(settings *renderSettings) {
+		settings.meta.Title = title
+	}
+}
+
+// Description returns a RenderOption which sets the meta description of the page.
+// The layout reads it with the "pageDescription" template function.
+func Description(description string) RenderOption {
+	return func(settings *renderSettings) {
+		settings.meta.Description = description
+	}
+}
+
+// setTitle sets the title of the page from within a page template, overriding the
+// Title option. It renders nothing:
+//
+//	{{ setTitle (printf "%s - Users" .User.Name) }}
+//
+// The layout is rendered after the page templates, so it can use the title:
+//
+//	<title>{{ pageTitle }}</title>
+//
+// For fragment responses a <title> element holding the title is appended to the
+// response, which HTMX uses to update the title of the browser tab.
+func (scope *requestScope) setTitle(title string) string {
+	scope.mutex.Lock()
+	defer scope.mutex.Unlock()
+
+	scope.meta.Title = title
+
+	return ""
+}
+
+// setDescription sets the meta description of the page from within a page template,
+// overriding the Description option. It renders nothing.
+func (scope *requestScope) setDescription(description string) string {
+	scope.mutex.Lock()
+	defer scope.mutex.Unlock()
+
+	scope.meta.Description = description
+
+	return ""
+}
+
+// pageTitle returns the title of the page.
+func (scope *requestScope) pageTitle() string {
+	scope.mutex.Lock()
+	defer scope.mutex.Unlock()
+
+	return scope.meta.Title
+}
+
+// pageDescription returns the meta description of the page.
+func (scope *requestScope) pageDescription() string {
+	scope.mutex.Lock()
+	defer scope.mutex.Unlock()
+
+	return scope.meta.Description
+}
+
+// openGraph returns the Open Graph metadata of the page, with the title and
+// description of the page used when not set.
+func (scope *requestScope) openGraph() OpenGraph {
+	scope.mutex.Lock()
+	defer scope.mutex.Unlock()
+
+	openGraph := scope.meta.OpenGraph
+
+	if openGraph.Title == "" {
+		openGraph.Title = scope.meta.Title
+	}
+
+	if openGraph.Description == "" {
+		openGraph.Description = scope.meta.Description
+	}
+
+	if openGraph.Type == "" {
+		openGraph.Type = "website"
+	}
+
+	return openGraph
+}
+
+// titleElement returns the <title> element appended to fragment responses, or an
+// empty string if the page has no title.
+func (scope *requestScope) titleElement() string {
+	scope.mutex.Lock()
+	defer scope.mutex.Unlock()
+
+	if scope.meta.Title == "" {
+		return ""
+	}
+
+	return "<title>" + html.EscapeString(scope.meta.Title) + "</title>"
+}
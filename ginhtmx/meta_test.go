@@ -0,0 +1,102 @@
+package ginhtmx_test
+
+import (
+	"html/template"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/jeffscottbrown/ginhtmxtemplates/ginhtmx"
+	"github.com/stretchr/testify/suite"
+)
+
+func (suite *PageMetaTestSuite) TestLayoutUsesTitleFromTemplate() {
+	body := suite.render(suite.htmx, false, "user")
+
+	suite.Equal(`<html><title>Jerry &amp; co</title><meta name="description" content="A user"><p>Jerry</p></html>`, body)
+}
+
+func (suite *PageMetaTestSuite) TestLayoutUsesTitleFromOptions() {
+	body := suite.render(suite.htmx.With(ginhtmx.Title("Users"), ginhtmx.Description("All users")), false, "users")
+
+	suite.Equal(`<html><title>Users</title><meta name="description" content="All users"><p>users</p></html>`, body)
+}
+
+func (suite *PageMetaTestSuite) TestTemplateOverridesOptions() {
+	body := suite.render(suite.htmx.With(ginhtmx.Title("Users")), false, "user")
+
+	suite.Contains(body, `<title>Jerry &amp; co</title>`)
+}
+
+func (suite *PageMetaTestSuite) TestFragmentsIncludeTitleElement() {
+	suite.Equal(`<p>Jerry</p><title>Jerry &amp; co</title>`, suite.render(suite.htmx, true, "user"))
+	suite.Equal(`<p>users</p>`, suite.render(suite.htmx, true, "users"))
+}
+
+func (suite *PageMetaTestSuite) TestOpenGraph() {
+	body := suite.render(suite.social.With(ginhtmx.WithOpenGraph(ginhtmx.OpenGraph{
+		Image:    "https://example.com/jerry.png",
+		URL:      "https://example.com/users/3",
+		SiteName: "Example",
+	})), false, "social")
+
+	suite.Equal(`<meta property="og:title" content="Jerry &amp; co">`+
+		`<meta property="og:description" content="A user">`+
+		`<meta property="og:type" content="website">`+
+		`<meta property="og:url" content="https://example.com/users/3">`+
+		`<meta property="og:image" content="https://example.com/jerry.png">`+
+		`<meta name="twitter:card" content="summary_large_image">`+
+		`<meta property="og:site_name" content="Example">`, body)
+
+	body = suite.render(suite.social.With(ginhtmx.WithOpenGraph(ginhtmx.OpenGraph{
+		Title:       "Jerry",
+		Description: "Comedian",
+		Type:        "profile",
+	})), false, "social")
+
+	suite.Equal(`<meta property="og:title" content="Jerry">`+
+		`<meta property="og:description" content="Comedian">`+
+		`<meta property="og:type" content="profile">`, body)
+}
+
+func (suite *PageMetaTestSuite) render(htmx *ginhtmx.Htmx, htmxRequest bool, name string) string {
+	recorder := httptest.NewRecorder()
+	testContext, _ := gin.CreateTestContext(recorder)
+	testContext.Request = httptest.NewRequest(http.MethodGet, "/", nil)
+
+	if htmxRequest {
+		testContext.Request.Header.Set("Hx-Request", "true")
+	}
+
+	htmx.Render(testContext, gin.H{}, name)
+
+	return recorder.Body.String()
+}
+
+func (suite *PageMetaTestSuite) SetupSuite() {
+	tmpl := template.Must(template.New("").Funcs(ginhtmx.FuncMap()).Parse(`
+{{- define "layout"}}<html><title>{{pageTitle}}</title><meta name="description" content="{{pageDescription}}">{{.Content}}</html>{{end}}
+{{- define "user"}}{{setTitle "Jerry & co"}}{{setDescription "A user"}}<p>Jerry</p>{{end}}
+{{- define "users"}}<p>users</p>{{end}}
+{{- define "social"}}{{setTitle "Jerry & co"}}{{setDescription "A user"}}{{end}}
+{{- define "social_layout"}}{{template "ginhtmx/open_graph" openGraph}}{{end}}
+`))
+	suite.htmx = ginhtmx.NewHtmx(tmpl)
+	suite.social = ginhtmx.NewHtmxWithConfig(tmpl, ginhtmx.HtmxConfig{
+		LayoutTemplateName:  "social_layout",
+		ContentVariableName: "Content",
+	})
+}
+
+func TestPageMetaTestSuite(t *testing.T) {
+	t.Parallel()
+	suite.Run(t, new(PageMetaTestSuite))
+}
+
+type PageMetaTestSuite struct {
+	suite.Suite
+
+	htmx   *ginhtmx.Htmx
+	social *ginhtmx.Htmx
+}
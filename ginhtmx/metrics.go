@@ -0,0 +1,134 @@
+package ginhtmx
+
+import "time"
+
+// MetricsRecorder receives measurements of render operations so that they can be
+// exported to a metrics system. A recorder backed by Prometheus might look like:
+//
+//	type prometheusRecorder struct {
+//	  durations *prometheus.HistogramVec // labels: template, variant
+//	  errors    *prometheus.CounterVec   // labels: template, variant
+//	}
+//
+//	func (recorder *prometheusRecorder) ObserveTemplate(observation ginhtmx.TemplateObservation) {
+//	  variant := observation.Variant()
+//	  recorder.durations.WithLabelValues(observation.Template, variant).Observe(observation.Duration.Seconds())
+//	  if observation.Err != nil {
+//	    recorder.errors.WithLabelValues(observation.Template, variant).Inc()
+//	  }
+//	}
+//
+//	func (recorder *prometheusRecorder) ObserveRender(ginhtmx.RenderObservation) {}
+//
+//	func (recorder *prometheusRecorder) ObserveCache(ginhtmx.CacheObservation) {}
+//
+// Recorders are called from the goroutine handling the request, or from the
+// goroutines rendering each template when ConcurrentRendering is enabled, and must
+// be safe for concurrent use.
+type MetricsRecorder interface {
+	// ObserveTemplate is called after each template, including the layout, is executed.
+	ObserveTemplate(observation TemplateObservation)
+
+	// ObserveRender is called once the response of a render has been written.
+	ObserveRender(observation RenderObservation)
+
+	// ObserveCache is called each time the output of a render using CacheFor is
+	// looked up in the Cache, so that the hit rate of the cache can be monitored.
+	ObserveCache(observation CacheObservation)
+}
+
+// CacheResult is the outcome of looking up the output of templates in the Cache.
+type CacheResult string
+
+const (
+	// CacheHit means fresh output was found and served.
+	CacheHit CacheResult = "hit"
+
+	// CacheMiss means no usable output was found, or the cache could not be read,
+	// and the templates were rendered.
+	CacheMiss CacheResult = "miss"
+
+	// CacheStale means output whose ttl has expired was served while it is rendered
+	// again in the background, see StaleWhileRevalidate.
+	CacheStale CacheResult = "stale"
+)
+
+// CacheObservation describes a lookup of the output of templates in the Cache.
+type CacheObservation struct {
+	// Templates are the names of the templates whose output was looked up.
+	Templates []string
+
+	// Fragment is true when the output is sent as a fragment rather than a full page.
+	Fragment bool
+
+	// Result is the outcome of the lookup.
+	Result CacheResult
+}
+
+// Variant returns "htmx" for a fragment and "full" for a full page, which is
+// convenient as a metric label.
+func (observation CacheObservation) Variant() string {
+	return variantLabel(observation.Fragment)
+}
+
+// TemplateObservation describes the execution of a single template.
+type TemplateObservation struct {
+	// Template is the name of the template which was executed.
+	Template string
+
+	// Fragment is true when the output is sent as a fragment rather than a full page.
+	Fragment bool
+
+	// Bytes is the size of the output of the template.
+	Bytes int
+
+	// Duration is the time taken to execute the template.
+	Duration time.Duration
+
+	// Err is the error returned by the template, if any.
+	Err error
+}
+
+// Variant returns "htmx" for a fragment and "full" for a full page, which is
+// convenient as a metric label.
+func (observation TemplateObservation) Variant() string {
+	return variantLabel(observation.Fragment)
+}
+
+// RenderObservation describes the outcome of a call to Render or RenderWithStatus.
+// The same values are stored in the gin context under the keys described in
+// context.go.
+type RenderObservation struct {
+	// Templates are the names of the templates which were rendered.
+	Templates []string
+
+	// Status is the HTTP status code written to the response.
+	Status int
+
+	// Fragment is true when the templates were written as a fragment and false
+	// when they were wrapped in the layout.
+	Fragment bool
+
+	// Bytes is the number of bytes written to the response body.
+	Bytes int
+
+	// Duration is the time spent rendering and writing the response.
+	Duration time.Duration
+
+	// Err is the error returned while rendering, or nil if rendering succeeded.
+	Err error
+}
+
+// Variant returns "htmx" for a fragment and "full" for a full page, which is
+// convenient as a metric label.
+func (observation RenderObservation) Variant() string {
+	return variantLabel(observation.Fragment)
+}
+
+func variantLabel(fragment bool) string {
+	if fragment {
+		return "htmx"
+	}
+
+	return "full"
+}
@@ -0,0 +1,151 @@
+package ginhtmx_test
+
+import (
+	"html/template"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/jeffscottbrown/ginhtmxtemplates/ginhtmx"
+	"github.com/stretchr/testify/suite"
+)
+
+func (suite *MetricsTestSuite) TestFullPageIsObserved() {
+	recorder := &observingRecorder{}
+	htmx := suite.newHtmx(recorder)
+
+	testContext, _ := gin.CreateTestContext(httptest.NewRecorder())
+	testContext.Request = httptest.NewRequest(http.MethodGet, "/", nil)
+
+	htmx.Render(testContext, gin.H{}, "hello", "missing")
+
+	suite.Require().Len(recorder.templates, 3)
+	suite.Equal("hello", recorder.templates[0].Template)
+	suite.Equal("full", recorder.templates[0].Variant())
+	suite.Equal(len("<p>Hello</p>"), recorder.templates[0].Bytes)
+	suite.NoError(recorder.templates[0].Err)
+	suite.Error(recorder.templates[1].Err)
+	suite.Equal("layout", recorder.templates[2].Template)
+
+	suite.Require().Len(recorder.renders, 1)
+	suite.Equal([]string{"hello", "missing"}, recorder.renders[0].Templates)
+	suite.Equal("full", recorder.renders[0].Variant())
+	suite.Equal(http.StatusOK, recorder.renders[0].Status)
+	suite.Error(recorder.renders[0].Err)
+}
+
+func (suite *MetricsTestSuite) TestFragmentIsObserved() {
+	recorder := &observingRecorder{}
+	htmx := suite.newHtmx(recorder)
+
+	testContext, _ := gin.CreateTestContext(httptest.NewRecorder())
+	testContext.Request = httptest.NewRequest(http.MethodGet, "/", nil)
+	testContext.Request.Header.Set("Hx-Request", "true")
+
+	htmx.Render(testContext, gin.H{}, "hello")
+
+	suite.Require().Len(recorder.templates, 1)
+	suite.Equal("htmx", recorder.templates[0].Variant())
+	suite.Require().Len(recorder.renders, 1)
+	suite.Equal("htmx", recorder.renders[0].Variant())
+	suite.Equal(len("<p>Hello</p>"), recorder.renders[0].Bytes)
+	suite.NoError(recorder.renders[0].Err)
+}
+
+func (suite *MetricsTestSuite) TestCacheLookupsAreObserved() {
+	recorder := &observingRecorder{}
+
+	var now atomic.Int64
+
+	now.Store(time.Now().UnixNano())
+
+	htmx := ginhtmx.NewHtmxWithConfig(template.Must(template.New("").Parse(`{{define "hello"}}<p>Hello</p>{{end}}`)),
+		ginhtmx.HtmxConfig{Metrics: recorder, Cache: ginhtmx.NewMemoryCache()})
+	cached := htmx.With(
+		ginhtmx.CacheFor(time.Minute, ""),
+		ginhtmx.StaleWhileRevalidate(time.Hour),
+		ginhtmx.Clock(func() time.Time { return time.Unix(0, now.Load()) }),
+	)
+
+	for _, elapsed := range []time.Duration{0, time.Second, 2 * time.Minute, 2 * time.Hour} {
+		now.Add(int64(elapsed))
+
+		testContext, _ := gin.CreateTestContext(httptest.NewRecorder())
+		testContext.Request = httptest.NewRequest(http.MethodGet, "/", nil)
+		testContext.Request.Header.Set("Hx-Request", "true")
+
+		cached.Render(testContext, gin.H{}, "hello")
+	}
+
+	suite.Equal([]ginhtmx.CacheResult{ginhtmx.CacheMiss, ginhtmx.CacheHit, ginhtmx.CacheStale, ginhtmx.CacheMiss},
+		recorder.results())
+	suite.Equal([]string{"hello"}, recorder.caches[0].Templates)
+	suite.Equal("htmx", recorder.caches[0].Variant())
+}
+
+func (suite *MetricsTestSuite) newHtmx(recorder ginhtmx.MetricsRecorder) *ginhtmx.Htmx {
+	tmpl := template.Must(template.New("").Parse(`
+{{define "layout"}}<html>{{.Content}}</html>{{end}}
+{{define "hello"}}<p>Hello</p>{{end}}
+`))
+
+	return ginhtmx.NewHtmxWithConfig(tmpl, ginhtmx.HtmxConfig{
+		LayoutTemplateName:  "layout",
+		ContentVariableName: "Content",
+		Metrics:             recorder,
+	})
+}
+
+func TestMetricsTestSuite(t *testing.T) {
+	t.Parallel()
+	suite.Run(t, new(MetricsTestSuite))
+}
+
+type MetricsTestSuite struct {
+	suite.Suite
+}
+
+type observingRecorder struct {
+	mutex     sync.Mutex
+	templates []ginhtmx.TemplateObservation
+	renders   []ginhtmx.RenderObservation
+	caches    []ginhtmx.CacheObservation
+}
+
+func (recorder *observingRecorder) ObserveTemplate(observation ginhtmx.TemplateObservation) {
+	recorder.mutex.Lock()
+	defer recorder.mutex.Unlock()
+
+	recorder.templates = append(recorder.templates, observation)
+}
+
+func (recorder *observingRecorder) ObserveRender(observation ginhtmx.RenderObservation) {
+	recorder.mutex.Lock()
+	defer recorder.mutex.Unlock()
+
+	recorder.renders = append(recorder.renders, observation)
+}
+
+func (recorder *observingRecorder) ObserveCache(observation ginhtmx.CacheObservation) {
+	recorder.mutex.Lock()
+	defer recorder.mutex.Unlock()
+
+	recorder.caches = append(recorder.caches, observation)
+}
+
+// results returns the results of the cache lookups observed so far.
+func (recorder *observingRecorder) results() []ginhtmx.CacheResult {
+	recorder.mutex.Lock()
+	defer recorder.mutex.Unlock()
+
+	results := make([]ginhtmx.CacheResult, 0, len(recorder.caches))
+	for _, observation := range recorder.caches {
+		results = append(results, observation.Result)
+	}
+
+	return results
+}
@@ -0,0 +1,59 @@
+package ginhtmx
+
+import (
+	"html"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	// DefaultModalTarget is the element modals are added to unless configured otherwise.
+	DefaultModalTarget = "body"
+
+	// DefaultModalSwap is the swap strategy used for modals unless configured otherwise.
+	DefaultModalSwap = "beforeend"
+)
+
+// Retarget returns a RenderOption which sets the HX-Retarget response header, so that
+// the response is swapped into the elements matching selector instead of the target
+// of the element which made the request.
+func Retarget(selector string) RenderOption {
+	return func(settings *renderSettings) {
+		settings.headers.Set("HX-Retarget", selector)
+	}
+}
+
+// RenderModal renders a modal dialog. For HTMX requests the response is retargeted
+// to the ModalTarget of the configuration, "body" by default, and swapped using the
+// ModalSwap of the configuration, "beforeend" by default, so that the modal is added
+// to the page whichever element opened it:
+//
+//	handler.htmx.RenderModal(c, gin.H{"User": user}, "edit_user_modal")
+//
+// Close the modal with CloseModal.
+func (htmx *Htmx) RenderModal(ginContext *gin.Context, data gin.H, templateNames ...string) {
+	target := htmx.config.ModalTarget
+	if target == "" {
+		target = DefaultModalTarget
+	}
+
+	swap := htmx.config.ModalSwap
+	if swap == "" {
+		swap = DefaultModalSwap
+	}
+
+	htmx.With(Retarget(target), Reswap(swap)).RenderWithStatus(ginContext, data, http.StatusOK, templateNames...)
+}
+
+// CloseModal responds with an out of band swap which removes the element with the id
+// modalID, usually the modal rendered by RenderModal, without swapping anything
+// into the target of the request. The options may trigger events, for example to
+// refresh the content the modal edited:
+//
+//	handler.htmx.CloseModal(c, "edit-user-modal", ginhtmx.Trigger("user-updated"))
+func (htmx *Htmx) CloseModal(ginContext *gin.Context, modalID string, options ...RenderOption) {
+	htmx.renderSettings(append([]RenderOption{Reswap("none")}, options...)...).writeHeaders(ginContext)
+	ginContext.Data(http.StatusOK, htmx.contentType(),
+		[]byte(`<div id="`+html.EscapeString(modalID)+`" hx-swap-oob="delete"></div>`))
+}
@@ -0,0 +1,75 @@
+package ginhtmx_test
+
+import (
+	"html/template"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/jeffscottbrown/ginhtmxtemplates/ginhtmx"
+	"github.com/stretchr/testify/suite"
+)
+
+func (suite *ModalTestSuite) TestRenderModalRetargetsBody() {
+	recorder, testContext := suite.newContext()
+
+	ginhtmx.NewHtmx(suite.template).RenderModal(testContext, gin.H{"Name": "Jerry"}, "edit_user_modal")
+
+	suite.Equal(`<dialog id="edit-user-modal" open>Jerry</dialog>`, recorder.Body.String())
+	suite.Equal("body", recorder.Header().Get("HX-Retarget"))
+	suite.Equal("beforeend", recorder.Header().Get("HX-Reswap"))
+}
+
+func (suite *ModalTestSuite) TestModalTargetAndSwapAreConfigurable() {
+	recorder, testContext := suite.newContext()
+
+	htmx := ginhtmx.NewHtmxWithConfig(suite.template, ginhtmx.HtmxConfig{
+		LayoutTemplateName:  "layout",
+		ContentVariableName: "Content",
+		ModalTarget:         "#modals",
+		ModalSwap:           "innerHTML",
+	})
+	htmx.RenderModal(testContext, gin.H{"Name": "Jerry"}, "edit_user_modal")
+
+	suite.Equal("#modals", recorder.Header().Get("HX-Retarget"))
+	suite.Equal("innerHTML", recorder.Header().Get("HX-Reswap"))
+}
+
+func (suite *ModalTestSuite) TestCloseModal() {
+	recorder, testContext := suite.newContext()
+
+	ginhtmx.NewHtmx(suite.template).CloseModal(testContext, `edit-user-modal"`, ginhtmx.Trigger("user-updated"))
+
+	suite.Equal(http.StatusOK, recorder.Code)
+	suite.Equal(`<div id="edit-user-modal&#34;" hx-swap-oob="delete"></div>`, recorder.Body.String())
+	suite.Equal("none", recorder.Header().Get("HX-Reswap"))
+	suite.JSONEq(`{"user-updated": null}`, recorder.Header().Get("HX-Trigger"))
+}
+
+func (suite *ModalTestSuite) newContext() (*httptest.ResponseRecorder, *gin.Context) {
+	recorder := httptest.NewRecorder()
+	testContext, _ := gin.CreateTestContext(recorder)
+	testContext.Request = httptest.NewRequest(http.MethodGet, "/users/3/edit", nil)
+	testContext.Request.Header.Set("Hx-Request", "true")
+
+	return recorder, testContext
+}
+
+func (suite *ModalTestSuite) SetupSuite() {
+	suite.template = template.Must(template.New("").Parse(`
+{{- define "layout"}}<html>{{.Content}}</html>{{end}}
+{{- define "edit_user_modal"}}<dialog id="edit-user-modal" open>{{.Name}}</dialog>{{end}}
+`))
+}
+
+func TestModalTestSuite(t *testing.T) {
+	t.Parallel()
+	suite.Run(t, new(ModalTestSuite))
+}
+
+type ModalTestSuite struct {
+	suite.Suite
+
+	template *template.Template
+}
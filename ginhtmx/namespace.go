@@ -0,0 +1,115 @@
+package ginhtmx
+
+import (
+	"fmt"
+	"html/template"
+	"io/fs"
+	"path"
+	"strings"
+	"text/template/parse"
+)
+
+// ParseNamespacedFS parses the files matching patterns in fsys into tmpl. Unlike
+// template.ParseFS, which names each file's template after its base name, each
+// file defines a template named after its path without the extension, so that
+// users/show.html and products/show.html become "users/show" and "products/show"
+// instead of silently replacing each other:
+//
+//	views, _ := fs.Sub(templates, "templates")
+//	tmpl, err := ginhtmx.ParseNamespacedFS(template.New("").Funcs(ginhtmx.FuncMap()),
+//	  views, "*.html", "users/*.html", "products/*.html")
+//
+// An error wrapping ErrDuplicateTemplate is returned if two files define a template
+// with the same name, whether by their paths or with define and block actions, and
+// ErrNoTemplateFiles is returned if no file matches the patterns.
+func ParseNamespacedFS(tmpl *template.Template, fsys fs.FS, patterns ...string) (*template.Template, error) {
+	files := newTemplateFiles(tmpl)
+	parsed := 0
+
+	for _, pattern := range patterns {
+		fileNames, err := fs.Glob(fsys, pattern)
+		if err != nil {
+			return nil, fmt.Errorf("matching template files: %w", err)
+		}
+
+		for _, fileName := range fileNames {
+			err = files.parse(fsys, fileName, strings.TrimSuffix(fileName, path.Ext(fileName)))
+			if err != nil {
+				return nil, err
+			}
+
+			parsed++
+		}
+	}
+
+	if parsed == 0 {
+		return nil, fmt.Errorf("%w: %v", ErrNoTemplateFiles, patterns)
+	}
+
+	return tmpl, nil
+}
+
+// NewHtmxFromNamespacedFS creates a new instance of Htmx with the provided
+// configuration and the templates parsed from fsys, see ParseNamespacedFS. The
+// template functions of the package are added to the templates.
+func NewHtmxFromNamespacedFS(config HtmxConfig, fsys fs.FS, patterns ...string) (*Htmx, error) {
+	tmpl, err := ParseNamespacedFS(template.New("").Funcs(FuncMap()), fsys, patterns...)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewHtmxWithConfig(tmpl, config), nil
+}
+
+// templateFiles parses template files into a template, detecting templates which
+// are defined by more than one file.
+type templateFiles struct {
+	template *template.Template
+	// definedBy maps the name of each template parsed to the file defining it
+	definedBy map[string]string
+}
+
+func newTemplateFiles(tmpl *template.Template) *templateFiles {
+	return &templateFiles{template: tmpl, definedBy: map[string]string{}}
+}
+
+// parse parses the file named fileName in fsys as the template called name, and
+// returns an error wrapping ErrDuplicateTemplate if the file defines a template
+// which an earlier file defined.
+func (files *templateFiles) parse(fsys fs.FS, fileName string, name string) error {
+	content, err := fs.ReadFile(fsys, fileName)
+	if err != nil {
+		return fmt.Errorf("reading %s: %w", fileName, err)
+	}
+
+	// the functions are checked when the file is parsed into the template below
+	tree := parse.New(name)
+	tree.Mode = parse.SkipFuncCheck
+	trees := map[string]*parse.Tree{}
+
+	_, err = tree.Parse(string(content), "", "", trees)
+	if err != nil {
+		return fmt.Errorf("parsing %s: %w", fileName, err)
+	}
+
+	for defined, definition := range trees {
+		// empty templates, such as the template of a file containing only
+		// definitions, do not replace existing templates
+		if parse.IsEmptyTree(definition.Root) {
+			continue
+		}
+
+		if existing, found := files.definedBy[defined]; found {
+			return fmt.Errorf("%w %q defined by %s and %s", ErrDuplicateTemplate, defined, existing, fileName)
+		}
+
+		files.definedBy[defined] = fileName
+	}
+
+	_, err = files.template.New(name).Parse(string(content))
+	if err != nil {
+		return fmt.Errorf("parsing %s: %w", fileName, err)
+	}
+
+	return nil
+}
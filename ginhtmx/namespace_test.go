@@ -0,0 +1,92 @@
+package ginhtmx_test
+
+import (
+	"html/template"
+	"path"
+	"testing"
+	"testing/fstest"
+
+	"github.com/gin-gonic/gin"
+	"github.com/jeffscottbrown/ginhtmxtemplates/ginhtmx"
+	"github.com/stretchr/testify/suite"
+)
+
+func (suite *NamespaceTestSuite) TestTemplatesAreNamedAfterTheirDirectories() {
+	htmx, err := ginhtmx.NewHtmxFromNamespacedFS(ginhtmx.HtmxConfig{
+		LayoutTemplateName:  "layout",
+		ContentVariableName: "Content",
+	}, suite.files, "*.html", "users/*.html", "products/*.html")
+	suite.Require().NoError(err)
+
+	for name, expected := range map[string]string{
+		"users/show":    "User Jerry",
+		"products/show": "Product Jerry",
+		"badge":         "<b>Jerry</b>",
+	} {
+		rendered, err := htmx.RenderToString(name, gin.H{"Name": "Jerry"})
+		suite.Require().NoError(err)
+		suite.Equal(expected, rendered)
+	}
+}
+
+func (suite *NamespaceTestSuite) TestDuplicateTemplatesAreReported() {
+	suite.files["users/show.tmpl"] = &fstest.MapFile{Data: []byte(`Other user`)}
+	_, err := suite.parse("*.html", "users/*")
+	suite.Require().ErrorIs(err, ginhtmx.ErrDuplicateTemplate)
+	suite.Require().EqualError(err, `duplicate template "users/show" defined by users/show.html and users/show.tmpl`)
+
+	suite.files["products/extra.html"] = &fstest.MapFile{Data: []byte(`{{define "badge"}}<i>{{ .Name }}</i>{{end}}`)}
+	_, err = suite.parse("*.html", "products/*.html")
+	suite.Require().EqualError(err, `duplicate template "badge" defined by layout.html and products/extra.html`)
+}
+
+func (suite *NamespaceTestSuite) TestEmptyTemplatesAreNotDuplicates() {
+	suite.files["products/extra.html"] = &fstest.MapFile{Data: []byte(`{{define "empty"}}{{end}}`)}
+	suite.files["users/extra.html"] = &fstest.MapFile{Data: []byte(`{{define "empty"}}{{end}}`)}
+
+	tmpl, err := suite.parse("users/*.html", "products/*.html")
+	suite.Require().NoError(err)
+	suite.NotNil(tmpl.Lookup("empty"))
+}
+
+func (suite *NamespaceTestSuite) TestInvalidTemplateFilesAreReported() {
+	_, err := suite.parse("missing/*.html")
+	suite.Require().ErrorIs(err, ginhtmx.ErrNoTemplateFiles)
+
+	_, err = suite.parse("[")
+	suite.Require().ErrorIs(err, path.ErrBadPattern)
+
+	_, err = suite.parse("users")
+	suite.Require().ErrorContains(err, "reading users")
+
+	suite.files["broken.html"] = &fstest.MapFile{Data: []byte(`{{ if }}`)}
+	_, err = ginhtmx.NewHtmxFromNamespacedFS(ginhtmx.HtmxConfig{}, suite.files, "broken.html")
+	suite.Require().ErrorContains(err, "parsing broken.html")
+
+	suite.files["broken.html"] = &fstest.MapFile{Data: []byte(`{{ undefinedFunction }}`)}
+	_, err = suite.parse("broken.html")
+	suite.Require().ErrorContains(err, `parsing broken.html: template: broken:1: function "undefinedFunction" not defined`)
+}
+
+func (suite *NamespaceTestSuite) parse(patterns ...string) (*template.Template, error) {
+	return ginhtmx.ParseNamespacedFS(template.New("").Funcs(ginhtmx.FuncMap()), suite.files, patterns...)
+}
+
+func (suite *NamespaceTestSuite) SetupTest() {
+	suite.files = fstest.MapFS{
+		"layout.html":        {Data: []byte(`{{define "badge"}}<b>{{ .Name }}</b>{{end}}`)},
+		"users/show.html":    {Data: []byte(`User {{ .Name }}`)},
+		"products/show.html": {Data: []byte(`Product {{ .Name }}`)},
+	}
+}
+
+func TestNamespaceTestSuite(t *testing.T) {
+	t.Parallel()
+	suite.Run(t, new(NamespaceTestSuite))
+}
+
+type NamespaceTestSuite struct {
+	suite.Suite
+
+	files fstest.MapFS
+}
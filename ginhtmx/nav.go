@@ -0,0 +1,49 @@
+package ginhtmx
+
+import (
+	"net/url"
+	"strings"
+)
+
+// currentPath returns the path of the page the user is viewing. For HTMX requests
+// the path of the HX-Current-URL header is used when present, as the request is
+// often made to an endpoint which only renders a fragment of the page:
+//
+//	<input type="hidden" name="return" value="{{ currentPath }}">
+func (scope *requestScope) currentPath() string {
+	if scope.ginContext == nil || scope.ginContext.Request == nil {
+		return ""
+	}
+
+	if scope.htmx.IsHTMXRequest(scope.ginContext) {
+		if current, err := url.Parse(scope.ginContext.GetHeader("HX-Current-URL")); err == nil && current.Path != "" {
+			return current.Path
+		}
+	}
+
+	return scope.ginContext.Request.URL.Path
+}
+
+// isCurrent reports whether path is the path of the current page:
+//
+//	<a href="/about"{{ if isCurrent "/about" }} aria-current="page"{{ end }}>About</a>
+func (scope *requestScope) isCurrent(path string) bool {
+	current := scope.currentPath()
+
+	return current != "" && strings.TrimSuffix(current, "/") == strings.TrimSuffix(path, "/")
+}
+
+// isActive reports whether the current page is path or is within the section at
+// path, so that "/users" is active for "/users/3" but not for "/usersettings".
+// The root path is only active for the root page:
+//
+//	<a href="/users" class="{{ if isActive "/users" }}active{{ end }}">Users</a>
+func (scope *requestScope) isActive(path string) bool {
+	if scope.isCurrent(path) {
+		return true
+	}
+
+	section := strings.TrimSuffix(path, "/")
+
+	return section != "" && strings.HasPrefix(scope.currentPath(), section+"/")
+}
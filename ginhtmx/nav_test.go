@@ -0,0 +1,79 @@
+package ginhtmx_test
+
+import (
+	"html/template"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/jeffscottbrown/ginhtmxtemplates/ginhtmx"
+	"github.com/stretchr/testify/suite"
+)
+
+func (suite *NavigationTestSuite) TestRequestPath() {
+	suite.Equal("[/users] /", suite.render("/users/3", ""))
+	suite.Equal("[/users] /", suite.render("/users", ""))
+	suite.Equal("/users [/]", suite.render("/", ""))
+	suite.Equal("/users /", suite.render("/usersettings", ""))
+}
+
+func (suite *NavigationTestSuite) TestCurrentURLOfHTMXRequests() {
+	suite.Equal("[/users] /", suite.render("/fragments/edit", "https://example.com/users/3/"))
+	suite.Equal("/users [/]", suite.render("/users/3", "https://example.com/"))
+	suite.Equal("[/users] /", suite.render("/users/3", "%zz"))
+}
+
+func (suite *NavigationTestSuite) TestIsCurrent() {
+	recorder := httptest.NewRecorder()
+	testContext, _ := gin.CreateTestContext(recorder)
+	testContext.Request = httptest.NewRequest(http.MethodGet, "/users/", nil)
+
+	suite.htmx.Render(testContext, gin.H{}, "current")
+
+	suite.Equal("<html>true false</html>", recorder.Body.String())
+}
+
+func (suite *NavigationTestSuite) TestDefaultsOutsideRequest() {
+	tmpl := template.Must(template.New("").Funcs(ginhtmx.FuncMap()).Parse(`{{isActive "/"}} {{isCurrent "/"}}`))
+
+	output, err := ginhtmx.NewHtmx(tmpl).RenderToString("", nil)
+
+	suite.Require().NoError(err)
+	suite.Equal("false false", output)
+}
+
+func (suite *NavigationTestSuite) render(path string, currentURL string) string {
+	recorder := httptest.NewRecorder()
+	testContext, _ := gin.CreateTestContext(recorder)
+	testContext.Request = httptest.NewRequest(http.MethodGet, path, nil)
+
+	if currentURL != "" {
+		testContext.Request.Header.Set("Hx-Request", "true")
+		testContext.Request.Header.Set("Hx-Current-Url", currentURL)
+	}
+
+	suite.htmx.With(ginhtmx.ForceFragment()).Render(testContext, gin.H{}, "nav")
+
+	return recorder.Body.String()
+}
+
+func (suite *NavigationTestSuite) SetupSuite() {
+	tmpl := template.Must(template.New("").Funcs(ginhtmx.FuncMap()).Parse(`
+{{- define "layout"}}<html>{{.Content}}</html>{{end}}
+{{- define "nav"}}{{if isActive "/users"}}[/users]{{else}}/users{{end}} {{if isActive "/"}}[/]{{else}}/{{end}}{{end}}
+{{- define "current"}}{{isCurrent "/users"}} {{isCurrent "/users/3"}}{{end}}
+`))
+	suite.htmx = ginhtmx.NewHtmx(tmpl)
+}
+
+func TestNavigationTestSuite(t *testing.T) {
+	t.Parallel()
+	suite.Run(t, new(NavigationTestSuite))
+}
+
+type NavigationTestSuite struct {
+	suite.Suite
+
+	htmx *ginhtmx.Htmx
+}
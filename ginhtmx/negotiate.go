@@ -0,0 +1,19 @@
+package ginhtmx
+
+import "github.com/gin-gonic/gin"
+
+// PrefersJSON reports whether the Accept header of the request prefers a JSON
+// response to an HTML response. Requests without an Accept header prefer HTML.
+func PrefersJSON(ginContext *gin.Context) bool {
+	return ginContext.NegotiateFormat(gin.MIMEHTML, gin.MIMEJSON) == gin.MIMEJSON
+}
+
+// wantsJSON reports whether the model should be rendered as JSON instead of
+// rendering the templates.
+func (htmx *Htmx) wantsJSON(ginContext *gin.Context) bool {
+	if htmx.config.JSONPredicate != nil {
+		return htmx.config.JSONPredicate(ginContext)
+	}
+
+	return htmx.config.NegotiateJSON && PrefersJSON(ginContext)
+}
@@ -0,0 +1,103 @@
+package ginhtmx_test
+
+import (
+	"html/template"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/jeffscottbrown/ginhtmxtemplates/ginhtmx"
+	"github.com/stretchr/testify/suite"
+)
+
+func (suite *NegotiateJSONTestSuite) TestJSONIsRenderedWhenPreferred() {
+	htmx := suite.newHtmx(ginhtmx.HtmxConfig{NegotiateJSON: true})
+
+	recorder, testContext := suite.render(htmx, "application/json")
+
+	suite.Equal(http.StatusCreated, recorder.Code)
+	suite.Equal("application/json; charset=utf-8", recorder.Header().Get("Content-Type"))
+	suite.JSONEq(`{"Name": "Jerry", "Decorated": true}`, recorder.Body.String())
+
+	status, _ := testContext.Get(ginhtmx.RenderStatusKey)
+	suite.Equal(http.StatusCreated, status)
+}
+
+func (suite *NegotiateJSONTestSuite) TestHTMLIsRenderedForBrowsers() {
+	htmx := suite.newHtmx(ginhtmx.HtmxConfig{NegotiateJSON: true})
+
+	recorder, _ := suite.render(htmx, "text/html,application/xhtml+xml,application/xml;q=0.9,*/*;q=0.8")
+	suite.Equal("<html><p>Jerry</p></html>", recorder.Body.String())
+
+	recorder, _ = suite.render(htmx, "")
+	suite.Equal("<html><p>Jerry</p></html>", recorder.Body.String())
+}
+
+func (suite *NegotiateJSONTestSuite) TestAcceptHeaderIsIgnoredUnlessEnabled() {
+	htmx := suite.newHtmx(ginhtmx.HtmxConfig{})
+
+	recorder, _ := suite.render(htmx, "application/json")
+
+	suite.Equal("<html><p>Jerry</p></html>", recorder.Body.String())
+}
+
+func (suite *NegotiateJSONTestSuite) TestPredicateOverridesAcceptHeader() {
+	htmx := suite.newHtmx(ginhtmx.HtmxConfig{
+		NegotiateJSON: true,
+		JSONPredicate: func(ginContext *gin.Context) bool {
+			return ginContext.Query("format") == "json"
+		},
+	})
+
+	recorder, _ := suite.render(htmx, "application/json")
+	suite.Equal("<html><p>Jerry</p></html>", recorder.Body.String())
+
+	recorder, _ = suite.renderURL(htmx, "/?format=json", "")
+	suite.JSONEq(`{"Name": "Jerry", "Decorated": true}`, recorder.Body.String())
+}
+
+func (suite *NegotiateJSONTestSuite) render(
+	htmx *ginhtmx.Htmx, accept string,
+) (*httptest.ResponseRecorder, *gin.Context) {
+	return suite.renderURL(htmx, "/", accept)
+}
+
+func (suite *NegotiateJSONTestSuite) renderURL(
+	htmx *ginhtmx.Htmx, url string, accept string,
+) (*httptest.ResponseRecorder, *gin.Context) {
+	recorder := httptest.NewRecorder()
+	testContext, _ := gin.CreateTestContext(recorder)
+	testContext.Request = httptest.NewRequest(http.MethodGet, url, nil)
+
+	if accept != "" {
+		testContext.Request.Header.Set("Accept", accept)
+	}
+
+	htmx.RenderWithStatus(testContext, gin.H{"Name": "Jerry"}, http.StatusCreated, "user")
+
+	return recorder, testContext
+}
+
+func (suite *NegotiateJSONTestSuite) newHtmx(config ginhtmx.HtmxConfig) *ginhtmx.Htmx {
+	tmpl := template.Must(template.New("").Parse(`
+{{- define "layout"}}<html>{{.Content}}</html>{{end}}
+{{- define "user"}}<p>{{.Name}}</p>{{end}}
+`))
+	config.LayoutTemplateName = "layout"
+	config.ContentVariableName = "Content"
+	config.ModelDecorator = ginhtmx.ModelDecoratorFunc(func(_ *gin.Context, data *gin.H) {
+		(*data)["Decorated"] = true
+	})
+
+	return ginhtmx.NewHtmxWithConfig(tmpl, config)
+}
+
+func TestNegotiateJSONTestSuite(t *testing.T) {
+	t.Parallel()
+	suite.Run(t, new(NegotiateJSONTestSuite))
+}
+
+type NegotiateJSONTestSuite struct {
+	suite.Suite
+}
@@ -0,0 +1,209 @@
+package ginhtmx
+
+import (
+	"cmp"
+	"fmt"
+	"math"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// NumberFormat describes how the "formatNumber", "formatCurrency" and
+// "formatPercent" template functions format numbers for a locale.
+type NumberFormat struct {
+	// Decimal separates the integer part of a number from its fraction, such as ".".
+	Decimal string
+
+	// Group separates groups of thousands, such as ",".
+	Group string
+
+	// Currency is the pattern of amounts of money, in which "¤" is replaced by the
+	// currency symbol and "#" by the amount, such as "¤#" or "# ¤".
+	Currency string
+
+	// Percent is the pattern of percentages, in which "#" is replaced by the
+	// percentage, such as "#%" or "# %".
+	Percent string
+}
+
+// defaultNumberFormat is used for locales without a known format.
+var defaultNumberFormat = NumberFormat{Decimal: ".", Group: ",", Currency: "¤#", Percent: "#%"}
+
+// defaultNumberFormats are the formats of common locales, keyed by normalized locale.
+var defaultNumberFormats = map[string]NumberFormat{
+	"en":    defaultNumberFormat,
+	"de":    {Decimal: ",", Group: ".", Currency: "# ¤", Percent: "# %"},
+	"de-ch": {Decimal: ".", Group: "’", Currency: "¤ #", Percent: "#%"},
+	"fr":    {Decimal: ",", Group: "\u202f", Currency: "# ¤", Percent: "# %"},
+	"es":    {Decimal: ",", Group: ".", Currency: "# ¤", Percent: "# %"},
+	"it":    {Decimal: ",", Group: ".", Currency: "# ¤", Percent: "#%"},
+	"pt":    {Decimal: ",", Group: ".", Currency: "¤ #", Percent: "#%"},
+	"nl":    {Decimal: ",", Group: ".", Currency: "¤ #", Percent: "#%"},
+	"ja":    defaultNumberFormat,
+	"zh":    defaultNumberFormat,
+}
+
+// currencySymbols are the symbols of common currencies. Other currencies are shown
+// by their code.
+var currencySymbols = map[string]string{
+	"USD": "$",
+	"EUR": "€",
+	"GBP": "£",
+	"JPY": "¥",
+	"CNY": "¥",
+	"INR": "₹",
+	"KRW": "₩",
+	"BRL": "R$",
+}
+
+// currencyDecimals are the numbers of decimals of currencies which do not have two.
+var currencyDecimals = map[string]int{
+	"JPY": 0,
+	"KRW": 0,
+}
+
+// formatNumber formats a number with the grouping and decimal separators of the
+// locale of the request:
+//
+//	<td>{{ formatNumber .Visitors }}</td>
+//	<td>{{ formatNumber .Average 2 }}</td>
+//
+// An optional number of decimals rounds the number, otherwise integers have no
+// decimals and other numbers as many as they need.
+func (scope *requestScope) formatNumber(value any, decimals ...int) (string, error) {
+	number, err := toFloat(value)
+	if err != nil {
+		return "", err
+	}
+
+	precision := -1
+	if len(decimals) > 0 {
+		precision = decimals[0]
+	}
+
+	return formatDecimal(number, precision, scope.numberFormat()), nil
+}
+
+// formatCurrency formats an amount of money in the currency with the specified
+// ISO 4217 code, or in the Currency of the HtmxConfig, for the locale of the
+// request:
+//
+//	<td>{{ formatCurrency .Order.Total }}</td>
+//	<td>{{ formatCurrency .Order.Total "EUR" }}</td>
+func (scope *requestScope) formatCurrency(value any, currency ...string) (string, error) {
+	amount, err := toFloat(value)
+	if err != nil {
+		return "", err
+	}
+
+	code := ""
+	if len(currency) > 0 {
+		code = currency[0]
+	} else if scope.htmx != nil {
+		code = scope.htmx.config.Currency
+	}
+
+	code = strings.ToUpper(code)
+	if code == "" {
+		return "", fmt.Errorf("%w: formatCurrency requires a currency", errInvalidArgument)
+	}
+
+	decimals, found := currencyDecimals[code]
+	if !found {
+		decimals = 2
+	}
+
+	symbol := cmp.Or(currencySymbols[code], code)
+	format := scope.numberFormat()
+
+	formatted := strings.NewReplacer("¤", symbol, "#", formatDecimal(math.Abs(amount), decimals, format)).
+		Replace(format.Currency)
+	if amount < 0 {
+		formatted = "-" + formatted
+	}
+
+	return formatted, nil
+}
+
+// formatPercent formats a ratio, such as 0.25, as a percentage for the locale of
+// the request, such as "25%". An optional number of decimals rounds the
+// percentage, which otherwise has none.
+func (scope *requestScope) formatPercent(value any, decimals ...int) (string, error) {
+	ratio, err := toFloat(value)
+	if err != nil {
+		return "", err
+	}
+
+	precision := 0
+	if len(decimals) > 0 {
+		precision = decimals[0]
+	}
+
+	format := scope.numberFormat()
+
+	return strings.ReplaceAll(format.Percent, "#", formatDecimal(ratio*100, precision, format)), nil //nolint:mnd
+}
+
+// numberFormat returns the format for the locale of the request.
+func (scope *requestScope) numberFormat() NumberFormat {
+	var configured map[string]NumberFormat
+	if scope.htmx != nil {
+		configured = scope.htmx.config.NumberFormats
+	}
+
+	return localeFormat(scope.locale(), defaultNumberFormat, defaultNumberFormats, configured,
+		func(format NumberFormat, override NumberFormat) NumberFormat {
+			return NumberFormat{
+				Decimal:  cmp.Or(override.Decimal, format.Decimal),
+				Group:    cmp.Or(override.Group, format.Group),
+				Currency: cmp.Or(override.Currency, format.Currency),
+				Percent:  cmp.Or(override.Percent, format.Percent),
+			}
+		})
+}
+
+// formatDecimal formats number with precision decimals, or as many as needed when
+// precision is negative, using the separators of format.
+func formatDecimal(number float64, precision int, format NumberFormat) string {
+	digits := strconv.FormatFloat(number, 'f', precision, 64)
+
+	sign := ""
+	if strings.HasPrefix(digits, "-") {
+		sign, digits = "-", digits[1:]
+	}
+
+	integer, fraction, hasFraction := strings.Cut(digits, ".")
+
+	var grouped strings.Builder
+
+	for index, digit := range integer {
+		if index > 0 && (len(integer)-index)%3 == 0 {
+			grouped.WriteString(format.Group)
+		}
+
+		grouped.WriteRune(digit)
+	}
+
+	if hasFraction {
+		return sign + grouped.String() + format.Decimal + fraction
+	}
+
+	return sign + grouped.String()
+}
+
+// toFloat converts any integer or floating point number to a float64.
+func toFloat(value any) (float64, error) {
+	reflected := reflect.ValueOf(value)
+
+	switch {
+	case reflected.CanInt():
+		return float64(reflected.Int()), nil
+	case reflected.CanUint():
+		return float64(reflected.Uint()), nil
+	case reflected.CanFloat():
+		return reflected.Float(), nil
+	default:
+		return 0, fmt.Errorf("%w: %v is not a number", errInvalidArgument, value)
+	}
+}
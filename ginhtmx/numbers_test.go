@@ -0,0 +1,82 @@
+package ginhtmx_test
+
+import (
+	"html/template"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/jeffscottbrown/ginhtmxtemplates/ginhtmx"
+	"github.com/stretchr/testify/suite"
+)
+
+func (suite *NumbersTestSuite) TestNumbersAreFormattedForTheLocale() {
+	suite.Equal("1,234,567", suite.render(`{{ formatNumber .Value }}`, 1234567, "en-US"))
+	suite.Equal("-1.234.567,25", suite.render(`{{ formatNumber .Value }}`, -1234567.25, "de"))
+	suite.Equal("1\u202f234,5", suite.render(`{{ formatNumber .Value }}`, float32(1234.5), "fr-FR"))
+	suite.Equal("1’234.57", suite.render(`{{ formatNumber .Value 2 }}`, 1234.567, "de-CH"))
+	suite.Equal("999", suite.render(`{{ formatNumber .Value }}`, uint16(999), ""))
+	suite.Equal("12", suite.render(`{{ formatNumber .Value 0 }}`, 12.4, "xx"))
+}
+
+func (suite *NumbersTestSuite) TestCurrenciesAreFormattedForTheLocale() {
+	suite.Equal("$1,234.50", suite.render(`{{ formatCurrency .Value }}`, 1234.5, "en"))
+	suite.Equal("1.234,50 €", suite.render(`{{ formatCurrency .Value "eur" }}`, 1234.5, "de"))
+	suite.Equal("-£3.00", suite.render(`{{ formatCurrency .Value "GBP" }}`, -3, "en-GB"))
+	suite.Equal("¥1,235", suite.render(`{{ formatCurrency .Value "JPY" }}`, 1234.6, "ja"))
+	suite.Equal("CHF 10.00", suite.render(`{{ formatCurrency .Value "CHF" }}`, 10, "de-CH"))
+}
+
+func (suite *NumbersTestSuite) TestPercentagesAreFormattedForTheLocale() {
+	suite.Equal("25%", suite.render(`{{ formatPercent .Value }}`, 0.25, "en"))
+	suite.Equal("12,5 %", suite.render(`{{ formatPercent .Value 1 }}`, 0.125, "de"))
+	suite.Equal("100%", suite.render(`{{ formatPercent .Value }}`, 1, "it"))
+}
+
+func (suite *NumbersTestSuite) TestConfiguredFormatsOverrideBuiltInFormats() {
+	suite.Equal("1 234,50 $", suite.render(`{{ formatCurrency .Value }}`, 1234.5, "en-CA"))
+	suite.Equal("1,234.5", suite.render(`{{ formatNumber .Value }}`, 1234.5, "en"))
+}
+
+func (suite *NumbersTestSuite) TestInvalidNumbersAreReported() {
+	for _, source := range []string{`{{ formatNumber . }}`, `{{ formatCurrency . "USD" }}`, `{{ formatPercent . }}`} {
+		err := template.Must(template.New("").Funcs(ginhtmx.FuncMap()).Parse(source)).Execute(io.Discard, "many")
+		suite.Require().ErrorContains(err, "invalid argument: many is not a number")
+	}
+
+	err := template.Must(template.New("").Funcs(ginhtmx.FuncMap()).Parse(`{{ formatCurrency . }}`)).Execute(io.Discard, 10)
+	suite.Require().ErrorContains(err, "invalid argument: formatCurrency requires a currency")
+}
+
+func (suite *NumbersTestSuite) render(source string, value any, locale string) string {
+	tmpl := template.Must(template.New("").Funcs(ginhtmx.FuncMap()).Parse(`{{define "number"}}` + source + `{{end}}`))
+	htmx := ginhtmx.NewHtmxWithConfig(tmpl, ginhtmx.HtmxConfig{
+		LayoutTemplateName:  "layout",
+		ContentVariableName: "Content",
+		Currency:            "usd",
+		NumberFormats: map[string]ginhtmx.NumberFormat{
+			"en-CA": {Decimal: ",", Group: " ", Currency: "# ¤", Percent: ""},
+		},
+	})
+
+	recorder := httptest.NewRecorder()
+	testContext, _ := gin.CreateTestContext(recorder)
+	testContext.Request = httptest.NewRequest(http.MethodGet, "/", nil)
+	testContext.Request.Header.Set("Hx-Request", "true")
+	testContext.Set(ginhtmx.LocaleKey, locale)
+
+	htmx.Render(testContext, gin.H{"Value": value}, "number")
+
+	return recorder.Body.String()
+}
+
+func TestNumbersTestSuite(t *testing.T) {
+	t.Parallel()
+	suite.Run(t, new(NumbersTestSuite))
+}
+
+type NumbersTestSuite struct {
+	suite.Suite
+}
@@ -0,0 +1,220 @@
+package ginhtmx
+
+import (
+	"html/template"
+	"net/http"
+	"slices"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// RenderOption customises the renders performed by an Htmx instance. Options are
+// applied using With.
+type RenderOption func(settings *renderSettings)
+
+// renderSettings holds the result of applying the RenderOptions of an instance.
+type renderSettings struct {
+	// selectIDs are the ids of the elements the client is expected to hx-select
+	selectIDs []string
+	// layout overrides whether the layout is rendered, nil means use the request headers
+	layout *bool
+	// headers are the response headers to set before the response is written
+	headers http.Header
+	// triggers are the events to add to the HX-Trigger response header
+	triggers []string
+	// errors are errors which occurred while preparing the render
+	errors []error
+	// wrappers are the templates rendered around the content, innermost first
+	wrappers []string
+	// appended are templates rendered after the requested templates with their own data
+	appended []appendedTemplate
+	// meta is the title and description of the page
+	meta PageMeta
+	// cache describes how the output of the templates is cached, nil means it is not
+	cache *cacheSettings
+	// staleGrace is how long cached output is served after expiring, see StaleWhileRevalidate
+	staleGrace time.Duration
+	// sanitized are the keys of the model whose values are sanitized as HTML
+	sanitized []string
+	// templateSet is the name of the template set rendered, empty for the default set
+	templateSet string
+	// permissions are the permissions the Authorizer must grant before rendering
+	permissions []string
+	// errorResponse marks the render of the error response of another render, which
+	// is not subject to the permissions and guards and is not itself replaced by an
+	// error response
+	errorResponse bool
+	// textContentType is the content type of renders of text templates, empty when
+	// the HTML templates are rendered
+	textContentType string
+	// templ is the templ component rendered after the templates
+	templ TemplComponent
+	// turboStream are the Turbo Stream actions rendered after the templates
+	turboStream []TurboStreamAction
+	// upEvents are the events to add to the X-Up-Events response header
+	upEvents []string
+	// funcs are template functions which override those of the templates
+	funcs template.FuncMap
+	// now returns the current time used by the time functions, nil means time.Now
+	now func() time.Time
+	// layoutModel are the values added to the data of the layout, see LayoutModel
+	layoutModel gin.H
+}
+
+// appendedTemplate is a template rendered after the requested templates.
+type appendedTemplate struct {
+	name string
+	data any
+}
+
+// Trigger returns a RenderOption which adds the named events to the HX-Trigger
+// response header, using AddTrigger, so that they are triggered on the client when
+// the response is received.
+func Trigger(events ...string) RenderOption {
+	return func(settings *renderSettings) {
+		settings.triggers = append(settings.triggers, events...)
+	}
+}
+
+// Reswap returns a RenderOption which sets the HX-Reswap response header, overriding
+// the swap strategy of the element which made the request, for example "none" or
+// "outerHTML".
+func Reswap(strategy string) RenderOption {
+	return func(settings *renderSettings) {
+		settings.headers.Set("HX-Reswap", strategy)
+	}
+}
+
+// ForceFullPage returns a RenderOption which always renders the layout, even for
+// HTMX requests. This is useful for printable pages or content loaded into an iframe:
+//
+//	handler.htmx.With(ginhtmx.ForceFullPage()).Render(c, data, "report")
+func ForceFullPage() RenderOption {
+	return func(settings *renderSettings) {
+		layout := true
+		settings.layout = &layout
+	}
+}
+
+// ForceFragment returns a RenderOption which never renders the layout, even for
+// requests which are not HTMX requests. This is useful for widgets which are
+// embedded in other pages.
+func ForceFragment() RenderOption {
+	return func(settings *renderSettings) {
+		layout := false
+		settings.layout = &layout
+	}
+}
+
+// With returns a copy of htmx which applies options to every render it performs.
+// The copy shares templates and configuration with htmx, so it is cheap to create
+// one for a single render:
+//
+//	handler.htmx.With(ginhtmx.ExpectSelect("user-row")).Render(c, data, "user")
+func (htmx *Htmx) With(options ...RenderOption) *Htmx {
+	derived := *htmx
+	derived.options = append(slices.Clip(htmx.options), options...)
+
+	return &derived
+}
+
+func (htmx *Htmx) renderSettings(options ...RenderOption) *renderSettings {
+	settings := &renderSettings{
+		selectIDs:       nil,
+		layout:          nil,
+		headers:         http.Header{},
+		triggers:        nil,
+		errors:          nil,
+		wrappers:        nil,
+		appended:        nil,
+		meta:            PageMeta{},
+		cache:           nil,
+		staleGrace:      0,
+		sanitized:       nil,
+		templateSet:     "",
+		permissions:     nil,
+		errorResponse:   false,
+		textContentType: "",
+		templ:           nil,
+		turboStream:     nil,
+		upEvents:        nil,
+		funcs:           nil,
+		now:             nil,
+		layoutModel:     nil,
+	}
+
+	for _, option := range htmx.options {
+		option(settings)
+	}
+
+	for _, option := range options {
+		option(settings)
+	}
+
+	return settings
+}
+
+// isFragment reports whether the layout should be omitted from the response, which
+// by default it is for HTMX requests, Turbo frame requests and Unpoly fragment
+// updates.
+func (settings *renderSettings) isFragment(htmx *Htmx, ginContext *gin.Context) bool {
+	if settings.layout != nil {
+		return !*settings.layout
+	}
+
+	return htmx.IsHTMXRequest(ginContext) || TurboFrame(ginContext) != "" || UnpolyTarget(ginContext) != ""
+}
+
+// PushURL returns a RenderOption which sets the HX-Push-Url response header so that
+// url is pushed into the browser history.
+func PushURL(url string) RenderOption {
+	return func(settings *renderSettings) {
+		settings.headers.Set("HX-Push-Url", url)
+	}
+}
+
+// ReplaceURL returns a RenderOption which sets the HX-Replace-Url response header so
+// that url replaces the current URL in the browser location bar without creating a
+// new history entry.
+func ReplaceURL(url string) RenderOption {
+	return func(settings *renderSettings) {
+		settings.headers.Set("HX-Replace-Url", url)
+	}
+}
+
+// withRenderError returns a RenderOption which reports err, if it is not nil, as an
+// error of the render.
+func withRenderError(err error) RenderOption {
+	return func(settings *renderSettings) {
+		if err != nil {
+			settings.errors = append(settings.errors, err)
+		}
+	}
+}
+
+// asErrorResponse returns a RenderOption which marks the render as the error
+// response of another render, such as the 403 response of a denied render.
+func asErrorResponse() RenderOption {
+	return func(settings *renderSettings) {
+		settings.errorResponse = true
+	}
+}
+
+// writeHeaders sets the response headers accumulated by the options.
+func (settings *renderSettings) writeHeaders(ginContext *gin.Context) {
+	for name, values := range settings.headers {
+		ginContext.Writer.Header()[name] = values
+	}
+
+	for _, event := range settings.triggers {
+		// events without detail always encode, so this can only fail when the header
+		// was set to an invalid value, which is then left as it is
+		_ = AddTrigger(ginContext, event, nil)
+	}
+
+	for _, event := range settings.upEvents {
+		// likewise events without properties always encode
+		_ = AddUpEvent(ginContext, event, nil)
+	}
+}
@@ -0,0 +1,79 @@
+package ginhtmx_test
+
+import (
+	"html/template"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/jeffscottbrown/ginhtmxtemplates/ginhtmx"
+	"github.com/stretchr/testify/suite"
+)
+
+func (suite *RenderOptionsTestSuite) TestForceFullPageRendersLayoutForHTMXRequests() {
+	body := suite.render(suite.htmx.With(ginhtmx.ForceFullPage()), true)
+
+	suite.Equal("<html><p>report</p></html>", body)
+}
+
+func (suite *RenderOptionsTestSuite) TestForceFragmentOmitsLayoutForFullPageRequests() {
+	body := suite.render(suite.htmx.With(ginhtmx.ForceFragment()), false)
+
+	suite.Equal("<p>report</p>", body)
+}
+
+func (suite *RenderOptionsTestSuite) TestLastOptionWins() {
+	body := suite.render(suite.htmx.With(ginhtmx.ForceFragment(), ginhtmx.ForceFullPage()), true)
+
+	suite.Equal("<html><p>report</p></html>", body)
+}
+
+func (suite *RenderOptionsTestSuite) TestHeadersAreUsedByDefault() {
+	suite.Equal("<p>report</p>", suite.render(suite.htmx, true))
+	suite.Equal("<html><p>report</p></html>", suite.render(suite.htmx, false))
+}
+
+func (suite *RenderOptionsTestSuite) TestHeaderOptionsAreAppliedToRenders() {
+	recorder := httptest.NewRecorder()
+	testContext, _ := gin.CreateTestContext(recorder)
+	testContext.Request = httptest.NewRequest(http.MethodGet, "/", nil)
+
+	suite.htmx.With(ginhtmx.Trigger("saved", "refresh"), ginhtmx.Reswap("outerHTML")).Render(testContext, gin.H{}, "report")
+
+	suite.JSONEq(`{"saved": null, "refresh": null}`, recorder.Header().Get("HX-Trigger"))
+	suite.Equal("outerHTML", recorder.Header().Get("HX-Reswap"))
+}
+
+func (suite *RenderOptionsTestSuite) render(htmx *ginhtmx.Htmx, htmxRequest bool) string {
+	recorder := httptest.NewRecorder()
+	testContext, _ := gin.CreateTestContext(recorder)
+	testContext.Request = httptest.NewRequest(http.MethodGet, "/", nil)
+
+	if htmxRequest {
+		testContext.Request.Header.Set("Hx-Request", "true")
+	}
+
+	htmx.Render(testContext, gin.H{}, "report")
+
+	return recorder.Body.String()
+}
+
+func (suite *RenderOptionsTestSuite) SetupSuite() {
+	tmpl := template.Must(template.New("").Parse(`
+{{- define "layout"}}<html>{{.Content}}</html>{{end}}
+{{- define "report"}}<p>report</p>{{end}}
+`))
+	suite.htmx = ginhtmx.NewHtmx(tmpl)
+}
+
+func TestRenderOptionsTestSuite(t *testing.T) {
+	t.Parallel()
+	suite.Run(t, new(RenderOptionsTestSuite))
+}
+
+type RenderOptionsTestSuite struct {
+	suite.Suite
+
+	htmx *ginhtmx.Htmx
+}
@@ -0,0 +1,187 @@
+package ginhtmx
+
+import (
+	"net/url"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	// DefaultPageParam is the query parameter holding the page number.
+	DefaultPageParam = "page"
+
+	// DefaultPaginationWindow is the number of pages linked either side of the current page.
+	DefaultPaginationWindow = 2
+)
+
+// Paginator describes the position of a page within a list of items and produces
+// the links used to navigate between pages. Render it with the built-in
+// "ginhtmx/pagination" template, which may be overridden by defining a template
+// with the same name:
+//
+//	paginator := ginhtmx.NewPaginator(c, total, 20)
+//	users := loadUsers(paginator.Offset(), paginator.PerPage)
+//	handler.htmx.Render(c, gin.H{"Users": users, "Pagination": paginator}, "users")
+//
+//	{{ template "ginhtmx/pagination" .Pagination }}
+type Paginator struct {
+	// Page is the current page, starting at 1
+	Page int
+
+	// PerPage is the number of items on each page
+	PerPage int
+
+	// Total is the total number of items
+	Total int
+
+	// Window is the number of pages linked either side of the current page
+	Window int
+
+	// PageParam is the query parameter which holds the page number
+	PageParam string
+
+	// Target is an optional hx-target for the links
+	Target string
+
+	// URL is the URL of the list. Its query parameters are preserved in the links.
+	URL *url.URL
+}
+
+// PageLink is a link to a page produced by Paginator.Links.
+type PageLink struct {
+	// Number is the page number
+	Number int
+
+	// URL is the URL of the page
+	URL string
+
+	// Current is true for the link to the current page
+	Current bool
+
+	// Gap is true for a placeholder standing in for pages which are not linked
+	Gap bool
+}
+
+// NewPaginator returns a Paginator for the request, reading the current page from
+// the DefaultPageParam query parameter. Page numbers outside of the range of pages
+// are clamped to the first or last page.
+func NewPaginator(ginContext *gin.Context, total int, perPage int) *Paginator {
+	page, _ := strconv.Atoi(ginContext.Query(DefaultPageParam))
+
+	paginator := &Paginator{
+		Page:      page,
+		PerPage:   max(perPage, 1),
+		Total:     max(total, 0),
+		Window:    DefaultPaginationWindow,
+		PageParam: DefaultPageParam,
+		Target:    "",
+		URL:       ginContext.Request.URL,
+	}
+	paginator.Page = min(max(page, 1), paginator.Pages())
+
+	return paginator
+}
+
+// Pages returns the number of pages, which is at least 1.
+func (paginator *Paginator) Pages() int {
+	if paginator.PerPage <= 0 || paginator.Total <= 0 {
+		return 1
+	}
+
+	return (paginator.Total + paginator.PerPage - 1) / paginator.PerPage
+}
+
+// Offset returns the index of the first item on the current page.
+func (paginator *Paginator) Offset() int {
+	return max(paginator.Page-1, 0) * paginator.PerPage
+}
+
+// HasPrevious reports whether there is a page before the current page.
+func (paginator *Paginator) HasPrevious() bool {
+	return paginator.Page > 1
+}
+
+// HasNext reports whether there is a page after the current page.
+func (paginator *Paginator) HasNext() bool {
+	return paginator.Page < paginator.Pages()
+}
+
+// Previous returns the number of the page before the current page.
+func (paginator *Paginator) Previous() int {
+	return max(paginator.Page-1, 1)
+}
+
+// Next returns the number of the page after the current page.
+func (paginator *Paginator) Next() int {
+	return min(paginator.Page+1, paginator.Pages())
+}
+
+// NextPageURL returns the URL of the page after the current page, or an empty
+// string on the last page.
+func (paginator *Paginator) NextPageURL() string {
+	if !paginator.HasNext() {
+		return ""
+	}
+
+	return paginator.PageURL(paginator.Next())
+}
+
+// PageURL returns the URL of the page, preserving the other query parameters.
+func (paginator *Paginator) PageURL(page int) string {
+	pageURL := url.URL{Path: "", RawQuery: ""}
+	if paginator.URL != nil {
+		pageURL = *paginator.URL
+	}
+
+	param := paginator.PageParam
+	if param == "" {
+		param = DefaultPageParam
+	}
+
+	query := pageURL.Query()
+	query.Set(param, strconv.Itoa(page))
+	pageURL.RawQuery = query.Encode()
+
+	return pageURL.RequestURI()
+}
+
+// Links returns the links to the first and last pages and to the pages within
+// Window pages of the current page, with gaps where pages are skipped.
+func (paginator *Paginator) Links() []PageLink {
+	pages := paginator.Pages()
+	first := max(paginator.Page-paginator.Window, 1)
+	last := min(paginator.Page+paginator.Window, pages)
+
+	var links []PageLink
+
+	if first > 1 {
+		links = append(links, paginator.link(1))
+		if first > 2 { //nolint:mnd
+			links = append(links, PageLink{Number: 0, URL: "", Current: false, Gap: true})
+		}
+	}
+
+	for page := first; page <= last; page++ {
+		links = append(links, paginator.link(page))
+	}
+
+	if last < pages {
+		if last < pages-1 {
+			links = append(links, PageLink{Number: 0, URL: "", Current: false, Gap: true})
+		}
+
+		links = append(links, paginator.link(pages))
+	}
+
+	return links
+}
+
+func (paginator *Paginator) link(page int) PageLink {
+	return PageLink{
+		Number:  page,
+		URL:     paginator.PageURL(page),
+		Current: page == paginator.Page,
+		Gap:     false,
+	}
+}
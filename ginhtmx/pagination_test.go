@@ -0,0 +1,125 @@
+package ginhtmx_test
+
+import (
+	"html/template"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/jeffscottbrown/ginhtmxtemplates/ginhtmx"
+	"github.com/stretchr/testify/suite"
+)
+
+func (suite *PaginatorTestSuite) TestNewPaginatorReadsAndClampsPage() {
+	paginator := ginhtmx.NewPaginator(suite.newContext("/users?page=3"), 95, 10)
+
+	suite.Equal(3, paginator.Page)
+	suite.Equal(10, paginator.Pages())
+	suite.Equal(20, paginator.Offset())
+	suite.True(paginator.HasPrevious())
+	suite.True(paginator.HasNext())
+	suite.Equal(2, paginator.Previous())
+	suite.Equal(4, paginator.Next())
+
+	suite.Equal(10, ginhtmx.NewPaginator(suite.newContext("/users?page=50"), 95, 10).Page)
+	suite.Equal(1, ginhtmx.NewPaginator(suite.newContext("/users?page=x"), 95, 10).Page)
+
+	empty := ginhtmx.NewPaginator(suite.newContext("/users"), 0, 0)
+	suite.Equal(1, empty.Pages())
+	suite.False(empty.HasPrevious())
+	suite.False(empty.HasNext())
+}
+
+func (suite *PaginatorTestSuite) TestPageURLPreservesQuery() {
+	paginator := ginhtmx.NewPaginator(suite.newContext("/users?q=jerry&page=2&sort=name"), 95, 10)
+
+	suite.Equal("/users?page=5&q=jerry&sort=name", paginator.PageURL(5))
+
+	paginator.PageParam = "p"
+	suite.Equal("/users?p=1&page=2&q=jerry&sort=name", paginator.PageURL(1))
+
+	suite.Equal("/?page=2", (&ginhtmx.Paginator{}).PageURL(2))
+}
+
+func (suite *PaginatorTestSuite) TestLinksIncludeWindowAndGaps() {
+	paginator := ginhtmx.NewPaginator(suite.newContext("/users?page=5"), 95, 10)
+
+	suite.Equal("1 … 3 4 [5] 6 7 … 10", suite.describe(paginator.Links()))
+
+	paginator.Page = 1
+	suite.Equal("[1] 2 3 … 10", suite.describe(paginator.Links()))
+
+	paginator.Page = 9
+	suite.Equal("1 … 7 8 [9] 10", suite.describe(paginator.Links()))
+
+	paginator.Page = 4
+	suite.Equal("1 2 3 [4] 5 6 … 10", suite.describe(paginator.Links()))
+}
+
+func (suite *PaginatorTestSuite) TestBuiltInTemplate() {
+	recorder := httptest.NewRecorder()
+	testContext, _ := gin.CreateTestContext(recorder)
+	testContext.Request = httptest.NewRequest(http.MethodGet, "/users?q=a&page=2", nil)
+	testContext.Request.Header.Set("Hx-Request", "true")
+
+	paginator := ginhtmx.NewPaginator(testContext, 30, 10)
+	paginator.Target = "#users"
+
+	tmpl := template.Must(template.New("").Parse(`{{define "users"}}{{template "ginhtmx/pagination" .Pagination}}{{end}}`))
+	ginhtmx.NewHtmx(tmpl).Render(testContext, gin.H{"Pagination": paginator}, "users")
+
+	body := recorder.Body.String()
+	suite.Contains(body, `<a href="/users?page=1&amp;q=a" hx-get="/users?page=1&amp;q=a" hx-target="#users" hx-push-url="true" rel="prev">Previous</a>`)
+	suite.Contains(body, `<span aria-current="page">2</span>`)
+	suite.Contains(body, `<a href="/users?page=3&amp;q=a" hx-get="/users?page=3&amp;q=a" hx-target="#users" hx-push-url="true">3</a>`)
+	suite.Contains(body, `rel="next">Next</a>`)
+}
+
+func (suite *PaginatorTestSuite) TestBuiltInTemplateMayBeOverridden() {
+	recorder := httptest.NewRecorder()
+	testContext, _ := gin.CreateTestContext(recorder)
+	testContext.Request = httptest.NewRequest(http.MethodGet, "/users", nil)
+	testContext.Request.Header.Set("Hx-Request", "true")
+
+	tmpl := template.Must(template.New("").Parse(`
+{{- define "ginhtmx/pagination"}}page {{.Page}} of {{.Pages}}{{end}}
+{{- define "users"}}{{template "ginhtmx/pagination" .Pagination}}{{end}}`))
+	ginhtmx.NewHtmx(tmpl).Render(testContext, gin.H{"Pagination": ginhtmx.NewPaginator(testContext, 30, 10)}, "users")
+
+	suite.Equal("page 1 of 3", recorder.Body.String())
+}
+
+func (suite *PaginatorTestSuite) describe(links []ginhtmx.PageLink) string {
+	descriptions := make([]string, 0, len(links))
+
+	for _, link := range links {
+		switch {
+		case link.Gap:
+			descriptions = append(descriptions, "…")
+		case link.Current:
+			descriptions = append(descriptions, "["+strings.TrimPrefix(link.URL, "/users?page=")+"]")
+		default:
+			descriptions = append(descriptions, strings.TrimPrefix(link.URL, "/users?page="))
+		}
+	}
+
+	return strings.Join(descriptions, " ")
+}
+
+func (suite *PaginatorTestSuite) newContext(target string) *gin.Context {
+	testContext, _ := gin.CreateTestContext(httptest.NewRecorder())
+	testContext.Request = httptest.NewRequest(http.MethodGet, target, nil)
+
+	return testContext
+}
+
+func TestPaginatorTestSuite(t *testing.T) {
+	t.Parallel()
+	suite.Run(t, new(PaginatorTestSuite))
+}
+
+type PaginatorTestSuite struct {
+	suite.Suite
+}
@@ -0,0 +1,31 @@
+package ginhtmx
+
+import (
+	"errors"
+	"fmt"
+	"io"
+)
+
+// ErrTemplatePanic is wrapped by the error reported when a template panics while it
+// is rendered. Panics of template functions are already reported as errors by the
+// template package, but other panics, such as those of iterator functions ranged
+// over by a template, would otherwise abort the request after partial output.
+var ErrTemplatePanic = errors.New("template panicked")
+
+// templateExecutor is implemented by the templates of both html/template and
+// text/template.
+type templateExecutor interface {
+	ExecuteTemplate(writer io.Writer, name string, data any) error
+}
+
+// executeRecovering executes the named template, returning a panic of the execution
+// as an error wrapping ErrTemplatePanic.
+func executeRecovering(writer io.Writer, tmpl templateExecutor, name string, data any) (err error) {
+	defer func() {
+		if recovered := recover(); recovered != nil {
+			err = fmt.Errorf("%w: executing %q: %v", ErrTemplatePanic, name, recovered)
+		}
+	}()
+
+	return tmpl.ExecuteTemplate(writer, name, data) //nolint:wrapcheck
+}
@@ -0,0 +1,105 @@
+package ginhtmx_test
+
+import (
+	"html/template"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/jeffscottbrown/ginhtmxtemplates/ginhtmx"
+	"github.com/stretchr/testify/suite"
+)
+
+func (suite *PanicTestSuite) TestPanicsAreRenderedAsErrorResponses() {
+	htmx := suite.newHtmx(map[int]string{http.StatusInternalServerError: "server_error"})
+
+	recorder, testContext := suite.render(htmx, true, "items")
+
+	suite.Equal(http.StatusInternalServerError, recorder.Code)
+	suite.Equal(`<p class="error">Internal Server Error</p>`, recorder.Body.String())
+
+	renderError, _ := testContext.Value(ginhtmx.RenderErrorKey).(error)
+	suite.Require().ErrorIs(renderError, ginhtmx.ErrTemplatePanic)
+	suite.Require().ErrorContains(renderError, `executing "items": exploded`)
+
+	recorder, _ = suite.render(htmx, false, "items")
+	suite.Equal(`<html><p class="error">Internal Server Error</p></html>`, recorder.Body.String())
+}
+
+func (suite *PanicTestSuite) TestPanicsWithoutErrorTemplates() {
+	recorder, _ := suite.render(suite.newHtmx(nil), false, "items")
+
+	suite.Equal(http.StatusInternalServerError, recorder.Code)
+	suite.Equal(`Internal Server Error`, recorder.Body.String())
+}
+
+func (suite *PanicTestSuite) TestPanickingErrorTemplatesAreNotRenderedAgain() {
+	htmx := ginhtmx.NewHtmxWithConfig(suite.template, ginhtmx.HtmxConfig{
+		LayoutTemplateName:  "layout",
+		ContentVariableName: "Content",
+		ErrorTemplates:      map[int]string{http.StatusInternalServerError: "items"},
+		ModelDecorator: ginhtmx.ModelDecoratorFunc(func(_ *gin.Context, model *gin.H) {
+			(*model)["Items"] = panickingItems
+		}),
+	})
+
+	recorder, _ := suite.render(htmx, true, "items")
+
+	suite.Equal(http.StatusInternalServerError, recorder.Code)
+	suite.Equal(`<ul>`, recorder.Body.String())
+}
+
+func (suite *PanicTestSuite) TestPanicsAreReturnedByRenderToString() {
+	_, err := suite.newHtmx(nil).RenderToString("items", gin.H{"Items": panickingItems})
+
+	suite.Require().ErrorIs(err, ginhtmx.ErrTemplatePanic)
+}
+
+func (suite *PanicTestSuite) render(
+	htmx *ginhtmx.Htmx, fragment bool, templateNames ...string,
+) (*httptest.ResponseRecorder, *gin.Context) {
+	recorder := httptest.NewRecorder()
+	testContext, _ := gin.CreateTestContext(recorder)
+	testContext.Request = httptest.NewRequest(http.MethodGet, "/", nil)
+
+	if fragment {
+		testContext.Request.Header.Set("Hx-Request", "true")
+	}
+
+	htmx.Render(testContext, gin.H{"Items": panickingItems}, templateNames...)
+
+	return recorder, testContext
+}
+
+func (suite *PanicTestSuite) newHtmx(errorTemplates map[int]string) *ginhtmx.Htmx {
+	return ginhtmx.NewHtmxWithConfig(suite.template, ginhtmx.HtmxConfig{
+		LayoutTemplateName:  "layout",
+		ContentVariableName: "Content",
+		ErrorTemplates:      errorTemplates,
+	})
+}
+
+func (suite *PanicTestSuite) SetupTest() {
+	suite.template = template.Must(template.New("").Parse(`
+{{- define "layout"}}<html>{{ .Content }}</html>{{end -}}
+{{- define "items"}}<ul>{{ range .Items }}<li>{{ . }}</li>{{ end }}</ul>{{end -}}
+{{- define "server_error"}}<p class="error">{{ .Error.Message }}</p>{{end -}}
+`))
+}
+
+func TestPanicTestSuite(t *testing.T) {
+	t.Parallel()
+	suite.Run(t, new(PanicTestSuite))
+}
+
+type PanicTestSuite struct {
+	suite.Suite
+
+	template *template.Template
+}
+
+// panickingItems is an iterator which panics when a template ranges over it.
+func panickingItems(func(int) bool) {
+	panic("exploded")
+}
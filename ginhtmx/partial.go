@@ -0,0 +1,63 @@
+package ginhtmx
+
+import (
+	"fmt"
+	"html/template"
+	"maps"
+
+	"github.com/gin-gonic/gin"
+)
+
+// partial renders the named template from within another template, with the data
+// of the calling template merged with additional alternating keys and values:
+//
+//	{{ partial "user_row" . "Editable" true }}
+//
+// Without additional keys and values the data is passed to the template unchanged,
+// otherwise it must be a map such as gin.H. The template is rendered in the same way
+// as the templates passed to Render, so that its use is recorded and instrumented.
+func (scope *requestScope) partial(name string, data any, pairs ...any) (template.HTML, error) {
+	if scope.htmx == nil || scope.template == nil {
+		return "", fmt.Errorf("%w: partial %q rendered outside of Htmx", errInvalidArgument, name)
+	}
+
+	if len(pairs) > 0 {
+		extra, err := dict(pairs...)
+		if err != nil {
+			return "", err
+		}
+
+		merged, err := mergeData(data, extra)
+		if err != nil {
+			return "", fmt.Errorf("partial %q: %w", name, err)
+		}
+
+		data = merged
+	}
+
+	rendered, err := scope.htmx.executeTemplate(scope.context(), scope.template, name, data, true)
+
+	//nolint:gosec
+	return template.HTML(rendered), err
+}
+
+// mergeData returns a copy of data, which must be a map, with the values of extra added.
+func mergeData(data any, extra gin.H) (gin.H, error) {
+	switch values := data.(type) {
+	case nil:
+		return extra, nil
+	case gin.H:
+		return mergeModels(values, extra), nil
+	case map[string]string:
+		merged := make(gin.H, len(values)+len(extra))
+		for key, value := range values {
+			merged[key] = value
+		}
+
+		maps.Copy(merged, extra)
+
+		return merged, nil
+	default:
+		return nil, fmt.Errorf("%w: cannot add keys to %T", errInvalidArgument, data)
+	}
+}
@@ -0,0 +1,85 @@
+package ginhtmx_test
+
+import (
+	"bytes"
+	"html/template"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/jeffscottbrown/ginhtmxtemplates/ginhtmx"
+	"github.com/stretchr/testify/suite"
+)
+
+func (suite *PartialTestSuite) TestPartialMergesData() {
+	output, err := suite.render("rows", gin.H{"Name": "Jerry"})
+
+	suite.Require().NoError(err)
+	suite.Equal("<tr>Jerry false</tr><tr>Jerry true</tr>", output)
+}
+
+func (suite *PartialTestSuite) TestPartialWithoutPairsPassesDataUnchanged() {
+	output, err := suite.render("plain", gin.H{"Name": "Elaine"})
+
+	suite.Require().NoError(err)
+	suite.Equal("<b>Elaine</b>", output)
+}
+
+func (suite *PartialTestSuite) TestPartialMergesIntoOtherMaps() {
+	output, err := suite.render("rows", map[string]string{"Name": "Kramer"})
+	suite.Require().NoError(err)
+	suite.Equal("<tr>Kramer false</tr><tr>Kramer true</tr>", output)
+
+	output, err = suite.render("nil", nil)
+	suite.Require().NoError(err)
+	suite.Equal("<tr> true</tr>", output)
+}
+
+func (suite *PartialTestSuite) TestPartialErrors() {
+	_, err := suite.render("rows", "Jerry")
+	suite.Require().Error(err)
+
+	_, err = suite.render("odd", gin.H{})
+	suite.Require().Error(err)
+
+	_, err = suite.render("missing", gin.H{})
+	suite.Require().ErrorIs(err, ginhtmx.ErrTemplateNotFound)
+
+	var output bytes.Buffer
+
+	err = template.Must(suite.template.Clone()).ExecuteTemplate(&output, "plain", gin.H{})
+	suite.Require().Error(err)
+}
+
+func (suite *PartialTestSuite) render(name string, data any) (string, error) {
+	var output bytes.Buffer
+
+	err := suite.htmx.RenderToWriter(&output, gin.H{"Data": data}, false, name)
+
+	return output.String(), err
+}
+
+func (suite *PartialTestSuite) SetupSuite() {
+	suite.template = template.Must(template.New("").Funcs(ginhtmx.FuncMap()).Parse(`
+{{- define "layout"}}{{.Content}}{{end}}
+{{- define "row"}}<tr>{{.Name}} {{.Editable}}</tr>{{end}}
+{{- define "name"}}<b>{{.Name}}</b>{{end}}
+{{- define "rows"}}{{partial "row" .Data "Editable" false}}{{partial "row" .Data "Editable" true}}{{end}}
+{{- define "plain"}}{{partial "name" .Data}}{{end}}
+{{- define "nil"}}{{partial "row" .Data "Editable" true "Name" ""}}{{end}}
+{{- define "odd"}}{{partial "row" .Data "Editable"}}{{end}}
+{{- define "missing"}}{{partial "unknown" .Data}}{{end}}
+`))
+	suite.htmx = ginhtmx.NewHtmx(suite.template)
+}
+
+func TestPartialTestSuite(t *testing.T) {
+	t.Parallel()
+	suite.Run(t, new(PartialTestSuite))
+}
+
+type PartialTestSuite struct {
+	suite.Suite
+
+	htmx     *ginhtmx.Htmx
+	template *template.Template
+}
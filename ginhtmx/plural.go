@@ -0,0 +1,206 @@
+package ginhtmx
+
+// The CLDR plural categories, see
+// https://www.unicode.org/cldr/charts/latest/supplemental/language_plural_rules.html.
+const (
+	PluralZero  = "zero"
+	PluralOne   = "one"
+	PluralTwo   = "two"
+	PluralFew   = "few"
+	PluralMany  = "many"
+	PluralOther = "other"
+)
+
+// PluralRule returns the CLDR plural category, such as PluralOne or PluralFew, of
+// count in a language.
+type PluralRule func(count int) string
+
+// pluralCategories lists the suffixes of the forms of a plural message.
+var pluralCategories = []string{PluralZero, PluralOne, PluralTwo, PluralFew, PluralMany, PluralOther}
+
+// pluralRules are the CLDR rules for integer counts of the languages whose rules
+// differ from the English rule, keyed by language.
+var pluralRules = map[string]PluralRule{
+	"ja": pluralOtherOnly, "ko": pluralOtherOnly, "zh": pluralOtherOnly, "vi": pluralOtherOnly,
+	"th": pluralOtherOnly, "id": pluralOtherOnly, "ms": pluralOtherOnly,
+
+	"fr": pluralZeroOrOne, "pt": pluralZeroOrOne, "hi": pluralZeroOrOne, "bn": pluralZeroOrOne,
+	"fa": pluralZeroOrOne,
+
+	"ru": pluralEastSlavic, "uk": pluralEastSlavic, "be": pluralEastSlavic,
+	"hr": pluralSerboCroatian, "sr": pluralSerboCroatian, "bs": pluralSerboCroatian,
+	"cs": pluralCzech, "sk": pluralCzech,
+
+	"pl": pluralPolish,
+	"lt": pluralLithuanian,
+	"lv": pluralLatvian,
+	"ro": pluralRomanian,
+	"sl": pluralSlovenian,
+	"ar": pluralArabic,
+	"he": pluralHebrew,
+	"ga": pluralIrish,
+}
+
+// pluralEnglish is the rule of English and most other European languages, such as
+// German, Spanish and Italian.
+func pluralEnglish(count int) string {
+	if count == 1 {
+		return PluralOne
+	}
+
+	return PluralOther
+}
+
+// pluralOtherOnly is the rule of languages without plural forms, such as Japanese.
+func pluralOtherOnly(int) string {
+	return PluralOther
+}
+
+// pluralZeroOrOne is the rule of languages in which zero is singular, such as French.
+func pluralZeroOrOne(count int) string {
+	if count == 0 || count == 1 {
+		return PluralOne
+	}
+
+	return PluralOther
+}
+
+func pluralEastSlavic(count int) string {
+	switch {
+	case count%10 == 1 && count%100 != 11:
+		return PluralOne
+	case endsInTwoToFour(count):
+		return PluralFew
+	default:
+		return PluralMany
+	}
+}
+
+func pluralSerboCroatian(count int) string {
+	switch {
+	case count%10 == 1 && count%100 != 11:
+		return PluralOne
+	case endsInTwoToFour(count):
+		return PluralFew
+	default:
+		return PluralOther
+	}
+}
+
+func pluralCzech(count int) string {
+	switch {
+	case count == 1:
+		return PluralOne
+	case count >= 2 && count <= 4:
+		return PluralFew
+	default:
+		return PluralOther
+	}
+}
+
+func pluralPolish(count int) string {
+	switch {
+	case count == 1:
+		return PluralOne
+	case endsInTwoToFour(count):
+		return PluralFew
+	default:
+		return PluralMany
+	}
+}
+
+func pluralLithuanian(count int) string {
+	teens := count%100 >= 11 && count%100 <= 19
+
+	switch {
+	case count%10 == 1 && !teens:
+		return PluralOne
+	case count%10 >= 2 && !teens:
+		return PluralFew
+	default:
+		return PluralOther
+	}
+}
+
+func pluralLatvian(count int) string {
+	switch {
+	case count%10 == 0 || (count%100 >= 11 && count%100 <= 19):
+		return PluralZero
+	case count%10 == 1:
+		return PluralOne
+	default:
+		return PluralOther
+	}
+}
+
+func pluralRomanian(count int) string {
+	switch {
+	case count == 1:
+		return PluralOne
+	case count == 0 || (count%100 >= 2 && count%100 <= 19):
+		return PluralFew
+	default:
+		return PluralOther
+	}
+}
+
+func pluralSlovenian(count int) string {
+	switch count % 100 {
+	case 1:
+		return PluralOne
+	case 2:
+		return PluralTwo
+	case 3, 4:
+		return PluralFew
+	default:
+		return PluralOther
+	}
+}
+
+func pluralArabic(count int) string {
+	switch {
+	case count == 0:
+		return PluralZero
+	case count == 1:
+		return PluralOne
+	case count == 2:
+		return PluralTwo
+	case count%100 >= 3 && count%100 <= 10:
+		return PluralFew
+	case count%100 >= 11:
+		return PluralMany
+	default:
+		return PluralOther
+	}
+}
+
+func pluralHebrew(count int) string {
+	switch count {
+	case 1:
+		return PluralOne
+	case 2:
+		return PluralTwo
+	default:
+		return PluralOther
+	}
+}
+
+func pluralIrish(count int) string {
+	switch {
+	case count == 1:
+		return PluralOne
+	case count == 2:
+		return PluralTwo
+	case count >= 3 && count <= 6:
+		return PluralFew
+	case count >= 7 && count <= 10:
+		return PluralMany
+	default:
+		return PluralOther
+	}
+}
+
+// endsInTwoToFour reports whether count ends in 2, 3 or 4 but not in 12, 13 or 14.
+func endsInTwoToFour(count int) bool {
+	return count%10 >= 2 && count%10 <= 4 && (count%100 < 12 || count%100 > 14)
+}
@@ -0,0 +1,81 @@
+package ginhtmx
+
+import (
+	"errors"
+	"fmt"
+	"html/template"
+	"io"
+
+	"github.com/flosch/pongo2/v6"
+	"github.com/gin-gonic/gin"
+)
+
+// Pongo2Engine returns a TemplateEngine which renders the templates of set, named
+// by their file names, such as "users/show.html". Templates are compiled once and
+// cached by set, unless the Debug of set is enabled.
+//
+// The model of a template is its context, so that the values of the model are
+// available by their keys, such as {{ User.Name }}. Values of the model which are
+// template.HTML, such as the content given to the layout, are not escaped. A model
+// which is not a map is available as Model.
+func Pongo2Engine(set *pongo2.TemplateSet) TemplateEngine {
+	return pongo2Engine{set: set}
+}
+
+type pongo2Engine struct {
+	set *pongo2.TemplateSet
+}
+
+func (engine pongo2Engine) Lookup(name string) bool {
+	_, err := engine.set.FromCache(name)
+
+	// templates which fail to compile are defined, so that rendering them reports why
+	return !isPongo2NotFound(err)
+}
+
+func (engine pongo2Engine) Execute(name string, data any, writer io.Writer) error {
+	tpl, err := engine.set.FromCache(name)
+
+	switch {
+	case isPongo2NotFound(err):
+		return fmt.Errorf("%w: %q", ErrTemplateNotFound, name)
+	case err != nil:
+		return err //nolint:wrapcheck
+	}
+
+	return tpl.ExecuteWriter(pongo2Context(data), writer) //nolint:wrapcheck
+}
+
+// isPongo2NotFound reports whether err is the error of pongo2 for a template which
+// could not be loaded.
+func isPongo2NotFound(err error) bool {
+	var pongo2Err *pongo2.Error
+
+	return errors.As(err, &pongo2Err) && pongo2Err.Sender == "fromfile"
+}
+
+// pongo2Context returns data as the context of a pongo2 template.
+func pongo2Context(data any) pongo2.Context {
+	var values map[string]any
+
+	switch data := data.(type) {
+	case gin.H:
+		values = data
+	case map[string]any:
+		values = data
+	default:
+		return pongo2.Context{ViewModelKey: data}
+	}
+
+	pongo2Context := make(pongo2.Context, len(values))
+
+	for key, value := range values {
+		if html, ok := value.(template.HTML); ok {
+			pongo2Context[key] = pongo2.AsSafeValue(string(html))
+		} else {
+			pongo2Context[key] = value
+		}
+	}
+
+	return pongo2Context
+}
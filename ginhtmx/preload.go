@@ -0,0 +1,115 @@
+package ginhtmx
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// PreloadLink describes a critical resource of the layout, such as the HTMX script
+// or the main stylesheet, which the browser should start loading before it has
+// parsed the page.
+type PreloadLink struct {
+	// URL is the URL of the resource
+	URL string
+
+	// Asset is the name of the resource in the Assets manifest of the configuration.
+	// When set, the cache-busted URL of the asset is used instead of URL.
+	Asset string
+
+	// As is the type of the resource, such as "script", "style", "font" or "image"
+	As string
+
+	// Type is the optional MIME type of the resource, such as "font/woff2"
+	Type string
+
+	// CrossOrigin requests the resource in anonymous CORS mode, which fonts require
+	CrossOrigin bool
+}
+
+// preloadHeaders adds the Link header for each of the Preload links of the
+// configuration to the response. It is used for full page responses, as fragments
+// are loaded into a page which already has the resources.
+func (htmx *Htmx) preloadHeaders(ginContext *gin.Context) {
+	for _, link := range htmx.config.Preload {
+		ginContext.Writer.Header().Add("Link", htmx.linkHeader(link))
+	}
+}
+
+func (htmx *Htmx) linkHeader(link PreloadLink) string {
+	url := link.URL
+	if link.Asset != "" && htmx.config.Assets != nil {
+		url = htmx.config.Assets.URL(link.Asset)
+	}
+
+	parts := []string{"<" + url + ">", "rel=preload"}
+
+	if link.As != "" {
+		parts = append(parts, "as="+link.As)
+	}
+
+	if link.Type != "" {
+		parts = append(parts, `type="`+link.Type+`"`)
+	}
+
+	if link.CrossOrigin {
+		parts = append(parts, "crossorigin")
+	}
+
+	return strings.Join(parts, "; ")
+}
+
+// EarlyHintsMiddleware returns gin middleware which sends a 103 Early Hints
+// informational response holding the Preload links of the configuration before the
+// handler runs, so that the browser can load them while the page is being
+// rendered. Hints are only sent for GET requests which are not HTMX requests, and
+// only for requests served by an http.Server, which supports informational
+// responses, rather than by a test recorder.
+func (htmx *Htmx) EarlyHintsMiddleware() gin.HandlerFunc {
+	return func(ginContext *gin.Context) {
+		if len(htmx.config.Preload) > 0 &&
+			ginContext.Request.Method == http.MethodGet &&
+			!htmx.IsHTMXRequest(ginContext) &&
+			ginContext.Request.ProtoAtLeast(1, 1) &&
+			ginContext.Request.Context().Value(http.ServerContextKey) != nil {
+			if writer, ok := informationalWriter(ginContext.Writer); ok {
+				htmx.writeEarlyHints(ginContext, writer)
+			}
+		}
+
+		ginContext.Next()
+	}
+}
+
+// writeEarlyHints sends the 103 Early Hints response holding only the Preload
+// links, then restores the Link headers which were set for the final response.
+func (htmx *Htmx) writeEarlyHints(ginContext *gin.Context, writer http.ResponseWriter) {
+	header := ginContext.Writer.Header()
+	links := header.Values("Link")
+
+	header.Del("Link")
+	htmx.preloadHeaders(ginContext)
+	writer.WriteHeader(http.StatusEarlyHints)
+	header.Del("Link")
+
+	for _, link := range links {
+		header.Add("Link", link)
+	}
+}
+
+// informationalWriter returns the http.ResponseWriter of the server underneath the
+// gin writer, which, unlike the gin writer, sends informational responses.
+func informationalWriter(writer http.ResponseWriter) (http.ResponseWriter, bool) {
+	unwrapped := false
+
+	for {
+		wrapper, ok := writer.(interface{ Unwrap() http.ResponseWriter })
+		if !ok {
+			return writer, unwrapped
+		}
+
+		writer = wrapper.Unwrap()
+		unwrapped = true
+	}
+}
@@ -0,0 +1,175 @@
+package ginhtmx_test
+
+import (
+	"context"
+	"html/template"
+	"net/http"
+	"net/http/httptest"
+	"net/http/httptrace"
+	"net/textproto"
+	"sync"
+	"testing"
+	"testing/fstest"
+
+	"github.com/gin-gonic/gin"
+	"github.com/jeffscottbrown/ginhtmxtemplates/ginhtmx"
+	"github.com/stretchr/testify/suite"
+)
+
+func (suite *PreloadTestSuite) TestFullPagesIncludeLinkHeaders() {
+	recorder := httptest.NewRecorder()
+	request := httptest.NewRequest(http.MethodGet, "/", nil)
+
+	suite.router.ServeHTTP(recorder, request)
+
+	suite.Equal(http.StatusOK, recorder.Code)
+	suite.Equal([]string{
+		"</static/app.0123456789ab.css>; rel=preload; as=style",
+		`</static/font.woff2>; rel=preload; as=font; type="font/woff2"; crossorigin`,
+	}, recorder.Header().Values("Link"))
+}
+
+func (suite *PreloadTestSuite) TestFragmentsDoNotIncludeLinkHeaders() {
+	recorder := httptest.NewRecorder()
+	request := httptest.NewRequest(http.MethodGet, "/", nil)
+	request.Header.Set("Hx-Request", "true")
+
+	suite.router.ServeHTTP(recorder, request)
+
+	suite.Empty(recorder.Header().Values("Link"))
+}
+
+func (suite *PreloadTestSuite) TestEarlyHintsAreSentBeforeTheResponse() {
+	server := httptest.NewServer(suite.router)
+	defer server.Close()
+
+	var mutex sync.Mutex
+
+	var hints []int
+
+	var hintLinks []string
+
+	trace := &httptrace.ClientTrace{
+		Got1xxResponse: func(code int, header textproto.MIMEHeader) error {
+			mutex.Lock()
+			defer mutex.Unlock()
+
+			hints = append(hints, code)
+			hintLinks = header.Values("Link")
+
+			return nil
+		},
+	}
+
+	request, err := http.NewRequestWithContext(
+		httptrace.WithClientTrace(context.Background(), trace), http.MethodGet, server.URL, nil)
+	suite.Require().NoError(err)
+
+	response, err := http.DefaultClient.Do(request)
+	suite.Require().NoError(err)
+
+	defer response.Body.Close()
+
+	suite.Equal(http.StatusOK, response.StatusCode)
+	suite.Len(response.Header.Values("Link"), 2)
+
+	mutex.Lock()
+	defer mutex.Unlock()
+
+	suite.Equal([]int{http.StatusEarlyHints}, hints)
+	suite.Len(hintLinks, 2)
+}
+
+func (suite *PreloadTestSuite) TestEarlyHintsKeepLinkHeadersOfTheFinalResponse() {
+	router := gin.New()
+	router.Use(func(c *gin.Context) { c.Header("Link", "<https://example.com/>; rel=canonical") })
+	router.Use(suite.htmx.EarlyHintsMiddleware())
+	router.GET("/", func(c *gin.Context) { suite.htmx.Render(c, gin.H{}, "home") })
+
+	server := httptest.NewServer(router)
+	defer server.Close()
+
+	hints, response := suite.get(server.URL)
+
+	defer response.Body.Close()
+
+	suite.Equal([]string{
+		"</static/app.0123456789ab.css>; rel=preload; as=style",
+		`</static/font.woff2>; rel=preload; as=font; type="font/woff2"; crossorigin`,
+	}, hints)
+	suite.Equal([]string{
+		"<https://example.com/>; rel=canonical",
+		"</static/app.0123456789ab.css>; rel=preload; as=style",
+		`</static/font.woff2>; rel=preload; as=font; type="font/woff2"; crossorigin`,
+	}, response.Header.Values("Link"))
+}
+
+func (suite *PreloadTestSuite) TestEarlyHintsAreNotSentForHTMXRequests() {
+	recorder := httptest.NewRecorder()
+	request := httptest.NewRequest(http.MethodGet, "/", nil)
+	request.Header.Set("Hx-Request", "true")
+
+	suite.router.ServeHTTP(recorder, request)
+
+	suite.Equal(http.StatusOK, recorder.Code)
+}
+
+// get requests url and returns the Link headers of the early hints it received,
+// together with the final response.
+func (suite *PreloadTestSuite) get(url string) ([]string, *http.Response) {
+	var hintLinks []string
+
+	trace := &httptrace.ClientTrace{
+		Got1xxResponse: func(_ int, header textproto.MIMEHeader) error {
+			hintLinks = header.Values("Link")
+
+			return nil
+		},
+	}
+
+	request, err := http.NewRequestWithContext(
+		httptrace.WithClientTrace(context.Background(), trace), http.MethodGet, url, nil)
+	suite.Require().NoError(err)
+
+	response, err := http.DefaultClient.Do(request)
+	suite.Require().NoError(err)
+
+	return hintLinks, response
+}
+
+func (suite *PreloadTestSuite) SetupSuite() {
+	manifest, err := ginhtmx.LoadAssetManifest(fstest.MapFS{
+		"manifest.json": {Data: []byte(`{"app.css": "app.0123456789ab.css"}`)},
+	}, "manifest.json", "/static/")
+	suite.Require().NoError(err)
+
+	tmpl := template.Must(template.New("").Parse(`
+{{- define "layout"}}<html>{{.Content}}</html>{{end}}
+{{- define "home"}}<p>home</p>{{end}}
+`))
+	suite.htmx = ginhtmx.NewHtmxWithConfig(tmpl, ginhtmx.HtmxConfig{
+		LayoutTemplateName:  "layout",
+		ContentVariableName: "Content",
+		Assets:              manifest,
+		Preload: []ginhtmx.PreloadLink{
+			{Asset: "app.css", As: "style"},
+			{URL: "/static/font.woff2", As: "font", Type: "font/woff2", CrossOrigin: true},
+		},
+	})
+
+	suite.router = gin.New()
+	suite.router.Use(suite.htmx.EarlyHintsMiddleware())
+	suite.router.GET("/", func(c *gin.Context) { suite.htmx.Render(c, gin.H{}, "home") })
+}
+
+func TestPreloadTestSuite(t *testing.T) {
+	t.Parallel()
+	suite.Run(t, new(PreloadTestSuite))
+}
+
+type PreloadTestSuite struct {
+	suite.Suite
+
+	htmx   *ginhtmx.Htmx
+	router *gin.Engine
+}
@@ -0,0 +1,78 @@
+package ginhtmx
+
+import (
+	"fmt"
+
+	"github.com/gin-gonic/gin"
+)
+
+// modelProvidersKey is the gin context key holding the providers registered for
+// the current route.
+const modelProvidersKey = "ginhtmx.modelProviders"
+
+// ModelProvider supplies data which is shared by every template rendered for the
+// routes it is registered with, for example counts shown in the sidebar of an
+// admin section.
+type ModelProvider func(ginContext *gin.Context) (any, error)
+
+type registeredProvider struct {
+	key      string
+	provider ModelProvider
+	resolved bool
+	value    any
+	err      error
+}
+
+// ProvideModel returns gin middleware which registers provider for the routes the
+// middleware is used with, typically a router group:
+//
+//	admin := router.Group("/admin")
+//	admin.Use(ginhtmx.ProvideModel("Sidebar", func(c *gin.Context) (any, error) {
+//	  return loadSidebarCounts(c)
+//	}))
+//
+// The provider is called at most once per request, when the first template is
+// rendered, and its result is added to the model under key. Data supplied by the
+// handler under the same key takes precedence. If the provider returns an error
+// the key is omitted and the error is recorded as a render error.
+func ProvideModel(key string, provider ModelProvider) gin.HandlerFunc {
+	return func(ginContext *gin.Context) {
+		providers, _ := ginContext.Value(modelProvidersKey).([]*registeredProvider)
+		providers = append(providers, &registeredProvider{
+			key:      key,
+			provider: provider,
+			resolved: false,
+			value:    nil,
+			err:      nil,
+		})
+		ginContext.Set(modelProvidersKey, providers)
+		ginContext.Next()
+	}
+}
+
+// applyModelProviders adds the data supplied by the providers registered for the
+// current route to model and returns any errors returned by them.
+func applyModelProviders(ginContext *gin.Context, model gin.H) []error {
+	providers, _ := ginContext.Value(modelProvidersKey).([]*registeredProvider)
+
+	var errs []error
+
+	for _, registered := range providers {
+		if !registered.resolved {
+			registered.value, registered.err = registered.provider(ginContext)
+			registered.resolved = true
+		}
+
+		if registered.err != nil {
+			errs = append(errs, fmt.Errorf("model provider %q: %w", registered.key, registered.err))
+
+			continue
+		}
+
+		if _, exists := model[registered.key]; !exists {
+			model[registered.key] = registered.value
+		}
+	}
+
+	return errs
+}
@@ -0,0 +1,83 @@
+package ginhtmx_test
+
+import (
+	"errors"
+	"html/template"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/jeffscottbrown/ginhtmxtemplates/ginhtmx"
+	"github.com/stretchr/testify/suite"
+)
+
+var errProviderFailed = errors.New("provider failed")
+
+func (suite *ModelProviderTestSuite) TestProvidersAreSharedByGroupRoutes() {
+	calls := 0
+
+	router := gin.New()
+	admin := router.Group("/admin")
+	admin.Use(ginhtmx.ProvideModel("Sidebar", func(_ *gin.Context) (any, error) {
+		calls++
+
+		return gin.H{"Users": 3}, nil
+	}))
+	admin.GET("/users", func(c *gin.Context) {
+		suite.htmx.Render(c, gin.H{}, "sidebar", "sidebar")
+	})
+	admin.GET("/override", func(c *gin.Context) {
+		suite.htmx.Render(c, gin.H{"Sidebar": gin.H{"Users": 7}}, "sidebar")
+	})
+	router.GET("/public", func(c *gin.Context) {
+		suite.htmx.Render(c, nil, "sidebar")
+	})
+
+	suite.Equal("Users: 3Users: 3", suite.get(router, "/admin/users").Body.String())
+	suite.Equal(1, calls)
+	suite.Equal("Users: 7", suite.get(router, "/admin/override").Body.String())
+	suite.Equal("Users: ", suite.get(router, "/public").Body.String())
+}
+
+func (suite *ModelProviderTestSuite) TestProviderErrorsAreRecorded() {
+	var renderErr any
+
+	router := gin.New()
+	router.Use(ginhtmx.ProvideModel("Sidebar", func(_ *gin.Context) (any, error) {
+		return nil, errProviderFailed
+	}))
+	router.GET("/", func(c *gin.Context) {
+		suite.htmx.Render(c, gin.H{}, "sidebar")
+		renderErr, _ = c.Get(ginhtmx.RenderErrorKey)
+	})
+
+	suite.Equal("Users: ", suite.get(router, "/").Body.String())
+	suite.Require().ErrorIs(renderErr.(error), errProviderFailed)
+	suite.Contains(renderErr.(error).Error(), `"Sidebar"`)
+}
+
+func (suite *ModelProviderTestSuite) get(router *gin.Engine, target string) *httptest.ResponseRecorder {
+	recorder := httptest.NewRecorder()
+	request := httptest.NewRequest(http.MethodGet, target, nil)
+	request.Header.Set("Hx-Request", "true")
+	router.ServeHTTP(recorder, request)
+
+	return recorder
+}
+
+func (suite *ModelProviderTestSuite) SetupSuite() {
+	tmpl := template.Must(template.New("").Parse(`{{define "sidebar"}}Users: {{with .Sidebar}}{{.Users}}{{end}}{{end}}`))
+	suite.htmx = ginhtmx.NewHtmx(tmpl)
+}
+
+func TestModelProviderTestSuite(t *testing.T) {
+	t.Parallel()
+	suite.Run(t, new(ModelProviderTestSuite))
+}
+
+type ModelProviderTestSuite struct {
+	suite.Suite
+
+	htmx *ginhtmx.Htmx
+}
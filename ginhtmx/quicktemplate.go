@@ -0,0 +1,38 @@
+package ginhtmx
+
+import (
+	"fmt"
+	"io"
+)
+
+// QuickTemplates is a TemplateEngine which renders templates compiled to Go
+// functions by quicktemplate (github.com/valyala/quicktemplate). As the functions
+// take typed arguments, each is registered by name with a function which passes
+// the model to it:
+//
+//	ginhtmx.QuickTemplates{
+//	  "layout": func(w io.Writer, data any) {
+//	    views.WriteLayout(w, data.(gin.H)["Content"].(template.HTML))
+//	  },
+//	  "user": func(w io.Writer, data any) { views.WriteUser(w, data.(gin.H)["User"].(*User)) },
+//	}
+type QuickTemplates map[string]func(writer io.Writer, data any)
+
+// Lookup reports whether a template with the specified name is registered.
+func (templates QuickTemplates) Lookup(name string) bool {
+	_, found := templates[name]
+
+	return found
+}
+
+// Execute renders the named template with data to writer.
+func (templates QuickTemplates) Execute(name string, data any, writer io.Writer) error {
+	render, found := templates[name]
+	if !found {
+		return fmt.Errorf("%w: %q", ErrTemplateNotFound, name)
+	}
+
+	render(writer, data)
+
+	return nil
+}
@@ -0,0 +1,98 @@
+package ginhtmx
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// RedirectMiddleware returns gin middleware which converts redirects in responses to
+// HTMX requests into HX-Redirect responses. HTMX follows redirects transparently
+// and swaps the content of the page which was redirected to into the target
+// element, which is rarely what a handler which redirects intends. When the request
+// is an HTMX request and the handler responds with a 301, 302 or 303 status and a
+// Location header, the response is instead written with a 200 status, an empty
+// body and an HX-Redirect header holding the location, so that the browser
+// navigates to the location. Other responses are not modified. HTMX requests are
+// identified by the HTMXRequestHeaders of the configuration, see IsHTMXRequest.
+func (htmx *Htmx) RedirectMiddleware() gin.HandlerFunc {
+	return func(ginContext *gin.Context) {
+		if !htmx.IsHTMXRequest(ginContext) {
+			ginContext.Next()
+
+			return
+		}
+
+		writer := &redirectWriter{ResponseWriter: ginContext.Writer, redirected: false}
+		ginContext.Writer = writer
+
+		ginContext.Next()
+
+		writer.convert()
+		ginContext.Writer = writer.ResponseWriter
+	}
+}
+
+// redirectWriter converts redirect responses into HX-Redirect responses before
+// the status is written.
+type redirectWriter struct {
+	gin.ResponseWriter
+
+	redirected bool
+}
+
+func (writer *redirectWriter) WriteHeaderNow() {
+	writer.convert()
+	writer.ResponseWriter.WriteHeaderNow()
+}
+
+func (writer *redirectWriter) Write(data []byte) (int, error) {
+	writer.convert()
+
+	if writer.redirected {
+		return len(data), nil
+	}
+
+	return writer.ResponseWriter.Write(data) //nolint:wrapcheck
+}
+
+func (writer *redirectWriter) WriteString(data string) (int, error) {
+	writer.convert()
+
+	if writer.redirected {
+		return len(data), nil
+	}
+
+	return writer.ResponseWriter.WriteString(data) //nolint:wrapcheck
+}
+
+func (writer *redirectWriter) Flush() {
+	writer.convert()
+	writer.ResponseWriter.Flush()
+}
+
+// convert rewrites a pending redirect response as an HX-Redirect response.
+func (writer *redirectWriter) convert() {
+	if writer.redirected || writer.Written() {
+		return
+	}
+
+	switch writer.Status() {
+	case http.StatusMovedPermanently, http.StatusFound, http.StatusSeeOther:
+	default:
+		return
+	}
+
+	header := writer.Header()
+
+	location := header.Get("Location")
+	if location == "" {
+		return
+	}
+
+	header.Del("Location")
+	header.Del("Content-Type")
+	header.Set("HX-Redirect", location)
+	writer.WriteHeader(http.StatusOK)
+	writer.redirected = true
+}
@@ -0,0 +1,101 @@
+package ginhtmx_test
+
+import (
+	"html/template"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/jeffscottbrown/ginhtmxtemplates/ginhtmx"
+	"github.com/stretchr/testify/suite"
+)
+
+func (suite *RedirectMiddlewareTestSuite) TestRedirectsBecomeHXRedirects() {
+	for _, path := range []string{"/redirect", "/status"} {
+		recorder := suite.request(path, true)
+
+		suite.Equal(http.StatusOK, recorder.Code, path)
+		suite.Equal("/users/3", recorder.Header().Get("HX-Redirect"), path)
+		suite.Empty(recorder.Header().Get("Location"), path)
+		suite.Empty(recorder.Body.String(), path)
+	}
+}
+
+func (suite *RedirectMiddlewareTestSuite) TestOtherResponsesAreNotModified() {
+	recorder := suite.request("/temporary", true)
+
+	suite.Equal(http.StatusTemporaryRedirect, recorder.Code)
+	suite.Equal("/users/3", recorder.Header().Get("Location"))
+
+	recorder = suite.request("/ok", true)
+
+	suite.Equal(http.StatusOK, recorder.Code)
+	suite.Equal("ok", recorder.Body.String())
+	suite.Empty(recorder.Header().Get("HX-Redirect"))
+}
+
+func (suite *RedirectMiddlewareTestSuite) TestRedirectsForOtherRequestsAreNotModified() {
+	recorder := suite.request("/redirect", false)
+
+	suite.Equal(http.StatusSeeOther, recorder.Code)
+	suite.Equal("/users/3", recorder.Header().Get("Location"))
+	suite.Empty(recorder.Header().Get("HX-Redirect"))
+}
+
+func (suite *RedirectMiddlewareTestSuite) TestConfiguredHTMXRequestHeadersAreUsed() {
+	recorder := httptest.NewRecorder()
+	request := httptest.NewRequest(http.MethodGet, "/redirect", nil)
+	request.Header.Set("X-HX-Request", "true")
+
+	suite.router.ServeHTTP(recorder, request)
+
+	suite.Equal(http.StatusOK, recorder.Code)
+	suite.Equal("/users/3", recorder.Header().Get("HX-Redirect"))
+}
+
+func (suite *RedirectMiddlewareTestSuite) request(path string, htmxRequest bool) *httptest.ResponseRecorder {
+	recorder := httptest.NewRecorder()
+	request := httptest.NewRequest(http.MethodGet, path, nil)
+
+	if htmxRequest {
+		request.Header.Set("Hx-Request", "true")
+	}
+
+	suite.router.ServeHTTP(recorder, request)
+
+	return recorder
+}
+
+func (suite *RedirectMiddlewareTestSuite) SetupSuite() {
+	htmx := ginhtmx.NewHtmxWithConfig(template.New(""), ginhtmx.HtmxConfig{
+		LayoutTemplateName:  "layout",
+		ContentVariableName: "Content",
+		HTMXRequestHeaders:  []string{"HX-Request", "X-HX-Request"},
+	})
+
+	suite.router = gin.New()
+	suite.router.Use(htmx.RedirectMiddleware())
+	suite.router.GET("/redirect", func(c *gin.Context) { c.Redirect(http.StatusSeeOther, "/users/3") })
+	suite.router.GET("/temporary", func(c *gin.Context) { c.Redirect(http.StatusTemporaryRedirect, "/users/3") })
+	suite.router.GET("/status", func(c *gin.Context) {
+		c.Status(http.StatusFound)
+		c.Header("Location", "/users/3")
+	})
+	suite.router.GET("/ok", func(c *gin.Context) {
+		c.Writer.WriteHeaderNow()
+		_, _ = c.Writer.WriteString("ok")
+		c.Writer.Flush()
+	})
+}
+
+func TestRedirectMiddlewareTestSuite(t *testing.T) {
+	t.Parallel()
+	suite.Run(t, new(RedirectMiddlewareTestSuite))
+}
+
+type RedirectMiddlewareTestSuite struct {
+	suite.Suite
+
+	router *gin.Engine
+}
@@ -0,0 +1,20 @@
+package ginhtmx
+
+import "github.com/gin-gonic/gin"
+
+// Renderer renders templates in response to a request. It is implemented by Htmx,
+// and handlers which depend on a Renderer rather than an *Htmx may be unit tested
+// with a fake, such as the RecorderRenderer of the ginhtmxtest package, which
+// records what was rendered without executing any templates:
+//
+//	type userHandler struct {
+//	  renderer ginhtmx.Renderer
+//	}
+type Renderer interface {
+	// Render renders the templates with data and a 200 status code, see Htmx.Render.
+	Render(ginContext *gin.Context, data gin.H, templateNames ...string)
+
+	// RenderWithStatus renders the templates with data and the status code, see
+	// Htmx.RenderWithStatus.
+	RenderWithStatus(ginContext *gin.Context, data gin.H, status int, templateNames ...string)
+}
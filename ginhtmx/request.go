@@ -0,0 +1,63 @@
+package ginhtmx
+
+import "net/http"
+
+// credentialHeaders are the request headers "header" never returns, so that
+// credentials cannot be rendered into pages.
+var credentialHeaders = []string{"Authorization", "Proxy-Authorization", "Cookie"}
+
+// request returns the request being rendered, or nil outside of a render.
+func (scope *requestScope) request() *http.Request {
+	if scope.ginContext == nil {
+		return nil
+	}
+
+	return scope.ginContext.Request
+}
+
+// queryParam returns the first value of the named query parameter of the request,
+// or an empty string if it is not present, so that templates need not be given the
+// gin context to read it:
+//
+//	<a href="?page={{ queryParam "page" }}&sort=name">Name</a>
+func (scope *requestScope) queryParam(name string) string {
+	request := scope.request()
+	if request == nil {
+		return ""
+	}
+
+	return request.URL.Query().Get(name)
+}
+
+// header returns the first value of the named request header, or an empty string
+// if it is not present. The Authorization, Proxy-Authorization and Cookie headers
+// are never returned:
+//
+//	{{ if eq (header "Save-Data") "on" }}<img src="/hero-small.jpg">{{ end }}
+func (scope *requestScope) header(name string) string {
+	request := scope.request()
+	if request == nil {
+		return ""
+	}
+
+	name = http.CanonicalHeaderKey(name)
+	for _, credential := range credentialHeaders {
+		if name == credential {
+			return ""
+		}
+	}
+
+	return request.Header.Get(name)
+}
+
+// isHTMXRequest reports whether the request being rendered was made by HTMX, see
+// Htmx.IsHTMXRequest:
+//
+//	{{ if not isHtmxRequest }}<script src="/js/page.js"></script>{{ end }}
+func (scope *requestScope) isHTMXRequest() bool {
+	if scope.request() == nil || scope.htmx == nil {
+		return false
+	}
+
+	return scope.htmx.IsHTMXRequest(scope.ginContext)
+}
@@ -0,0 +1,86 @@
+package ginhtmx_test
+
+import (
+	"html/template"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/jeffscottbrown/ginhtmxtemplates/ginhtmx"
+	"github.com/stretchr/testify/suite"
+)
+
+func (suite *RequestFuncsTestSuite) TestRequestIsAvailableToTemplates() {
+	request := httptest.NewRequest(http.MethodGet, "/users?page=3&page=4&sort=name", nil)
+	request.Header.Set("Save-Data", "on")
+
+	suite.Equal(`/users|3||on|false`, suite.render(request))
+}
+
+func (suite *RequestFuncsTestSuite) TestHTMXRequestsUseTheCurrentURL() {
+	request := httptest.NewRequest(http.MethodGet, "/fragments/users?sort=name", nil)
+	request.Header.Set("HX-Request", "true")
+	request.Header.Set("HX-Current-URL", "https://example.com/users?page=2")
+
+	suite.Equal(`/users||||true`, suite.render(request))
+}
+
+func (suite *RequestFuncsTestSuite) TestCredentialsAreNotAvailable() {
+	request := httptest.NewRequest(http.MethodGet, "/", nil)
+	request.Header.Set("Authorization", "Bearer secret")
+	request.Header.Set("Proxy-Authorization", "Basic secret")
+	request.Header.Set("Cookie", "session=secret")
+
+	tmpl := template.Must(template.New("").Funcs(ginhtmx.FuncMap()).Parse(
+		`{{define "credentials"}}[{{header "authorization"}}{{header "Proxy-Authorization"}}{{header "Cookie"}}]{{end}}`))
+
+	suite.Equal("[]", suite.renderWith(ginhtmx.NewHtmx(tmpl), request, "credentials"))
+}
+
+func (suite *RequestFuncsTestSuite) TestDefaultsOutsideRequest() {
+	tmpl := template.Must(template.New("").Funcs(ginhtmx.FuncMap()).Parse(
+		`{{currentPath}}|{{queryParam "page"}}|{{header "Accept"}}|{{isHtmxRequest}}`))
+
+	output, err := ginhtmx.NewHtmx(tmpl).RenderToString("", nil)
+
+	suite.Require().NoError(err)
+	suite.Equal("|||false", output)
+}
+
+func (suite *RequestFuncsTestSuite) render(request *http.Request) string {
+	return suite.renderWith(suite.htmx, request, "request")
+}
+
+func (suite *RequestFuncsTestSuite) renderWith(htmx *ginhtmx.Htmx, request *http.Request, name string) string {
+	recorder := httptest.NewRecorder()
+	testContext, _ := gin.CreateTestContext(recorder)
+	testContext.Request = request
+
+	htmx.With(ginhtmx.ForceFragment()).Render(testContext, gin.H{}, name)
+
+	return recorder.Body.String()
+}
+
+func (suite *RequestFuncsTestSuite) SetupTest() {
+	tmpl := template.Must(template.New("").Funcs(ginhtmx.FuncMap()).Parse(`
+{{define "layout"}}<html>{{.Content}}</html>{{end}}
+{{define "request"}}{{currentPath}}|{{queryParam "page"}}|{{queryParam "missing"}}|
+{{- header "save-data"}}|{{isHtmxRequest}}{{end}}
+`))
+	suite.htmx = ginhtmx.NewHtmxWithConfig(tmpl, ginhtmx.HtmxConfig{
+		LayoutTemplateName:  "layout",
+		ContentVariableName: "Content",
+	})
+}
+
+func TestRequestFuncsTestSuite(t *testing.T) {
+	t.Parallel()
+	suite.Run(t, new(RequestFuncsTestSuite))
+}
+
+type RequestFuncsTestSuite struct {
+	suite.Suite
+
+	htmx *ginhtmx.Htmx
+}
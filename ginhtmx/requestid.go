@@ -0,0 +1,119 @@
+package ginhtmx
+
+import (
+	"crypto/rand"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	// DefaultRequestIDHeader is the request header holding the ID of the request,
+	// and the response header it is returned in, unless configured otherwise.
+	DefaultRequestIDHeader = "X-Request-Id"
+
+	// RequestIDKey is the gin context key holding the ID of the request once it has
+	// been assigned, see RequestID.
+	RequestIDKey = "ginhtmx.requestID"
+
+	// RequestIDModelKey is the key under which the ID of the request is exposed to
+	// templates.
+	RequestIDModelKey = "RequestID"
+
+	// maxRequestIDLength is the length above which request IDs received from the
+	// client are replaced with generated ones.
+	maxRequestIDLength = 128
+)
+
+// RequestID returns the ID of the request, which correlates the rendered page with
+// the log entries of the render. The ID is taken from the request header named by
+// RequestIDHeader, usually set by a proxy or load balancer, or generated if the
+// request does not have one or it is not a short value made of letters, digits,
+// '-', '_', '.' and ':', such as a UUID. It is stored in the gin context under
+// RequestIDKey and returned in the response header of the same name, so that it is
+// the same for every call during a request.
+//
+// Every render exposes the ID to templates as .RequestID and through the
+// "requestID" function, so that error pages can show it to the user as a
+// reference:
+//
+//	<p>Something went wrong. Please quote reference {{ requestID }} when contacting us.</p>
+func (htmx *Htmx) RequestID(ginContext *gin.Context) string {
+	if requestID := ginContext.GetString(RequestIDKey); requestID != "" {
+		return requestID
+	}
+
+	header := htmx.requestIDHeader()
+
+	requestID := ginContext.GetHeader(header)
+	if !validRequestID(requestID) {
+		requestID = rand.Text()
+	}
+
+	ginContext.Set(RequestIDKey, requestID)
+	ginContext.Header(header, requestID)
+
+	return requestID
+}
+
+// RequestIDMiddleware returns gin middleware which assigns the ID of the request
+// before the handler runs, so that it is also available to the middleware and
+// handlers which run before the templates are rendered.
+func (htmx *Htmx) RequestIDMiddleware() gin.HandlerFunc {
+	return func(ginContext *gin.Context) {
+		htmx.RequestID(ginContext)
+		ginContext.Next()
+	}
+}
+
+// exposeRequestID returns a copy of the model of a render of templates with the ID
+// of the request added, unless the model already has a value with the same key. The
+// model of the handler is not modified, as it may be shared between requests. The
+// ID is not added to models serialized as JSON.
+func (htmx *Htmx) exposeRequestID(ginContext *gin.Context, data gin.H) gin.H {
+	if _, exists := data[RequestIDModelKey]; exists {
+		return data
+	}
+
+	return mergeModels(data, gin.H{RequestIDModelKey: htmx.RequestID(ginContext)})
+}
+
+// requestIDHeader returns the name of the header holding the ID of the request.
+func (htmx *Htmx) requestIDHeader() string {
+	if htmx.config.RequestIDHeader != "" {
+		return htmx.config.RequestIDHeader
+	}
+
+	return DefaultRequestIDHeader
+}
+
+// validRequestID reports whether requestID is a non-empty value of letters, digits,
+// '-', '_', '.' and ':' short enough to be logged and shown to users.
+func validRequestID(requestID string) bool {
+	if requestID == "" || len(requestID) > maxRequestIDLength {
+		return false
+	}
+
+	for _, char := range requestID {
+		switch {
+		case char >= 'a' && char <= 'z', char >= 'A' && char <= 'Z', char >= '0' && char <= '9':
+		case strings.ContainsRune("-_.:", char):
+		default:
+			return false
+		}
+	}
+
+	return true
+}
+
+// requestID returns the ID of the request being rendered, or an empty string
+// outside of a render:
+//
+//	<footer>Reference {{ requestID }}</footer>
+func (scope *requestScope) requestID() string {
+	if scope.ginContext == nil || scope.htmx == nil {
+		return ""
+	}
+
+	return scope.htmx.RequestID(scope.ginContext)
+}
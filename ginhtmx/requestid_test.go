@@ -0,0 +1,161 @@
+package ginhtmx_test
+
+import (
+	"bytes"
+	"errors"
+	"html/template"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/jeffscottbrown/ginhtmxtemplates/ginhtmx"
+	"github.com/stretchr/testify/suite"
+)
+
+func (suite *RequestIDTestSuite) TestRequestIDIsTakenFromTheRequest() {
+	recorder, _ := suite.render(suite.newHtmx(ginhtmx.HtmxConfig{}), "X-Request-Id", "req-42", "page")
+
+	suite.Equal("<p>req-42 req-42</p>", recorder.Body.String())
+	suite.Equal("req-42", recorder.Header().Get("X-Request-Id"))
+}
+
+func (suite *RequestIDTestSuite) TestRequestIDIsGeneratedWhenMissingOrInvalid() {
+	htmx := suite.newHtmx(ginhtmx.HtmxConfig{})
+
+	for _, requestID := range []string{"", "has space", "<script>", strings.Repeat("a", 129)} {
+		recorder, testContext := suite.render(htmx, "X-Request-Id", requestID, "page")
+
+		generated := recorder.Header().Get("X-Request-Id")
+		suite.Regexp(`^[A-Z2-7]{26}$`, generated)
+		suite.Equal("<p>"+generated+" "+generated+"</p>", recorder.Body.String())
+		suite.Equal(generated, testContext.GetString(ginhtmx.RequestIDKey))
+	}
+}
+
+func (suite *RequestIDTestSuite) TestRequestIDHeaderIsConfigurable() {
+	htmx := suite.newHtmx(ginhtmx.HtmxConfig{RequestIDHeader: "X-Correlation-Id"})
+
+	recorder, _ := suite.render(htmx, "X-Correlation-Id", "corr-7", "page")
+
+	suite.Equal("<p>corr-7 corr-7</p>", recorder.Body.String())
+	suite.Equal("corr-7", recorder.Header().Get("X-Correlation-Id"))
+	suite.Empty(recorder.Header().Get("X-Request-Id"))
+}
+
+func (suite *RequestIDTestSuite) TestRequestIDIsTheSameForTheWholeRequest() {
+	htmx := suite.newHtmx(ginhtmx.HtmxConfig{})
+
+	var assigned string
+
+	router := gin.New()
+	router.Use(htmx.RequestIDMiddleware())
+	router.GET("/", func(c *gin.Context) {
+		assigned = c.GetString(ginhtmx.RequestIDKey)
+		htmx.Render(c, gin.H{"RequestID": "model"}, "page")
+	})
+
+	recorder := httptest.NewRecorder()
+	router.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/", nil))
+
+	suite.NotEmpty(assigned)
+	suite.Equal("<p>model "+assigned+"</p>", recorder.Body.String())
+	suite.Equal(assigned, recorder.Header().Get("X-Request-Id"))
+}
+
+func (suite *RequestIDTestSuite) TestTheModelOfTheHandlerIsNotModified() {
+	data := gin.H{"Name": "ann"}
+
+	recorder := httptest.NewRecorder()
+	testContext, _ := gin.CreateTestContext(recorder)
+	testContext.Request = httptest.NewRequest(http.MethodGet, "/", nil)
+	testContext.Request.Header.Set("HX-Request", "true")
+	testContext.Request.Header.Set("X-Request-Id", "abc")
+
+	suite.newHtmx(ginhtmx.HtmxConfig{}).Render(testContext, data, "page")
+
+	suite.Equal("<p>abc abc</p>", recorder.Body.String())
+	suite.Equal(gin.H{"Name": "ann"}, data)
+}
+
+func (suite *RequestIDTestSuite) TestErrorPagesAndLogsShowTheRequestID() {
+	var logs bytes.Buffer
+
+	htmx := suite.newHtmx(ginhtmx.HtmxConfig{
+		ErrorTemplates: map[int]string{http.StatusInternalServerError: "error"},
+		Logger:         slog.New(slog.NewTextHandler(&logs, nil)),
+	})
+
+	recorder := httptest.NewRecorder()
+	testContext, _ := gin.CreateTestContext(recorder)
+	testContext.Request = httptest.NewRequest(http.MethodGet, "/", nil)
+	testContext.Request.Header.Set("X-Request-Id", "req-500")
+
+	htmx.RenderError(testContext, errors.New("database unavailable")) //nolint:err113
+	htmx.Render(testContext, gin.H{}, "missing")
+
+	suite.Equal("<p>Internal Server Error, reference req-500</p>", recorder.Body.String())
+	suite.Contains(logs.String(), "request_id=req-500")
+}
+
+func (suite *RequestIDTestSuite) TestRequestIDIsNotAddedToJSON() {
+	htmx := suite.newHtmx(ginhtmx.HtmxConfig{NegotiateJSON: true})
+
+	recorder := httptest.NewRecorder()
+	testContext, _ := gin.CreateTestContext(recorder)
+	testContext.Request = httptest.NewRequest(http.MethodGet, "/", nil)
+	testContext.Request.Header.Set("Accept", "application/json")
+
+	htmx.Render(testContext, gin.H{"Name": "Lamp"}, "page")
+
+	suite.JSONEq(`{"Name": "Lamp"}`, recorder.Body.String())
+}
+
+func (suite *RequestIDTestSuite) TestRequestIDFunctionIsEmptyOutsideOfARender() {
+	tmpl := template.Must(template.New("").Funcs(ginhtmx.FuncMap()).Parse(`[{{requestID}}]`))
+
+	var output bytes.Buffer
+
+	suite.Require().NoError(tmpl.Execute(&output, nil))
+	suite.Equal("[]", output.String())
+}
+
+func (suite *RequestIDTestSuite) render(
+	htmx *ginhtmx.Htmx, header string, requestID string, templateNames ...string,
+) (*httptest.ResponseRecorder, *gin.Context) {
+	recorder := httptest.NewRecorder()
+	testContext, _ := gin.CreateTestContext(recorder)
+	testContext.Request = httptest.NewRequest(http.MethodGet, "/", nil)
+	testContext.Request.Header.Set("HX-Request", "true")
+
+	if requestID != "" {
+		testContext.Request.Header.Set(header, requestID)
+	}
+
+	htmx.Render(testContext, gin.H{}, templateNames...)
+
+	return recorder, testContext
+}
+
+func (suite *RequestIDTestSuite) newHtmx(config ginhtmx.HtmxConfig) *ginhtmx.Htmx {
+	tmpl := template.Must(template.New("").Funcs(ginhtmx.FuncMap()).Parse(`
+{{define "layout"}}{{.Content}}{{end}}
+{{define "page"}}<p>{{.RequestID}} {{requestID}}</p>{{end}}
+{{define "error"}}<p>{{.Error.Message}}, reference {{.Error.RequestID}}</p>{{end}}
+`))
+	config.LayoutTemplateName = "layout"
+	config.ContentVariableName = "Content"
+
+	return ginhtmx.NewHtmxWithConfig(tmpl, config)
+}
+
+func TestRequestIDTestSuite(t *testing.T) {
+	t.Parallel()
+	suite.Run(t, new(RequestIDTestSuite))
+}
+
+type RequestIDTestSuite struct {
+	suite.Suite
+}
@@ -0,0 +1,44 @@
+package ginhtmx
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// StatusStopPolling is the status code which tells HTMX to stop polling an
+// element which has a trigger such as hx-trigger="every 2s".
+const StatusStopPolling = 286
+
+// StopPolling renders the specified templates in the same way as Render but with
+// the StatusStopPolling status code, so that the response is swapped in as the final
+// content of a polling element and the polling stops.
+func (htmx *Htmx) StopPolling(ginContext *gin.Context, data gin.H, templateNames ...string) {
+	htmx.RenderWithStatus(ginContext, data, StatusStopPolling, templateNames...)
+}
+
+// NoContent writes an empty 204 No Content response. It is intended for actions
+// which do not update the page but should trigger events on the client. The
+// response headers set by the options of htmx and by the provided options, such as
+// Trigger and Reswap, are included in the response:
+//
+//	handler.htmx.NoContent(c, ginhtmx.Trigger("item-deleted"), ginhtmx.Reswap("none"))
+func (htmx *Htmx) NoContent(ginContext *gin.Context, options ...RenderOption) {
+	htmx.renderSettings(options...).writeHeaders(ginContext)
+	ginContext.Status(http.StatusNoContent)
+	ginContext.Writer.WriteHeaderNow()
+}
+
+// pushRequestURL pushes the URL of a GET request into the browser history unless
+// the history has already been updated by an option.
+func pushRequestURL(ginContext *gin.Context, settings *renderSettings) {
+	if ginContext.Request.Method != http.MethodGet {
+		return
+	}
+
+	if settings.headers.Get("HX-Push-Url") != "" || settings.headers.Get("HX-Replace-Url") != "" {
+		return
+	}
+
+	settings.headers.Set("HX-Push-Url", ginContext.Request.URL.RequestURI())
+}
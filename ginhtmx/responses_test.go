@@ -0,0 +1,118 @@
+package ginhtmx_test
+
+import (
+	"html/template"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/jeffscottbrown/ginhtmxtemplates/ginhtmx"
+	"github.com/stretchr/testify/suite"
+)
+
+func (suite *ResponsesTestSuite) TestStopPollingRendersFragmentWith286() {
+	recorder, testContext := suite.newContext(true)
+
+	suite.htmx.StopPolling(testContext, gin.H{"Progress": 100}, "progress")
+
+	suite.Equal(ginhtmx.StatusStopPolling, recorder.Code)
+	suite.Equal("<p>100%</p>", recorder.Body.String())
+}
+
+func (suite *ResponsesTestSuite) TestFragmentsUseTheProvidedStatus() {
+	recorder, testContext := suite.newContext(true)
+
+	suite.htmx.RenderWithStatus(testContext, gin.H{"Progress": 50}, http.StatusAccepted, "progress")
+
+	suite.Equal(http.StatusAccepted, recorder.Code)
+	suite.Equal("<p>50%</p>", recorder.Body.String())
+}
+
+func (suite *ResponsesTestSuite) TestNoContentWritesHeadersOnly() {
+	recorder, testContext := suite.newContext(true)
+
+	suite.htmx.With(ginhtmx.Trigger("item-deleted")).NoContent(testContext, ginhtmx.Reswap("none"))
+
+	suite.Equal(http.StatusNoContent, recorder.Code)
+	suite.Empty(recorder.Body.String())
+	suite.JSONEq(`{"item-deleted": null}`, recorder.Header().Get("HX-Trigger"))
+	suite.Equal("none", recorder.Header().Get("HX-Reswap"))
+}
+
+func (suite *ResponsesTestSuite) TestNoContentWithoutOptions() {
+	recorder, testContext := suite.newContext(true)
+
+	suite.htmx.NoContent(testContext)
+
+	suite.Equal(http.StatusNoContent, recorder.Code)
+	suite.Empty(recorder.Header().Get("HX-Trigger"))
+}
+
+func (suite *ResponsesTestSuite) TestPushAndReplaceURL() {
+	recorder, testContext := suite.newContext(true)
+
+	suite.htmx.With(ginhtmx.PushURL("/users/3"), ginhtmx.ReplaceURL("/users?page=2")).Render(testContext, gin.H{}, "progress")
+
+	suite.Equal("/users/3", recorder.Header().Get("HX-Push-Url"))
+	suite.Equal("/users?page=2", recorder.Header().Get("HX-Replace-Url"))
+}
+
+func (suite *ResponsesTestSuite) TestAutoPushURL() {
+	htmx := ginhtmx.NewHtmxWithConfig(suite.template, ginhtmx.HtmxConfig{
+		LayoutTemplateName:  "layout",
+		ContentVariableName: "Content",
+		AutoPushURL:         true,
+	})
+
+	recorder, testContext := suite.newContext(true)
+	testContext.Request = httptest.NewRequest(http.MethodGet, "/users?page=2", nil)
+	testContext.Request.Header.Set("Hx-Request", "true")
+	htmx.Render(testContext, gin.H{}, "progress")
+	suite.Equal("/users?page=2", recorder.Header().Get("HX-Push-Url"))
+
+	recorder, testContext = suite.newContext(true)
+	htmx.With(ginhtmx.ReplaceURL("/users")).Render(testContext, gin.H{}, "progress")
+	suite.Empty(recorder.Header().Get("HX-Push-Url"))
+
+	recorder, testContext = suite.newContext(false)
+	htmx.Render(testContext, gin.H{}, "progress")
+	suite.Empty(recorder.Header().Get("HX-Push-Url"))
+
+	recorder, testContext = suite.newContext(true)
+	testContext.Request.Method = http.MethodPost
+	htmx.Render(testContext, gin.H{}, "progress")
+	suite.Empty(recorder.Header().Get("HX-Push-Url"))
+}
+
+func (suite *ResponsesTestSuite) newContext(htmxRequest bool) (*httptest.ResponseRecorder, *gin.Context) {
+	recorder := httptest.NewRecorder()
+	testContext, _ := gin.CreateTestContext(recorder)
+	testContext.Request = httptest.NewRequest(http.MethodGet, "/", nil)
+
+	if htmxRequest {
+		testContext.Request.Header.Set("Hx-Request", "true")
+	}
+
+	return recorder, testContext
+}
+
+func (suite *ResponsesTestSuite) SetupSuite() {
+	suite.template = template.Must(template.New("").Parse(`
+{{- define "layout"}}<html>{{.Content}}</html>{{end}}
+{{- define "progress"}}<p>{{.Progress}}%</p>{{end}}
+`))
+	suite.htmx = ginhtmx.NewHtmx(suite.template)
+}
+
+func TestResponsesTestSuite(t *testing.T) {
+	t.Parallel()
+	suite.Run(t, new(ResponsesTestSuite))
+}
+
+type ResponsesTestSuite struct {
+	suite.Suite
+
+	htmx     *ginhtmx.Htmx
+	template *template.Template
+}
@@ -0,0 +1,138 @@
+package ginhtmx
+
+import (
+	"errors"
+	"fmt"
+	"maps"
+	"net/http"
+	"net/url"
+	"slices"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ErrRouteNotFound is returned when a URL is requested for a route which is not
+// registered.
+var ErrRouteNotFound = errors.New("route not found")
+
+// Routes maps route names to gin path patterns, such as "/users/:id", so that URLs
+// can be generated from the names instead of being written out in templates,
+// where they drift from the router. Routes are usually registered on the router
+// and named at the same time with GET, POST and Handle:
+//
+//	routes := ginhtmx.Routes{}
+//	routes.GET(router, "user_show", "/users/:id", handler.showUser)
+//	htmx := ginhtmx.NewHtmxWithConfig(tmpl, ginhtmx.HtmxConfig{
+//	  LayoutTemplateName:  "layout",
+//	  ContentVariableName: "Content",
+//	  Routes:              routes,
+//	})
+//
+// Templates then use the "urlFor" template function:
+//
+//	<a hx-get="{{ urlFor "user_show" "id" .User.ID }}">{{ .User.Name }}</a>
+type Routes map[string]string
+
+// Handle registers handlers for method and path on router and names the route.
+// The name refers to the full path of the route, including the base path of
+// router when it is a router group.
+func (routes Routes) Handle(router gin.IRoutes, method string, name string, path string,
+	handlers ...gin.HandlerFunc,
+) {
+	router.Handle(method, path, handlers...)
+
+	if group, ok := router.(interface{ BasePath() string }); ok {
+		path = strings.TrimSuffix(group.BasePath(), "/") + path
+	}
+
+	routes[name] = path
+}
+
+// GET registers a GET route on router and names it, see Handle.
+func (routes Routes) GET(router gin.IRoutes, name string, path string, handlers ...gin.HandlerFunc) {
+	routes.Handle(router, http.MethodGet, name, path, handlers...)
+}
+
+// POST registers a POST route on router and names it, see Handle.
+func (routes Routes) POST(router gin.IRoutes, name string, path string, handlers ...gin.HandlerFunc) {
+	routes.Handle(router, http.MethodPost, name, path, handlers...)
+}
+
+// URL returns the path of the named route with its parameters replaced by the
+// values of the alternating parameter names and values of pairs. Values which do
+// not correspond to a parameter of the route are added to the query string:
+//
+//	routes.URL("user_show", "id", 42, "tab", "orders") // "/users/42?tab=orders"
+//
+// An error wrapping ErrRouteNotFound is returned if there is no route with the
+// name, and an error is returned if a parameter of the route has no value.
+func (routes Routes) URL(name string, pairs ...any) (string, error) {
+	pattern, found := routes[name]
+	if !found {
+		return "", fmt.Errorf("%w: %q", ErrRouteNotFound, name)
+	}
+
+	values, err := dict(pairs...)
+	if err != nil {
+		return "", fmt.Errorf("url for %q: %w", name, err)
+	}
+
+	segments := strings.Split(pattern, "/")
+
+	for index, segment := range segments {
+		if segment == "" || (segment[0] != ':' && segment[0] != '*') {
+			continue
+		}
+
+		value, found := values[segment[1:]]
+		if !found {
+			return "", fmt.Errorf("%w: url for %q requires a value for %q", errInvalidArgument, name, segment[1:])
+		}
+
+		delete(values, segment[1:])
+
+		if segment[0] == '*' {
+			segments[index] = escapePath(strings.TrimPrefix(fmt.Sprint(value), "/"))
+		} else {
+			segments[index] = url.PathEscape(fmt.Sprint(value))
+		}
+	}
+
+	location := strings.Join(segments, "/")
+
+	if len(values) > 0 {
+		query := url.Values{}
+		for _, key := range slices.Sorted(maps.Keys(values)) {
+			query.Set(key, fmt.Sprint(values[key]))
+		}
+
+		location += "?" + query.Encode()
+	}
+
+	return location, nil
+}
+
+// URLFor returns the path of the named route of the configuration, see Routes.URL.
+func (htmx *Htmx) URLFor(name string, pairs ...any) (string, error) {
+	return htmx.config.Routes.URL(name, pairs...)
+}
+
+// urlFor returns the path of the named route, see Routes.URL.
+func (scope *requestScope) urlFor(name string, pairs ...any) (string, error) {
+	if scope.htmx == nil {
+		return "", fmt.Errorf("%w: urlFor %q used outside of Htmx", errInvalidArgument, name)
+	}
+
+	return scope.htmx.URLFor(name, pairs...)
+}
+
+// escapePath escapes each segment of a catch-all parameter value.
+func escapePath(value string) string {
+	segments := strings.Split(value, "/")
+	for index, segment := range segments {
+		segments[index] = url.PathEscape(segment)
+	}
+
+	return strings.Join(segments, "/")
+}
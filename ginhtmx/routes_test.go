@@ -0,0 +1,94 @@
+package ginhtmx_test
+
+import (
+	"html/template"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/jeffscottbrown/ginhtmxtemplates/ginhtmx"
+	"github.com/stretchr/testify/suite"
+)
+
+func (suite *RoutesTestSuite) TestURLsAreGeneratedFromRouteNames() {
+	url, err := suite.routes.URL("user_show", "id", 42, "tab", "orders", "q", "a b")
+	suite.Require().NoError(err)
+	suite.Equal("/admin/users/42?q=a+b&tab=orders", url)
+
+	url, err = suite.routes.URL("file", "path", "/docs/read me.txt")
+	suite.Require().NoError(err)
+	suite.Equal("/files/docs/read%20me.txt", url)
+
+	url, err = suite.routes.URL("home")
+	suite.Require().NoError(err)
+	suite.Equal("/", url)
+}
+
+func (suite *RoutesTestSuite) TestRegisteredRoutesAreServed() {
+	recorder := httptest.NewRecorder()
+	suite.router.ServeHTTP(recorder, httptest.NewRequest(http.MethodPost, "/admin/users/42", nil))
+
+	suite.Equal("updated 42", recorder.Body.String())
+}
+
+func (suite *RoutesTestSuite) TestInvalidURLsAreReported() {
+	_, err := suite.routes.URL("missing")
+	suite.Require().ErrorIs(err, ginhtmx.ErrRouteNotFound)
+
+	_, err = suite.routes.URL("user_show")
+	suite.Require().EqualError(err, `invalid argument: url for "user_show" requires a value for "id"`)
+
+	_, err = suite.routes.URL("user_show", "id")
+	suite.Require().EqualError(err, `url for "user_show": invalid argument: dict requires an even number of arguments`)
+
+	_, err = suite.routes.URL("user_show", 1, 2)
+	suite.Require().EqualError(err, `url for "user_show": invalid argument: dict key 1 is not a string`)
+}
+
+func (suite *RoutesTestSuite) TestURLForTemplateFunction() {
+	tmpl := template.Must(template.New("").Funcs(ginhtmx.FuncMap()).Parse(
+		`{{define "user"}}<a hx-get="{{ urlFor "user_show" "id" .ID }}">User</a>{{end}}`))
+	htmx := ginhtmx.NewHtmxWithConfig(tmpl, ginhtmx.HtmxConfig{
+		LayoutTemplateName:  "layout",
+		ContentVariableName: "Content",
+		Routes:              suite.routes,
+	})
+
+	rendered, err := htmx.RenderToString("user", gin.H{"ID": 7})
+	suite.Require().NoError(err)
+	suite.Equal(`<a hx-get="/admin/users/7">User</a>`, rendered)
+
+	url, err := htmx.URLFor("home")
+	suite.Require().NoError(err)
+	suite.Equal("/", url)
+
+	err = template.Must(template.New("").Funcs(ginhtmx.FuncMap()).Parse(`{{ urlFor "home" }}`)).Execute(
+		httptest.NewRecorder(), nil)
+	suite.Require().ErrorContains(err, `urlFor "home" used outside of Htmx`)
+}
+
+func (suite *RoutesTestSuite) SetupTest() {
+	suite.router = gin.New()
+	suite.routes = ginhtmx.Routes{}
+
+	admin := suite.router.Group("/admin/")
+	suite.routes.GET(suite.router, "home", "/", func(*gin.Context) {})
+	suite.routes.GET(admin, "user_show", "/users/:id", func(*gin.Context) {})
+	suite.routes.POST(admin, "user_update", "/users/:id", func(c *gin.Context) {
+		c.String(http.StatusOK, "updated "+c.Param("id"))
+	})
+	suite.routes.GET(suite.router, "file", "/files/*path", func(*gin.Context) {})
+}
+
+func TestRoutesTestSuite(t *testing.T) {
+	t.Parallel()
+	suite.Run(t, new(RoutesTestSuite))
+}
+
+type RoutesTestSuite struct {
+	suite.Suite
+
+	router *gin.Engine
+	routes ginhtmx.Routes
+}
@@ -0,0 +1,67 @@
+package ginhtmx
+
+import (
+	"fmt"
+	"html/template"
+	"sync"
+
+	"github.com/gin-gonic/gin"
+	"github.com/microcosm-cc/bluemonday"
+)
+
+// defaultSanitizer is the policy used when HtmxConfig.Sanitizer is not provided.
+var defaultSanitizer = sync.OnceValue(bluemonday.UGCPolicy)
+
+// sanitizer returns the configured sanitizer policy or the default policy.
+func (htmx *Htmx) sanitizer() *bluemonday.Policy {
+	if htmx == nil || htmx.config.Sanitizer == nil {
+		return defaultSanitizer()
+	}
+
+	return htmx.config.Sanitizer
+}
+
+// sanitize removes the elements and attributes not allowed by the sanitizer policy
+// from user supplied HTML, such as rich text entered by users, so that it may be
+// output in a template:
+//
+//	<div class="comment">{{ sanitize .Comment.Body }}</div>
+func (scope *requestScope) sanitize(html string) template.HTML {
+	//nolint:gosec
+	return template.HTML(scope.htmx.sanitizer().Sanitize(html))
+}
+
+// SanitizedHTML is a render option which replaces the string values of the model
+// with the specified keys with template.HTML sanitized by the sanitizer policy, so
+// that templates can output user supplied rich text without calling "sanitize":
+//
+//	htmx.With(ginhtmx.SanitizedHTML("Body")).Render(c, gin.H{"Body": comment.Body}, "comment")
+//
+// The values are sanitized after model providers and the model decorator have been
+// applied. Missing keys are ignored and a value which is not a string is reported
+// as a render error.
+func SanitizedHTML(keys ...string) RenderOption {
+	return func(settings *renderSettings) {
+		settings.sanitized = append(settings.sanitized, keys...)
+	}
+}
+
+// sanitizeModel replaces the values of data named by the SanitizedHTML option with
+// their sanitized HTML.
+func (htmx *Htmx) sanitizeModel(settings *renderSettings, data gin.H) []error {
+	var errs []error
+
+	for _, key := range settings.sanitized {
+		switch value := data[key].(type) {
+		case nil:
+			continue
+		case string:
+			//nolint:gosec
+			data[key] = template.HTML(htmx.sanitizer().Sanitize(value))
+		default:
+			errs = append(errs, fmt.Errorf("%w: cannot sanitize %q of type %T", errInvalidArgument, key, value))
+		}
+	}
+
+	return errs
+}
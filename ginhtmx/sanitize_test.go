@@ -0,0 +1,83 @@
+package ginhtmx_test
+
+import (
+	"html/template"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/jeffscottbrown/ginhtmxtemplates/ginhtmx"
+	"github.com/microcosm-cc/bluemonday"
+	"github.com/stretchr/testify/suite"
+)
+
+const unsafeHTML = `<p onclick="steal()">Hello <b>world</b><script>alert(1)</script></p>`
+
+func (suite *SanitizeTestSuite) TestSanitizeFunctionUsesDefaultPolicy() {
+	rendered, err := suite.newHtmx(nil).RenderToString("comment", gin.H{"Body": unsafeHTML})
+	suite.Require().NoError(err)
+	suite.Equal(`<div><p>Hello <b>world</b></p></div>`, rendered)
+
+	var output strings.Builder
+
+	err = template.Must(template.New("").Funcs(ginhtmx.FuncMap()).Parse(`{{ sanitize . }}`)).Execute(&output, unsafeHTML)
+	suite.Require().NoError(err)
+	suite.Equal(`<p>Hello <b>world</b></p>`, output.String())
+}
+
+func (suite *SanitizeTestSuite) TestSanitizeFunctionUsesConfiguredPolicy() {
+	rendered, err := suite.newHtmx(bluemonday.StrictPolicy()).RenderToString("comment", gin.H{"Body": unsafeHTML})
+	suite.Require().NoError(err)
+	suite.Equal(`<div>Hello world</div>`, rendered)
+}
+
+func (suite *SanitizeTestSuite) TestSanitizedHTMLOptionSanitizesModelValues() {
+	recorder, testContext := suite.render(gin.H{"Body": unsafeHTML, "Missing": nil},
+		ginhtmx.SanitizedHTML("Body", "Missing", "Absent"))
+
+	suite.Equal(`<div><p>Hello <b>world</b></p></div>`, recorder.Body.String())
+	suite.Nil(testContext.Value(ginhtmx.RenderErrorKey))
+}
+
+func (suite *SanitizeTestSuite) TestSanitizedHTMLOptionReportsValuesWhichAreNotStrings() {
+	_, testContext := suite.render(gin.H{"Body": 42}, ginhtmx.SanitizedHTML("Body"))
+
+	renderErr, _ := testContext.Value(ginhtmx.RenderErrorKey).(error)
+	suite.Require().EqualError(renderErr, `invalid argument: cannot sanitize "Body" of type int`)
+}
+
+func (suite *SanitizeTestSuite) render(data gin.H, options ...ginhtmx.RenderOption) (*httptest.ResponseRecorder, *gin.Context) {
+	tmpl := template.Must(template.New("").Funcs(ginhtmx.FuncMap()).Parse(`{{define "comment"}}<div>{{ .Body }}</div>{{end}}`))
+	htmx := ginhtmx.NewHtmx(tmpl)
+
+	recorder := httptest.NewRecorder()
+	testContext, _ := gin.CreateTestContext(recorder)
+	testContext.Request = httptest.NewRequest(http.MethodGet, "/", nil)
+	testContext.Request.Header.Set("Hx-Request", "true")
+
+	htmx.With(options...).Render(testContext, data, "comment")
+
+	return recorder, testContext
+}
+
+func (suite *SanitizeTestSuite) newHtmx(policy *bluemonday.Policy) *ginhtmx.Htmx {
+	tmpl := template.Must(template.New("").Funcs(ginhtmx.FuncMap()).Parse(
+		`{{define "comment"}}<div>{{ sanitize .Body }}</div>{{end}}`))
+
+	return ginhtmx.NewHtmxWithConfig(tmpl, ginhtmx.HtmxConfig{
+		LayoutTemplateName:  "layout",
+		ContentVariableName: "Content",
+		Sanitizer:           policy,
+	})
+}
+
+func TestSanitizeTestSuite(t *testing.T) {
+	t.Parallel()
+	suite.Run(t, new(SanitizeTestSuite))
+}
+
+type SanitizeTestSuite struct {
+	suite.Suite
+}
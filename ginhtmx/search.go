@@ -0,0 +1,123 @@
+package ginhtmx
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	// DefaultSearchParam is the query parameter holding the search term.
+	DefaultSearchParam = "q"
+
+	// DefaultSearchDelay is the time the search input waits for typing to stop.
+	DefaultSearchDelay = 300 * time.Millisecond
+
+	// SearchModelKey is the key under which RenderSearch exposes the Search to templates.
+	SearchModelKey = "Search"
+)
+
+// Search supports the active search pattern, where the results are updated as the
+// user types into a search input. The input is rendered with the built-in
+// "ginhtmx/search" template, which may be overridden:
+//
+//	{{ template "ginhtmx/search" .Search }}
+//	<div id="results">{{ template "results" . }}</div>
+//
+// The handler renders the results with RenderSearch, which pushes a URL holding the
+// search term into the browser history so that searches can be shared:
+//
+//	search := ginhtmx.NewSearch(c)
+//	search.Target = "#results"
+//	handler.htmx.RenderSearch(c, search, gin.H{"Users": findUsers(search.Query)}, "results")
+type Search struct {
+	// Query is the search term
+	Query string
+
+	// Param is the query parameter which holds the search term
+	Param string
+
+	// URL is the URL the input requests the results from
+	URL string
+
+	// Target is an optional hx-target for the results
+	Target string
+
+	// Indicator is an optional hx-indicator shown while searching
+	Indicator string
+
+	// Placeholder is an optional placeholder for the input
+	Placeholder string
+
+	// Delay is the time to wait for typing to stop before searching
+	Delay time.Duration
+
+	// page is the URL of the request, which is pushed with the search term
+	page *url.URL
+}
+
+// NewSearch returns a Search for the request, reading the search term from the
+// DefaultSearchParam query parameter. The results are requested from the path of
+// the request.
+func NewSearch(ginContext *gin.Context) *Search {
+	return &Search{
+		Query:       strings.TrimSpace(ginContext.Query(DefaultSearchParam)),
+		Param:       DefaultSearchParam,
+		URL:         ginContext.Request.URL.Path,
+		Target:      "",
+		Indicator:   "",
+		Placeholder: "",
+		Delay:       DefaultSearchDelay,
+		page:        ginContext.Request.URL,
+	}
+}
+
+// Trigger returns the hx-trigger of the search input, which searches once typing
+// has stopped for Delay, when enter is pressed or when the input is cleared.
+func (search *Search) Trigger() string {
+	return fmt.Sprintf("input changed delay:%dms, keyup[key=='Enter'], search", search.Delay.Milliseconds())
+}
+
+// PushURL returns the URL of the current page with the search term, which is
+// omitted when empty, preserving the other query parameters.
+func (search *Search) PushURL() string {
+	pageURL := url.URL{Path: search.URL, RawQuery: ""}
+	if search.page != nil {
+		pageURL = *search.page
+	}
+
+	query := pageURL.Query()
+	query.Del(search.Param)
+
+	if search.Query != "" {
+		query.Set(search.Param, search.Query)
+	}
+
+	pageURL.RawQuery = query.Encode()
+
+	return pageURL.RequestURI()
+}
+
+// RenderSearch renders the results of a search. The search is exposed to templates
+// as .Search and, for HTMX requests, the URL of the search is pushed into the
+// browser history.
+func (htmx *Htmx) RenderSearch(ginContext *gin.Context, search *Search, data gin.H, templateNames ...string) {
+	if data == nil {
+		data = gin.H{}
+	}
+
+	if _, exists := data[SearchModelKey]; !exists {
+		data[SearchModelKey] = search
+	}
+
+	derived := htmx
+	if htmx.IsHTMXRequest(ginContext) {
+		derived = htmx.With(PushURL(search.PushURL()))
+	}
+
+	derived.RenderWithStatus(ginContext, data, http.StatusOK, templateNames...)
+}
@@ -0,0 +1,88 @@
+package ginhtmx_test
+
+import (
+	"html/template"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/jeffscottbrown/ginhtmxtemplates/ginhtmx"
+	"github.com/stretchr/testify/suite"
+)
+
+func (suite *SearchTestSuite) TestNewSearchReadsQuery() {
+	_, testContext := suite.newContext("/users?q=+jerry+&sort=name", true)
+
+	search := ginhtmx.NewSearch(testContext)
+
+	suite.Equal("jerry", search.Query)
+	suite.Equal("/users", search.URL)
+	suite.Equal("/users?q=jerry&sort=name", search.PushURL())
+	suite.Equal("input changed delay:300ms, keyup[key=='Enter'], search", search.Trigger())
+
+	search.Query = ""
+	search.Delay = time.Second
+	suite.Equal("/users?sort=name", search.PushURL())
+	suite.Equal("input changed delay:1000ms, keyup[key=='Enter'], search", search.Trigger())
+
+	suite.Equal("/people?q=kramer", (&ginhtmx.Search{Query: "kramer", Param: "q", URL: "/people"}).PushURL())
+}
+
+func (suite *SearchTestSuite) TestRenderSearchPushesURLForHTMXRequests() {
+	recorder, testContext := suite.newContext("/users?q=jerry", true)
+
+	suite.htmx.RenderSearch(testContext, ginhtmx.NewSearch(testContext), nil, "results")
+
+	suite.Equal("/users?q=jerry", recorder.Header().Get("HX-Push-Url"))
+	suite.Equal("<p>results for jerry</p>", recorder.Body.String())
+}
+
+func (suite *SearchTestSuite) TestRenderSearchRendersInputForFullPages() {
+	recorder, testContext := suite.newContext("/users?q=jerry", false)
+
+	search := ginhtmx.NewSearch(testContext)
+	search.Target = "#results"
+	search.Indicator = "#spinner"
+	search.Placeholder = "Search users"
+
+	suite.htmx.RenderSearch(testContext, search, gin.H{}, "results")
+
+	suite.Empty(recorder.Header().Get("HX-Push-Url"))
+	body := recorder.Body.String()
+	suite.Contains(body, `<input type="search" name="q" value="jerry" placeholder="Search users" aria-label="Search users"`)
+	suite.Contains(body, `hx-get="/users" hx-trigger="input changed delay:300ms, keyup[key==&#39;Enter&#39;], search"`)
+	suite.Contains(body, ` hx-target="#results" hx-indicator="#spinner"><p>results for jerry</p>`)
+}
+
+func (suite *SearchTestSuite) newContext(target string, htmxRequest bool) (*httptest.ResponseRecorder, *gin.Context) {
+	recorder := httptest.NewRecorder()
+	testContext, _ := gin.CreateTestContext(recorder)
+	testContext.Request = httptest.NewRequest(http.MethodGet, target, nil)
+
+	if htmxRequest {
+		testContext.Request.Header.Set("Hx-Request", "true")
+	}
+
+	return recorder, testContext
+}
+
+func (suite *SearchTestSuite) SetupSuite() {
+	tmpl := template.Must(template.New("").Parse(`
+{{- define "layout"}}{{template "ginhtmx/search" .Search}}{{.Content}}{{end}}
+{{- define "results"}}<p>results for {{.Search.Query}}</p>{{end}}
+`))
+	suite.htmx = ginhtmx.NewHtmx(tmpl)
+}
+
+func TestSearchTestSuite(t *testing.T) {
+	t.Parallel()
+	suite.Run(t, new(SearchTestSuite))
+}
+
+type SearchTestSuite struct {
+	suite.Suite
+
+	htmx *ginhtmx.Htmx
+}
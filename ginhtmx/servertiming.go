@@ -0,0 +1,85 @@
+package ginhtmx
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// serverTimingKey is the context key of the serverTimings of a render.
+type serverTimingKey struct{}
+
+// serverTimings collects the durations of the templates executed by a render.
+type serverTimings struct {
+	mutex   sync.Mutex
+	entries []serverTiming
+}
+
+type serverTiming struct {
+	template string
+	duration time.Duration
+}
+
+// withServerTimings returns a context in which the durations of executed templates
+// are collected, if Server-Timing headers are enabled.
+func (htmx *Htmx) withServerTimings(ctx context.Context) context.Context {
+	if !htmx.config.ServerTiming {
+		return ctx
+	}
+
+	return context.WithValue(ctx, serverTimingKey{}, &serverTimings{mutex: sync.Mutex{}, entries: nil})
+}
+
+// recordServerTiming records the duration of a template executed with ctx.
+func recordServerTiming(ctx context.Context, template string, duration time.Duration) {
+	timings, ok := ctx.Value(serverTimingKey{}).(*serverTimings)
+	if !ok {
+		return
+	}
+
+	timings.mutex.Lock()
+	defer timings.mutex.Unlock()
+
+	timings.entries = append(timings.entries, serverTiming{template: template, duration: duration})
+}
+
+// writeServerTiming adds a Server-Timing header describing the templates executed
+// with ctx and the total time since start, so that the breakdown of the render is
+// shown by the browser developer tools:
+//
+//	Server-Timing: tmpl;desc="home";dur=1.2, layout;desc="layout";dur=0.4, render;dur=1.9
+func (htmx *Htmx) writeServerTiming(ctx context.Context, ginContext *gin.Context, start time.Time) {
+	timings, ok := ctx.Value(serverTimingKey{}).(*serverTimings)
+	if !ok {
+		return
+	}
+
+	timings.mutex.Lock()
+	defer timings.mutex.Unlock()
+
+	metrics := make([]string, 0, len(timings.entries)+1)
+
+	for _, entry := range timings.entries {
+		name := "tmpl"
+		if entry.template == htmx.config.LayoutTemplateName {
+			name = "layout"
+		}
+
+		metrics = append(metrics, fmt.Sprintf("%s;desc=%s;dur=%s",
+			name, strconv.Quote(entry.template), formatMilliseconds(entry.duration)))
+	}
+
+	metrics = append(metrics, "render;dur="+formatMilliseconds(time.Since(start)))
+
+	ginContext.Writer.Header().Add("Server-Timing", strings.Join(metrics, ", "))
+}
+
+// formatMilliseconds formats duration in milliseconds, as used by Server-Timing.
+func formatMilliseconds(duration time.Duration) string {
+	return strconv.FormatFloat(float64(duration.Microseconds())/1000, 'f', -1, 64) //nolint:mnd
+}
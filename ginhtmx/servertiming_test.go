@@ -0,0 +1,66 @@
+package ginhtmx_test
+
+import (
+	"html/template"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/jeffscottbrown/ginhtmxtemplates/ginhtmx"
+	"github.com/stretchr/testify/suite"
+)
+
+func (suite *ServerTimingTestSuite) TestFullPageTimingsIncludeTheLayout() {
+	recorder := suite.render(true, false)
+
+	suite.Regexp(regexp.MustCompile(`^tmpl;desc="home";dur=[0-9.]+, tmpl;desc="footer";dur=[0-9.]+, `+
+		`layout;desc="layout";dur=[0-9.]+, render;dur=[0-9.]+$`), recorder.Header().Get("Server-Timing"))
+}
+
+func (suite *ServerTimingTestSuite) TestFragmentTimings() {
+	recorder := suite.render(true, true)
+
+	suite.Regexp(regexp.MustCompile(`^tmpl;desc="home";dur=[0-9.]+, tmpl;desc="footer";dur=[0-9.]+, `+
+		`render;dur=[0-9.]+$`), recorder.Header().Get("Server-Timing"))
+}
+
+func (suite *ServerTimingTestSuite) TestHeaderIsOmittedUnlessEnabled() {
+	recorder := suite.render(false, false)
+
+	suite.Empty(recorder.Header().Values("Server-Timing"))
+}
+
+func (suite *ServerTimingTestSuite) render(enabled bool, fragment bool) *httptest.ResponseRecorder {
+	htmx := ginhtmx.NewHtmxWithConfig(template.Must(template.New("").Parse(`
+{{define "layout"}}<html>{{.Content}}</html>{{end}}
+{{define "home"}}Home{{end}}
+{{define "footer"}}Footer{{end}}
+`)), ginhtmx.HtmxConfig{
+		LayoutTemplateName:  "layout",
+		ContentVariableName: "Content",
+		ServerTiming:        enabled,
+	})
+
+	recorder := httptest.NewRecorder()
+	testContext, _ := gin.CreateTestContext(recorder)
+	testContext.Request = httptest.NewRequest(http.MethodGet, "/", nil)
+
+	if fragment {
+		testContext.Request.Header.Set("Hx-Request", "true")
+	}
+
+	htmx.Render(testContext, gin.H{}, "home", "footer")
+
+	return recorder
+}
+
+func TestServerTimingTestSuite(t *testing.T) {
+	t.Parallel()
+	suite.Run(t, new(ServerTimingTestSuite))
+}
+
+type ServerTimingTestSuite struct {
+	suite.Suite
+}
@@ -0,0 +1,122 @@
+package ginhtmx
+
+import (
+	"net/http"
+
+	ginsessions "github.com/gin-contrib/sessions"
+	"github.com/gin-gonic/gin"
+	gorillasessions "github.com/gorilla/sessions"
+)
+
+// SessionKey is the gin context key holding the Session of the current request, as
+// resolved by the SessionResolver of the HtmxConfig, see SessionFrom.
+const SessionKey = "ginhtmx.session"
+
+// Session is the minimal interface of the session of a request used by the
+// features of this package which are backed by a session, such as Flash, so that
+// they work with any session library. The sessions of gin-contrib/sessions
+// implement it, and GorillaSessions adapts the sessions of gorilla/sessions.
+type Session interface {
+	// Get returns the value stored under key, or nil if there is none
+	Get(key any) any
+
+	// Set stores value under key
+	Set(key any, value any)
+
+	// Delete removes the value stored under key
+	Delete(key any)
+
+	// Save persists the changes made to the session
+	Save() error
+}
+
+// session returns the Session of the request, resolving it with the
+// SessionResolver of the configuration the first time it is needed, or nil if
+// there is none.
+func (htmx *Htmx) session(ginContext *gin.Context) Session {
+	if session, ok := ginContext.Value(SessionKey).(Session); ok {
+		return session
+	}
+
+	if htmx.config.SessionResolver == nil {
+		return nil
+	}
+
+	session := htmx.config.SessionResolver(ginContext)
+	if session != nil {
+		ginContext.Set(SessionKey, session)
+	}
+
+	return session
+}
+
+// SessionFrom returns the Session of the request being rendered. It is intended for
+// model decorators and model providers, which are called by Render but have no
+// access to its configuration:
+//
+//	func (decorator cart) DecorateModel(c *gin.Context, model *gin.H) {
+//	  (*model)["CartID"] = ginhtmx.SessionFrom(c).Get("cart")
+//	}
+//
+// Nil is returned outside of a render or when there is no SessionResolver.
+func SessionFrom(ginContext *gin.Context) Session {
+	session, _ := ginContext.Value(SessionKey).(Session)
+
+	return session
+}
+
+// GinContribSession is a SessionResolver which returns the default session of the
+// gin-contrib/sessions middleware, or nil if the middleware is not in use:
+//
+//	router.Use(sessions.Sessions("app", cookie.NewStore(secret)))
+//	htmx := ginhtmx.NewHtmxWithConfig(tmpl, ginhtmx.HtmxConfig{SessionResolver: ginhtmx.GinContribSession})
+func GinContribSession(ginContext *gin.Context) Session {
+	if _, exists := ginContext.Get(ginsessions.DefaultKey); !exists {
+		return nil
+	}
+
+	return ginsessions.Default(ginContext)
+}
+
+// GorillaSessions returns a SessionResolver which returns the session with the
+// specified name from a gorilla/sessions store:
+//
+//	htmx := ginhtmx.NewHtmxWithConfig(tmpl, ginhtmx.HtmxConfig{
+//	  SessionResolver: ginhtmx.GorillaSessions(sessions.NewCookieStore(secret), "app"),
+//	})
+//
+// A new session is used when the stored session cannot be decoded, as with the
+// store itself.
+func GorillaSessions(store gorillasessions.Store, name string) func(ginContext *gin.Context) Session {
+	return func(ginContext *gin.Context) Session {
+		session, _ := store.Get(ginContext.Request, name)
+		if session == nil {
+			return nil
+		}
+
+		return &gorillaSession{session: session, request: ginContext.Request, writer: ginContext.Writer}
+	}
+}
+
+// gorillaSession adapts a session of gorilla/sessions to Session.
+type gorillaSession struct {
+	session *gorillasessions.Session
+	request *http.Request
+	writer  http.ResponseWriter
+}
+
+func (session *gorillaSession) Get(key any) any {
+	return session.session.Values[key]
+}
+
+func (session *gorillaSession) Set(key any, value any) {
+	session.session.Values[key] = value
+}
+
+func (session *gorillaSession) Delete(key any) {
+	delete(session.session.Values, key)
+}
+
+func (session *gorillaSession) Save() error {
+	return session.session.Save(session.request, session.writer) //nolint:wrapcheck
+}
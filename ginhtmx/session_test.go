@@ -0,0 +1,180 @@
+package ginhtmx_test
+
+import (
+	"errors"
+	"html/template"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-contrib/sessions"
+	"github.com/gin-contrib/sessions/cookie"
+	"github.com/gin-gonic/gin"
+	gorillasessions "github.com/gorilla/sessions"
+	"github.com/jeffscottbrown/ginhtmxtemplates/ginhtmx"
+	"github.com/stretchr/testify/suite"
+)
+
+func (suite *SessionTestSuite) TestFlashesAreShownByTheNextRender() {
+	router := gin.New()
+	router.Use(sessions.Sessions("app", cookie.NewStore([]byte("secret"))))
+
+	suite.testFlashes(router, ginhtmx.GinContribSession)
+}
+
+func (suite *SessionTestSuite) TestGorillaSessionsAreSupported() {
+	suite.testFlashes(gin.New(), ginhtmx.GorillaSessions(gorillasessions.NewCookieStore([]byte("secret")), "app"))
+}
+
+func (suite *SessionTestSuite) TestSessionsAreAvailableToDecorators() {
+	session := suite.session()
+	session.Set("user", "Jerry")
+
+	htmx := suite.newHtmx(func(*gin.Context) ginhtmx.Session { return session })
+	recorder, testContext := suite.newContext()
+
+	suite.Nil(ginhtmx.SessionFrom(testContext))
+
+	htmx.Render(testContext, gin.H{}, "user")
+
+	suite.Equal(`<p>Jerry</p>`, recorder.Body.String())
+}
+
+func (suite *SessionTestSuite) TestFlashRequiresASession() {
+	_, testContext := suite.newContext()
+
+	err := ginhtmx.NewHtmx(suite.template).Flash(testContext, ginhtmx.ToastInfo, "Hello")
+	suite.Require().ErrorIs(err, ginhtmx.ErrSessionsDisabled)
+
+	err = suite.newHtmx(ginhtmx.GinContribSession).Flash(testContext, ginhtmx.ToastInfo, "Hello")
+	suite.Require().ErrorIs(err, ginhtmx.ErrSessionsDisabled)
+}
+
+func (suite *SessionTestSuite) TestSessionErrorsAreReported() {
+	session := suite.session()
+	session.Set("ginhtmx.flashes", "not json")
+	session.saveErr = errSaveFailed
+
+	htmx := suite.newHtmx(func(*gin.Context) ginhtmx.Session { return session })
+	_, testContext := suite.newContext()
+
+	suite.Require().ErrorIs(htmx.Flash(testContext, ginhtmx.ToastInfo, "Hello"), errSaveFailed)
+
+	session.Set("ginhtmx.flashes", "not json")
+	htmx.Render(testContext, gin.H{}, "user")
+
+	renderError, _ := testContext.Value(ginhtmx.RenderErrorKey).(error)
+	suite.Require().ErrorContains(renderError, "reading flashes: invalid character")
+	suite.Require().ErrorIs(renderError, errSaveFailed)
+	suite.Nil(session.Get("ginhtmx.flashes"))
+}
+
+func (suite *SessionTestSuite) testFlashes(router *gin.Engine, resolver func(*gin.Context) ginhtmx.Session) {
+	htmx := suite.newHtmx(resolver)
+
+	router.POST("/users", func(ginContext *gin.Context) {
+		suite.NoError(htmx.Flash(ginContext, ginhtmx.ToastSuccess, "User saved"))
+		suite.NoError(htmx.Flash(ginContext, ginhtmx.ToastInfo, "Welcome"))
+		ginContext.Redirect(http.StatusSeeOther, "/users")
+	})
+	router.GET("/users", func(ginContext *gin.Context) {
+		htmx.Render(ginContext, gin.H{}, "user")
+	})
+
+	cookies := suite.serve(router, http.MethodPost, nil).Result().Cookies()
+
+	recorder := suite.serve(router, http.MethodGet, cookies)
+	suite.Equal(`<p></p>`+
+		`<div hx-swap-oob="beforeend:#toasts"><div class="toast toast-success" role="status">User saved</div></div>`+
+		`<div hx-swap-oob="beforeend:#toasts"><div class="toast toast-info" role="status">Welcome</div></div>`,
+		recorder.Body.String())
+
+	recorder = suite.serve(router, http.MethodGet, recorder.Result().Cookies())
+	suite.Equal(`<p></p>`, recorder.Body.String())
+}
+
+func (suite *SessionTestSuite) serve(router *gin.Engine, method string, cookies []*http.Cookie) *httptest.ResponseRecorder {
+	request := httptest.NewRequest(method, "/users", nil)
+	request.Header.Set("Hx-Request", "true")
+
+	// like a browser, keep the last of the cookies with the same name
+	latest := map[string]*http.Cookie{}
+	for _, cookie := range cookies {
+		latest[cookie.Name] = cookie
+	}
+
+	for _, cookie := range latest {
+		request.AddCookie(cookie)
+	}
+
+	recorder := httptest.NewRecorder()
+	router.ServeHTTP(recorder, request)
+
+	return recorder
+}
+
+func (suite *SessionTestSuite) newHtmx(resolver func(*gin.Context) ginhtmx.Session) *ginhtmx.Htmx {
+	return ginhtmx.NewHtmxWithConfig(suite.template, ginhtmx.HtmxConfig{
+		LayoutTemplateName:  "layout",
+		ContentVariableName: "Content",
+		SessionResolver:     resolver,
+		ModelDecorator: ginhtmx.ModelDecoratorFunc(func(ginContext *gin.Context, model *gin.H) {
+			if session := ginhtmx.SessionFrom(ginContext); session != nil {
+				(*model)["User"] = session.Get("user")
+			}
+		}),
+	})
+}
+
+func (suite *SessionTestSuite) newContext() (*httptest.ResponseRecorder, *gin.Context) {
+	recorder := httptest.NewRecorder()
+	testContext, _ := gin.CreateTestContext(recorder)
+	testContext.Request = httptest.NewRequest(http.MethodGet, "/", nil)
+	testContext.Request.Header.Set("Hx-Request", "true")
+
+	return recorder, testContext
+}
+
+func (suite *SessionTestSuite) session() *mapSession {
+	return &mapSession{values: map[any]any{}, saveErr: nil}
+}
+
+func (suite *SessionTestSuite) SetupTest() {
+	suite.template = template.Must(template.New("").Parse(
+		`{{define "layout"}}<html>{{ .Content }}</html>{{end}}{{define "user"}}<p>{{ .User }}</p>{{end}}`))
+}
+
+func TestSessionTestSuite(t *testing.T) {
+	t.Parallel()
+	suite.Run(t, new(SessionTestSuite))
+}
+
+type SessionTestSuite struct {
+	suite.Suite
+
+	template *template.Template
+}
+
+var errSaveFailed = errors.New("save failed")
+
+// mapSession is a Session held in memory whose saves may fail.
+type mapSession struct {
+	values  map[any]any
+	saveErr error
+}
+
+func (session *mapSession) Get(key any) any {
+	return session.values[key]
+}
+
+func (session *mapSession) Set(key any, value any) {
+	session.values[key] = value
+}
+
+func (session *mapSession) Delete(key any) {
+	delete(session.values, key)
+}
+
+func (session *mapSession) Save() error {
+	return session.saveErr
+}
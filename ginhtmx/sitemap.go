@@ -0,0 +1,166 @@
+package ginhtmx
+
+import (
+	"encoding/xml"
+	"maps"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// StaticPage is a page whose content does not depend on the request, such as an
+// about or privacy page, see StaticPages.
+type StaticPage struct {
+	// Path is the path of the route, relative to the router it is registered with
+	Path string
+
+	// Template is the name of the template of the page
+	Template string
+
+	// Data is the data the template is rendered with
+	Data gin.H
+
+	// LastModified is the optional date of the last change, listed in the sitemap
+	LastModified time.Time
+
+	// ChangeFrequency is the optional frequency of changes listed in the sitemap,
+	// such as "monthly"
+	ChangeFrequency string
+
+	// Priority is the optional priority between 0 and 1 listed in the sitemap
+	Priority float64
+
+	// Hidden excludes the page from the sitemap
+	Hidden bool
+}
+
+// SitemapURL is an entry of the sitemap served by SitemapHandler.
+type SitemapURL struct {
+	// Location is the path or absolute URL of the page. Paths are made absolute
+	// with the BaseURL of the configuration, or the host of the request.
+	Location string
+
+	// LastModified is the optional date of the last change
+	LastModified time.Time
+
+	// ChangeFrequency is the optional frequency of changes, such as "daily"
+	ChangeFrequency string
+
+	// Priority is the optional priority between 0 and 1
+	Priority float64
+}
+
+// sitemap holds the URLs served by SitemapHandler. It is shared by the copies of
+// an Htmx.
+type sitemap struct {
+	mutex sync.Mutex
+	urls  []SitemapURL
+}
+
+func newSitemap() *sitemap {
+	return &sitemap{mutex: sync.Mutex{}, urls: nil}
+}
+
+// StaticPages registers a GET route on router for each of pages which renders
+// its template with its data, and adds the pages which are not hidden to the
+// sitemap:
+//
+//	htmx.StaticPages(router,
+//	  ginhtmx.StaticPage{Path: "/about", Template: "about", Priority: 0.8},
+//	  ginhtmx.StaticPage{Path: "/privacy", Template: "privacy", ChangeFrequency: "yearly"},
+//	)
+func (htmx *Htmx) StaticPages(router gin.IRoutes, pages ...StaticPage) {
+	basePath := ""
+	if group, ok := router.(interface{ BasePath() string }); ok {
+		basePath = strings.TrimSuffix(group.BasePath(), "/")
+	}
+
+	for _, page := range pages {
+		router.GET(page.Path, func(ginContext *gin.Context) {
+			htmx.Render(ginContext, maps.Clone(page.Data), page.Template)
+		})
+
+		if !page.Hidden {
+			htmx.AddToSitemap(SitemapURL{
+				Location:        basePath + page.Path,
+				LastModified:    page.LastModified,
+				ChangeFrequency: page.ChangeFrequency,
+				Priority:        page.Priority,
+			})
+		}
+	}
+}
+
+// AddToSitemap adds urls to the sitemap, for example the pages of products as they
+// are created. It is safe to call while the sitemap is being served.
+func (htmx *Htmx) AddToSitemap(urls ...SitemapURL) {
+	htmx.sitemap.mutex.Lock()
+	defer htmx.sitemap.mutex.Unlock()
+
+	htmx.sitemap.urls = append(htmx.sitemap.urls, urls...)
+}
+
+// SitemapHandler returns a handler which serves the sitemap, made of the static
+// pages and the URLs added with AddToSitemap, in the sitemaps.org XML format:
+//
+//	router.GET("/sitemap.xml", htmx.SitemapHandler())
+func (htmx *Htmx) SitemapHandler() gin.HandlerFunc {
+	return func(ginContext *gin.Context) {
+		htmx.sitemap.mutex.Lock()
+		urls := htmx.sitemap.urls[:len(htmx.sitemap.urls):len(htmx.sitemap.urls)]
+		htmx.sitemap.mutex.Unlock()
+
+		base := htmx.baseURL(ginContext)
+		document := sitemapDocument{Namespace: "http://www.sitemaps.org/schemas/sitemap/0.9", URLs: nil}
+
+		for _, entry := range urls {
+			document.URLs = append(document.URLs, newSitemapEntry(base, entry))
+		}
+
+		output, _ := xml.Marshal(document)
+		ginContext.Data(http.StatusOK, "application/xml; charset=utf-8", append([]byte(xml.Header), output...))
+	}
+}
+
+type sitemapDocument struct {
+	XMLName   xml.Name       `xml:"urlset"`
+	Namespace string         `xml:"xmlns,attr"`
+	URLs      []sitemapEntry `xml:"url"`
+}
+
+type sitemapEntry struct {
+	Location        string `xml:"loc"`
+	LastModified    string `xml:"lastmod,omitempty"`
+	ChangeFrequency string `xml:"changefreq,omitempty"`
+	Priority        string `xml:"priority,omitempty"`
+}
+
+func newSitemapEntry(base *url.URL, entry SitemapURL) sitemapEntry {
+	location := entry.Location
+	if !strings.Contains(location, "://") {
+		absolute := url.URL{Scheme: base.Scheme, Host: base.Host, Path: strings.TrimSuffix(base.Path, "/") + location}
+		location = absolute.String()
+	}
+
+	sitemapped := sitemapEntry{
+		Location:        location,
+		LastModified:    "",
+		ChangeFrequency: entry.ChangeFrequency,
+		Priority:        "",
+	}
+
+	if !entry.LastModified.IsZero() {
+		sitemapped.LastModified = entry.LastModified.UTC().Format(time.DateOnly)
+	}
+
+	if entry.Priority > 0 {
+		sitemapped.Priority = strconv.FormatFloat(entry.Priority, 'f', 1, 64)
+	}
+
+	return sitemapped
+}
@@ -0,0 +1,85 @@
+package ginhtmx_test
+
+import (
+	"html/template"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/jeffscottbrown/ginhtmxtemplates/ginhtmx"
+	"github.com/stretchr/testify/suite"
+)
+
+func (suite *SitemapTestSuite) TestStaticPagesAreRendered() {
+	router := gin.New()
+	suite.htmx.StaticPages(router, ginhtmx.StaticPage{Path: "/about", Template: "about", Data: gin.H{"Name": "Us"}})
+
+	recorder := suite.serve(router, "/about")
+
+	suite.Equal(http.StatusOK, recorder.Code)
+	suite.Equal("<html>About Us</html>", recorder.Body.String())
+}
+
+func (suite *SitemapTestSuite) TestSitemapListsStaticPagesAndAddedURLs() {
+	router := gin.New()
+	router.GET("/sitemap.xml", suite.htmx.SitemapHandler())
+
+	suite.htmx.StaticPages(router.Group("/company"),
+		ginhtmx.StaticPage{
+			Path:            "/about",
+			Template:        "about",
+			LastModified:    time.Date(2025, 3, 14, 12, 0, 0, 0, time.UTC),
+			ChangeFrequency: "monthly",
+			Priority:        0.8,
+		},
+		ginhtmx.StaticPage{Path: "/secret", Template: "about", Hidden: true},
+	)
+	suite.htmx.With(ginhtmx.ForceFragment()).AddToSitemap(
+		ginhtmx.SitemapURL{Location: "/products/7"},
+		ginhtmx.SitemapURL{Location: "https://cdn.example.com/catalog.pdf"},
+	)
+
+	recorder := suite.serve(router, "/sitemap.xml")
+
+	suite.Equal("application/xml; charset=utf-8", recorder.Header().Get("Content-Type"))
+	suite.Equal(`<?xml version="1.0" encoding="UTF-8"?>`+"\n"+
+		`<urlset xmlns="http://www.sitemaps.org/schemas/sitemap/0.9">`+
+		`<url><loc>https://example.com/shop/company/about</loc><lastmod>2025-03-14</lastmod>`+
+		`<changefreq>monthly</changefreq><priority>0.8</priority></url>`+
+		`<url><loc>https://example.com/shop/products/7</loc></url>`+
+		`<url><loc>https://cdn.example.com/catalog.pdf</loc></url>`+
+		`</urlset>`, recorder.Body.String())
+}
+
+func (suite *SitemapTestSuite) serve(router *gin.Engine, path string) *httptest.ResponseRecorder {
+	recorder := httptest.NewRecorder()
+	router.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, path, nil))
+
+	return recorder
+}
+
+func (suite *SitemapTestSuite) SetupTest() {
+	tmpl := template.Must(template.New("").Parse(`
+{{define "layout"}}<html>{{.Content}}</html>{{end}}
+{{define "about"}}About {{.Name}}{{end}}
+`))
+
+	suite.htmx = ginhtmx.NewHtmxWithConfig(tmpl, ginhtmx.HtmxConfig{
+		LayoutTemplateName:  "layout",
+		ContentVariableName: "Content",
+		BaseURL:             "https://example.com/shop/",
+	})
+}
+
+func TestSitemapTestSuite(t *testing.T) {
+	t.Parallel()
+	suite.Run(t, new(SitemapTestSuite))
+}
+
+type SitemapTestSuite struct {
+	suite.Suite
+
+	htmx *ginhtmx.Htmx
+}
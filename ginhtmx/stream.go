@@ -0,0 +1,106 @@
+package ginhtmx
+
+import (
+	"context"
+	"html/template"
+	"io"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	// contentToken identifies the content in the output of the layout. Being
+	// alphanumeric, it survives escaping in every context of the layout.
+	contentToken = "ginhtmxcontent8d1f0c"
+
+	// contentMarker stands in for the content when the layout is rendered ahead of
+	// the content of a streamed page.
+	contentMarker = "<ginhtmx-content>" + contentToken + "</ginhtmx-content>"
+)
+
+// streamsLayout reports whether full pages are streamed. Streaming is not possible
+// when the complete response is needed before it is written, as it is to replace
+// the response in strict mode and to compute ETags.
+func (htmx *Htmx) streamsLayout() bool {
+	return htmx.config.StreamLayout && !htmx.config.Strict && !htmx.config.ETags
+}
+
+// splitLayout renders the layout with a marker in place of the content and returns
+// the parts of the page before and after the content. It reports false if the
+// layout failed to render or does not contain the content exactly once as HTML, in
+// which case the page is rendered without streaming.
+func (htmx *Htmx) splitLayout(
+	ctx context.Context, tmpl *template.Template, data gin.H, settings *renderSettings,
+) (string, string, bool) {
+	page, err := htmx.renderLayout(ctx, tmpl, data, settings, contentMarker)
+
+	if err != nil {
+		return "", "", false
+	}
+
+	// Where the content is not output as HTML, such as in an attribute, it is
+	// escaped and the token appears without the marker.
+	if strings.Count(page, contentToken) != 1 || strings.Count(page, contentMarker) != 1 {
+		return "", "", false
+	}
+
+	head, tail, _ := strings.Cut(page, contentMarker)
+
+	return head, tail, true
+}
+
+// streamPage writes the head of the layout and flushes it, so that the browser can
+// start loading the resources of the page, then writes the output of each template
+// as it is rendered, followed by the tail of the layout.
+func (htmx *Htmx) streamPage(
+	ctx context.Context, ginContext *gin.Context, tmpl *template.Template, data gin.H, settings *renderSettings,
+	templateNames []string, head string, tail string,
+) []error {
+	writer := ginContext.Writer
+	writer.Header().Set("Content-Type", htmx.contentType())
+
+	err := writeString(ginContext, head)
+	if err != nil {
+		return []error{err}
+	}
+
+	writer.Flush()
+
+	var errs []error
+
+	if len(settings.wrappers) > 0 || htmx.config.ConcurrentRendering || settings.cache != nil ||
+		htmx.config.ErrorBoundary != "" {
+		content, contentErrs := htmx.renderContent(ctx, ginContext, tmpl, data, settings, templateNames, false)
+		errs = append(errs, contentErrs...)
+		errs = append(errs, writeString(ginContext, content))
+	} else {
+		for _, name := range templateNames {
+			err = htmx.executeTemplateTo(ctx, writer, tmpl, name, data, false)
+			if err != nil && clientGone(ginContext, err) {
+				// stop rendering, nobody is listening
+				return append(errs, writeError(ginContext, err))
+			}
+
+			errs = append(errs, err)
+			writer.Flush()
+		}
+
+		for _, appended := range settings.appended {
+			errs = append(errs, htmx.executeTemplateTo(ctx, writer, tmpl, appended.name, appended.data, false))
+		}
+	}
+
+	errs = append(errs, writeString(ginContext, htmx.injectLiveReload(tail)))
+
+	writer.Flush()
+
+	return errs
+}
+
+// writeString writes content to the response, see writeError.
+func writeString(ginContext *gin.Context, content string) error {
+	_, err := io.WriteString(ginContext.Writer, content)
+
+	return writeError(ginContext, err)
+}
@@ -0,0 +1,139 @@
+package ginhtmx_test
+
+import (
+	"bufio"
+	"html/template"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/jeffscottbrown/ginhtmxtemplates/ginhtmx"
+	"github.com/stretchr/testify/suite"
+)
+
+func (suite *StreamLayoutTestSuite) TestHeadIsSentBeforeContentIsRendered() {
+	release := make(chan struct{})
+	htmx := suite.newHtmx(ginhtmx.HtmxConfig{StreamLayout: true}, template.FuncMap{
+		"wait": func() string {
+			<-release
+
+			return ""
+		},
+	})
+
+	router := gin.New()
+	router.GET("/", func(c *gin.Context) { htmx.Render(c, gin.H{"Title": "Home"}, "slow") })
+
+	server := httptest.NewServer(router)
+	defer server.Close()
+
+	response, err := http.Get(server.URL) //nolint:noctx
+	suite.Require().NoError(err)
+
+	defer response.Body.Close()
+
+	reader := bufio.NewReader(response.Body)
+
+	head, err := reader.ReadString('\n')
+	suite.Require().NoError(err)
+	suite.Equal("<html><head><title>Home</title></head>\n", head)
+	suite.Equal("text/html; charset=utf-8", response.Header.Get("Content-Type"))
+
+	close(release)
+
+	rest, err := reader.ReadString(0)
+	suite.Equal("<body>Slow</body></html>", rest)
+	suite.Error(err)
+}
+
+func (suite *StreamLayoutTestSuite) TestStreamedPageMatchesBufferedPage() {
+	streamed := suite.render(suite.newHtmx(ginhtmx.HtmxConfig{StreamLayout: true}, nil), nil, "home", "footer")
+	buffered := suite.render(suite.newHtmx(ginhtmx.HtmxConfig{}, nil), nil, "home", "footer")
+
+	suite.True(streamed.Flushed)
+	suite.False(buffered.Flushed)
+	suite.Equal(buffered.Body.String(), streamed.Body.String())
+	suite.Equal("<html><head><title>Home</title></head>\n<body>HomeFooter</body></html>", streamed.Body.String())
+}
+
+func (suite *StreamLayoutTestSuite) TestWrappedContentIsStreamed() {
+	htmx := suite.newHtmx(ginhtmx.HtmxConfig{StreamLayout: true}, nil)
+
+	recorder := suite.render(htmx, []ginhtmx.RenderOption{ginhtmx.WrapIn("card")}, "home")
+
+	suite.True(recorder.Flushed)
+	suite.Equal(`<html><head><title>Home</title></head>`+"\n"+`<body><div class="card">Home</div></body></html>`,
+		recorder.Body.String())
+}
+
+func (suite *StreamLayoutTestSuite) TestStrictPagesAreNotStreamed() {
+	recorder := suite.render(suite.newHtmx(ginhtmx.HtmxConfig{StreamLayout: true, Strict: true}, nil), nil, "home")
+
+	suite.False(recorder.Flushed)
+	suite.Contains(recorder.Body.String(), "<body>Home</body>")
+}
+
+func (suite *StreamLayoutTestSuite) TestLayoutsWithoutHTMLContentAreNotStreamed() {
+	tmpl := template.Must(template.New("").Parse(`
+{{define "layout"}}<html><body data-content="{{.Content}}">{{.Content}}</body></html>{{end}}
+{{define "home"}}Home{{end}}
+`))
+	htmx := ginhtmx.NewHtmxWithConfig(tmpl, ginhtmx.HtmxConfig{
+		LayoutTemplateName:  "layout",
+		ContentVariableName: "Content",
+		StreamLayout:        true,
+	})
+
+	recorder := suite.render(htmx, nil, "home")
+
+	suite.False(recorder.Flushed)
+	suite.Equal(`<html><body data-content="Home">Home</body></html>`, recorder.Body.String())
+}
+
+func (suite *StreamLayoutTestSuite) TestMissingLayoutIsReportedWithoutStreaming() {
+	htmx := ginhtmx.NewHtmxWithConfig(template.Must(template.New("").Parse(`{{define "home"}}Home{{end}}`)),
+		ginhtmx.HtmxConfig{LayoutTemplateName: "layout", ContentVariableName: "Content", StreamLayout: true})
+
+	recorder := suite.render(htmx, nil, "home")
+
+	suite.False(recorder.Flushed)
+	suite.Empty(recorder.Body.String())
+}
+
+func (suite *StreamLayoutTestSuite) newHtmx(config ginhtmx.HtmxConfig, funcs template.FuncMap) *ginhtmx.Htmx {
+	tmpl := template.Must(template.New("").Funcs(template.FuncMap{"wait": func() string { return "" }}).Funcs(funcs).Parse(`
+{{define "layout"}}<html><head><title>{{.Title}}</title></head>
+<body>{{.Content}}</body></html>{{end}}
+{{define "home"}}Home{{end}}
+{{define "footer"}}Footer{{end}}
+{{define "slow"}}{{wait}}Slow{{end}}
+{{define "card"}}<div class="card">{{.Children}}</div>{{end}}
+`))
+
+	config.LayoutTemplateName = "layout"
+	config.ContentVariableName = "Content"
+
+	return ginhtmx.NewHtmxWithConfig(tmpl, config)
+}
+
+func (suite *StreamLayoutTestSuite) render(
+	htmx *ginhtmx.Htmx, options []ginhtmx.RenderOption, names ...string,
+) *httptest.ResponseRecorder {
+	recorder := httptest.NewRecorder()
+	testContext, _ := gin.CreateTestContext(recorder)
+	testContext.Request = httptest.NewRequest(http.MethodGet, "/", nil)
+
+	htmx.With(options...).Render(testContext, gin.H{"Title": "Home"}, names...)
+
+	return recorder
+}
+
+func TestStreamLayoutTestSuite(t *testing.T) {
+	t.Parallel()
+	suite.Run(t, new(StreamLayoutTestSuite))
+}
+
+type StreamLayoutTestSuite struct {
+	suite.Suite
+}
@@ -0,0 +1,14 @@
+package ginhtmx
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// writeStrictError replaces the response with a 500 error describing err. It is
+// used in strict mode so that render errors are never hidden behind partial output.
+func writeStrictError(ginContext *gin.Context, err error) {
+	message := http.StatusText(http.StatusInternalServerError) + "\n\nginhtmx: " + err.Error() + "\n"
+	ginContext.Data(http.StatusInternalServerError, "text/plain; charset=utf-8", []byte(message))
+}
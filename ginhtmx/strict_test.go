@@ -0,0 +1,74 @@
+package ginhtmx_test
+
+import (
+	"html/template"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/jeffscottbrown/ginhtmxtemplates/ginhtmx"
+	"github.com/stretchr/testify/suite"
+)
+
+func (suite *StrictTestSuite) TestUndefinedTemplateServesError() {
+	recorder := suite.render(suite.newHtmx(true), gin.H{"Name": "Jerry"}, "hello", "missing")
+
+	suite.Equal(http.StatusInternalServerError, recorder.Code)
+	suite.Equal("text/plain; charset=utf-8", recorder.Header().Get("Content-Type"))
+	suite.Contains(recorder.Body.String(), `template not found: "missing"`)
+	suite.NotContains(recorder.Body.String(), "Jerry")
+}
+
+func (suite *StrictTestSuite) TestMissingKeyServesError() {
+	recorder := suite.render(suite.newHtmx(true), gin.H{}, "hello")
+
+	suite.Equal(http.StatusInternalServerError, recorder.Code)
+	suite.Contains(recorder.Body.String(), `map has no entry for key "Name"`)
+}
+
+func (suite *StrictTestSuite) TestValidRenderIsUnaffected() {
+	recorder := suite.render(suite.newHtmx(true), gin.H{"Name": "Jerry"}, "hello")
+
+	suite.Equal(http.StatusOK, recorder.Code)
+	suite.Equal("<html><p>Hello, Jerry</p></html>", recorder.Body.String())
+}
+
+func (suite *StrictTestSuite) TestErrorsAreSilentWithoutStrictMode() {
+	recorder := suite.render(suite.newHtmx(false), gin.H{}, "hello", "missing")
+
+	suite.Equal(http.StatusOK, recorder.Code)
+	suite.Equal("<html><p>Hello, </p></html>", recorder.Body.String())
+}
+
+func (suite *StrictTestSuite) render(htmx *ginhtmx.Htmx, data gin.H, templateNames ...string) *httptest.ResponseRecorder {
+	recorder := httptest.NewRecorder()
+	testContext, _ := gin.CreateTestContext(recorder)
+	testContext.Request = httptest.NewRequest(http.MethodGet, "/", nil)
+
+	htmx.Render(testContext, data, templateNames...)
+
+	return recorder
+}
+
+func (suite *StrictTestSuite) newHtmx(strict bool) *ginhtmx.Htmx {
+	tmpl := template.Must(template.New("").Parse(`
+{{- define "layout"}}<html>{{.Content}}</html>{{end}}
+{{- define "hello"}}<p>Hello, {{.Name}}</p>{{end}}
+`))
+
+	return ginhtmx.NewHtmxWithConfig(tmpl, ginhtmx.HtmxConfig{
+		LayoutTemplateName:  "layout",
+		ContentVariableName: "Content",
+		Strict:              strict,
+	})
+}
+
+func TestStrictTestSuite(t *testing.T) {
+	t.Parallel()
+	suite.Run(t, new(StrictTestSuite))
+}
+
+type StrictTestSuite struct {
+	suite.Suite
+}
@@ -0,0 +1,151 @@
+package ginhtmx
+
+import (
+	"net/url"
+
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	// DefaultSortParam is the query parameter holding the field a table is sorted by.
+	DefaultSortParam = "sort"
+
+	// DefaultDirectionParam is the query parameter holding the direction of the sort.
+	DefaultDirectionParam = "direction"
+
+	// SortAscending is the value of the direction parameter for ascending sorts.
+	SortAscending = "asc"
+
+	// SortDescending is the value of the direction parameter for descending sorts.
+	SortDescending = "desc"
+)
+
+// Column describes a column of a Table.
+type Column struct {
+	// Label is the text of the column header
+	Label string
+
+	// Field is the name of the field the column displays, used as the sort parameter
+	Field string
+
+	// Sortable is true if the table may be sorted by the column
+	Sortable bool
+}
+
+// Sort describes the order of the rows of a Table.
+type Sort struct {
+	// Field is the field of the column the rows are sorted by
+	Field string
+
+	// Descending is true if the rows are sorted in descending order
+	Descending bool
+}
+
+// Table describes a table whose rows may be sorted by clicking the column headers.
+// The headers are rendered with the built-in "ginhtmx/table_header" template, which
+// may be overridden:
+//
+//	table := ginhtmx.NewTable(c, columns, ginhtmx.Sort{Field: "name"})
+//	table.Target = "#users"
+//	users := loadUsers(table.Sort.Field, table.Sort.Descending)
+//	handler.htmx.Render(c, gin.H{"Table": table, "Users": users}, "users")
+//
+//	<table id="users">{{ template "ginhtmx/table_header" .Table }}<tbody>...</tbody></table>
+type Table struct {
+	// Columns are the columns of the table
+	Columns []Column
+
+	// Sort is the current order of the rows
+	Sort Sort
+
+	// Target is an optional hx-target for the header links
+	Target string
+
+	// URL is the URL of the table. Its query parameters are preserved in the header
+	// links, except for the page parameter as sorting returns to the first page.
+	URL *url.URL
+}
+
+// TableHeader is a column header produced by Table.Headers.
+type TableHeader struct {
+	Column
+
+	// URL is the URL which sorts the table by the column
+	URL string
+
+	// Active is true if the table is sorted by the column
+	Active bool
+
+	// Descending is true if the table is sorted by the column in descending order
+	Descending bool
+}
+
+// NewTable returns a Table for the request with the sort read by ParseSort.
+func NewTable(ginContext *gin.Context, columns []Column, defaultSort Sort) *Table {
+	return &Table{
+		Columns: columns,
+		Sort:    ParseSort(ginContext, columns, defaultSort),
+		Target:  "",
+		URL:     ginContext.Request.URL,
+	}
+}
+
+// ParseSort returns the sort requested by the DefaultSortParam and
+// DefaultDirectionParam query parameters. The default sort is returned if the
+// requested field is not the field of a sortable column, so the result is always
+// safe to use in a query.
+func ParseSort(ginContext *gin.Context, columns []Column, defaultSort Sort) Sort {
+	field := ginContext.Query(DefaultSortParam)
+
+	for _, column := range columns {
+		if column.Sortable && column.Field == field {
+			return Sort{Field: field, Descending: ginContext.Query(DefaultDirectionParam) == SortDescending}
+		}
+	}
+
+	return defaultSort
+}
+
+// Headers returns the column headers. The URL of the header of the column the table
+// is sorted by reverses the direction of the sort.
+func (table *Table) Headers() []TableHeader {
+	headers := make([]TableHeader, 0, len(table.Columns))
+
+	for _, column := range table.Columns {
+		active := column.Sortable && column.Field == table.Sort.Field
+		header := TableHeader{
+			Column:     column,
+			URL:        "",
+			Active:     active,
+			Descending: active && table.Sort.Descending,
+		}
+
+		if column.Sortable {
+			header.URL = table.sortURL(column.Field, active && !table.Sort.Descending)
+		}
+
+		headers = append(headers, header)
+	}
+
+	return headers
+}
+
+func (table *Table) sortURL(field string, descending bool) string {
+	sortURL := url.URL{Path: "", RawQuery: ""}
+	if table.URL != nil {
+		sortURL = *table.URL
+	}
+
+	direction := SortAscending
+	if descending {
+		direction = SortDescending
+	}
+
+	query := sortURL.Query()
+	query.Del(DefaultPageParam)
+	query.Set(DefaultSortParam, field)
+	query.Set(DefaultDirectionParam, direction)
+	sortURL.RawQuery = query.Encode()
+
+	return sortURL.RequestURI()
+}
@@ -0,0 +1,89 @@
+package ginhtmx_test
+
+import (
+	"html/template"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/jeffscottbrown/ginhtmxtemplates/ginhtmx"
+	"github.com/stretchr/testify/suite"
+)
+
+func (suite *TableTestSuite) TestParseSort() {
+	defaultSort := ginhtmx.Sort{Field: "name", Descending: false}
+
+	suite.Equal(ginhtmx.Sort{Field: "email", Descending: true},
+		ginhtmx.ParseSort(suite.newContext("/users?sort=email&direction=desc"), suite.columns, defaultSort))
+	suite.Equal(ginhtmx.Sort{Field: "email", Descending: false},
+		ginhtmx.ParseSort(suite.newContext("/users?sort=email&direction=up"), suite.columns, defaultSort))
+	suite.Equal(defaultSort,
+		ginhtmx.ParseSort(suite.newContext("/users?sort=password;drop"), suite.columns, defaultSort))
+	suite.Equal(defaultSort,
+		ginhtmx.ParseSort(suite.newContext("/users?sort=actions"), suite.columns, defaultSort))
+}
+
+func (suite *TableTestSuite) TestHeaders() {
+	table := ginhtmx.NewTable(suite.newContext("/users?q=j&page=3&sort=name"), suite.columns, ginhtmx.Sort{})
+
+	headers := table.Headers()
+
+	suite.Require().Len(headers, 3)
+	suite.True(headers[0].Active)
+	suite.False(headers[0].Descending)
+	suite.Equal("/users?direction=desc&q=j&sort=name", headers[0].URL)
+	suite.False(headers[1].Active)
+	suite.Equal("/users?direction=asc&q=j&sort=email", headers[1].URL)
+	suite.Empty(headers[2].URL)
+
+	table.Sort.Descending = true
+	suite.Equal("/users?direction=asc&q=j&sort=name", table.Headers()[0].URL)
+	suite.True(table.Headers()[0].Descending)
+
+	suite.Equal("/?direction=asc&sort=name", (&ginhtmx.Table{Columns: suite.columns}).Headers()[0].URL)
+}
+
+func (suite *TableTestSuite) TestBuiltInTemplate() {
+	recorder := httptest.NewRecorder()
+	testContext, _ := gin.CreateTestContext(recorder)
+	testContext.Request = httptest.NewRequest(http.MethodGet, "/users?sort=email&direction=desc", nil)
+	testContext.Request.Header.Set("Hx-Request", "true")
+
+	table := ginhtmx.NewTable(testContext, suite.columns, ginhtmx.Sort{Field: "name"})
+	table.Target = "#users"
+
+	tmpl := template.Must(template.New("").Parse(`{{define "users"}}{{template "ginhtmx/table_header" .Table}}{{end}}`))
+	ginhtmx.NewHtmx(tmpl).Render(testContext, gin.H{"Table": table}, "users")
+
+	body := recorder.Body.String()
+	suite.Contains(body, `<th><a href="/users?direction=asc&amp;sort=name" hx-get="/users?direction=asc&amp;sort=name" hx-target="#users" hx-push-url="true">Name</a></th>`)
+	suite.Contains(body, `<th aria-sort="descending"><a href="/users?direction=asc&amp;sort=email"`)
+	suite.Contains(body, `<th>Actions</th>`)
+}
+
+func (suite *TableTestSuite) newContext(target string) *gin.Context {
+	testContext, _ := gin.CreateTestContext(httptest.NewRecorder())
+	testContext.Request = httptest.NewRequest(http.MethodGet, target, nil)
+
+	return testContext
+}
+
+func (suite *TableTestSuite) SetupSuite() {
+	suite.columns = []ginhtmx.Column{
+		{Label: "Name", Field: "name", Sortable: true},
+		{Label: "Email", Field: "email", Sortable: true},
+		{Label: "Actions", Field: "actions", Sortable: false},
+	}
+}
+
+func TestTableTestSuite(t *testing.T) {
+	t.Parallel()
+	suite.Run(t, new(TableTestSuite))
+}
+
+type TableTestSuite struct {
+	suite.Suite
+
+	columns []ginhtmx.Column
+}
@@ -0,0 +1,182 @@
+package ginhtmx
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// DefaultTelemetryInterval is the interval at which ExportTelemetry reports usage
+// when it is given an interval which is not positive.
+const DefaultTelemetryInterval = time.Minute
+
+// TemplateUsage describes how often a template was rendered during a reporting
+// interval of ExportTelemetry.
+type TemplateUsage struct {
+	// Name is the name of the template.
+	Name string
+
+	// Renders is the number of times the template was executed.
+	Renders int64
+
+	// Errors is the number of executions which returned an error.
+	Errors int64
+}
+
+// ErrorRate returns the fraction of renders of the template which failed, or
+// zero if the template was not rendered.
+func (usage TemplateUsage) ErrorRate() float64 {
+	if usage.Renders == 0 {
+		return 0
+	}
+
+	return float64(usage.Errors) / float64(usage.Renders)
+}
+
+// ExportTelemetry periodically reports how often each template was rendered and
+// how often rendering failed. Every interval report is called with the usage of
+// every template defined in the template set, including templates which were
+// not rendered at all, sorted by name. Counts are reset after each report. An
+// interval which is not positive is replaced with DefaultTelemetryInterval.
+//
+// Usage is only recorded while ExportTelemetry is running. Several exporters, such
+// as one sending metrics and one writing logs, may run at once, and each reports
+// every render made since its own previous report. ExportTelemetry blocks until ctx is
+// done, at which point a final report is made, so it is usually started in its
+// own goroutine:
+//
+//	go htmx.ExportTelemetry(ctx, time.Minute, func(usage []ginhtmx.TemplateUsage) {
+//	  for _, template := range usage {
+//	    metrics.Gauge("renders", template.Renders, "template", template.Name)
+//	  }
+//	})
+func (htmx *Htmx) ExportTelemetry(ctx context.Context, interval time.Duration, report func([]TemplateUsage)) {
+	exporter := htmx.usage.register()
+	defer htmx.usage.unregister(exporter)
+
+	if interval <= 0 {
+		interval = DefaultTelemetryInterval
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			report(htmx.usage.snapshot(exporter, htmx.templateNames()))
+
+			return
+		case <-ticker.C:
+			report(htmx.usage.snapshot(exporter, htmx.templateNames()))
+		}
+	}
+}
+
+// templateNames returns the names of the templates of the application, excluding
+// the built-in templates, which are only reported once they have been rendered.
+func (htmx *Htmx) templateNames() []string {
+	var names []string
+
+	for _, tmpl := range htmx.currentTemplate().Templates() {
+		if tmpl.Name() != "" && !strings.HasPrefix(tmpl.Name(), builtinTemplatePrefix) {
+			names = append(names, tmpl.Name())
+		}
+	}
+
+	return names
+}
+
+// usageRecorder counts template renders for each running exporter, so that every
+// exporter reports all of the renders made since its own previous report.
+type usageRecorder struct {
+	enabled   atomic.Int32
+	mutex     sync.Mutex
+	exporters map[*usageCounts]struct{}
+}
+
+// usageCounts are the renders counted for one exporter, guarded by the mutex of
+// the usageRecorder.
+type usageCounts struct {
+	counts map[string]*TemplateUsage
+}
+
+func newUsageRecorder() *usageRecorder {
+	return &usageRecorder{
+		enabled:   atomic.Int32{},
+		mutex:     sync.Mutex{},
+		exporters: map[*usageCounts]struct{}{},
+	}
+}
+
+// register starts counting renders for an exporter.
+func (recorder *usageRecorder) register() *usageCounts {
+	recorder.mutex.Lock()
+	defer recorder.mutex.Unlock()
+
+	exporter := &usageCounts{counts: map[string]*TemplateUsage{}}
+	recorder.exporters[exporter] = struct{}{}
+	recorder.enabled.Add(1)
+
+	return exporter
+}
+
+// unregister stops counting renders for exporter.
+func (recorder *usageRecorder) unregister(exporter *usageCounts) {
+	recorder.mutex.Lock()
+	defer recorder.mutex.Unlock()
+
+	delete(recorder.exporters, exporter)
+	recorder.enabled.Add(-1)
+}
+
+func (recorder *usageRecorder) record(name string, err error) {
+	if recorder.enabled.Load() == 0 {
+		return
+	}
+
+	recorder.mutex.Lock()
+	defer recorder.mutex.Unlock()
+
+	for exporter := range recorder.exporters {
+		usage, found := exporter.counts[name]
+		if !found {
+			usage = &TemplateUsage{Name: name, Renders: 0, Errors: 0}
+			exporter.counts[name] = usage
+		}
+
+		usage.Renders++
+
+		if err != nil {
+			usage.Errors++
+		}
+	}
+}
+
+// snapshot returns the usage recorded for exporter since its previous snapshot,
+// including a zero entry for each of the defined template names which was not
+// rendered.
+func (recorder *usageRecorder) snapshot(exporter *usageCounts, definedNames []string) []TemplateUsage {
+	recorder.mutex.Lock()
+	counts := exporter.counts
+	exporter.counts = map[string]*TemplateUsage{}
+	recorder.mutex.Unlock()
+
+	for _, name := range definedNames {
+		if _, found := counts[name]; !found {
+			counts[name] = &TemplateUsage{Name: name, Renders: 0, Errors: 0}
+		}
+	}
+
+	usage := make([]TemplateUsage, 0, len(counts))
+	for _, count := range counts {
+		usage = append(usage, *count)
+	}
+
+	sort.Slice(usage, func(i, j int) bool { return usage[i].Name < usage[j].Name })
+
+	return usage
+}
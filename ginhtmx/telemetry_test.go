@@ -0,0 +1,164 @@
+package ginhtmx_test
+
+import (
+	"context"
+	"html/template"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/jeffscottbrown/ginhtmxtemplates/ginhtmx"
+	"github.com/stretchr/testify/suite"
+)
+
+func (suite *TelemetryTestSuite) TestFinalReportIncludesUnusedTemplates() {
+	tmpl := template.Must(template.New("").Parse(`
+{{define "layout"}}<html>{{.Content}}</html>{{end}}
+{{define "unused"}}Unused{{end}}
+`))
+	htmx := ginhtmx.NewHtmx(tmpl)
+
+	var usage []ginhtmx.TemplateUsage
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	htmx.ExportTelemetry(ctx, time.Hour, func(reported []ginhtmx.TemplateUsage) { usage = reported })
+
+	suite.Equal([]ginhtmx.TemplateUsage{
+		{Name: "layout", Renders: 0, Errors: 0},
+		{Name: "unused", Renders: 0, Errors: 0},
+	}, usage)
+	suite.Zero(usage[0].ErrorRate())
+}
+
+func (suite *TelemetryTestSuite) TestIntervalsWhichAreNotPositiveUseTheDefault() {
+	htmx := ginhtmx.NewHtmx(template.Must(template.New("").Parse(`{{define "hello"}}Hello{{end}}`)))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	for _, interval := range []time.Duration{0, -time.Second} {
+		var usage []ginhtmx.TemplateUsage
+
+		suite.NotPanics(func() {
+			htmx.ExportTelemetry(ctx, interval, func(reported []ginhtmx.TemplateUsage) { usage = reported })
+		})
+		suite.Equal([]ginhtmx.TemplateUsage{{Name: "hello", Renders: 0, Errors: 0}}, usage)
+	}
+}
+
+func (suite *TelemetryTestSuite) TestErrorRate() {
+	tmpl := template.Must(template.New("").Parse(`
+{{define "layout"}}<html>{{.Content}}</html>{{end}}
+{{define "hello"}}Hello{{end}}
+{{define "broken"}}{{ template "missing" }}{{end}}
+`))
+	htmx := ginhtmx.NewHtmx(tmpl)
+
+	reports := make(chan []ginhtmx.TemplateUsage, 10)
+	ctx, cancel := context.WithCancel(context.Background())
+
+	go htmx.ExportTelemetry(ctx, 20*time.Millisecond, func(usage []ginhtmx.TemplateUsage) { reports <- usage })
+
+	// Keep rendering until a report includes the renders, which proves the exporter is running.
+	var byName map[string]ginhtmx.TemplateUsage
+
+	suite.Eventually(func() bool {
+		suite.render(htmx, "hello", "broken")
+		suite.render(htmx, "hello")
+
+		select {
+		case usage := <-reports:
+			byName = map[string]ginhtmx.TemplateUsage{}
+			for _, entry := range usage {
+				byName[entry.Name] = entry
+			}
+
+			return byName["broken"].Renders > 0
+		default:
+			return false
+		}
+	}, 5*time.Second, 5*time.Millisecond)
+
+	cancel()
+
+	suite.Equal(byName["broken"].Renders, byName["broken"].Errors)
+	suite.InDelta(1.0, byName["broken"].ErrorRate(), 0.0001)
+	suite.Equal(2*byName["broken"].Renders, byName["hello"].Renders)
+	suite.Equal(byName["hello"].Renders, byName["layout"].Renders)
+}
+
+func (suite *TelemetryTestSuite) TestEveryExporterReportsEveryRender() {
+	tmpl := template.Must(template.New("").Parse(`
+{{define "layout"}}<html>{{.Content}}</html>{{end}}
+{{define "probe"}}Probe{{end}}
+{{define "hello"}}Hello{{end}}
+`))
+	htmx := ginhtmx.NewHtmx(tmpl)
+
+	var (
+		mutex   sync.Mutex
+		probes  [2]int64
+		renders [2]int64
+		done    sync.WaitGroup
+	)
+
+	ctx, cancel := context.WithCancel(context.Background())
+
+	for exporter := range 2 {
+		done.Go(func() {
+			htmx.ExportTelemetry(ctx, 5*time.Millisecond, func(usage []ginhtmx.TemplateUsage) {
+				mutex.Lock()
+				defer mutex.Unlock()
+
+				for _, entry := range usage {
+					switch entry.Name {
+					case "probe":
+						probes[exporter] += entry.Renders
+					case "hello":
+						renders[exporter] += entry.Renders
+					}
+				}
+			})
+		})
+	}
+
+	// Render the probe until both exporters have reported it, which proves that both are running.
+	suite.Eventually(func() bool {
+		suite.render(htmx, "probe")
+
+		mutex.Lock()
+		defer mutex.Unlock()
+
+		return probes[0] > 0 && probes[1] > 0
+	}, 5*time.Second, 5*time.Millisecond)
+
+	for range 5 {
+		suite.render(htmx, "hello")
+	}
+
+	cancel()
+	done.Wait()
+
+	suite.Equal([2]int64{5, 5}, renders)
+}
+
+func (suite *TelemetryTestSuite) render(htmx *ginhtmx.Htmx, templateNames ...string) {
+	testContext, _ := gin.CreateTestContext(httptest.NewRecorder())
+	testContext.Request = httptest.NewRequest(http.MethodGet, "/", nil)
+
+	htmx.Render(testContext, gin.H{}, templateNames...)
+}
+
+func TestTelemetryTestSuite(t *testing.T) {
+	t.Parallel()
+	suite.Run(t, new(TelemetryTestSuite))
+}
+
+type TelemetryTestSuite struct {
+	suite.Suite
+}
@@ -0,0 +1,56 @@
+package ginhtmx
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// TemplComponent is implemented by the components generated by templ
+// (github.com/a-h/templ), so that they can be rendered without this package
+// depending on templ.
+type TemplComponent interface {
+	Render(ctx context.Context, w io.Writer) error
+}
+
+// RenderTempl renders component with a 200 status code in place of templates. As
+// with Render, the component is wrapped in the layout unless the request is an HTMX
+// request, and the model of the layout is decorated by the model providers and the
+// ModelDecorator, so that pages can be migrated from html/template to templ one at a
+// time:
+//
+//	handler.htmx.RenderTempl(c, views.UserCard(user))
+//
+// The component is rendered with the context of the request.
+func (htmx *Htmx) RenderTempl(ginContext *gin.Context, component TemplComponent) {
+	htmx.RenderTemplWithStatus(ginContext, http.StatusOK, component)
+}
+
+// RenderTemplWithStatus renders component like RenderTempl with the provided status
+// code.
+func (htmx *Htmx) RenderTemplWithStatus(ginContext *gin.Context, status int, component TemplComponent) {
+	htmx.With(withTempl(component)).RenderWithStatus(ginContext, gin.H{}, status)
+}
+
+// withTempl returns a RenderOption which renders component after the templates.
+func withTempl(component TemplComponent) RenderOption {
+	return func(settings *renderSettings) {
+		settings.templ = component
+	}
+}
+
+// renderTempl renders component to a string.
+func renderTempl(ctx context.Context, component TemplComponent) (string, error) {
+	var rendered strings.Builder
+
+	err := component.Render(ctx, &contextWriter{ctx: ctx, writer: &rendered})
+	if err != nil {
+		return rendered.String(), fmt.Errorf("rendering templ component: %w", err)
+	}
+
+	return rendered.String(), nil
+}
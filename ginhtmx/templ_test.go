@@ -0,0 +1,132 @@
+package ginhtmx_test
+
+import (
+	"context"
+	"errors"
+	"html/template"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/jeffscottbrown/ginhtmxtemplates/ginhtmx"
+	"github.com/stretchr/testify/suite"
+)
+
+var errComponentFailed = errors.New("component failed")
+
+func (suite *TemplTestSuite) TestComponentsAreWrappedInTheLayout() {
+	recorder, _ := suite.render(false, http.StatusOK, greeting("Ada"))
+
+	suite.Equal(http.StatusOK, recorder.Code)
+	suite.Equal(`<html title="decorated"><p>Hello Ada</p></html>`, recorder.Body.String())
+	suite.Equal("text/html; charset=utf-8", recorder.Header().Get("Content-Type"))
+}
+
+func (suite *TemplTestSuite) TestComponentsAreRenderedAsFragmentsForHTMXRequests() {
+	recorder, _ := suite.render(true, http.StatusCreated, greeting("Ada"))
+
+	suite.Equal(http.StatusCreated, recorder.Code)
+	suite.Equal(`<p>Hello Ada</p>`, recorder.Body.String())
+}
+
+func (suite *TemplTestSuite) TestComponentsAreRenderedWithTheRequestContext() {
+	recorder, _ := suite.render(true, http.StatusOK, templComponent(func(ctx context.Context, w io.Writer) error {
+		_, err := io.WriteString(w, ctx.Value(requestIDKey{}).(string)) //nolint:forcetypeassert
+
+		return err
+	}))
+
+	suite.Equal(`request-7`, recorder.Body.String())
+}
+
+func (suite *TemplTestSuite) TestComponentsAreNotStreamed() {
+	htmx := ginhtmx.NewHtmxWithConfig(suite.templates(), ginhtmx.HtmxConfig{
+		LayoutTemplateName:  "layout",
+		ContentVariableName: "Content",
+		StreamLayout:        true,
+	})
+
+	recorder := httptest.NewRecorder()
+	testContext, _ := gin.CreateTestContext(recorder)
+	testContext.Request = httptest.NewRequest(http.MethodGet, "/", nil)
+
+	htmx.RenderTempl(testContext, greeting("Ada"))
+
+	suite.Equal(`<html title=""><p>Hello Ada</p></html>`, recorder.Body.String())
+}
+
+func (suite *TemplTestSuite) TestComponentErrorsAreRecorded() {
+	_, testContext := suite.render(true, http.StatusOK, templComponent(func(context.Context, io.Writer) error {
+		return errComponentFailed
+	}))
+
+	renderErr, _ := testContext.Value(ginhtmx.RenderErrorKey).(error)
+	suite.Require().ErrorIs(renderErr, errComponentFailed)
+	suite.Require().EqualError(renderErr, "rendering templ component: component failed")
+}
+
+func (suite *TemplTestSuite) render(
+	htmxRequest bool, status int, component ginhtmx.TemplComponent,
+) (*httptest.ResponseRecorder, *gin.Context) {
+	recorder := httptest.NewRecorder()
+	testContext, _ := gin.CreateTestContext(recorder)
+	testContext.Request = httptest.NewRequestWithContext(
+		context.WithValue(context.Background(), requestIDKey{}, "request-7"), http.MethodGet, "/", nil)
+
+	if htmxRequest {
+		testContext.Request.Header.Set("Hx-Request", "true")
+	}
+
+	if status == http.StatusOK {
+		suite.htmx.RenderTempl(testContext, component)
+	} else {
+		suite.htmx.RenderTemplWithStatus(testContext, status, component)
+	}
+
+	return recorder, testContext
+}
+
+func (suite *TemplTestSuite) templates() *template.Template {
+	return template.Must(template.New("").Parse(
+		`{{define "layout"}}<html title="{{.Title}}">{{.Content}}</html>{{end}}`))
+}
+
+func (suite *TemplTestSuite) SetupTest() {
+	suite.htmx = ginhtmx.NewHtmxWithConfig(suite.templates(), ginhtmx.HtmxConfig{
+		LayoutTemplateName:  "layout",
+		ContentVariableName: "Content",
+		ModelDecorator: ginhtmx.ModelDecoratorFunc(func(_ *gin.Context, data *gin.H) {
+			(*data)["Title"] = "decorated"
+		}),
+	})
+}
+
+func TestTemplTestSuite(t *testing.T) {
+	t.Parallel()
+	suite.Run(t, new(TemplTestSuite))
+}
+
+type TemplTestSuite struct {
+	suite.Suite
+
+	htmx *ginhtmx.Htmx
+}
+
+type requestIDKey struct{}
+
+// templComponent adapts a function to ginhtmx.TemplComponent, like templ.ComponentFunc.
+type templComponent func(ctx context.Context, w io.Writer) error
+
+func (component templComponent) Render(ctx context.Context, w io.Writer) error {
+	return component(ctx, w)
+}
+
+func greeting(name string) templComponent {
+	return func(_ context.Context, w io.Writer) error {
+		_, err := io.WriteString(w, "<p>Hello "+template.HTMLEscapeString(name)+"</p>")
+
+		return err
+	}
+}
@@ -0,0 +1,148 @@
+package ginhtmx
+
+import (
+	"errors"
+	"fmt"
+	"html/template"
+	"sync"
+	"sync/atomic"
+)
+
+// ErrTemplateSetNotFound is reported when a render uses a template set which has
+// not been added with AddTemplateSet.
+var ErrTemplateSetNotFound = errors.New("template set not found")
+
+// templateStore holds the templates of an Htmx instance. It is shared by the copies
+// made by With, so that the templates can be replaced by Reload while the
+// application is running.
+type templateStore struct {
+	current atomic.Pointer[templateSet]
+
+	// mutex guards named and aliases
+	mutex sync.RWMutex
+	// named holds the template sets added with AddTemplateSet
+	named map[string]*templateSet
+	// aliases maps the names added with Alias to the names of the templates they render
+	aliases map[string]string
+}
+
+// templateSet is a parsed set of templates along with the pool of clones which
+// request scoped functions are bound to.
+type templateSet struct {
+	template *template.Template
+	clones   sync.Pool
+}
+
+func newTemplateStore(tmpl *template.Template) *templateStore {
+	store := &templateStore{
+		current: atomic.Pointer[templateSet]{},
+		mutex:   sync.RWMutex{},
+		named:   map[string]*templateSet{},
+		aliases: map[string]string{},
+	}
+	store.current.Store(newTemplateSet(tmpl))
+
+	return store
+}
+
+// replace makes tmpl the current set of templates. Renders which have already
+// started finish with the templates they started with.
+func (store *templateStore) replace(tmpl *template.Template) {
+	store.current.Store(newTemplateSet(tmpl))
+}
+
+// replaceNamed makes tmpl the set of templates with the specified name.
+func (store *templateStore) replaceNamed(name string, tmpl *template.Template) {
+	store.mutex.Lock()
+	defer store.mutex.Unlock()
+
+	store.named[name] = newTemplateSet(tmpl)
+}
+
+// load returns the current set of templates.
+func (store *templateStore) load() *templateSet {
+	return store.current.Load()
+}
+
+// loadNamed returns the set of templates with the specified name, or the current
+// set if name is empty. The current set is returned along with an error wrapping
+// ErrTemplateSetNotFound if there is no set with that name.
+func (store *templateStore) loadNamed(name string) (*templateSet, error) {
+	if name == "" {
+		return store.load(), nil
+	}
+
+	store.mutex.RLock()
+	defer store.mutex.RUnlock()
+
+	set, found := store.named[name]
+	if !found {
+		return store.load(), fmt.Errorf("%w: %q", ErrTemplateSetNotFound, name)
+	}
+
+	return set, nil
+}
+
+func newTemplateSet(tmpl *template.Template) *templateSet {
+	set := &templateSet{template: tmpl, clones: sync.Pool{}}
+	set.clones.New = set.cloneTemplate
+
+	return set
+}
+
+func (set *templateSet) cloneTemplate() any {
+	clone, err := set.template.Clone()
+	if err != nil {
+		return nil
+	}
+
+	return clone
+}
+
+// Reload replaces the templates of htmx, and of every copy made by With, with tmpl.
+// It is intended for reloading templates during development, see LiveReload.
+// Renders which have already started finish with the previous templates.
+func (htmx *Htmx) Reload(tmpl *template.Template) {
+	htmx.prepareTemplate(tmpl)
+	htmx.templates.replace(tmpl)
+}
+
+// AddTemplateSet adds tmpl to htmx, and to every copy made by With, as an
+// independent set of templates with the specified name, replacing any set with
+// that name. Templates in different sets may have the same names without
+// colliding, so that unrelated parts of an application, such as the public site,
+// the administration pages and emails, can each have their own layout and pages:
+//
+//	htmx.AddTemplateSet("admin", adminTemplates)
+//	admin := htmx.Group(router.Group("/admin"), ginhtmx.GroupConfig{TemplateSet: "admin"})
+//
+// Renders use a set with the TemplateSet option and otherwise use the templates
+// htmx was created with.
+func (htmx *Htmx) AddTemplateSet(name string, tmpl *template.Template) {
+	htmx.prepareTemplate(tmpl)
+	htmx.templates.replaceNamed(name, tmpl)
+}
+
+// TemplateSet is a render option which renders the templates of the set with the
+// specified name, see AddTemplateSet. If there is no set with that name, the error
+// is reported like a render error and the default templates are used.
+func TemplateSet(name string) RenderOption {
+	return func(settings *renderSettings) {
+		settings.templateSet = name
+	}
+}
+
+// prepareTemplate adds the built-in templates and the options required by the
+// configuration to tmpl.
+func (htmx *Htmx) prepareTemplate(tmpl *template.Template) {
+	installBuiltinTemplates(tmpl)
+
+	if htmx.config.Strict {
+		tmpl.Option("missingkey=error")
+	}
+}
+
+// currentTemplate returns the current templates.
+func (htmx *Htmx) currentTemplate() *template.Template {
+	return htmx.templates.load().template
+}
@@ -0,0 +1,103 @@
+package ginhtmx_test
+
+import (
+	"html/template"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/jeffscottbrown/ginhtmxtemplates/ginhtmx"
+	"github.com/stretchr/testify/suite"
+)
+
+func (suite *TemplateSetsTestSuite) TestTemplateSetIsSelectedPerRender() {
+	suite.Equal(`<html>Public home</html>`, suite.render(suite.htmx, false, "home"))
+	suite.Equal(`<admin>Admin home</admin>`, suite.render(suite.htmx.With(ginhtmx.TemplateSet("admin")), false, "home"))
+	suite.Equal(`Admin home`, suite.render(suite.htmx.With(ginhtmx.TemplateSet("admin")), true, "home"))
+}
+
+func (suite *TemplateSetsTestSuite) TestTemplateSetIsSelectedPerGroup() {
+	router := gin.New()
+	admin := suite.htmx.Group(router.Group("/admin"), ginhtmx.GroupConfig{TemplateSet: "admin"})
+	admin.Router.GET("/", func(ginContext *gin.Context) {
+		admin.Render(ginContext, nil, "home")
+	})
+
+	recorder := httptest.NewRecorder()
+	router.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/admin/", nil))
+
+	suite.Equal(`<admin>Admin home</admin>`, recorder.Body.String())
+}
+
+func (suite *TemplateSetsTestSuite) TestUnknownTemplateSetIsReported() {
+	recorder := httptest.NewRecorder()
+	testContext, _ := gin.CreateTestContext(recorder)
+	testContext.Request = httptest.NewRequest(http.MethodGet, "/", nil)
+
+	suite.htmx.With(ginhtmx.TemplateSet("email")).Render(testContext, nil, "home")
+
+	renderError, _ := testContext.Value(ginhtmx.RenderErrorKey).(error)
+	suite.Require().ErrorIs(renderError, ginhtmx.ErrTemplateSetNotFound)
+	suite.Equal(`<html>Public home</html>`, recorder.Body.String())
+
+	_, err := suite.htmx.With(ginhtmx.TemplateSet("email")).RenderToString("home", nil)
+	suite.Require().ErrorIs(err, ginhtmx.ErrTemplateSetNotFound)
+
+	err = suite.htmx.With(ginhtmx.TemplateSet("email")).RenderToWriter(httptest.NewRecorder(), nil, true, "home")
+	suite.Require().EqualError(err, `template set not found: "email"`)
+}
+
+func (suite *TemplateSetsTestSuite) TestTemplateSetsAreRenderedToStrings() {
+	rendered, err := suite.htmx.With(ginhtmx.TemplateSet("admin")).RenderToString("home", nil)
+	suite.Require().NoError(err)
+	suite.Equal(`Admin home`, rendered)
+}
+
+func (suite *TemplateSetsTestSuite) TestCachedOutputIsKeptPerTemplateSet() {
+	htmx := suite.htmx.With(ginhtmx.CacheFor(time.Minute, ""))
+
+	suite.Equal(`Public home`, suite.render(htmx, true, "home"))
+	suite.Equal(`Admin home`, suite.render(htmx.With(ginhtmx.TemplateSet("admin")), true, "home"))
+	suite.Equal(`Public home`, suite.render(htmx, true, "home"))
+}
+
+func (suite *TemplateSetsTestSuite) render(htmx *ginhtmx.Htmx, fragment bool, templateNames ...string) string {
+	recorder := httptest.NewRecorder()
+	testContext, _ := gin.CreateTestContext(recorder)
+	testContext.Request = httptest.NewRequest(http.MethodGet, "/", nil)
+
+	if fragment {
+		testContext.Request.Header.Set("Hx-Request", "true")
+	}
+
+	htmx.Render(testContext, nil, templateNames...)
+
+	return recorder.Body.String()
+}
+
+func (suite *TemplateSetsTestSuite) SetupTest() {
+	public := template.Must(template.New("").Funcs(ginhtmx.FuncMap()).Parse(
+		`{{define "layout"}}<html>{{ .Content }}</html>{{end}}{{define "home"}}Public home{{end}}`))
+	admin := template.Must(template.New("").Funcs(ginhtmx.FuncMap()).Parse(
+		`{{define "layout"}}<admin>{{ .Content }}</admin>{{end}}{{define "home"}}Admin home{{end}}`))
+
+	suite.htmx = ginhtmx.NewHtmxWithConfig(public, ginhtmx.HtmxConfig{
+		LayoutTemplateName:  "layout",
+		ContentVariableName: "Content",
+		Cache:               ginhtmx.NewMemoryCache(),
+	})
+	suite.htmx.AddTemplateSet("admin", admin)
+}
+
+func TestTemplateSetsTestSuite(t *testing.T) {
+	t.Parallel()
+	suite.Run(t, new(TemplateSetsTestSuite))
+}
+
+type TemplateSetsTestSuite struct {
+	suite.Suite
+
+	htmx *ginhtmx.Htmx
+}
@@ -0,0 +1,145 @@
+package ginhtmx
+
+import (
+	"context"
+	"html/template"
+	"maps"
+	"net"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// TenantKey is the gin context key holding the tenant of the current request as a
+// string, as resolved by the TenantResolver of the HtmxConfig.
+const TenantKey = "ginhtmx.tenant"
+
+// Tenant holds the template functions and default model values of a tenant, see
+// the Tenants of the HtmxConfig:
+//
+//	Tenants: map[string]ginhtmx.Tenant{
+//	  "acme": {
+//	    Funcs: template.FuncMap{"supportURL": func() string { return "https://help.acme.example" }},
+//	    Data:  gin.H{"Brand": "Acme", "Logo": "/static/acme/logo.svg"},
+//	  },
+//	},
+type Tenant struct {
+	// Funcs are template functions which replace those with the same names when
+	// the templates are rendered for the tenant, including those provided by this
+	// package. Like the functions passed to Funcs, which take precedence over them,
+	// they must already be known to the templates when they are parsed.
+	Funcs template.FuncMap
+
+	// Data holds values added to the model of every render of templates for the
+	// tenant, unless the model already has a value with the same key. The values
+	// are not added to models serialized as JSON.
+	Data gin.H
+}
+
+// withTenant returns a context in which templates are resolved for the tenant of
+// the request, if a TenantResolver is configured and returns a tenant. Templates of
+// the tenant are preferred over the templates of the theme, which must therefore
+// already have been resolved.
+func (htmx *Htmx) withTenant(ctx context.Context, ginContext *gin.Context) context.Context {
+	if htmx.config.TenantResolver == nil {
+		return ctx
+	}
+
+	tenant := htmx.config.TenantResolver(ginContext)
+	if tenant == "" {
+		return ctx
+	}
+
+	ginContext.Set(TenantKey, tenant)
+
+	if theme := ginContext.GetString(ThemeKey); theme != "" {
+		return withTemplatePrefixes(ctx, tenant+"/"+theme+"/", tenant+"/")
+	}
+
+	return withTemplatePrefixes(ctx, tenant+"/")
+}
+
+// TenantFromHost returns a TenantResolver which resolves the tenant of a request
+// from its Host header, ignoring any port, using tenants to map host names to
+// tenants:
+//
+//	TenantResolver: ginhtmx.TenantFromHost(map[string]string{
+//	  "shop.acme.example":   "acme",
+//	  "store.globex.example": "globex",
+//	}),
+//
+// Requests for other hosts have no tenant and are rendered with the shared templates.
+func TenantFromHost(tenants map[string]string) func(ginContext *gin.Context) string {
+	normalized := make(map[string]string, len(tenants))
+	for host, tenant := range tenants {
+		normalized[strings.ToLower(host)] = tenant
+	}
+
+	return func(ginContext *gin.Context) string {
+		host := ginContext.Request.Host
+		if name, _, err := net.SplitHostPort(host); err == nil {
+			host = name
+		}
+
+		return normalized[strings.ToLower(host)]
+	}
+}
+
+// TenantFromContext returns a TenantResolver which resolves the tenant of a request
+// from the string stored in the gin context under key, usually by authentication
+// or routing middleware which runs before the handler.
+func TenantFromContext(key string) func(ginContext *gin.Context) string {
+	return func(ginContext *gin.Context) string {
+		return ginContext.GetString(key)
+	}
+}
+
+// tenant returns the tenant of the request being rendered, or an empty string if it
+// has none, so that templates can refer to it:
+//
+//	<link rel="stylesheet" href="{{ asset (printf "%s/brand.css" tenant) }}">
+func (scope *requestScope) tenant() string {
+	if scope.ginContext == nil {
+		return ""
+	}
+
+	return scope.ginContext.GetString(TenantKey)
+}
+
+// tenantConfig returns the Tenant configured for the tenant of the request, if any.
+func (htmx *Htmx) tenantConfig(ginContext *gin.Context) (Tenant, bool) {
+	name := ginContext.GetString(TenantKey)
+	if name == "" {
+		return Tenant{Funcs: nil, Data: nil}, false
+	}
+
+	tenant, found := htmx.config.Tenants[name]
+
+	return tenant, found
+}
+
+// tenantFuncs returns the template functions of the tenant of the request overridden
+// by funcs, the functions of the render.
+func (htmx *Htmx) tenantFuncs(ginContext *gin.Context, funcs template.FuncMap) template.FuncMap {
+	tenant, found := htmx.tenantConfig(ginContext)
+	if !found || len(tenant.Funcs) == 0 {
+		return funcs
+	}
+
+	merged := maps.Clone(tenant.Funcs)
+	maps.Copy(merged, funcs)
+
+	return merged
+}
+
+// applyTenantData returns a copy of the model of a render of templates with the
+// default values of the tenant of the request added to it, unless the model already
+// has values with the same keys. The model of the handler is not modified.
+func (htmx *Htmx) applyTenantData(ginContext *gin.Context, data gin.H) gin.H {
+	tenant, found := htmx.tenantConfig(ginContext)
+	if !found || len(tenant.Data) == 0 {
+		return data
+	}
+
+	return mergeModels(tenant.Data, data)
+}
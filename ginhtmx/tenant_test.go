@@ -0,0 +1,115 @@
+package ginhtmx_test
+
+import (
+	"html/template"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/jeffscottbrown/ginhtmxtemplates/ginhtmx"
+	"github.com/stretchr/testify/suite"
+)
+
+func (suite *TenantTestSuite) TestTenantTemplatesArePreferredOverSharedTemplates() {
+	htmx := suite.newHtmx(ginhtmx.TenantFromHost(map[string]string{"Shop.Acme.Example": "acme"}))
+
+	suite.Equal(`<header class="acme">Acme</header><footer>Shared footer</footer>`,
+		suite.render(htmx, "shop.acme.example:8080", "", "header", "footer"))
+	suite.Equal(`<header class="">Shared</header><footer>Shared footer</footer>`,
+		suite.render(htmx, "other.example", "", "header", "footer"))
+}
+
+func (suite *TenantTestSuite) TestTenantTemplatesArePreferredOverThemeTemplates() {
+	htmx := suite.newHtmx(ginhtmx.TenantFromHost(map[string]string{"acme.example": "acme"}))
+
+	suite.Equal(`<header class="acme">Acme dark</header><footer>Dark footer</footer>`,
+		suite.render(htmx, "acme.example", "dark", "header", "footer"))
+	suite.Equal(`<header class="">Shared</header><footer>Dark footer</footer>`,
+		suite.render(htmx, "other.example", "dark", "header", "footer"))
+}
+
+func (suite *TenantTestSuite) TestTenantFromContext() {
+	htmx := suite.newHtmx(ginhtmx.TenantFromContext("customer"))
+
+	suite.Equal(`<header class="acme">Acme</header>`, suite.render(htmx, "acme", "", "header"))
+
+	var output strings.Builder
+
+	err := template.Must(template.New("").Funcs(ginhtmx.FuncMap()).Parse(`[{{ tenant }}]`)).Execute(&output, nil)
+	suite.Require().NoError(err)
+	suite.Equal("[]", output.String())
+}
+
+func (suite *TenantTestSuite) TestTenantFuncsAndDataAreUsedForTheirRequests() {
+	htmx := suite.newHtmx(ginhtmx.TenantFromHost(map[string]string{"acme.example": "acme"}))
+
+	suite.Equal(`<a href="https://help.acme.example">Acme</a>`, suite.render(htmx, "acme.example", "", "support"))
+	suite.Equal(`<a href="https://help.example"></a>`, suite.render(htmx, "other.example", "", "support"))
+
+	overridden := htmx.With(ginhtmx.Funcs(template.FuncMap{"supportURL": func() string { return "/help" }}))
+	suite.Equal(`<a href="/help">Acme</a>`, suite.render(overridden, "acme.example", "", "support"))
+
+	recorder := httptest.NewRecorder()
+	testContext, _ := gin.CreateTestContext(recorder)
+	testContext.Request = httptest.NewRequest(http.MethodGet, "/", nil)
+	testContext.Request.Host = "acme.example"
+	testContext.Request.Header.Set("Hx-Request", "true")
+
+	data := gin.H{"Brand": "Acme Outlet"}
+	htmx.Render(testContext, data, "support")
+
+	suite.Equal(`<a href="https://help.acme.example">Acme Outlet</a>`, recorder.Body.String())
+	suite.Equal(gin.H{"Brand": "Acme Outlet"}, data)
+}
+
+func (suite *TenantTestSuite) render(htmx *ginhtmx.Htmx, host string, theme string, templateNames ...string) string {
+	recorder := httptest.NewRecorder()
+	testContext, _ := gin.CreateTestContext(recorder)
+	testContext.Request = httptest.NewRequest(http.MethodGet, "/?theme="+theme, nil)
+	testContext.Request.Host = host
+	testContext.Request.Header.Set("Hx-Request", "true")
+	testContext.Set("customer", host)
+
+	htmx.Render(testContext, gin.H{}, templateNames...)
+
+	return recorder.Body.String()
+}
+
+func (suite *TenantTestSuite) newHtmx(resolver func(*gin.Context) string) *ginhtmx.Htmx {
+	tmpl := template.Must(template.New("").Funcs(ginhtmx.FuncMap()).Funcs(template.FuncMap{
+		"supportURL": func() string { return "https://help.example" },
+	}).Parse(`
+{{- define "header"}}<header class="{{ tenant }}">Shared</header>{{end -}}
+{{- define "acme/header"}}<header class="{{ tenant }}">Acme</header>{{end -}}
+{{- define "acme/dark/header"}}<header class="{{ tenant }}">Acme dark</header>{{end -}}
+{{- define "footer"}}<footer>Shared footer</footer>{{end -}}
+{{- define "dark/footer"}}<footer>Dark footer</footer>{{end -}}
+{{- define "support"}}<a href="{{ supportURL }}">{{ .Brand }}</a>{{end -}}
+`))
+
+	return ginhtmx.NewHtmxWithConfig(tmpl, ginhtmx.HtmxConfig{
+		LayoutTemplateName:  "layout",
+		ContentVariableName: "Content",
+		TenantResolver:      resolver,
+		Tenants: map[string]ginhtmx.Tenant{
+			"acme": {
+				Funcs: template.FuncMap{"supportURL": func() string { return "https://help.acme.example" }},
+				Data:  gin.H{"Brand": "Acme"},
+			},
+		},
+		ThemeResolver: func(ginContext *gin.Context) string {
+			return ginContext.Query("theme")
+		},
+	})
+}
+
+func TestTenantTestSuite(t *testing.T) {
+	t.Parallel()
+	suite.Run(t, new(TenantTestSuite))
+}
+
+type TenantTestSuite struct {
+	suite.Suite
+}
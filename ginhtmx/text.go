@@ -0,0 +1,93 @@
+package ginhtmx
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Text returns a RenderOption which renders the named templates from the
+// TextTemplates of the configuration, with text/template and so without HTML
+// escaping, and writes the result with the specified content type. It is intended
+// for endpoints which return plain text, calendars or CSV through the same Htmx
+// instance, model decorators and model providers as the rest of the application:
+//
+//	handler.htmx.With(ginhtmx.Text("text/calendar; charset=utf-8")).Render(c, data, "event.ics")
+//
+// The layout is never rendered, the response is never negotiated as JSON and the
+// templates are not subject to aliases, experiments, themes, tenants or template
+// sets.
+func Text(contentType string) RenderOption {
+	return func(settings *renderSettings) {
+		settings.textContentType = contentType
+	}
+}
+
+// renderText renders the named text templates and writes the result to the
+// response with contentType, returning the error of the render.
+func (htmx *Htmx) renderText(
+	ctx context.Context, ginContext *gin.Context, status int, contentType string, data gin.H, templateNames []string,
+) error {
+	var body bytes.Buffer
+
+	var errs []error
+
+	for _, name := range templateNames {
+		errs = append(errs, htmx.executeTextTemplate(ctx, &body, name, data))
+
+		if ctx.Err() != nil {
+			break
+		}
+	}
+
+	renderErr := errors.Join(errs...)
+
+	switch {
+	case ctx.Err() != nil:
+		return abortRender(ginContext, ctx.Err(), renderErr)
+	case htmx.config.Strict && renderErr != nil:
+		writeStrictError(ginContext, renderErr)
+
+		return renderErr
+	default:
+		return errors.Join(renderErr, htmx.writeResponse(ginContext, status, contentType, false, body.Bytes()))
+	}
+}
+
+// executeTextTemplate renders the named text template to writer and records the
+// usage and metrics of the execution.
+func (htmx *Htmx) executeTextTemplate(ctx context.Context, writer io.Writer, name string, data any) error {
+	start := time.Now()
+	counter := &countingWriter{writer: &contextWriter{ctx: ctx, writer: writer}, bytes: 0}
+	tmpl := htmx.config.TextTemplates
+
+	var err error
+
+	switch {
+	case ctx.Err() != nil:
+		err = fmt.Errorf("rendering %q: %w", name, ctx.Err())
+	case tmpl == nil || tmpl.Lookup(name) == nil:
+		err = fmt.Errorf("%w: %q", ErrTemplateNotFound, name)
+	default:
+		err = executeRecovering(counter, tmpl, name, data)
+	}
+
+	htmx.usage.record(name, err)
+
+	if htmx.config.Metrics != nil {
+		htmx.config.Metrics.ObserveTemplate(TemplateObservation{
+			Template: name,
+			Fragment: true,
+			Bytes:    counter.bytes,
+			Duration: time.Since(start),
+			Err:      err,
+		})
+	}
+
+	return err
+}
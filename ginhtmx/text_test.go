@@ -0,0 +1,127 @@
+package ginhtmx_test
+
+import (
+	"context"
+	"html/template"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	texttemplate "text/template"
+
+	"github.com/gin-gonic/gin"
+	"github.com/jeffscottbrown/ginhtmxtemplates/ginhtmx"
+	"github.com/stretchr/testify/suite"
+)
+
+func (suite *TextTestSuite) TestTextTemplatesAreNotEscaped() {
+	htmx := suite.htmx.With(ginhtmx.Text("text/csv; charset=utf-8"))
+
+	recorder, testContext := suite.render(htmx, context.Background(), gin.H{"Name": `<Ada> & "Bob"`}, "row.csv")
+
+	suite.Equal(http.StatusOK, recorder.Code)
+	suite.Equal("text/csv; charset=utf-8", recorder.Header().Get("Content-Type"))
+	suite.Equal(`name,<Ada> & "Bob",decorated`+"\n", recorder.Body.String())
+	suite.Nil(testContext.Value(ginhtmx.RenderErrorKey))
+}
+
+func (suite *TextTestSuite) TestTextTemplatesAreConcatenatedWithoutTheLayout() {
+	htmx := suite.htmx.With(ginhtmx.Text("text/calendar"))
+
+	recorder, _ := suite.render(htmx, context.Background(), gin.H{"Name": "Ada"}, "header.ics", "row.csv")
+
+	suite.Equal("BEGIN:VCALENDAR\nname,Ada,decorated\n", recorder.Body.String())
+	suite.Equal("text/calendar", recorder.Header().Get("Content-Type"))
+}
+
+func (suite *TextTestSuite) TestMissingTextTemplatesAreReported() {
+	recorder, testContext := suite.render(
+		suite.htmx.With(ginhtmx.Text("text/plain")), context.Background(), gin.H{}, "page")
+
+	suite.Empty(recorder.Body.String())
+
+	renderErr, _ := testContext.Value(ginhtmx.RenderErrorKey).(error)
+	suite.Require().EqualError(renderErr, `template not found: "page"`)
+
+	htmx := ginhtmx.NewHtmx(template.Must(template.New("").Parse(`{{define "layout"}}{{end}}`)))
+	_, testContext = suite.render(htmx.With(ginhtmx.Text("text/plain")), context.Background(), gin.H{}, "page")
+
+	renderErr, _ = testContext.Value(ginhtmx.RenderErrorKey).(error)
+	suite.Require().ErrorIs(renderErr, ginhtmx.ErrTemplateNotFound)
+}
+
+func (suite *TextTestSuite) TestStrictTextRendersFail() {
+	htmx := ginhtmx.NewHtmxWithConfig(template.Must(template.New("").Parse(`{{define "layout"}}{{end}}`)),
+		ginhtmx.HtmxConfig{
+			LayoutTemplateName:  "layout",
+			ContentVariableName: "Content",
+			Strict:              true,
+			TextTemplates:       texttemplate.Must(texttemplate.New("").Parse(`{{define "name"}}{{.Name}}{{end}}`)),
+		})
+
+	recorder, _ := suite.render(htmx.With(ginhtmx.Text("text/plain")), context.Background(), gin.H{}, "name")
+
+	suite.Equal(http.StatusInternalServerError, recorder.Code)
+	suite.Contains(recorder.Body.String(), `map has no entry for key "Name"`)
+}
+
+func (suite *TextTestSuite) TestCanceledTextRendersAreAborted() {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	recorder, testContext := suite.render(
+		suite.htmx.With(ginhtmx.Text("text/plain")), ctx, gin.H{}, "header.ics", "row.csv")
+
+	suite.Empty(recorder.Body.String())
+	suite.True(testContext.IsAborted())
+
+	renderErr, _ := testContext.Value(ginhtmx.RenderErrorKey).(error)
+	suite.Require().ErrorIs(renderErr, context.Canceled)
+	suite.NotContains(renderErr.Error(), "row.csv")
+}
+
+func (suite *TextTestSuite) render(
+	htmx *ginhtmx.Htmx, ctx context.Context, data gin.H, templateNames ...string,
+) (*httptest.ResponseRecorder, *gin.Context) {
+	recorder := httptest.NewRecorder()
+	testContext, _ := gin.CreateTestContext(recorder)
+	testContext.Request = httptest.NewRequestWithContext(ctx, http.MethodGet, "/", nil)
+	testContext.Request.Header.Set("Accept", "application/json")
+
+	htmx.Render(testContext, data, templateNames...)
+
+	return recorder, testContext
+}
+
+func (suite *TextTestSuite) SetupTest() {
+	tmpl := template.Must(template.New("").Parse(`
+{{define "layout"}}<html>{{.Content}}</html>{{end}}
+{{define "page"}}<p>Page</p>{{end}}
+`))
+	text := texttemplate.Must(texttemplate.New("").Parse(`
+{{- define "header.ics"}}BEGIN:VCALENDAR
+{{end -}}
+{{define "row.csv"}}name,{{.Name}},{{.Decorated}}
+{{end -}}
+`))
+
+	suite.htmx = ginhtmx.NewHtmxWithConfig(tmpl, ginhtmx.HtmxConfig{
+		LayoutTemplateName:  "layout",
+		ContentVariableName: "Content",
+		NegotiateJSON:       true,
+		TextTemplates:       text,
+		ModelDecorator: ginhtmx.ModelDecoratorFunc(func(_ *gin.Context, data *gin.H) {
+			(*data)["Decorated"] = "decorated"
+		}),
+	})
+}
+
+func TestTextTestSuite(t *testing.T) {
+	t.Parallel()
+	suite.Run(t, new(TextTestSuite))
+}
+
+type TextTestSuite struct {
+	suite.Suite
+
+	htmx *ginhtmx.Htmx
+}
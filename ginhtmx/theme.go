@@ -0,0 +1,94 @@
+package ginhtmx
+
+import (
+	"context"
+	"html/template"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ThemeKey is the gin context key holding the theme of the current request as a
+// string, as resolved by the ThemeResolver of the HtmxConfig.
+const ThemeKey = "ginhtmx.theme"
+
+// templatePrefixesKey is the context key of the prefixes of the template variants
+// preferred by a render.
+type templatePrefixesKey struct{}
+
+// withTheme returns a context in which templates are resolved for the theme of the
+// request, if a ThemeResolver is configured and returns a theme.
+func (htmx *Htmx) withTheme(ctx context.Context, ginContext *gin.Context) context.Context {
+	if htmx.config.ThemeResolver == nil {
+		return ctx
+	}
+
+	theme := htmx.config.ThemeResolver(ginContext)
+	if theme == "" {
+		return ctx
+	}
+
+	ginContext.Set(ThemeKey, theme)
+
+	return withTemplatePrefixes(ctx, theme+"/")
+}
+
+// withTemplatePrefixes returns a context in which templates named with one of the
+// prefixes are preferred, in order, over templates without them.
+func withTemplatePrefixes(ctx context.Context, prefixes ...string) context.Context {
+	existing, _ := ctx.Value(templatePrefixesKey{}).([]string)
+
+	return context.WithValue(ctx, templatePrefixesKey{}, append(prefixes, existing...))
+}
+
+// resolveTemplateName returns the name of the variant of the named template which
+// is preferred by ctx, such as "dark/card" for "card" when the theme of the request
+// is "dark", or name itself if no variant is defined. The template of an experiment
+// variant assigned to the render, and then the target of an alias, replace the
+// named template before the prefixes are considered.
+func (htmx *Htmx) resolveTemplateName(ctx context.Context, tmpl *template.Template, name string) string {
+	_, name = htmx.unthemedTemplateName(ctx, name)
+
+	prefixes, _ := ctx.Value(templatePrefixesKey{}).([]string)
+
+	for _, prefix := range prefixes {
+		if htmx.lookupTemplate(tmpl, prefix+name) != nil {
+			return prefix + name
+		}
+	}
+
+	return name
+}
+
+// unthemedTemplateName returns the template of the experiment variant of the named
+// template assigned to the render, or name itself, and the target of that template
+// if it is an alias.
+func (htmx *Htmx) unthemedTemplateName(ctx context.Context, name string) (string, string) {
+	variants, _ := ctx.Value(templateVariantsKey{}).(map[string]string)
+	if variant, ok := variants[name]; ok {
+		name = variant
+	}
+
+	return name, htmx.templates.alias(name)
+}
+
+// resolveTemplateNames resolves each of the names with resolveTemplateName.
+func (htmx *Htmx) resolveTemplateNames(ctx context.Context, tmpl *template.Template, names []string) []string {
+	resolved := make([]string, len(names))
+	for index, name := range names {
+		resolved[index] = htmx.resolveTemplateName(ctx, tmpl, name)
+	}
+
+	return resolved
+}
+
+// theme returns the theme of the request being rendered, or an empty string if it
+// has none, so that layouts can refer to it:
+//
+//	<html data-theme="{{ theme }}">
+func (scope *requestScope) theme() string {
+	if scope.ginContext == nil {
+		return ""
+	}
+
+	return scope.ginContext.GetString(ThemeKey)
+}
@@ -0,0 +1,89 @@
+package ginhtmx_test
+
+import (
+	"html/template"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/jeffscottbrown/ginhtmxtemplates/ginhtmx"
+	"github.com/stretchr/testify/suite"
+)
+
+func (suite *ThemeTestSuite) TestThemedTemplatesArePreferred() {
+	suite.Equal(`<div class="dark">Dark card</div>`, suite.render("dark", true, "page"))
+	suite.Equal(`<div class="light">Card</div>`, suite.render("light", true, "page"))
+	suite.Equal(`<div class="">Card</div>`, suite.render("", true, "page"))
+}
+
+func (suite *ThemeTestSuite) TestThemedLayoutsArePreferred() {
+	suite.Equal(`<html data-theme="dark" class="dark"><div class="dark">Dark card</div></html>`,
+		suite.render("dark", false, "page"))
+	suite.Equal(`<html data-theme="light"><div class="light">Card</div></html>`, suite.render("light", false, "page"))
+}
+
+func (suite *ThemeTestSuite) TestCachedOutputIsKeptPerTheme() {
+	htmx := suite.htmx.With(ginhtmx.CacheFor(time.Minute, ""))
+
+	suite.Equal(`Dark card`, suite.renderWith(htmx, "dark", true, "card"))
+	suite.Equal(`Card`, suite.renderWith(htmx, "light", true, "card"))
+	suite.Equal(`Dark card`, suite.renderWith(htmx, "dark", true, "card"))
+}
+
+func (suite *ThemeTestSuite) TestThemeFunctionOutsideOfRequests() {
+	var output strings.Builder
+
+	err := template.Must(template.New("").Funcs(ginhtmx.FuncMap()).Parse(`[{{ theme }}]`)).Execute(&output, nil)
+	suite.Require().NoError(err)
+	suite.Equal("[]", output.String())
+}
+
+func (suite *ThemeTestSuite) render(theme string, fragment bool, templateNames ...string) string {
+	return suite.renderWith(suite.htmx, theme, fragment, templateNames...)
+}
+
+func (suite *ThemeTestSuite) renderWith(htmx *ginhtmx.Htmx, theme string, fragment bool, templateNames ...string) string {
+	recorder := httptest.NewRecorder()
+	testContext, _ := gin.CreateTestContext(recorder)
+	testContext.Request = httptest.NewRequest(http.MethodGet, "/?theme="+theme, nil)
+
+	if fragment {
+		testContext.Request.Header.Set("Hx-Request", "true")
+	}
+
+	htmx.Render(testContext, gin.H{}, templateNames...)
+
+	return recorder.Body.String()
+}
+
+func (suite *ThemeTestSuite) SetupTest() {
+	tmpl := template.Must(template.New("").Funcs(ginhtmx.FuncMap()).Parse(`
+{{- define "layout"}}<html data-theme="{{ theme }}">{{ .Content }}</html>{{end -}}
+{{- define "dark/layout"}}<html data-theme="{{ theme }}" class="dark">{{ .Content }}</html>{{end -}}
+{{- define "page"}}<div class="{{ theme }}">{{ partial "card" . }}</div>{{end -}}
+{{- define "card"}}Card{{end -}}
+{{- define "dark/card"}}Dark card{{end -}}
+`))
+	suite.htmx = ginhtmx.NewHtmxWithConfig(tmpl, ginhtmx.HtmxConfig{
+		LayoutTemplateName:  "layout",
+		ContentVariableName: "Content",
+		Cache:               ginhtmx.NewMemoryCache(),
+		ThemeResolver: func(ginContext *gin.Context) string {
+			return ginContext.Query("theme")
+		},
+	})
+}
+
+func TestThemeTestSuite(t *testing.T) {
+	t.Parallel()
+	suite.Run(t, new(ThemeTestSuite))
+}
+
+type ThemeTestSuite struct {
+	suite.Suite
+
+	htmx *ginhtmx.Htmx
+}
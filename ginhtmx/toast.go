@@ -0,0 +1,85 @@
+package ginhtmx
+
+import "github.com/gin-gonic/gin"
+
+const (
+	// ToastInfo is the level of informational toasts.
+	ToastInfo = "info"
+
+	// ToastSuccess is the level of toasts reporting success.
+	ToastSuccess = "success"
+
+	// ToastWarning is the level of warning toasts.
+	ToastWarning = "warning"
+
+	// ToastError is the level of toasts reporting errors.
+	ToastError = "error"
+
+	// DefaultToastContainer is the selector of the element toasts are added to unless configured otherwise.
+	DefaultToastContainer = "#toasts"
+
+	// ToastsModelKey is the key under which the toasts of full page requests are exposed to templates.
+	ToastsModelKey = "Toasts"
+
+	// toastTemplate is the name of the built-in template which renders a toast.
+	toastTemplate = builtinTemplatePrefix + "toast"
+
+	// toastsKey is the key under which pending toasts are stored in the gin context.
+	toastsKey = "ginhtmx.toasts"
+)
+
+// Toast is a notification shown to the user.
+type Toast struct {
+	// Level is the level of the toast, such as ToastSuccess
+	Level string
+
+	// Message is the text of the toast
+	Message string
+
+	// Container is the selector of the element the toast is added to
+	Container string
+}
+
+// Toast adds a toast to the next response rendered for the request. For HTMX
+// requests each toast is appended to the rendered templates as an out of band swap
+// which adds it to the ToastContainer of the configuration, "#toasts" by default,
+// using the built-in "ginhtmx/toast" template, which may be overridden. For other
+// requests the toasts are exposed to templates as .Toasts so that the layout can
+// render them in its container:
+//
+//	handler.htmx.Toast(c, ginhtmx.ToastSuccess, "User saved")
+//	handler.htmx.Render(c, gin.H{"User": user}, "user")
+//
+// Toasts are not rendered by responses which have no body, such as NoContent.
+func (htmx *Htmx) Toast(ginContext *gin.Context, level string, message string) {
+	container := htmx.config.ToastContainer
+	if container == "" {
+		container = DefaultToastContainer
+	}
+
+	toasts, _ := ginContext.Value(toastsKey).([]Toast)
+	ginContext.Set(toastsKey, append(toasts, Toast{Level: level, Message: message, Container: container}))
+}
+
+// applyToasts renders the pending toasts of the request as out of band swaps of a
+// fragment or exposes them to the templates of a full page.
+func applyToasts(ginContext *gin.Context, settings *renderSettings, data gin.H, fragment bool) {
+	toasts, _ := ginContext.Value(toastsKey).([]Toast)
+	if len(toasts) == 0 {
+		return
+	}
+
+	ginContext.Set(toastsKey, []Toast(nil))
+
+	if !fragment {
+		if _, exists := data[ToastsModelKey]; !exists {
+			data[ToastsModelKey] = toasts
+		}
+
+		return
+	}
+
+	for _, toast := range toasts {
+		settings.appended = append(settings.appended, appendedTemplate{name: toastTemplate, data: toast})
+	}
+}
@@ -0,0 +1,94 @@
+package ginhtmx_test
+
+import (
+	"html/template"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/jeffscottbrown/ginhtmxtemplates/ginhtmx"
+	"github.com/stretchr/testify/suite"
+)
+
+func (suite *ToastTestSuite) TestToastsAreAppendedAsOutOfBandSwaps() {
+	recorder, testContext := suite.newContext(true)
+	htmx := ginhtmx.NewHtmx(suite.template)
+
+	htmx.Toast(testContext, ginhtmx.ToastSuccess, "User saved")
+	htmx.Toast(testContext, ginhtmx.ToastWarning, "Email <unverified>")
+	htmx.Render(testContext, gin.H{}, "user")
+
+	suite.Equal(`<p>user</p>`+
+		`<div hx-swap-oob="beforeend:#toasts"><div class="toast toast-success" role="status">User saved</div></div>`+
+		`<div hx-swap-oob="beforeend:#toasts"><div class="toast toast-warning" role="status">Email &lt;unverified&gt;</div></div>`,
+		recorder.Body.String())
+}
+
+func (suite *ToastTestSuite) TestToastsAreRenderedOnlyOnce() {
+	_, testContext := suite.newContext(true)
+	htmx := ginhtmx.NewHtmx(suite.template)
+
+	htmx.Toast(testContext, ginhtmx.ToastInfo, "Hello")
+	htmx.Render(testContext, gin.H{}, "user")
+
+	recorder := httptest.NewRecorder()
+	testContext.Writer = gin.CreateTestContextOnly(recorder, gin.New()).Writer
+	htmx.Render(testContext, gin.H{}, "user")
+
+	suite.Equal(`<p>user</p>`, recorder.Body.String())
+}
+
+func (suite *ToastTestSuite) TestToastContainerIsConfigurable() {
+	recorder, testContext := suite.newContext(true)
+	htmx := ginhtmx.NewHtmxWithConfig(suite.template, ginhtmx.HtmxConfig{
+		LayoutTemplateName:  "layout",
+		ContentVariableName: "Content",
+		ToastContainer:      "#notifications",
+	})
+
+	htmx.Toast(testContext, ginhtmx.ToastError, "Failed")
+	htmx.Render(testContext, gin.H{}, "user")
+
+	suite.Contains(recorder.Body.String(), `hx-swap-oob="beforeend:#notifications"`)
+}
+
+func (suite *ToastTestSuite) TestToastsAreExposedToFullPages() {
+	recorder, testContext := suite.newContext(false)
+	htmx := ginhtmx.NewHtmx(suite.template)
+
+	htmx.Toast(testContext, ginhtmx.ToastInfo, "Welcome back")
+	htmx.Render(testContext, gin.H{}, "user")
+
+	suite.Equal(`<html><div id="toasts"><div class="info">Welcome back</div></div><p>user</p></html>`, recorder.Body.String())
+}
+
+func (suite *ToastTestSuite) newContext(htmxRequest bool) (*httptest.ResponseRecorder, *gin.Context) {
+	recorder := httptest.NewRecorder()
+	testContext, _ := gin.CreateTestContext(recorder)
+	testContext.Request = httptest.NewRequest(http.MethodPost, "/users", nil)
+
+	if htmxRequest {
+		testContext.Request.Header.Set("Hx-Request", "true")
+	}
+
+	return recorder, testContext
+}
+
+func (suite *ToastTestSuite) SetupTest() {
+	suite.template = template.Must(template.New("").Parse(`
+{{- define "layout"}}<html><div id="toasts">{{range .Toasts}}<div class="{{.Level}}">{{.Message}}</div>{{end}}</div>{{.Content}}</html>{{end}}
+{{- define "user"}}<p>user</p>{{end}}
+`))
+}
+
+func TestToastTestSuite(t *testing.T) {
+	t.Parallel()
+	suite.Run(t, new(ToastTestSuite))
+}
+
+type ToastTestSuite struct {
+	suite.Suite
+
+	template *template.Template
+}
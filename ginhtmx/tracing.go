@@ -0,0 +1,83 @@
+package ginhtmx
+
+import (
+	"context"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+	"go.opentelemetry.io/otel/trace/noop"
+)
+
+// tracerName is the instrumentation scope name of the spans created by Htmx.
+const tracerName = "github.com/jeffscottbrown/ginhtmxtemplates/ginhtmx"
+
+// newTracer returns the tracer used for the spans of an Htmx instance. When no
+// provider is configured a no-op tracer is returned so that spans are never
+// recorded against the spans of the caller.
+func newTracer(provider trace.TracerProvider) trace.Tracer {
+	if provider == nil {
+		provider = noop.NewTracerProvider()
+	}
+
+	return provider.Tracer(tracerName)
+}
+
+// startRenderSpan starts the span which covers a whole call to RenderWithStatus.
+func (htmx *Htmx) startRenderSpan(ctx context.Context, templateNames []string, fragment bool) (context.Context, trace.Span) {
+	attributes := []attribute.KeyValue{
+		attribute.StringSlice("ginhtmx.templates", templateNames),
+		attribute.Bool("ginhtmx.layout", !fragment),
+	}
+
+	if !fragment {
+		attributes = append(attributes, attribute.String("ginhtmx.layout.name", htmx.config.LayoutTemplateName))
+	}
+
+	return htmx.tracer.Start(ctx, "ginhtmx.Render", trace.WithAttributes(attributes...))
+}
+
+// startRevalidateSpan starts the span which covers rendering cached output again
+// in the background, see StaleWhileRevalidate. It is the root of a trace of its own
+// which is linked to the span of the request rendered with requestCtx, as that
+// span has usually ended before the background render does.
+func (htmx *Htmx) startRevalidateSpan(
+	requestCtx context.Context, ctx context.Context, templateNames []string,
+) (context.Context, trace.Span) {
+	return htmx.tracer.Start(ctx, "ginhtmx.Revalidate",
+		trace.WithNewRoot(),
+		trace.WithLinks(trace.LinkFromContext(requestCtx)),
+		trace.WithAttributes(attribute.StringSlice("ginhtmx.templates", templateNames)))
+}
+
+// endRenderSpan records the outcome of a render on its span and ends it.
+func endRenderSpan(span trace.Span, observation RenderObservation) {
+	span.SetAttributes(
+		attribute.Int("ginhtmx.output.bytes", observation.Bytes),
+		attribute.Int("http.response.status_code", observation.Status),
+	)
+	endSpan(span, observation.Err)
+}
+
+// startTemplateSpan starts the span which covers the execution of a single template.
+func (htmx *Htmx) startTemplateSpan(ctx context.Context, name string) trace.Span {
+	_, span := htmx.tracer.Start(ctx, "ginhtmx.ExecuteTemplate",
+		trace.WithAttributes(attribute.String("ginhtmx.template", name)))
+
+	return span
+}
+
+// endTemplateSpan records the outcome of a template execution on its span and ends it.
+func endTemplateSpan(span trace.Span, bytes int, err error) {
+	span.SetAttributes(attribute.Int("ginhtmx.output.bytes", bytes))
+	endSpan(span, err)
+}
+
+func endSpan(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+
+	span.End()
+}
@@ -0,0 +1,146 @@
+package ginhtmx_test
+
+import (
+	"context"
+	"html/template"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/jeffscottbrown/ginhtmxtemplates/ginhtmx"
+	"github.com/stretchr/testify/suite"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+	"go.opentelemetry.io/otel/trace/noop"
+)
+
+func (suite *TracingTestSuite) TestSpansAreCreatedForRenderAndTemplates() {
+	provider := &recordingTracerProvider{}
+	htmx := suite.newHtmx(provider)
+
+	testContext, _ := gin.CreateTestContext(httptest.NewRecorder())
+	testContext.Request = httptest.NewRequest(http.MethodGet, "/", nil)
+
+	htmx.Render(testContext, gin.H{}, "hello")
+
+	suite.Require().Len(provider.spans, 3)
+	suite.Equal("ginhtmx.Render", provider.spans[0].name)
+	suite.Equal(attribute.StringSliceValue([]string{"hello"}), provider.spans[0].attributes["ginhtmx.templates"])
+	suite.Equal(attribute.BoolValue(true), provider.spans[0].attributes["ginhtmx.layout"])
+	suite.Equal(attribute.StringValue("layout"), provider.spans[0].attributes["ginhtmx.layout.name"])
+	suite.Equal(attribute.IntValue(http.StatusOK), provider.spans[0].attributes["http.response.status_code"])
+	suite.True(provider.spans[0].ended)
+
+	suite.Equal("ginhtmx.ExecuteTemplate", provider.spans[1].name)
+	suite.Equal(attribute.StringValue("hello"), provider.spans[1].attributes["ginhtmx.template"])
+	suite.Equal(attribute.IntValue(len("<p>Hello</p>")), provider.spans[1].attributes["ginhtmx.output.bytes"])
+	suite.True(provider.spans[1].ended)
+
+	suite.Equal(attribute.StringValue("layout"), provider.spans[2].attributes["ginhtmx.template"])
+}
+
+func (suite *TracingTestSuite) TestErrorsAreRecordedOnSpans() {
+	provider := &recordingTracerProvider{}
+	htmx := suite.newHtmx(provider)
+
+	testContext, _ := gin.CreateTestContext(httptest.NewRecorder())
+	testContext.Request = httptest.NewRequest(http.MethodGet, "/", nil)
+	testContext.Request.Header.Set("Hx-Request", "true")
+
+	htmx.Render(testContext, gin.H{}, "missing")
+
+	suite.Require().Len(provider.spans, 2)
+	suite.Equal(attribute.BoolValue(false), provider.spans[0].attributes["ginhtmx.layout"])
+	suite.NotContains(provider.spans[0].attributes, "ginhtmx.layout.name")
+	suite.Equal(codes.Error, provider.spans[0].status)
+	suite.Equal(codes.Error, provider.spans[1].status)
+	suite.Error(provider.spans[1].err)
+}
+
+func (suite *TracingTestSuite) newHtmx(provider trace.TracerProvider) *ginhtmx.Htmx {
+	tmpl := template.Must(template.New("").Parse(`
+{{define "layout"}}<html>{{.Content}}</html>{{end}}
+{{define "hello"}}<p>Hello</p>{{end}}
+`))
+
+	return ginhtmx.NewHtmxWithConfig(tmpl, ginhtmx.HtmxConfig{
+		LayoutTemplateName:  "layout",
+		ContentVariableName: "Content",
+		TracerProvider:      provider,
+	})
+}
+
+func TestTracingTestSuite(t *testing.T) {
+	t.Parallel()
+	suite.Run(t, new(TracingTestSuite))
+}
+
+type TracingTestSuite struct {
+	suite.Suite
+}
+
+// recordingTracerProvider records the spans started by its tracers.
+type recordingTracerProvider struct {
+	noop.TracerProvider
+
+	mutex sync.Mutex
+	spans []*recordingSpan
+}
+
+func (provider *recordingTracerProvider) Tracer(string, ...trace.TracerOption) trace.Tracer {
+	return &recordingTracer{Tracer: noop.Tracer{}, provider: provider}
+}
+
+type recordingTracer struct {
+	noop.Tracer
+
+	provider *recordingTracerProvider
+}
+
+func (tracer *recordingTracer) Start(
+	ctx context.Context, name string, options ...trace.SpanStartOption,
+) (context.Context, trace.Span) {
+	config := trace.NewSpanStartConfig(options...)
+	span := &recordingSpan{Span: noop.Span{}, name: name, attributes: map[attribute.Key]attribute.Value{}}
+	span.SetAttributes(config.Attributes()...)
+	span.newRoot = config.NewRoot()
+	span.links = len(config.Links())
+
+	if parent, ok := trace.SpanFromContext(ctx).(*recordingSpan); ok && !span.newRoot {
+		span.parent = parent
+	}
+
+	tracer.provider.mutex.Lock()
+	tracer.provider.spans = append(tracer.provider.spans, span)
+	tracer.provider.mutex.Unlock()
+
+	return trace.ContextWithSpan(ctx, span), span
+}
+
+type recordingSpan struct {
+	noop.Span
+
+	name       string
+	parent     *recordingSpan
+	newRoot    bool
+	links      int
+	attributes map[attribute.Key]attribute.Value
+	status     codes.Code
+	err        error
+	ended      bool
+}
+
+func (span *recordingSpan) SetAttributes(attributes ...attribute.KeyValue) {
+	for _, keyValue := range attributes {
+		span.attributes[keyValue.Key] = keyValue.Value
+	}
+}
+
+func (span *recordingSpan) SetStatus(code codes.Code, _ string) { span.status = code }
+
+func (span *recordingSpan) RecordError(err error, _ ...trace.EventOption) { span.err = err }
+
+func (span *recordingSpan) End(...trace.SpanEndOption) { span.ended = true }
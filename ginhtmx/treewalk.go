@@ -0,0 +1,91 @@
+package ginhtmx
+
+import (
+	"html/template"
+	"reflect"
+	"text/template/parse"
+)
+
+// walkTemplate calls visit for every node in the parse tree of tmpl.
+func walkTemplate(tmpl *template.Template, visit func(node parse.Node)) {
+	if tmpl == nil || tmpl.Tree == nil || tmpl.Tree.Root == nil {
+		return
+	}
+
+	walkNode(tmpl.Tree.Root, visit)
+}
+
+func walkNode(node parse.Node, visit func(node parse.Node)) {
+	// Optional children, such as the else branch of an if, are typed nil pointers.
+	if node == nil || reflect.ValueOf(node).IsNil() {
+		return
+	}
+
+	visit(node)
+
+	switch typed := node.(type) {
+	case *parse.ListNode:
+		for _, child := range typed.Nodes {
+			walkNode(child, visit)
+		}
+	case *parse.ActionNode:
+		walkNode(typed.Pipe, visit)
+	case *parse.IfNode:
+		walkBranch(&typed.BranchNode, visit)
+	case *parse.RangeNode:
+		walkBranch(&typed.BranchNode, visit)
+	case *parse.WithNode:
+		walkBranch(&typed.BranchNode, visit)
+	case *parse.TemplateNode:
+		walkNode(typed.Pipe, visit)
+	case *parse.PipeNode:
+		for _, command := range typed.Cmds {
+			walkNode(command, visit)
+		}
+	case *parse.CommandNode:
+		for _, argument := range typed.Args {
+			walkNode(argument, visit)
+		}
+	case *parse.ChainNode:
+		walkNode(typed.Node, visit)
+	}
+}
+
+func walkBranch(branch *parse.BranchNode, visit func(node parse.Node)) {
+	walkNode(branch.Pipe, visit)
+	walkNode(branch.List, visit)
+	walkNode(branch.ElseList, visit)
+}
+
+// referencesField reports whether the template named name, or any template it
+// invokes, refers to the top level field with the given name, either as .Field
+// or $.Field.
+func referencesField(tmpl *template.Template, name string, field string) bool {
+	visited := map[string]bool{}
+
+	var search func(name string) bool
+
+	search = func(name string) bool {
+		if visited[name] {
+			return false
+		}
+
+		visited[name] = true
+		found := false
+
+		walkTemplate(tmpl.Lookup(name), func(node parse.Node) {
+			switch typed := node.(type) {
+			case *parse.FieldNode:
+				found = found || len(typed.Ident) > 0 && typed.Ident[0] == field
+			case *parse.VariableNode:
+				found = found || len(typed.Ident) > 1 && typed.Ident[0] == "$" && typed.Ident[1] == field
+			case *parse.TemplateNode:
+				found = found || search(typed.Name)
+			}
+		})
+
+		return found
+	}
+
+	return search(name)
+}
@@ -0,0 +1,72 @@
+package ginhtmx
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// AddTrigger adds an event to the HX-Trigger header of the response, so that HTMX
+// triggers the event with detail, which may be nil, when the response is received.
+// Events which are already in the header, whether they were added by AddTrigger,
+// by the Trigger option or by setting the header directly, are preserved and the
+// header is rewritten as a single JSON object holding all of the events. Adding an
+// event which is already present replaces its detail. This allows model decorators,
+// middleware and handlers to each trigger their own events:
+//
+//	err := ginhtmx.AddTrigger(c, "toast", gin.H{"message": "Saved"})
+//
+// An error is returned if detail cannot be marshalled to JSON or the header is
+// already set to a value which is not valid, in which case the header is not changed.
+func AddTrigger(ginContext *gin.Context, event string, detail any) error {
+	header := ginContext.Writer.Header()
+
+	events, err := parseTriggers(header.Get("HX-Trigger"))
+	if err != nil {
+		return err
+	}
+
+	encoded, err := json.Marshal(detail)
+	if err != nil {
+		return fmt.Errorf("encoding the detail of the %q event: %w", event, err)
+	}
+
+	events[event] = encoded
+
+	value, err := json.Marshal(events)
+	if err != nil {
+		return fmt.Errorf("encoding the HX-Trigger header: %w", err)
+	}
+
+	header.Set("HX-Trigger", string(value))
+
+	return nil
+}
+
+// parseTriggers parses an HX-Trigger header, which is either a JSON object mapping
+// event names to details or a comma separated list of event names.
+func parseTriggers(value string) (map[string]json.RawMessage, error) {
+	events := map[string]json.RawMessage{}
+
+	value = strings.TrimSpace(value)
+
+	if strings.HasPrefix(value, "{") {
+		err := json.Unmarshal([]byte(value), &events)
+		if err != nil {
+			return nil, fmt.Errorf("parsing the HX-Trigger header: %w", err)
+		}
+
+		return events, nil
+	}
+
+	for event := range strings.SplitSeq(value, ",") {
+		event = strings.TrimSpace(event)
+		if event != "" {
+			events[event] = json.RawMessage("null")
+		}
+	}
+
+	return events, nil
+}
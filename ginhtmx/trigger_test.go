@@ -0,0 +1,81 @@
+package ginhtmx_test
+
+import (
+	"html/template"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/jeffscottbrown/ginhtmxtemplates/ginhtmx"
+	"github.com/stretchr/testify/suite"
+)
+
+func (suite *TriggerTestSuite) TestTriggersAreMerged() {
+	recorder, testContext := suite.newContext()
+
+	suite.Require().NoError(ginhtmx.AddTrigger(testContext, "toast", gin.H{"message": "Saved"}))
+	suite.Require().NoError(ginhtmx.AddTrigger(testContext, "refresh", nil))
+
+	suite.JSONEq(`{"toast": {"message": "Saved"}, "refresh": null}`, recorder.Header().Get("HX-Trigger"))
+}
+
+func (suite *TriggerTestSuite) TestHeadersSetDirectlyArePreserved() {
+	recorder, testContext := suite.newContext()
+	testContext.Header("HX-Trigger", "first, second")
+
+	suite.Require().NoError(ginhtmx.AddTrigger(testContext, "third", 3))
+	suite.JSONEq(`{"first": null, "second": null, "third": 3}`, recorder.Header().Get("HX-Trigger"))
+
+	testContext.Header("HX-Trigger", `{"first": "detail"}`)
+
+	suite.Require().NoError(ginhtmx.AddTrigger(testContext, "first", "replaced"))
+	suite.JSONEq(`{"first": "replaced"}`, recorder.Header().Get("HX-Trigger"))
+}
+
+func (suite *TriggerTestSuite) TestErrorsLeaveTheHeaderUnchanged() {
+	recorder, testContext := suite.newContext()
+	testContext.Header("HX-Trigger", `{"first"`)
+
+	suite.Require().Error(ginhtmx.AddTrigger(testContext, "second", nil))
+	suite.Equal(`{"first"`, recorder.Header().Get("HX-Trigger"))
+
+	testContext.Header("HX-Trigger", "first")
+
+	suite.Require().Error(ginhtmx.AddTrigger(testContext, "second", func() {}))
+	suite.Equal("first", recorder.Header().Get("HX-Trigger"))
+}
+
+func (suite *TriggerTestSuite) TestDecoratorAndOptionTriggersAreMerged() {
+	tmpl := template.Must(template.New("").Parse(`{{define "layout"}}{{.Content}}{{end}}{{define "row"}}row{{end}}`))
+	htmx := ginhtmx.NewHtmxWithConfig(tmpl, ginhtmx.HtmxConfig{
+		LayoutTemplateName:  "layout",
+		ContentVariableName: "Content",
+		ModelDecorator: ginhtmx.ModelDecoratorFunc(func(ginContext *gin.Context, _ *gin.H) {
+			_ = ginhtmx.AddTrigger(ginContext, "decorated", nil)
+		}),
+	})
+
+	recorder, testContext := suite.newContext()
+	htmx.With(ginhtmx.Trigger("saved")).Render(testContext, gin.H{}, "row")
+
+	suite.JSONEq(`{"saved": null, "decorated": null}`, recorder.Header().Get("HX-Trigger"))
+}
+
+func (suite *TriggerTestSuite) newContext() (*httptest.ResponseRecorder, *gin.Context) {
+	recorder := httptest.NewRecorder()
+	testContext, _ := gin.CreateTestContext(recorder)
+	testContext.Request = httptest.NewRequest(http.MethodGet, "/", nil)
+	testContext.Request.Header.Set("Hx-Request", "true")
+
+	return recorder, testContext
+}
+
+func TestTriggerTestSuite(t *testing.T) {
+	t.Parallel()
+	suite.Run(t, new(TriggerTestSuite))
+}
+
+type TriggerTestSuite struct {
+	suite.Suite
+}
@@ -0,0 +1,135 @@
+package ginhtmx
+
+import (
+	"context"
+	"html"
+	"html/template"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// TurboStreamMediaType is the media type of Turbo Stream responses, which Turbo
+// includes in the Accept header of form submissions.
+const TurboStreamMediaType = "text/vnd.turbo-stream.html"
+
+// TurboFrame returns the id of the Turbo frame which made the request, from the
+// Turbo-Frame request header, or an empty string if the request was not made by a
+// Turbo frame. Like HTMX requests, requests made by Turbo frames are rendered
+// without the layout.
+func TurboFrame(ginContext *gin.Context) string {
+	return ginContext.GetHeader("Turbo-Frame")
+}
+
+// WantsTurboStream reports whether the request accepts a Turbo Stream response.
+func WantsTurboStream(ginContext *gin.Context) bool {
+	return ginContext.NegotiateFormat(TurboStreamMediaType, "text/html") == TurboStreamMediaType
+}
+
+// TurboStreamAction is an action of a Turbo Stream response, which renders a
+// template and applies it to the element with the id Target. See RenderTurboStream.
+type TurboStreamAction struct {
+	// Action is the Turbo Stream action, such as "append" or "replace"
+	Action string
+
+	// Target is the id of the element the action applies to
+	Target string
+
+	// Template is the name of the template rendered for the action, empty for
+	// actions without content such as "remove"
+	Template string
+
+	// Data is the model of the template
+	Data any
+}
+
+// TurboAppend returns an action which appends the named template, rendered with
+// data, to the content of the element with the id target.
+func TurboAppend(target string, template string, data any) TurboStreamAction {
+	return TurboStreamAction{Action: "append", Target: target, Template: template, Data: data}
+}
+
+// TurboPrepend returns an action which prepends the named template, rendered with
+// data, to the content of the element with the id target.
+func TurboPrepend(target string, template string, data any) TurboStreamAction {
+	return TurboStreamAction{Action: "prepend", Target: target, Template: template, Data: data}
+}
+
+// TurboReplace returns an action which replaces the element with the id target
+// with the named template, rendered with data.
+func TurboReplace(target string, template string, data any) TurboStreamAction {
+	return TurboStreamAction{Action: "replace", Target: target, Template: template, Data: data}
+}
+
+// TurboUpdate returns an action which replaces the content of the element with the
+// id target with the named template, rendered with data.
+func TurboUpdate(target string, template string, data any) TurboStreamAction {
+	return TurboStreamAction{Action: "update", Target: target, Template: template, Data: data}
+}
+
+// TurboRemove returns an action which removes the element with the id target.
+func TurboRemove(target string) TurboStreamAction {
+	return TurboStreamAction{Action: "remove", Target: target, Template: "", Data: nil}
+}
+
+// RenderTurboStream renders actions as a Turbo Stream response, so that a single
+// response, usually to a form submission, can update several parts of the page:
+//
+//	if ginhtmx.WantsTurboStream(c) {
+//	  handler.htmx.RenderTurboStream(c,
+//	    ginhtmx.TurboAppend("messages", "message", message),
+//	    ginhtmx.TurboReplace("message-form", "message_form", gin.H{}),
+//	  )
+//	}
+//
+// The template of each action is rendered with its own data, in the same way as
+// the templates passed to Render, and the layout is never rendered.
+func (htmx *Htmx) RenderTurboStream(ginContext *gin.Context, actions ...TurboStreamAction) {
+	htmx.With(ForceFragment(), withTurboStream(actions)).RenderWithStatus(ginContext, gin.H{}, http.StatusOK)
+}
+
+// withTurboStream returns a RenderOption which renders actions after the templates.
+func withTurboStream(actions []TurboStreamAction) RenderOption {
+	return func(settings *renderSettings) {
+		settings.turboStream = append(settings.turboStream, actions...)
+	}
+}
+
+// renderTurboStream renders each of the actions as a turbo-stream element.
+func (htmx *Htmx) renderTurboStream(
+	ctx context.Context, tmpl *template.Template, actions []TurboStreamAction,
+) (string, []error) {
+	var content string
+
+	var errs []error
+
+	for _, action := range actions {
+		var rendered string
+
+		if action.Template != "" {
+			var err error
+
+			rendered, err = htmx.executeTemplate(ctx, tmpl, action.Template, action.Data, true)
+			errs = append(errs, err)
+		}
+
+		content += `<turbo-stream action="` + html.EscapeString(action.Action) +
+			`" target="` + html.EscapeString(action.Target) + `"><template>` + rendered + `</template></turbo-stream>`
+	}
+
+	return content, errs
+}
+
+// contentType returns the value of the Content-Type header of the response of a
+// render with settings.
+func (settings *renderSettings) contentType(htmx *Htmx) string {
+	if settings.textContentType != "" {
+		return settings.textContentType
+	}
+
+	if len(settings.turboStream) > 0 {
+		return TurboStreamMediaType + "; charset=utf-8"
+	}
+
+	return htmx.contentType()
+}
@@ -0,0 +1,100 @@
+package ginhtmx_test
+
+import (
+	"html/template"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/jeffscottbrown/ginhtmxtemplates/ginhtmx"
+	"github.com/stretchr/testify/suite"
+)
+
+func (suite *TurboTestSuite) TestTurboFrameRequestsAreRenderedWithoutTheLayout() {
+	recorder, testContext := suite.newContext()
+	testContext.Request.Header.Set("Turbo-Frame", "messages")
+
+	suite.htmx.Render(testContext, gin.H{"Text": "Hi"}, "message")
+
+	suite.Equal(`<li>Hi</li>`, recorder.Body.String())
+	suite.Equal("messages", ginhtmx.TurboFrame(testContext))
+
+	recorder, testContext = suite.newContext()
+	suite.htmx.Render(testContext, gin.H{"Text": "Hi"}, "message")
+
+	suite.Equal(`<html><li>Hi</li></html>`, recorder.Body.String())
+	suite.Empty(ginhtmx.TurboFrame(testContext))
+}
+
+func (suite *TurboTestSuite) TestTurboStreamsRenderEachAction() {
+	recorder, testContext := suite.newContext()
+
+	suite.htmx.RenderTurboStream(testContext,
+		ginhtmx.TurboAppend("messages", "message", gin.H{"Text": "<b>"}),
+		ginhtmx.TurboPrepend("messages", "message", gin.H{"Text": "first"}),
+		ginhtmx.TurboReplace("form", "form", nil),
+		ginhtmx.TurboUpdate("count", "count", 3),
+		ginhtmx.TurboRemove(`notice"`),
+	)
+
+	suite.Equal(http.StatusOK, recorder.Code)
+	suite.Equal("text/vnd.turbo-stream.html; charset=utf-8", recorder.Header().Get("Content-Type"))
+	suite.Equal(`<turbo-stream action="append" target="messages"><template><li>&lt;b&gt;</li></template></turbo-stream>`+
+		`<turbo-stream action="prepend" target="messages"><template><li>first</li></template></turbo-stream>`+
+		`<turbo-stream action="replace" target="form"><template><form></form></template></turbo-stream>`+
+		`<turbo-stream action="update" target="count"><template>3</template></turbo-stream>`+
+		`<turbo-stream action="remove" target="notice&#34;"><template></template></turbo-stream>`,
+		recorder.Body.String())
+}
+
+func (suite *TurboTestSuite) TestTurboStreamErrorsAreRecorded() {
+	_, testContext := suite.newContext()
+
+	suite.htmx.RenderTurboStream(testContext, ginhtmx.TurboAppend("messages", "missing", nil))
+
+	renderErr, _ := testContext.Value(ginhtmx.RenderErrorKey).(error)
+	suite.Require().ErrorIs(renderErr, ginhtmx.ErrTemplateNotFound)
+}
+
+func (suite *TurboTestSuite) TestTurboStreamsAreNegotiated() {
+	_, testContext := suite.newContext()
+	testContext.Request.Header.Set("Accept", "text/vnd.turbo-stream.html, text/html, application/xhtml+xml")
+	suite.True(ginhtmx.WantsTurboStream(testContext))
+
+	_, testContext = suite.newContext()
+	testContext.Request.Header.Set("Accept", "text/html, application/xhtml+xml")
+	suite.False(ginhtmx.WantsTurboStream(testContext))
+}
+
+func (suite *TurboTestSuite) newContext() (*httptest.ResponseRecorder, *gin.Context) {
+	recorder := httptest.NewRecorder()
+	testContext, _ := gin.CreateTestContext(recorder)
+	testContext.Request = httptest.NewRequest(http.MethodPost, "/messages", nil)
+
+	return recorder, testContext
+}
+
+func (suite *TurboTestSuite) SetupTest() {
+	tmpl := template.Must(template.New("").Parse(`
+{{define "layout"}}<html>{{.Content}}</html>{{end}}
+{{define "message"}}<li>{{.Text}}</li>{{end}}
+{{define "form"}}<form></form>{{end}}
+{{define "count"}}{{.}}{{end}}
+`))
+	suite.htmx = ginhtmx.NewHtmxWithConfig(tmpl, ginhtmx.HtmxConfig{
+		LayoutTemplateName:  "layout",
+		ContentVariableName: "Content",
+	})
+}
+
+func TestTurboTestSuite(t *testing.T) {
+	t.Parallel()
+	suite.Run(t, new(TurboTestSuite))
+}
+
+type TurboTestSuite struct {
+	suite.Suite
+
+	htmx *ginhtmx.Htmx
+}
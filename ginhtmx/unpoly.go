@@ -0,0 +1,81 @@
+package ginhtmx
+
+import (
+	"encoding/json"
+	"fmt"
+	"maps"
+
+	"github.com/gin-gonic/gin"
+)
+
+// IsUnpolyRequest reports whether the request was made by Unpoly, which sends its
+// version in the X-Up-Version request header.
+func IsUnpolyRequest(ginContext *gin.Context) bool {
+	return ginContext.GetHeader("X-Up-Version") != ""
+}
+
+// UnpolyTarget returns the selector of the fragment Unpoly is updating, from the
+// X-Up-Target request header, or an empty string if the request is not a fragment
+// update. Like HTMX requests, fragment updates are rendered without the layout.
+func UnpolyTarget(ginContext *gin.Context) string {
+	return ginContext.GetHeader("X-Up-Target")
+}
+
+// UpTarget returns a RenderOption which sets the X-Up-Target response header, so
+// that Unpoly updates the fragment matching selector instead of the target of the
+// request.
+func UpTarget(selector string) RenderOption {
+	return func(settings *renderSettings) {
+		settings.headers.Set("X-Up-Target", selector)
+	}
+}
+
+// UpEvents returns a RenderOption which adds the events, without properties, to the
+// X-Up-Events response header using AddUpEvent, so that Unpoly emits them when the
+// response is received.
+func UpEvents(events ...string) RenderOption {
+	return func(settings *renderSettings) {
+		settings.upEvents = append(settings.upEvents, events...)
+	}
+}
+
+// AddUpEvent adds an event of the specified type with props to the X-Up-Events
+// header of the response, so that Unpoly emits the event when the response is
+// received. The events already in the header are preserved:
+//
+//	err := ginhtmx.AddUpEvent(c, "user:created", gin.H{"id": user.ID})
+//
+// An error is returned if props cannot be marshalled to JSON or the header is
+// already set to a value which is not a JSON array, in which case the header is
+// not changed.
+func AddUpEvent(ginContext *gin.Context, eventType string, props gin.H) error {
+	header := ginContext.Writer.Header()
+
+	var events []json.RawMessage
+
+	if value := header.Get("X-Up-Events"); value != "" {
+		err := json.Unmarshal([]byte(value), &events)
+		if err != nil {
+			return fmt.Errorf("parsing the X-Up-Events header: %w", err)
+		}
+	}
+
+	event := maps.Clone(props)
+	if event == nil {
+		event = gin.H{}
+	}
+
+	event["type"] = eventType
+
+	encoded, err := json.Marshal(event)
+	if err != nil {
+		return fmt.Errorf("encoding the %q event: %w", eventType, err)
+	}
+
+	// the events are raw messages which were valid JSON, so encoding cannot fail
+	value, _ := json.Marshal(append(events, encoded))
+
+	header.Set("X-Up-Events", string(value))
+
+	return nil
+}
@@ -0,0 +1,88 @@
+package ginhtmx_test
+
+import (
+	"html/template"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/jeffscottbrown/ginhtmxtemplates/ginhtmx"
+	"github.com/stretchr/testify/suite"
+)
+
+func (suite *UnpolyTestSuite) TestFragmentUpdatesAreRenderedWithoutTheLayout() {
+	recorder, testContext := suite.newContext()
+	testContext.Request.Header.Set("X-Up-Version", "3.11.0")
+	testContext.Request.Header.Set("X-Up-Target", ".users")
+
+	suite.htmx.Render(testContext, gin.H{}, "users")
+
+	suite.Equal(`<ul class="users"></ul>`, recorder.Body.String())
+	suite.True(ginhtmx.IsUnpolyRequest(testContext))
+	suite.Equal(".users", ginhtmx.UnpolyTarget(testContext))
+
+	recorder, testContext = suite.newContext()
+	testContext.Request.Header.Set("X-Up-Version", "3.11.0")
+
+	suite.htmx.Render(testContext, gin.H{}, "users")
+
+	suite.Equal(`<html><ul class="users"></ul></html>`, recorder.Body.String())
+	suite.True(ginhtmx.IsUnpolyRequest(testContext))
+	suite.Empty(ginhtmx.UnpolyTarget(testContext))
+}
+
+func (suite *UnpolyTestSuite) TestResponseHeadersAreSet() {
+	recorder, testContext := suite.newContext()
+	testContext.Request.Header.Set("X-Up-Target", ".users")
+
+	suite.Require().NoError(ginhtmx.AddUpEvent(testContext, "user:created", gin.H{"id": 7}))
+	suite.htmx.With(ginhtmx.UpTarget("#main"), ginhtmx.UpEvents("users:changed")).Render(testContext, gin.H{}, "users")
+
+	suite.Equal("#main", recorder.Header().Get("X-Up-Target"))
+	suite.JSONEq(`[{"type": "user:created", "id": 7}, {"type": "users:changed"}]`,
+		recorder.Header().Get("X-Up-Events"))
+}
+
+func (suite *UnpolyTestSuite) TestInvalidEventsAreReported() {
+	_, testContext := suite.newContext()
+
+	suite.Require().ErrorContains(ginhtmx.AddUpEvent(testContext, "bad", gin.H{"value": func() {}}),
+		`encoding the "bad" event`)
+	suite.Empty(testContext.Writer.Header().Get("X-Up-Events"))
+
+	testContext.Writer.Header().Set("X-Up-Events", "user:created")
+	suite.Require().ErrorContains(ginhtmx.AddUpEvent(testContext, "user:deleted", nil),
+		"parsing the X-Up-Events header")
+	suite.Equal("user:created", testContext.Writer.Header().Get("X-Up-Events"))
+}
+
+func (suite *UnpolyTestSuite) newContext() (*httptest.ResponseRecorder, *gin.Context) {
+	recorder := httptest.NewRecorder()
+	testContext, _ := gin.CreateTestContext(recorder)
+	testContext.Request = httptest.NewRequest(http.MethodGet, "/users", nil)
+
+	return recorder, testContext
+}
+
+func (suite *UnpolyTestSuite) SetupTest() {
+	tmpl := template.Must(template.New("").Parse(`
+{{define "layout"}}<html>{{.Content}}</html>{{end}}
+{{define "users"}}<ul class="users"></ul>{{end}}
+`))
+	suite.htmx = ginhtmx.NewHtmxWithConfig(tmpl, ginhtmx.HtmxConfig{
+		LayoutTemplateName:  "layout",
+		ContentVariableName: "Content",
+	})
+}
+
+func TestUnpolyTestSuite(t *testing.T) {
+	t.Parallel()
+	suite.Run(t, new(UnpolyTestSuite))
+}
+
+type UnpolyTestSuite struct {
+	suite.Suite
+
+	htmx *ginhtmx.Htmx
+}
@@ -0,0 +1,69 @@
+package ginhtmx
+
+import (
+	"errors"
+	"fmt"
+	"html/template"
+	"maps"
+	"slices"
+)
+
+// ErrContentVariableMissing is returned by Validate when the layout template does
+// not refer to the configured content variable, which means that the content of
+// full page renders would be silently dropped.
+var ErrContentVariableMissing = errors.New("layout does not refer to the content variable")
+
+// Validate checks that the configured layout template is defined and refers to
+// the configured content variable, and that every one of templateNames and the
+// target of every alias added with Alias is defined. It is intended to be called
+// when the application starts so that a misspelled template name fails immediately
+// instead of producing empty pages:
+//
+//	htmx := ginhtmx.NewHtmx(tmpl)
+//	if err := htmx.Validate("home", "about"); err != nil {
+//	  log.Fatal(err)
+//	}
+//
+// Each problem found is wrapped in the returned error, which wraps
+// ErrTemplateNotFound or ErrContentVariableMissing as appropriate.
+func (htmx *Htmx) Validate(templateNames ...string) error {
+	var errs []error
+
+	templates := htmx.currentTemplate()
+	layout := htmx.config.LayoutTemplateName
+
+	switch {
+	case htmx.lookupTemplate(templates, layout) == nil:
+		errs = append(errs, fmt.Errorf("layout %w: %q", ErrTemplateNotFound, layout))
+	case !htmx.engineDefines(layout) && !referencesField(templates, layout, htmx.contentVariable()):
+		errs = append(errs, fmt.Errorf("%w: %q does not refer to .%s",
+			ErrContentVariableMissing, layout, htmx.contentVariable()))
+	}
+
+	for _, name := range templateNames {
+		if htmx.lookupTemplate(templates, htmx.templates.alias(name)) == nil {
+			errs = append(errs, fmt.Errorf("%w: %q", ErrTemplateNotFound, name))
+		}
+	}
+
+	errs = append(errs, htmx.validateAliases(templates)...)
+
+	return errors.Join(errs...)
+}
+
+// validateAliases reports the aliases whose targets are not defined.
+func (htmx *Htmx) validateAliases(templates *template.Template) []error {
+	htmx.templates.mutex.RLock()
+	defer htmx.templates.mutex.RUnlock()
+
+	var errs []error
+
+	for _, alias := range slices.Sorted(maps.Keys(htmx.templates.aliases)) {
+		target := htmx.templates.aliases[alias]
+		if htmx.lookupTemplate(templates, target) == nil {
+			errs = append(errs, fmt.Errorf("alias %q %w: %q", alias, ErrTemplateNotFound, target))
+		}
+	}
+
+	return errs
+}
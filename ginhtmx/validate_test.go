@@ -0,0 +1,56 @@
+package ginhtmx_test
+
+import (
+	"html/template"
+	"testing"
+
+	"github.com/jeffscottbrown/ginhtmxtemplates/ginhtmx"
+	"github.com/stretchr/testify/suite"
+)
+
+func (suite *ValidateTestSuite) TestValidTemplates() {
+	testCases := []string{
+		`{{define "layout"}}<html>{{.Content}}</html>{{end}}`,
+		`{{define "layout"}}<html>{{with .Title}}{{.}}{{end}}{{$.Content}}</html>{{end}}`,
+		`{{define "layout"}}<html>{{template "body" .}}</html>{{end}}
+		 {{define "body"}}{{if .Content}}{{range .Items}}{{.}}{{else}}{{.Content}}{{end}}{{end}}{{end}}`,
+		`{{define "layout"}}<html>{{ printf "%s" .Content | print }}</html>{{end}}`,
+		`{{define "layout"}}<html>{{ (.Content) }}</html>{{end}}`,
+	}
+
+	for _, layout := range testCases {
+		htmx := ginhtmx.NewHtmx(template.Must(template.New("").Parse(layout + `{{define "home"}}Home{{end}}`)))
+
+		suite.NoError(htmx.Validate("home"), layout)
+	}
+}
+
+func (suite *ValidateTestSuite) TestMissingTemplates() {
+	htmx := ginhtmx.NewHtmx(template.Must(template.New("").Parse(`{{define "home"}}Home{{end}}`)))
+
+	err := htmx.Validate("home", "about")
+
+	suite.Require().ErrorIs(err, ginhtmx.ErrTemplateNotFound)
+	suite.Contains(err.Error(), `layout template not found: "layout"`)
+	suite.Contains(err.Error(), `template not found: "about"`)
+	suite.NotContains(err.Error(), `"home"`)
+}
+
+func (suite *ValidateTestSuite) TestLayoutWithoutContentVariable() {
+	htmx := ginhtmx.NewHtmx(template.Must(template.New("").Parse(
+		`{{define "layout"}}<html>{{template "layout" .}}{{.Body}}{{$x := 1}}{{$x}}</html>{{end}}`)))
+
+	err := htmx.Validate()
+
+	suite.Require().ErrorIs(err, ginhtmx.ErrContentVariableMissing)
+	suite.Contains(err.Error(), ".Content")
+}
+
+func TestValidateTestSuite(t *testing.T) {
+	t.Parallel()
+	suite.Run(t, new(ValidateTestSuite))
+}
+
+type ValidateTestSuite struct {
+	suite.Suite
+}
@@ -0,0 +1,37 @@
+package ginhtmx
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ViewModelKey is the key under which RenderModelAuto exposes the view model to its
+// template, so that the template refers to its fields as .Model.Name and so on.
+const ViewModelKey = "Model"
+
+// ViewModel is implemented by models which choose the template that renders them,
+// centralizing the mapping between domain objects and the fragments showing them:
+//
+//	func (user UserCard) TemplateName() string { return "users/card" }
+type ViewModel interface {
+	// TemplateName returns the name of the template which renders the model
+	TemplateName() string
+}
+
+// RenderModelAuto renders the template chosen by model, with the model in the data
+// under ViewModelKey. As with Render, the template is wrapped in the layout unless
+// the request is an HTMX request, and the response has a 200 status code:
+//
+//	htmx.RenderModelAuto(c, UserCard{User: user})
+func (htmx *Htmx) RenderModelAuto(ginContext *gin.Context, model ViewModel) {
+	if model == nil {
+		htmx.With(withRenderError(fmt.Errorf("%w: nil view model", errInvalidArgument))).
+			RenderWithStatus(ginContext, gin.H{}, http.StatusOK)
+
+		return
+	}
+
+	htmx.RenderWithStatus(ginContext, gin.H{ViewModelKey: model}, http.StatusOK, model.TemplateName())
+}
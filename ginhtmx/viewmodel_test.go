@@ -0,0 +1,85 @@
+package ginhtmx_test
+
+import (
+	"html/template"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/jeffscottbrown/ginhtmxtemplates/ginhtmx"
+	"github.com/stretchr/testify/suite"
+)
+
+func (suite *ViewModelTestSuite) TestModelsChooseTheirTemplates() {
+	recorder, _ := suite.render(true, userCard{Name: "Jerry"})
+	suite.Equal(`<div class="card">Jerry</div>`, recorder.Body.String())
+
+	recorder, _ = suite.render(false, productRow{SKU: "A-1"})
+	suite.Equal(`<html><tr><td>A-1</td><td>Jerry</td></tr></html>`, recorder.Body.String())
+}
+
+func (suite *ViewModelTestSuite) TestNilModelsAreReported() {
+	recorder, testContext := suite.render(true, nil)
+
+	suite.Empty(recorder.Body.String())
+
+	renderError, _ := testContext.Value(ginhtmx.RenderErrorKey).(error)
+	suite.Require().EqualError(renderError, "invalid argument: nil view model")
+}
+
+func (suite *ViewModelTestSuite) render(fragment bool, model ginhtmx.ViewModel) (*httptest.ResponseRecorder, *gin.Context) {
+	recorder := httptest.NewRecorder()
+	testContext, _ := gin.CreateTestContext(recorder)
+	testContext.Request = httptest.NewRequest(http.MethodGet, "/", nil)
+
+	if fragment {
+		testContext.Request.Header.Set("Hx-Request", "true")
+	}
+
+	suite.htmx.RenderModelAuto(testContext, model)
+
+	return recorder, testContext
+}
+
+func (suite *ViewModelTestSuite) SetupTest() {
+	tmpl := template.Must(template.New("").Parse(`
+{{- define "layout"}}<html>{{ .Content }}</html>{{end -}}
+{{- define "users/card"}}<div class="card">{{ .Model.Name }}</div>{{end -}}
+{{- define "products/row"}}<tr><td>{{ .Model.SKU }}</td><td>{{ .User }}</td></tr>{{end -}}
+`))
+	suite.htmx = ginhtmx.NewHtmxWithConfig(tmpl, ginhtmx.HtmxConfig{
+		LayoutTemplateName:  "layout",
+		ContentVariableName: "Content",
+		ModelDecorator: ginhtmx.ModelDecoratorFunc(func(_ *gin.Context, model *gin.H) {
+			(*model)["User"] = "Jerry"
+		}),
+	})
+}
+
+func TestViewModelTestSuite(t *testing.T) {
+	t.Parallel()
+	suite.Run(t, new(ViewModelTestSuite))
+}
+
+type ViewModelTestSuite struct {
+	suite.Suite
+
+	htmx *ginhtmx.Htmx
+}
+
+type userCard struct {
+	Name string
+}
+
+func (userCard) TemplateName() string {
+	return "users/card"
+}
+
+type productRow struct {
+	SKU string
+}
+
+func (productRow) TemplateName() string {
+	return "products/row"
+}
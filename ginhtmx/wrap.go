@@ -0,0 +1,81 @@
+package ginhtmx
+
+import (
+	"context"
+	"fmt"
+	"html/template"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ChildrenKey is the key under which wrapper templates receive the rendered content
+// they wrap.
+const ChildrenKey = "Children"
+
+// WrapIn returns a RenderOption which renders the wrapper template around the
+// rendered templates, which are passed to the wrapper as .Children. This allows
+// templates such as cards, panels and modal shells to be reused around different
+// content. When WrapIn is used more than once the first wrapper is innermost.
+func WrapIn(wrapper string) RenderOption {
+	return func(settings *renderSettings) {
+		settings.wrappers = append(settings.wrappers, wrapper)
+	}
+}
+
+// RenderWrapped renders the specified templates wrapped in the wrapper template, as
+// described by WrapIn:
+//
+//	handler.htmx.RenderWrapped(c, gin.H{"User": user}, "card", "user_details")
+func (htmx *Htmx) RenderWrapped(ginContext *gin.Context, data gin.H, wrapper string, templateNames ...string) {
+	htmx.With(WrapIn(wrapper)).RenderWithStatus(ginContext, data, http.StatusOK, templateNames...)
+}
+
+// renderWrappers renders each of the wrappers around content in turn.
+func (htmx *Htmx) renderWrappers(
+	ctx context.Context, tmpl *template.Template, data gin.H, content string, wrappers []string, fragment bool,
+) (string, []error) {
+	var errs []error
+
+	for _, wrapper := range wrappers {
+		//nolint:gosec
+		data[ChildrenKey] = template.HTML(content)
+
+		rendered, err := htmx.executeTemplate(ctx, tmpl, wrapper, data, fragment)
+		errs = append(errs, err)
+		content = rendered
+	}
+
+	return content, errs
+}
+
+// wrap renders the named template and then the wrapper template around it from
+// within another template:
+//
+//	{{ wrap "card" "user_details" . }}
+//
+// The wrapper receives the data with the rendered template added as .Children, so
+// the data must be a map such as gin.H.
+func (scope *requestScope) wrap(wrapper string, name string, data any) (template.HTML, error) {
+	if scope.htmx == nil || scope.template == nil {
+		return "", fmt.Errorf("%w: wrap %q rendered outside of Htmx", errInvalidArgument, wrapper)
+	}
+
+	ctx := scope.context()
+
+	children, err := scope.htmx.executeTemplate(ctx, scope.template, name, data, true)
+	if err != nil {
+		return "", err
+	}
+
+	//nolint:gosec
+	wrapperData, err := mergeData(data, gin.H{ChildrenKey: template.HTML(children)})
+	if err != nil {
+		return "", fmt.Errorf("wrap %q: %w", wrapper, err)
+	}
+
+	rendered, err := scope.htmx.executeTemplate(ctx, scope.template, wrapper, wrapperData, true)
+
+	//nolint:gosec
+	return template.HTML(rendered), err
+}
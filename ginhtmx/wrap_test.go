@@ -0,0 +1,96 @@
+package ginhtmx_test
+
+import (
+	"bytes"
+	"html/template"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/jeffscottbrown/ginhtmxtemplates/ginhtmx"
+	"github.com/stretchr/testify/suite"
+)
+
+func (suite *WrapTestSuite) TestRenderWrapped() {
+	recorder, testContext := suite.newContext(true)
+
+	suite.htmx.RenderWrapped(testContext, gin.H{"Name": "Jerry"}, "card", "details", "details")
+
+	suite.Equal(`<div class="card"><p>Jerry</p><p>Jerry</p></div>`, recorder.Body.String())
+}
+
+func (suite *WrapTestSuite) TestWrappersNestInsideLayout() {
+	recorder, testContext := suite.newContext(false)
+
+	suite.htmx.With(ginhtmx.WrapIn("card"), ginhtmx.WrapIn("modal")).Render(testContext, gin.H{"Name": "Jerry"}, "details")
+
+	suite.Equal(`<html><dialog><div class="card"><p>Jerry</p></div></dialog></html>`, recorder.Body.String())
+}
+
+func (suite *WrapTestSuite) TestMissingWrapperIsReported() {
+	_, testContext := suite.newContext(true)
+
+	suite.htmx.RenderWrapped(testContext, gin.H{}, "unknown", "details")
+
+	renderErr, _ := testContext.Value(ginhtmx.RenderErrorKey).(error)
+	suite.Require().ErrorIs(renderErr, ginhtmx.ErrTemplateNotFound)
+}
+
+func (suite *WrapTestSuite) TestWrapFunction() {
+	var output bytes.Buffer
+
+	err := suite.htmx.RenderToWriter(&output, gin.H{"User": gin.H{"Name": "Elaine"}}, false, "page")
+
+	suite.Require().NoError(err)
+	suite.Equal(`<div class="card"><p>Elaine</p></div>`, output.String())
+}
+
+func (suite *WrapTestSuite) TestWrapFunctionErrors() {
+	var output bytes.Buffer
+
+	for _, name := range []string{"not_a_map", "missing_child", "missing_wrapper"} {
+		suite.Require().Error(suite.htmx.RenderToWriter(&output, gin.H{}, false, name), name)
+	}
+
+	err := template.Must(suite.template.Clone()).ExecuteTemplate(&output, "page", gin.H{})
+	suite.Require().Error(err)
+}
+
+func (suite *WrapTestSuite) newContext(htmxRequest bool) (*httptest.ResponseRecorder, *gin.Context) {
+	recorder := httptest.NewRecorder()
+	testContext, _ := gin.CreateTestContext(recorder)
+	testContext.Request = httptest.NewRequest(http.MethodGet, "/", nil)
+
+	if htmxRequest {
+		testContext.Request.Header.Set("Hx-Request", "true")
+	}
+
+	return recorder, testContext
+}
+
+func (suite *WrapTestSuite) SetupSuite() {
+	suite.template = template.Must(template.New("").Funcs(ginhtmx.FuncMap()).Parse(`
+{{- define "layout"}}<html>{{.Content}}</html>{{end}}
+{{- define "card"}}<div class="card">{{.Children}}</div>{{end}}
+{{- define "modal"}}<dialog>{{.Children}}</dialog>{{end}}
+{{- define "details"}}<p>{{.Name}}</p>{{end}}
+{{- define "page"}}{{wrap "card" "details" .User}}{{end}}
+{{- define "not_a_map"}}{{wrap "card" "details" "Jerry"}}{{end}}
+{{- define "missing_child"}}{{wrap "card" "unknown" .}}{{end}}
+{{- define "missing_wrapper"}}{{wrap "unknown" "details" .}}{{end}}
+`))
+	suite.htmx = ginhtmx.NewHtmx(suite.template)
+}
+
+func TestWrapTestSuite(t *testing.T) {
+	t.Parallel()
+	suite.Run(t, new(WrapTestSuite))
+}
+
+type WrapTestSuite struct {
+	suite.Suite
+
+	htmx     *ginhtmx.Htmx
+	template *template.Template
+}
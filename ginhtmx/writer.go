@@ -0,0 +1,78 @@
+package ginhtmx
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"maps"
+
+	"github.com/gin-gonic/gin"
+)
+
+// RenderToWriter renders the specified templates with the provided data outside of
+// a gin request and writes the concatenated result to writer. When withLayout is
+// true the result is wrapped in the layout template, as it would be for a request
+// which is not an HTMX request. This allows the same templates and functions to be
+// used to generate emails, static exports or content in background jobs:
+//
+//	var email bytes.Buffer
+//	err := htmx.RenderToWriter(&email, gin.H{"User": user}, false, "welcome_email")
+//
+// As there is no request, model decorators and model providers are not applied
+// and template functions which depend on the request fall back to their defaults.
+// The data map is not modified. Nothing is written to writer if any template fails
+// to render, in which case the error is returned.
+func (htmx *Htmx) RenderToWriter(writer io.Writer, data gin.H, withLayout bool, templateNames ...string) error {
+	settings := htmx.renderSettings()
+
+	tmpl, release, err := htmx.acquireTemplate(htmx.newRequestScope(nil), settings.templateSet)
+	defer release()
+
+	if err != nil {
+		return err
+	}
+
+	ctx := context.Background()
+	model := maps.Clone(data)
+
+	if model == nil {
+		model = gin.H{}
+	}
+
+	content, errs := htmx.renderTemplates(ctx, tmpl, model, templateNames, !withLayout)
+
+	if withLayout {
+		page, err := htmx.renderLayout(ctx, tmpl, model, settings, content)
+		errs = append(errs, err)
+		content = page
+	}
+
+	err = errors.Join(errs...)
+	if err != nil {
+		return err
+	}
+
+	_, err = io.WriteString(writer, content)
+	if err != nil {
+		return fmt.Errorf("writing rendered templates: %w", err)
+	}
+
+	return nil
+}
+
+// RenderToString renders the named template with the provided data and returns the
+// result as a string. It is intended for embedding rendered fragments in JSON
+// payloads or HX-Trigger events. As with RenderToWriter the layout, model
+// decorators and model providers are not applied. An error wrapping
+// ErrTemplateNotFound is returned if no template with that name exists.
+func (htmx *Htmx) RenderToString(name string, data any) (string, error) {
+	tmpl, release, err := htmx.acquireTemplate(htmx.newRequestScope(nil), htmx.renderSettings().templateSet)
+	defer release()
+
+	if err != nil {
+		return "", err
+	}
+
+	return htmx.executeTemplate(context.Background(), tmpl, name, data, true)
+}
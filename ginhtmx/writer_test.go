@@ -0,0 +1,103 @@
+package ginhtmx_test
+
+import (
+	"bytes"
+	"errors"
+	"html/template"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/jeffscottbrown/ginhtmxtemplates/ginhtmx"
+	"github.com/stretchr/testify/suite"
+)
+
+var errWriteFailed = errors.New("write failed")
+
+func (suite *RenderToWriterTestSuite) TestRenderFragment() {
+	var output bytes.Buffer
+
+	err := suite.htmx.RenderToWriter(&output, gin.H{"Name": "Jerry"}, false, "hello", "hello")
+
+	suite.Require().NoError(err)
+	suite.Equal("<p>Hello, Jerry</p><p>Hello, Jerry</p>", output.String())
+}
+
+func (suite *RenderToWriterTestSuite) TestRenderWithLayoutDoesNotModifyData() {
+	var output bytes.Buffer
+
+	data := gin.H{"Name": "Jerry"}
+	err := suite.htmx.RenderToWriter(&output, data, true, "hello")
+
+	suite.Require().NoError(err)
+	suite.Equal("<html><p>Hello, Jerry</p></html>", output.String())
+	suite.NotContains(data, "Content")
+
+	output.Reset()
+	suite.Require().NoError(suite.htmx.RenderToWriter(&output, nil, true))
+	suite.Equal("<html></html>", output.String())
+}
+
+func (suite *RenderToWriterTestSuite) TestTemplateFunctionsUseDefaults() {
+	var output bytes.Buffer
+
+	err := suite.htmx.RenderToWriter(&output, gin.H{}, false, "translated")
+
+	suite.Require().NoError(err)
+	suite.Equal("greeting", output.String())
+}
+
+func (suite *RenderToWriterTestSuite) TestNothingIsWrittenOnError() {
+	var output bytes.Buffer
+
+	err := suite.htmx.RenderToWriter(&output, gin.H{"Name": "Jerry"}, false, "hello", "missing")
+
+	suite.Require().ErrorIs(err, ginhtmx.ErrTemplateNotFound)
+	suite.Empty(output.String())
+}
+
+func (suite *RenderToWriterTestSuite) TestWriteErrorsAreReturned() {
+	err := suite.htmx.RenderToWriter(failingWriter{}, gin.H{"Name": "Jerry"}, false, "hello")
+
+	suite.Require().ErrorIs(err, errWriteFailed)
+}
+
+func (suite *RenderToWriterTestSuite) TestRenderToString() {
+	rendered, err := suite.htmx.RenderToString("hello", map[string]string{"Name": "Elaine"})
+
+	suite.Require().NoError(err)
+	suite.Equal("<p>Hello, Elaine</p>", rendered)
+
+	_, err = suite.htmx.RenderToString("missing", nil)
+
+	suite.Require().ErrorIs(err, ginhtmx.ErrTemplateNotFound)
+}
+
+func (suite *RenderToWriterTestSuite) SetupSuite() {
+	tmpl := template.Must(template.New("").Funcs(ginhtmx.FuncMap()).Parse(`
+{{- define "layout"}}<html>{{.Content}}</html>{{end}}
+{{- define "hello"}}<p>Hello, {{.Name}}</p>{{end}}
+{{- define "translated"}}{{ t "greeting" }}{{end}}
+`))
+	suite.htmx = ginhtmx.NewHtmxWithConfig(tmpl, ginhtmx.HtmxConfig{
+		LayoutTemplateName:  "layout",
+		ContentVariableName: "Content",
+		Translator:          ginhtmx.NewCatalog("en"),
+	})
+}
+
+func TestRenderToWriterTestSuite(t *testing.T) {
+	t.Parallel()
+	suite.Run(t, new(RenderToWriterTestSuite))
+}
+
+type RenderToWriterTestSuite struct {
+	suite.Suite
+
+	htmx *ginhtmx.Htmx
+}
+
+type failingWriter struct{}
+
+func (failingWriter) Write([]byte) (int, error) {
+	return 0, errWriteFailed
+}